@@ -6,6 +6,7 @@ import (
 	"TaskManager/internal/routes"
 	"log"
 	"net/http"
+	_ "time/tzdata" // recurring tasks resolve IANA time zones even on hosts without zoneinfo
 
 	"github.com/gin-gonic/gin"
 )
@@ -31,6 +32,18 @@ func main() {
 
 	routes.SetupUserRoutes(router, app.Controller.User)
 	routes.SetupAuthRoutes(router, app.Controller.Auth)
+	routes.SetupTaskRoutes(router, app.Controller.Task)
+	routes.SetupProjectRoutes(router, app.Controller.Project)
+	routes.SetupBoardRoutes(router, app.Controller.Board)
+	routes.SetupWorkflowRoutes(router, app.Controller.Workflow)
+	routes.SetupLabelRoutes(router, app.Controller.Label)
+	routes.SetupCommentRoutes(router, app.Controller.Comment)
+	routes.SetupAttachmentRoutes(router, app.Controller.Attachment)
+	routes.SetupWorklogRoutes(router, app.Controller.Worklog)
+	routes.SetupCustomFieldRoutes(router, app.Controller.CustomField)
+	routes.SetupTemplateRoutes(router, app.Controller.Template)
+	routes.SetupViewRoutes(router, app.Controller.View)
+	routes.SetupSearchRoutes(router, app.Controller.Search)
 
 	log.Println("Server is running at http://localhost:8080")
 	if err := router.Run(":8080"); err != nil {
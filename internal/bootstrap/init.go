@@ -3,18 +3,34 @@ package bootstrap
 import (
 	"TaskManager/internal/config"
 	"TaskManager/internal/controllers"
+	"TaskManager/internal/middleware"
 	"TaskManager/internal/models"
 	"TaskManager/internal/repositories"
 	"TaskManager/internal/services"
+	"TaskManager/pkg/blobstore"
+	"TaskManager/pkg/mailer"
 	"fmt"
 	"log"
+	"os"
 
 	"gorm.io/gorm"
 )
 
 type Controller struct {
-	User *controllers.UserController
-	Auth *controllers.AuthController
+	User        *controllers.UserController
+	Auth        *controllers.AuthController
+	Task        *controllers.TaskController
+	Project     *controllers.ProjectController
+	Board       *controllers.BoardController
+	Workflow    *controllers.WorkflowController
+	Label       *controllers.LabelController
+	Comment     *controllers.CommentController
+	Attachment  *controllers.AttachmentController
+	Worklog     *controllers.WorklogController
+	CustomField *controllers.CustomFieldController
+	Template    *controllers.TemplateController
+	View        *controllers.ViewController
+	Search      *controllers.SearchController
 }
 
 type AppContainer struct {
@@ -35,23 +51,116 @@ func InitializeApp() (*AppContainer, error) {
 		return nil, fmt.Errorf("❌ Failed to connect to database: %w", err)
 	}
 
-	if err := db.AutoMigrate(&models.User{}); err != nil {
+	if err := db.AutoMigrate(
+		&models.User{},
+		&models.Project{},
+		&models.ProjectMember{},
+		&models.Task{},
+		&models.ChecklistItem{},
+		&models.TaskDependency{},
+		&models.Board{},
+		&models.BoardColumn{},
+		&models.Workflow{},
+		&models.WorkflowState{},
+		&models.WorkflowTransition{},
+		&models.Label{},
+		&models.Comment{},
+		&models.CommentRevision{},
+		&models.CommentMention{},
+		&models.Attachment{},
+		&models.Worklog{},
+		&models.CustomField{},
+		&models.CustomFieldValue{},
+		&models.TaskTemplate{},
+		&models.SavedView{},
+		&models.Session{},
+		&models.RefreshToken{},
+		&models.RevokedToken{},
+		&models.PasswordResetToken{},
+		&models.EmailVerificationToken{},
+	); err != nil {
 		return nil, fmt.Errorf("❌ Failed to auto-migrate models: %w", err)
 	}
 
+	// Add the full-text search columns and indexes
+	searchRepo := repositories.NewSearchRepository(db)
+	if err := searchRepo.Migrate(); err != nil {
+		return nil, fmt.Errorf("❌ Failed to migrate search indexes: %w", err)
+	}
+
 	// Initalize repositories
 	log.Println("📦 Initializing repositories...")
 	userRepo := repositories.NewUserRepository(db)
+	taskRepo := repositories.NewTaskRepository(db)
+	projectRepo := repositories.NewProjectRepository(db)
+	boardRepo := repositories.NewBoardRepository(db)
+	labelRepo := repositories.NewLabelRepository(db)
+	commentRepo := repositories.NewCommentRepository(db)
+	attachmentRepo := repositories.NewAttachmentRepository(db)
+	worklogRepo := repositories.NewWorklogRepository(db)
+	templateRepo := repositories.NewTemplateRepository(db)
+	viewRepo := repositories.NewViewRepository(db)
+	tokenRepo := repositories.NewTokenRepository(db)
+
+	// Initialize the blob store for attachments
+	blobs, err := blobstore.NewLocalStore(config.Config.AttachmentDir)
+	if err != nil {
+		return nil, fmt.Errorf("❌ Failed to open attachment store: %w", err)
+	}
+
+	// Initialize the mailer
+	mail, err := newMailer()
+	if err != nil {
+		return nil, fmt.Errorf("❌ Failed to set up mail: %w", err)
+	}
 
 	// Initalize service
 	log.Println("🧠 Initializing services...")
+	revocations := services.NewTokenRevocationStore(tokenRepo, config.Config.RevocationCacheTTL, config.Config.SessionSeenInterval)
+	middleware.SetTokenChecker(revocations)
+	middleware.SetSessionTracker(revocations)
+	middleware.SetRequireVerifiedEmail(config.Config.RequireVerifiedEmail)
 	userService := services.NewUserService(userRepo)
-	authService := services.NewAuthService(userRepo)
+	authService := services.NewAuthService(userRepo, tokenRepo, revocations, mail, config.Config.AccessTokenTTL, config.Config.RefreshTokenTTL, services.PasswordResetSettings{
+		TTL: config.Config.PasswordResetTTL,
+		URL: config.Config.PasswordResetURL,
+	}, services.EmailVerificationSettings{
+		TTL:            config.Config.EmailVerificationTTL,
+		URL:            config.Config.EmailVerificationURL,
+		ResendInterval: config.Config.EmailVerificationResendInterval,
+	})
+	taskService := services.NewTaskService(taskRepo, projectRepo, userRepo, blobs)
+	projectService := services.NewProjectService(projectRepo, taskRepo, userRepo, blobs)
+	boardService := services.NewBoardService(boardRepo, taskRepo, projectRepo, taskService)
+	workflowService := services.NewWorkflowService(projectRepo, taskRepo, boardRepo, taskService)
+	labelService := services.NewLabelService(labelRepo, taskRepo, projectRepo, taskService)
+	commentService := services.NewCommentService(commentRepo, taskRepo, projectRepo, userRepo, taskService)
+	attachmentService := services.NewAttachmentService(attachmentRepo, taskRepo, projectRepo, blobs, services.AttachmentLimits{
+		MaxFileSize: config.Config.AttachmentMaxSize,
+		Quota:       config.Config.AttachmentQuota,
+	}, taskService)
+	worklogService := services.NewWorklogService(worklogRepo, taskRepo, projectRepo, taskService)
+	customFieldService := services.NewCustomFieldService(projectRepo)
+	templateService := services.NewTemplateService(templateRepo, taskRepo, projectRepo, labelRepo, taskService)
+	viewService := services.NewViewService(viewRepo, taskRepo, projectRepo)
+	searchService := services.NewSearchService(searchRepo)
 
 	// Initalize controllers
 	log.Println("🎮 Initializing controllers...")
-	userController := controllers.NewUserController(userService)
+	userController := controllers.NewUserController(userService, authService)
 	authController := controllers.NewAuthController(authService)
+	taskController := controllers.NewTaskController(taskService)
+	projectController := controllers.NewProjectController(projectService)
+	boardController := controllers.NewBoardController(boardService)
+	workflowController := controllers.NewWorkflowController(workflowService)
+	labelController := controllers.NewLabelController(labelService)
+	commentController := controllers.NewCommentController(commentService)
+	attachmentController := controllers.NewAttachmentController(attachmentService, config.Config.AttachmentMaxSize)
+	worklogController := controllers.NewWorklogController(worklogService)
+	customFieldController := controllers.NewCustomFieldController(customFieldService)
+	templateController := controllers.NewTemplateController(templateService)
+	viewController := controllers.NewViewController(viewService)
+	searchController := controllers.NewSearchController(searchService)
 
 	log.Println("✅ Application initialized successfully.")
 
@@ -59,8 +168,35 @@ func InitializeApp() (*AppContainer, error) {
 	return &AppContainer{
 		DB: db,
 		Controller: Controller{
-			User: userController,
-			Auth: authController,
+			User:        userController,
+			Auth:        authController,
+			Task:        taskController,
+			Project:     projectController,
+			Board:       boardController,
+			Workflow:    workflowController,
+			Label:       labelController,
+			Comment:     commentController,
+			Attachment:  attachmentController,
+			Worklog:     worklogController,
+			CustomField: customFieldController,
+			Template:    templateController,
+			View:        viewController,
+			Search:      searchController,
 		},
 	}, nil
 }
+
+// newMailer returns the mailer chosen by the MAIL_DRIVER setting
+func newMailer() (mailer.Mailer, error) {
+	switch config.Config.MailDriver {
+	case "smtp":
+		return mailer.NewSMTPMailer(config.Config.SMTPHost, config.Config.SMTPPort, config.Config.SMTPUsername, config.Config.SMTPPassword, config.Config.MailFrom), nil
+	case "file":
+		log.Println("⚠️ MAIL_DRIVER=file stores reset and verification links in", config.Config.MailFile, "- use it for development only")
+		return mailer.NewFileMailer(config.Config.MailFile, config.Config.MailFrom), nil
+	case "log":
+		log.Println("⚠️ MAIL_DRIVER=log prints reset and verification links to stderr - use it for development only")
+		return mailer.NewLogMailer(os.Stderr), nil
+	}
+	return nil, fmt.Errorf("unknown mail driver %q", config.Config.MailDriver)
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -15,6 +17,42 @@ type AppConfig struct {
 	DBName     string
 	DBPort     string
 	JWTSecret  string
+
+	// Access tokens are short-lived JWTs, renewed with refresh tokens
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	// How long token revocation checks are cached; other instances see a
+	// logout within this time
+	RevocationCacheTTL time.Duration
+	// How often a session's last-seen time is written at most
+	SessionSeenInterval time.Duration
+	// How long password reset links are valid, and the page they lead to;
+	// the token is appended as the "token" query parameter
+	PasswordResetTTL time.Duration
+	PasswordResetURL string
+	// How long email verification links are valid, the endpoint they lead
+	// to and how often a user can have one resent at most
+	EmailVerificationTTL            time.Duration
+	EmailVerificationURL            string
+	EmailVerificationResendInterval time.Duration
+	// Whether users must verify their email before using protected routes
+	RequireVerifiedEmail bool
+
+	// Mail is sent with MailDriver: "smtp" (the default), "file" (appended
+	// to MailFile) or "log". The last two write reset and verification links
+	// out in the clear and are meant for development only.
+	MailDriver   string
+	MailFrom     string
+	MailFile     string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+
+	// Attachments are stored below AttachmentDir; sizes are in bytes
+	AttachmentDir     string
+	AttachmentMaxSize int64
+	AttachmentQuota   int64
 }
 
 var Config *AppConfig
@@ -36,6 +74,32 @@ func LoadConfig() {
 		DBName:     mustGetEnvOrDefault("DB_NAME", "gotasker"),
 		DBPort:     mustGetEnvOrDefault("DB_PORT", "5432"),
 		JWTSecret:  mustGetEnvOrDefault("JWT_SECRET", "mySuperSecretKey"),
+
+		AccessTokenTTL:  getEnvDurationOrDefault("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL: getEnvDurationOrDefault("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+
+		RevocationCacheTTL:  getEnvDurationOrDefault("REVOCATION_CACHE_TTL", 30*time.Second),
+		SessionSeenInterval: getEnvDurationOrDefault("SESSION_SEEN_INTERVAL", 5*time.Minute),
+
+		PasswordResetTTL: getEnvDurationOrDefault("PASSWORD_RESET_TTL", time.Hour),
+		PasswordResetURL: mustGetEnvOrDefault("PASSWORD_RESET_URL", "http://localhost:3000/reset-password"),
+
+		EmailVerificationTTL:            getEnvDurationOrDefault("EMAIL_VERIFICATION_TTL", 48*time.Hour),
+		EmailVerificationURL:            mustGetEnvOrDefault("EMAIL_VERIFICATION_URL", "http://localhost:8080/auth/verify"),
+		EmailVerificationResendInterval: getEnvDurationOrDefault("EMAIL_VERIFICATION_RESEND_INTERVAL", 2*time.Minute),
+		RequireVerifiedEmail:            getEnvBoolOrDefault("REQUIRE_VERIFIED_EMAIL", false),
+
+		MailDriver:   mustGetEnvOrDefault("MAIL_DRIVER", "smtp"),
+		MailFrom:     mustGetEnvOrDefault("MAIL_FROM", "TaskManager <noreply@localhost>"),
+		MailFile:     mustGetEnvOrDefault("MAIL_FILE", "data/mail.log"),
+		SMTPHost:     mustGetEnvOrDefault("SMTP_HOST", "localhost"),
+		SMTPPort:     getEnvIntOrDefault("SMTP_PORT", 587),
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+
+		AttachmentDir:     mustGetEnvOrDefault("ATTACHMENT_DIR", "data/attachments"),
+		AttachmentMaxSize: getEnvMegabytesOrDefault("ATTACHMENT_MAX_SIZE_MB", 25),
+		AttachmentQuota:   getEnvMegabytesOrDefault("ATTACHMENT_QUOTA_MB", 500),
 	}
 
 	log.Println("✅ Configuration loaded successfully.")
@@ -49,3 +113,63 @@ func mustGetEnvOrDefault(key, defaultValue string) string {
 	}
 	return value
 }
+
+// getEnvMegabytesOrDefault reads a size in megabytes from an env var and
+// returns it in bytes, falling back to the default when unset or invalid
+func getEnvMegabytesOrDefault(key string, defaultValue int64) int64 {
+	megabytes := defaultValue
+	if value := os.Getenv(key); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed <= 0 {
+			log.Printf("⚠️  Invalid %s %q, using %d", key, value, defaultValue)
+		} else {
+			megabytes = parsed
+		}
+	}
+	return megabytes << 20
+}
+
+// getEnvIntOrDefault reads a positive number from an env var, falling back
+// to the default when unset or invalid
+func getEnvIntOrDefault(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("⚠️  Invalid %s %q, using %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// getEnvBoolOrDefault reads a boolean such as "true" or "0" from an env
+// var, falling back to the default when unset or invalid
+func getEnvBoolOrDefault(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("⚠️  Invalid %s %q, using %t", key, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// getEnvDurationOrDefault reads a duration such as "15m" or "720h" from an
+// env var, falling back to the default when unset or invalid
+func getEnvDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("⚠️  Invalid %s %q, using %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
@@ -0,0 +1,221 @@
+// internal/controllers/attachment_controller.go
+package controllers
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	dto "TaskManager/pkg/utils"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead leaves room for multipart headers and boundaries on top
+// of the maximum file size when capping upload request bodies
+const multipartOverhead = 1 << 20
+
+// inlineContentTypes are the sniffed types browsers may display in place;
+// everything else is served as a download so uploaded HTML or SVG can't
+// run in the API's origin
+var inlineContentTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+	"image/bmp":  true,
+	"text/plain": true,
+}
+
+// AttachmentController handles HTTP requests related to task attachments
+type AttachmentController struct {
+	AttachmentService services.AttachmentService
+	MaxFileSize       int64
+}
+
+// NewAttachmentController creates and returns a new AttachmentController instance
+func NewAttachmentController(attachmentService services.AttachmentService, maxFileSize int64) *AttachmentController {
+	return &AttachmentController{
+		AttachmentService: attachmentService,
+		MaxFileSize:       maxFileSize,
+	}
+}
+
+// toAttachmentResponse maps an attachment model to its API representation
+func toAttachmentResponse(attachment *models.Attachment) dto.AttachmentResponse {
+	return dto.AttachmentResponse{
+		ID:          attachment.ID,
+		TaskID:      attachment.TaskID,
+		UserID:      attachment.UserID,
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Checksum:    attachment.Checksum,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+// respondAttachmentError writes the HTTP response matching an attachment service error
+func respondAttachmentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrAttachmentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Attachment not found"})
+	case errors.Is(err, services.ErrTaskNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+	case errors.Is(err, services.ErrAttachmentForbidden),
+		errors.Is(err, services.ErrProjectForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAttachmentEmpty):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAttachmentTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrQuotaExceeded):
+		c.JSON(http.StatusInsufficientStorage, gin.H{"error": err.Error()})
+	default:
+		log.Println("Attachment error:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// UploadAttachment handles a multipart upload to a task. The "file" part is
+// streamed to the blob store rather than buffered in memory or on disk.
+func (a *AttachmentController) UploadAttachment(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, a.MaxFileSize+multipartOverhead)
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Expected a multipart/form-data upload"})
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid multipart body"})
+			return
+		}
+		if part.FormName() != "file" || part.FileName() == "" {
+			part.Close()
+			continue
+		}
+
+		attachment, err := a.AttachmentService.UploadAttachment(taskID, currentUserID(c), part.FileName(), part)
+		part.Close()
+		if err != nil {
+			respondAttachmentError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, toAttachmentResponse(attachment))
+		return
+	}
+
+	c.JSON(http.StatusBadRequest, gin.H{"error": "Missing file field"})
+}
+
+// GetTaskAttachments handles listing the attachments of a task
+func (a *AttachmentController) GetTaskAttachments(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	attachments, err := a.AttachmentService.GetTaskAttachments(taskID, currentUserID(c))
+	if err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+
+	responses := make([]dto.AttachmentResponse, 0, len(attachments))
+	for i := range attachments {
+		responses = append(responses, toAttachmentResponse(&attachments[i]))
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetAttachment handles retrieving the metadata of an attachment
+func (a *AttachmentController) GetAttachment(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	attachment, err := a.AttachmentService.GetAttachment(id, currentUserID(c))
+	if err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toAttachmentResponse(attachment))
+}
+
+// DownloadAttachment handles streaming an attachment's content. Range and
+// conditional requests are answered by http.ServeContent.
+func (a *AttachmentController) DownloadAttachment(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	attachment, content, err := a.AttachmentService.OpenAttachment(id, currentUserID(c))
+	if err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+	defer content.Close()
+
+	disposition := "attachment"
+	mediaType, _, _ := mime.ParseMediaType(attachment.ContentType)
+	if inlineContentTypes[strings.ToLower(mediaType)] {
+		disposition = "inline"
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", attachment.ContentType)
+	header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Filename}))
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("Content-Security-Policy", "default-src 'none'; sandbox")
+	header.Set("ETag", `"`+attachment.Checksum+`"`)
+	http.ServeContent(c.Writer, c.Request, attachment.Filename, attachment.CreatedAt, content)
+}
+
+// DeleteAttachment handles deleting an attachment
+func (a *AttachmentController) DeleteAttachment(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := a.AttachmentService.DeleteAttachment(id, currentUserID(c)); err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+
+	log.Printf("Attachment with ID %d deleted successfully", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Attachment deleted successfully"})
+}
+
+// GetUsage handles reporting the authenticated user's attachment quota
+func (a *AttachmentController) GetUsage(c *gin.Context) {
+	usage, err := a.AttachmentService.GetUsage(currentUserID(c))
+	if err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.AttachmentUsageResponse{
+		Used:        usage.Used,
+		Quota:       usage.Quota,
+		MaxFileSize: usage.MaxFileSize,
+	})
+}
@@ -2,7 +2,12 @@ package controllers
 
 import (
 	"TaskManager/internal/services"
+	dto "TaskManager/pkg/utils"
+	"errors"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -19,6 +24,11 @@ func NewAuthController(authService services.AuthService) *AuthController {
 	}
 }
 
+// sessionClient describes the device a request comes from
+func sessionClient(c *gin.Context) services.SessionClient {
+	return services.SessionClient{UserAgent: c.Request.UserAgent(), IP: c.ClientIP()}
+}
+
 // Register registers a new user and returns a JWT token
 func (a *AuthController) Register(c *gin.Context) {
 	var input struct {
@@ -34,16 +44,18 @@ func (a *AuthController) Register(c *gin.Context) {
 	}
 
 	// Call the service layer to register the user
-	createdUser, token, err := a.AuthService.RegisterUser(input.Username, input.Password, input.Email)
+	createdUser, tokens, err := a.AuthService.RegisterUser(input.Username, input.Password, input.Email, sessionClient(c))
 	if err != nil {
 		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "User registered successfully",
-		"token":   token,
-		"user":    createdUser,
+		"message":       "User registered successfully",
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"expires_at":    tokens.ExpiresAt,
+		"user":          createdUser,
 	})
 }
 
@@ -62,15 +74,205 @@ func (a *AuthController) Login(c *gin.Context) {
 	}
 
 	// Authenticate the user using the service layer
-	user, token, err := a.AuthService.LoginUser(input.Username, input.Email, input.Password)
+	user, tokens, err := a.AuthService.LoginUser(input.Username, input.Email, input.Password, sessionClient(c))
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid username/email or"})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"username": user.Username,
-		"message":  "Login successful",
-		"token":    token,
+		"username":      user.Username,
+		"message":       "Login successful",
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"expires_at":    tokens.ExpiresAt,
+	})
+}
+
+// Refresh trades a refresh token in for a new access token and refresh token
+func (a *AuthController) Refresh(c *gin.Context) {
+	var input struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+
+	// Bind JSON data to input struct
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	// Rotate the refresh token using the service layer
+	tokens, err := a.AuthService.RefreshTokens(input.RefreshToken)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRefreshToken) || errors.Is(err, services.ErrRefreshTokenReused) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"expires_at":    tokens.ExpiresAt,
 	})
 }
+
+// Logout ends the login the request was made with: its access token is
+// revoked and its refresh token can no longer be used
+func (a *AuthController) Logout(c *gin.Context) {
+	if err := a.AuthService.Logout(currentTokenClaims(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
+}
+
+// LogoutEverywhere ends every login of the authenticated user
+func (a *AuthController) LogoutEverywhere(c *gin.Context) {
+	if err := a.AuthService.LogoutEverywhere(currentUserID(c)); err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out of every session"})
+}
+
+// GetSessions lists the sessions the authenticated user is logged in with,
+// marking the one the request was made with
+func (a *AuthController) GetSessions(c *gin.Context) {
+	sessions, err := a.AuthService.GetSessions(currentUserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	current := currentTokenClaims(c).SessionID
+	sessionResponses := make([]dto.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		sessionResponses[i] = dto.SessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			Current:    session.FamilyID == current,
+		}
+	}
+
+	c.JSON(http.StatusOK, sessionResponses)
+}
+
+// TerminateSession logs the authenticated user out of one of their sessions
+func (a *AuthController) TerminateSession(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := a.AuthService.TerminateSession(id, currentUserID(c)); err != nil {
+		if errors.Is(err, services.ErrSessionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session terminated"})
+}
+
+// ForgotPassword mails a password reset link to the given email. The
+// response is the same whether or not the email has an account, and the
+// mail is sent in the background so the response time doesn't tell either.
+func (a *AuthController) ForgotPassword(c *gin.Context) {
+	var input struct {
+		Email string `json:"email" binding:"required,email"`
+	}
+
+	// Bind JSON data to input struct
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	go func(email string) {
+		if err := a.AuthService.ForgotPassword(email); err != nil {
+			log.Println("Error sending password reset link:", err)
+		}
+	}(input.Email)
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "If an account with that email exists, a password reset link has been sent to it"})
+}
+
+// ResetPassword sets a new password with the token of a password reset link
+func (a *AuthController) ResetPassword(c *gin.Context) {
+	var input struct {
+		Token    string `json:"token" binding:"required"`
+		Password string `json:"password" binding:"required"`
+	}
+
+	// Bind JSON data to input struct
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	if err := a.AuthService.ResetPassword(input.Token, input.Password); err != nil {
+		if errors.Is(err, services.ErrInvalidResetToken) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset, please log in again"})
+}
+
+// VerifyEmail marks an email verified with the token of a verification link
+func (a *AuthController) VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing token"})
+		return
+	}
+
+	if err := a.AuthService.VerifyEmail(token); err != nil {
+		if errors.Is(err, services.ErrInvalidVerificationToken) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email verified"})
+}
+
+// ResendVerification mails the authenticated user a new verification link
+func (a *AuthController) ResendVerification(c *gin.Context) {
+	if err := a.AuthService.ResendVerification(currentUserID(c)); err != nil {
+		var retryErr *services.RetryLaterError
+		switch {
+		case errors.As(err, &retryErr):
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryErr.RetryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrEmailAlreadyVerified):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Verification link sent"})
+}
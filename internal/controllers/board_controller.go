@@ -0,0 +1,259 @@
+// internal/controllers/board_controller.go
+package controllers
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	dto "TaskManager/pkg/utils"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BoardController handles HTTP requests related to Kanban boards
+type BoardController struct {
+	BoardService services.BoardService
+}
+
+// NewBoardController creates and returns a new BoardController instance
+func NewBoardController(boardService services.BoardService) *BoardController {
+	return &BoardController{
+		BoardService: boardService,
+	}
+}
+
+// toBoardColumnResponse maps a board column to its API representation
+func toBoardColumnResponse(column *models.BoardColumn) dto.BoardColumnResponse {
+	return dto.BoardColumnResponse{
+		ID:       column.ID,
+		Name:     column.Name,
+		Status:   column.Status,
+		Position: column.Position,
+		WipLimit: column.WipLimit,
+	}
+}
+
+// toBoardResponse maps a board model to its API representation
+func toBoardResponse(board *models.Board) dto.BoardResponse {
+	response := dto.BoardResponse{
+		ID:        board.ID,
+		ProjectID: board.ProjectID,
+		Name:      board.Name,
+		Columns:   make([]dto.BoardColumnResponse, 0, len(board.Columns)),
+		CreatedAt: board.CreatedAt,
+		UpdatedAt: board.UpdatedAt,
+	}
+	for i := range board.Columns {
+		response.Columns = append(response.Columns, toBoardColumnResponse(&board.Columns[i]))
+	}
+	return response
+}
+
+// toBoardColumns maps requested columns to board column models
+func toBoardColumns(requests []dto.BoardColumnRequest) []models.BoardColumn {
+	columns := make([]models.BoardColumn, 0, len(requests))
+	for _, column := range requests {
+		columns = append(columns, models.BoardColumn{
+			Name:     column.Name,
+			Status:   column.Status,
+			WipLimit: column.WipLimit,
+		})
+	}
+	return columns
+}
+
+// respondBoardError writes the HTTP response matching a board service error
+func respondBoardError(c *gin.Context, err error) {
+	if respondTransitionError(c, err) {
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrBoardNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Board not found"})
+	case errors.Is(err, services.ErrProjectNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+	case errors.Is(err, services.ErrTaskNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+	case errors.Is(err, services.ErrColumnNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrProjectForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrBoardNameRequired),
+		errors.Is(err, services.ErrBoardColumnsRequired),
+		errors.Is(err, services.ErrColumnNameRequired),
+		errors.Is(err, services.ErrDuplicateColumnStatus),
+		errors.Is(err, services.ErrInvalidWipLimit),
+		errors.Is(err, services.ErrInvalidTaskStatus):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrWipLimitReached),
+		errors.Is(err, services.ErrCardMoved),
+		errors.Is(err, services.ErrTaskBlocked):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Println("Board error:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// CreateBoard handles creating a board in a project
+func (b *BoardController) CreateBoard(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var boardRequest dto.BoardCreateRequest
+	if err := c.ShouldBindJSON(&boardRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	board := models.Board{
+		ProjectID: projectID,
+		Name:      boardRequest.Name,
+		Columns:   toBoardColumns(boardRequest.Columns),
+	}
+
+	newBoard, err := b.BoardService.CreateBoard(&board, currentUserID(c))
+	if err != nil {
+		respondBoardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toBoardResponse(newBoard))
+}
+
+// GetProjectBoards handles listing the boards of a project
+func (b *BoardController) GetProjectBoards(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	boards, err := b.BoardService.GetBoardsByProject(projectID, currentUserID(c))
+	if err != nil {
+		respondBoardError(c, err)
+		return
+	}
+
+	boardResponses := make([]dto.BoardResponse, 0, len(boards))
+	for i := range boards {
+		boardResponses = append(boardResponses, toBoardResponse(&boards[i]))
+	}
+
+	c.JSON(http.StatusOK, boardResponses)
+}
+
+// GetBoard handles retrieving a board with its cards laid out in columns
+func (b *BoardController) GetBoard(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	view, err := b.BoardService.GetBoardView(id, currentUserID(c))
+	if err != nil {
+		respondBoardError(c, err)
+		return
+	}
+
+	response := dto.BoardViewResponse{
+		ID:        view.Board.ID,
+		ProjectID: view.Board.ProjectID,
+		Name:      view.Board.Name,
+		Columns:   make([]dto.BoardColumnCardsResponse, 0, len(view.Columns)),
+	}
+	for i := range view.Columns {
+		column := dto.BoardColumnCardsResponse{
+			BoardColumnResponse: toBoardColumnResponse(&view.Columns[i].Column),
+			Cards:               make([]dto.TaskResponse, 0, len(view.Columns[i].Cards)),
+		}
+		for j := range view.Columns[i].Cards {
+			column.Cards = append(column.Cards, toTaskResponse(&view.Columns[i].Cards[j]))
+		}
+		response.Columns = append(response.Columns, column)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateBoard handles renaming a board and replacing its columns
+func (b *BoardController) UpdateBoard(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var boardRequest dto.BoardUpdateRequest
+	if err := c.ShouldBindJSON(&boardRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	userID := currentUserID(c)
+	board, err := b.BoardService.GetBoardByID(id, userID)
+	if err != nil {
+		respondBoardError(c, err)
+		return
+	}
+
+	if boardRequest.Name != nil {
+		board.Name = *boardRequest.Name
+	}
+	if boardRequest.Columns != nil {
+		board.Columns = toBoardColumns(boardRequest.Columns)
+	}
+
+	updatedBoard, err := b.BoardService.UpdateBoard(board, userID)
+	if err != nil {
+		respondBoardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toBoardResponse(updatedBoard))
+}
+
+// DeleteBoard handles deleting a board; its tasks are kept
+func (b *BoardController) DeleteBoard(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := b.BoardService.DeleteBoard(id, currentUserID(c)); err != nil {
+		respondBoardError(c, err)
+		return
+	}
+
+	log.Printf("Board with ID %d deleted successfully", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Board deleted successfully"})
+}
+
+// MoveCard handles moving a card to a column and position in one step
+func (b *BoardController) MoveCard(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	taskID, ok := parseIDParam(c, "taskId")
+	if !ok {
+		return
+	}
+
+	var moveRequest dto.CardMoveRequest
+	if err := c.ShouldBindJSON(&moveRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	task, err := b.BoardService.MoveCard(id, taskID, moveRequest.ColumnID, moveRequest.Position, currentUserID(c))
+	if err != nil {
+		respondBoardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTaskResponse(task))
+}
@@ -0,0 +1,205 @@
+// internal/controllers/comment_controller.go
+package controllers
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	"TaskManager/pkg/markdown"
+	dto "TaskManager/pkg/utils"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CommentController handles HTTP requests related to task comments
+type CommentController struct {
+	CommentService services.CommentService
+}
+
+// NewCommentController creates and returns a new CommentController instance
+func NewCommentController(commentService services.CommentService) *CommentController {
+	return &CommentController{
+		CommentService: commentService,
+	}
+}
+
+// toCommentResponse maps a comment and its replies to their API representation,
+// rendering the Markdown body to safe HTML
+func toCommentResponse(comment *models.Comment) dto.CommentResponse {
+	response := dto.CommentResponse{
+		ID:        comment.ID,
+		TaskID:    comment.TaskID,
+		ParentID:  comment.ParentID,
+		Author:    dto.TaskUserResponse{ID: comment.UserID},
+		Deleted:   comment.DeletedAt.Valid,
+		EditedAt:  comment.EditedAt,
+		CreatedAt: comment.CreatedAt,
+	}
+	if comment.User != nil {
+		response.Author.Username = comment.User.Username
+	}
+	if !response.Deleted {
+		response.Body = comment.Body
+		response.BodyHTML = markdown.Render(comment.Body)
+	}
+	for _, mention := range comment.Mentions {
+		user := dto.TaskUserResponse{ID: mention.UserID}
+		if mention.User != nil {
+			user.Username = mention.User.Username
+		}
+		response.Mentions = append(response.Mentions, user)
+	}
+	for i := range comment.Replies {
+		response.Replies = append(response.Replies, toCommentResponse(&comment.Replies[i]))
+	}
+	return response
+}
+
+// toCommentResponses maps a list of comments to their API representation
+func toCommentResponses(comments []models.Comment) []dto.CommentResponse {
+	responses := make([]dto.CommentResponse, 0, len(comments))
+	for i := range comments {
+		responses = append(responses, toCommentResponse(&comments[i]))
+	}
+	return responses
+}
+
+// respondCommentError writes the HTTP response matching a comment service error
+func respondCommentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrCommentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+	case errors.Is(err, services.ErrTaskNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+	case errors.Is(err, services.ErrCommentForbidden),
+		errors.Is(err, services.ErrProjectForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCommentBodyRequired),
+		errors.Is(err, services.ErrCommentTooLong),
+		errors.Is(err, services.ErrInvalidParentComment):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Println("Comment error:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// CreateComment handles posting a comment or reply on a task
+func (cc *CommentController) CreateComment(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var commentRequest dto.CommentCreateRequest
+	if err := c.ShouldBindJSON(&commentRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	comment := models.Comment{
+		TaskID:   taskID,
+		ParentID: commentRequest.ParentID,
+		Body:     commentRequest.Body,
+	}
+
+	newComment, err := cc.CommentService.CreateComment(&comment, currentUserID(c))
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toCommentResponse(newComment))
+}
+
+// GetTaskComments handles listing the comment threads of a task
+func (cc *CommentController) GetTaskComments(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	comments, err := cc.CommentService.GetTaskComments(taskID, currentUserID(c))
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toCommentResponses(comments))
+}
+
+// UpdateComment handles editing the body of a comment
+func (cc *CommentController) UpdateComment(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var commentRequest dto.CommentUpdateRequest
+	if err := c.ShouldBindJSON(&commentRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	comment, err := cc.CommentService.UpdateComment(id, commentRequest.Body, currentUserID(c))
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toCommentResponse(comment))
+}
+
+// DeleteComment handles deleting a comment
+func (cc *CommentController) DeleteComment(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := cc.CommentService.DeleteComment(id, currentUserID(c)); err != nil {
+		respondCommentError(c, err)
+		return
+	}
+
+	log.Printf("Comment with ID %d deleted successfully", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Comment deleted successfully"})
+}
+
+// GetCommentHistory handles listing the previous versions of a comment
+func (cc *CommentController) GetCommentHistory(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	revisions, err := cc.CommentService.GetCommentHistory(id, currentUserID(c))
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+
+	responses := make([]dto.CommentRevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		responses = append(responses, dto.CommentRevisionResponse{
+			ID:        revision.ID,
+			Body:      revision.Body,
+			BodyHTML:  markdown.Render(revision.Body),
+			CreatedAt: revision.CreatedAt,
+		})
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetMentions handles listing the latest comments mentioning the authenticated user
+func (cc *CommentController) GetMentions(c *gin.Context) {
+	comments, err := cc.CommentService.GetMentions(currentUserID(c))
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toCommentResponses(comments))
+}
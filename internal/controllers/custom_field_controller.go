@@ -0,0 +1,217 @@
+// internal/controllers/custom_field_controller.go
+package controllers
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	dto "TaskManager/pkg/utils"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CustomFieldController handles HTTP requests related to a project's custom fields
+type CustomFieldController struct {
+	CustomFieldService services.CustomFieldService
+}
+
+// NewCustomFieldController creates and returns a new CustomFieldController instance
+func NewCustomFieldController(customFieldService services.CustomFieldService) *CustomFieldController {
+	return &CustomFieldController{
+		CustomFieldService: customFieldService,
+	}
+}
+
+// toCustomFieldResponse maps a custom field definition to its API representation
+func toCustomFieldResponse(field *models.CustomField) dto.CustomFieldResponse {
+	return dto.CustomFieldResponse{
+		ID:        field.ID,
+		ProjectID: field.ProjectID,
+		Key:       field.Key,
+		Name:      field.Name,
+		Type:      field.Type,
+		Required:  field.Required,
+		Options:   field.Options,
+		Position:  field.Position,
+		CreatedAt: field.CreatedAt,
+		UpdatedAt: field.UpdatedAt,
+	}
+}
+
+// toCustomFieldValues maps a task's custom values to their field keys;
+// multi-select fields collect their options in a list. Nil without values.
+func toCustomFieldValues(values []models.CustomFieldValue) map[string]interface{} {
+	if len(values) == 0 {
+		return nil
+	}
+	result := make(map[string]interface{}, len(values))
+	for _, value := range values {
+		if value.Field == nil {
+			continue
+		}
+		key := value.Field.Key
+		switch value.Field.Type {
+		case models.CustomFieldNumber:
+			if value.NumberValue != nil {
+				result[key] = *value.NumberValue
+			}
+		case models.CustomFieldDate:
+			if value.DateValue != nil {
+				result[key] = value.DateValue.Format("2006-01-02")
+			}
+		case models.CustomFieldUser:
+			if value.UserValue != nil {
+				result[key] = *value.UserValue
+			}
+		case models.CustomFieldMultiSelect:
+			options, _ := result[key].([]string)
+			result[key] = append(options, value.TextValue)
+		default:
+			result[key] = value.TextValue
+		}
+	}
+	return result
+}
+
+// respondCustomFieldError writes the HTTP response matching a custom field service error
+func respondCustomFieldError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrCustomFieldNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Custom field not found"})
+	case errors.Is(err, services.ErrProjectNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+	case errors.Is(err, services.ErrProjectForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidCustomField):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCustomFieldExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Println("Custom field error:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// GetFields handles listing the custom fields of a project; members only
+func (f *CustomFieldController) GetFields(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	fields, err := f.CustomFieldService.GetFields(projectID, currentUserID(c))
+	if err != nil {
+		respondCustomFieldError(c, err)
+		return
+	}
+
+	responses := make([]dto.CustomFieldResponse, 0, len(fields))
+	for i := range fields {
+		responses = append(responses, toCustomFieldResponse(&fields[i]))
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// CreateField handles adding a custom field to a project; owners only
+func (f *CustomFieldController) CreateField(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var fieldRequest dto.CustomFieldCreateRequest
+	if !bindJSON(c, &fieldRequest) {
+		return
+	}
+
+	field := models.CustomField{
+		ProjectID: projectID,
+		Key:       fieldRequest.Key,
+		Name:      fieldRequest.Name,
+		Type:      fieldRequest.Type,
+		Required:  fieldRequest.Required,
+		Options:   fieldRequest.Options,
+	}
+
+	newField, err := f.CustomFieldService.CreateField(&field, currentUserID(c))
+	if err != nil {
+		respondCustomFieldError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toCustomFieldResponse(newField))
+}
+
+// UpdateField handles renaming a custom field, changing whether it is
+// required or replacing its options; owners only
+func (f *CustomFieldController) UpdateField(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	fieldID, ok := parseIDParam(c, "fieldId")
+	if !ok {
+		return
+	}
+
+	var fieldRequest dto.CustomFieldUpdateRequest
+	if !bindJSON(c, &fieldRequest) {
+		return
+	}
+
+	userID := currentUserID(c)
+	fields, err := f.CustomFieldService.GetFields(projectID, userID)
+	if err != nil {
+		respondCustomFieldError(c, err)
+		return
+	}
+	var field *models.CustomField
+	for i := range fields {
+		if fields[i].ID == fieldID {
+			field = &fields[i]
+		}
+	}
+	if field == nil {
+		respondCustomFieldError(c, services.ErrCustomFieldNotFound)
+		return
+	}
+
+	if fieldRequest.Name != nil {
+		field.Name = *fieldRequest.Name
+	}
+	if fieldRequest.Required != nil {
+		field.Required = *fieldRequest.Required
+	}
+	if fieldRequest.Options != nil {
+		field.Options = fieldRequest.Options
+	}
+
+	updatedField, err := f.CustomFieldService.UpdateField(field, userID)
+	if err != nil {
+		respondCustomFieldError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toCustomFieldResponse(updatedField))
+}
+
+// DeleteField handles removing a custom field and its values; owners only
+func (f *CustomFieldController) DeleteField(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	fieldID, ok := parseIDParam(c, "fieldId")
+	if !ok {
+		return
+	}
+
+	if err := f.CustomFieldService.DeleteField(projectID, fieldID, currentUserID(c)); err != nil {
+		respondCustomFieldError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Custom field deleted successfully"})
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"TaskManager/internal/services"
+	"TaskManager/pkg/utils"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// currentUserID returns the ID of the authenticated user set by middleware.AuthRequired
+func currentUserID(c *gin.Context) uint {
+	return c.GetUint("user_id")
+}
+
+// currentTokenClaims returns the claims of the access token the request was
+// authenticated with, set by middleware.AuthRequired
+func currentTokenClaims(c *gin.Context) *utils.TokenClaims {
+	claims, _ := c.MustGet("token_claims").(*utils.TokenClaims)
+	return claims
+}
+
+// parseIDParam reads a numeric path parameter, writing a 400 response when it is invalid
+func parseIDParam(c *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// respondTransitionError writes a structured response for workflow transition
+// errors and reports whether err was one: 409 when no transition connects the
+// states, 422 when a guard of the transition fails
+func respondTransitionError(c *gin.Context, err error) bool {
+	var transitionErr *services.TransitionError
+	if !errors.As(err, &transitionErr) {
+		return false
+	}
+
+	if transitionErr.Guard != "" {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error": transitionErr.Error(),
+			"code":  "transition_guard_failed",
+			"from":  transitionErr.From,
+			"to":    transitionErr.To,
+			"guard": transitionErr.Guard,
+		})
+		return true
+	}
+
+	c.JSON(http.StatusConflict, gin.H{
+		"error":   transitionErr.Error(),
+		"code":    "invalid_transition",
+		"from":    transitionErr.From,
+		"to":      transitionErr.To,
+		"allowed": transitionErr.Allowed,
+	})
+	return true
+}
@@ -0,0 +1,254 @@
+// internal/controllers/label_controller.go
+package controllers
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	dto "TaskManager/pkg/utils"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LabelController handles HTTP requests related to labels
+type LabelController struct {
+	LabelService services.LabelService
+}
+
+// NewLabelController creates and returns a new LabelController instance
+func NewLabelController(labelService services.LabelService) *LabelController {
+	return &LabelController{
+		LabelService: labelService,
+	}
+}
+
+// toLabelResponse maps a label model to its API representation
+func toLabelResponse(label *models.Label) dto.LabelResponse {
+	return dto.LabelResponse{
+		ID:        label.ID,
+		Name:      label.Name,
+		Color:     label.Color,
+		UserID:    label.UserID,
+		ProjectID: label.ProjectID,
+		CreatedAt: label.CreatedAt,
+		UpdatedAt: label.UpdatedAt,
+	}
+}
+
+// toLabelResponses maps a list of labels to their API representation
+func toLabelResponses(labels []models.Label) []dto.LabelResponse {
+	responses := make([]dto.LabelResponse, 0, len(labels))
+	for i := range labels {
+		responses = append(responses, toLabelResponse(&labels[i]))
+	}
+	return responses
+}
+
+// respondLabelError writes the HTTP response matching a label service error
+func respondLabelError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrLabelNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Label not found"})
+	case errors.Is(err, services.ErrTaskNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+	case errors.Is(err, services.ErrProjectNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+	case errors.Is(err, services.ErrProjectForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrLabelNameRequired),
+		errors.Is(err, services.ErrInvalidLabelColor),
+		errors.Is(err, services.ErrLabelScopeMismatch),
+		errors.Is(err, services.ErrLabelMergeSelf):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrLabelExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Println("Label error:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// CreateLabel handles creating a personal or project label
+func (l *LabelController) CreateLabel(c *gin.Context) {
+	var labelRequest dto.LabelCreateRequest
+	if err := c.ShouldBindJSON(&labelRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	label := models.Label{
+		Name:      labelRequest.Name,
+		Color:     labelRequest.Color,
+		ProjectID: labelRequest.ProjectID,
+	}
+
+	newLabel, err := l.LabelService.CreateLabel(&label, currentUserID(c))
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toLabelResponse(newLabel))
+}
+
+// GetPersonalLabels handles listing the authenticated user's personal labels
+func (l *LabelController) GetPersonalLabels(c *gin.Context) {
+	labels, err := l.LabelService.GetPersonalLabels(currentUserID(c))
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toLabelResponses(labels))
+}
+
+// GetProjectLabels handles listing the labels of a project
+func (l *LabelController) GetProjectLabels(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	labels, err := l.LabelService.GetProjectLabels(projectID, currentUserID(c))
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toLabelResponses(labels))
+}
+
+// GetLabel handles retrieving a single label
+func (l *LabelController) GetLabel(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	label, err := l.LabelService.GetLabelByID(id, currentUserID(c))
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toLabelResponse(label))
+}
+
+// UpdateLabel handles renaming or recolouring a label
+func (l *LabelController) UpdateLabel(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var labelRequest dto.LabelUpdateRequest
+	if err := c.ShouldBindJSON(&labelRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	userID := currentUserID(c)
+	label, err := l.LabelService.GetLabelByID(id, userID)
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	if labelRequest.Name != nil {
+		label.Name = *labelRequest.Name
+	}
+	if labelRequest.Color != nil {
+		label.Color = *labelRequest.Color
+	}
+
+	updatedLabel, err := l.LabelService.UpdateLabel(label, userID)
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toLabelResponse(updatedLabel))
+}
+
+// DeleteLabel handles deleting a label; tagged tasks simply lose it
+func (l *LabelController) DeleteLabel(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := l.LabelService.DeleteLabel(id, currentUserID(c)); err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	log.Printf("Label with ID %d deleted successfully", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Label deleted successfully"})
+}
+
+// MergeLabel handles merging a label into another one
+func (l *LabelController) MergeLabel(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var mergeRequest dto.LabelMergeRequest
+	if err := c.ShouldBindJSON(&mergeRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	target, err := l.LabelService.MergeLabels(id, mergeRequest.IntoID, currentUserID(c))
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	log.Printf("Label with ID %d merged into %d", id, target.ID)
+	c.JSON(http.StatusOK, toLabelResponse(target))
+}
+
+// AddTaskLabel handles tagging a task with a label
+func (l *LabelController) AddTaskLabel(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var labelRequest dto.TaskLabelRequest
+	if err := c.ShouldBindJSON(&labelRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	task, err := l.LabelService.AddTaskLabel(taskID, labelRequest.LabelID, currentUserID(c))
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTaskResponse(task))
+}
+
+// RemoveTaskLabel handles removing a label from a task
+func (l *LabelController) RemoveTaskLabel(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	labelID, ok := parseIDParam(c, "labelId")
+	if !ok {
+		return
+	}
+
+	task, err := l.LabelService.RemoveTaskLabel(taskID, labelID, currentUserID(c))
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTaskResponse(task))
+}
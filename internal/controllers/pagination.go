@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"TaskManager/pkg/query"
+	dto "TaskManager/pkg/utils"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseListQuery reads the filter, sort and paging parameters of a list
+// request. Invalid parameters get a 400 response naming each of them.
+func parseListQuery(c *gin.Context, spec query.Spec) (*query.Query, bool) {
+	q, err := query.Parse(c.Request.URL.Query(), spec)
+	if err != nil {
+		var queryErr *query.Error
+		if errors.As(err, &queryErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "fields": queryErr.Params})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return nil, false
+	}
+	return q, true
+}
+
+// respondPage writes a page of a list in the {"data", "next_cursor"}
+// envelope. When more rows follow, a Link header points at the next page:
+// the request URL with the cursor replaced.
+func respondPage(c *gin.Context, data interface{}, next string) {
+	page := dto.PageResponse{Data: data}
+	if next != "" {
+		page.NextCursor = &next
+
+		nextURL := *c.Request.URL
+		values := nextURL.Query()
+		values.Set(query.ParamCursor, next)
+		nextURL.RawQuery = values.Encode()
+		c.Header("Link", fmt.Sprintf("<%s>; rel=\"next\"", nextURL.RequestURI()))
+	}
+	c.JSON(http.StatusOK, page)
+}
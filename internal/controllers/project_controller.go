@@ -0,0 +1,373 @@
+// internal/controllers/project_controller.go
+package controllers
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	dto "TaskManager/pkg/utils"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProjectController handles HTTP requests related to projects and their members
+type ProjectController struct {
+	ProjectService services.ProjectService
+}
+
+// NewProjectController creates and returns a new ProjectController instance
+func NewProjectController(projectService services.ProjectService) *ProjectController {
+	return &ProjectController{
+		ProjectService: projectService,
+	}
+}
+
+// toProjectResponse maps a project model to its API representation
+func toProjectResponse(project *models.Project) dto.ProjectResponse {
+	return dto.ProjectResponse{
+		ID:          project.ID,
+		Name:        project.Name,
+		Description: project.Description,
+		OwnerID:     project.OwnerID,
+		CreatedAt:   project.CreatedAt,
+		UpdatedAt:   project.UpdatedAt,
+	}
+}
+
+// toProjectMemberResponse maps a project membership to its API representation
+func toProjectMemberResponse(member *models.ProjectMember) dto.ProjectMemberResponse {
+	response := dto.ProjectMemberResponse{
+		UserID:   member.UserID,
+		Role:     member.Role,
+		JoinedAt: member.CreatedAt,
+	}
+	if member.User != nil {
+		response.Username = member.User.Username
+	}
+	return response
+}
+
+// respondProjectError writes the HTTP response matching a project service error
+func respondProjectError(c *gin.Context, err error) {
+	if respondValidationError(c, err) {
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrProjectNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+	case errors.Is(err, services.ErrMemberNotFound), errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrProjectForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrMemberExists),
+		errors.Is(err, services.ErrLastOwner),
+		errors.Is(err, services.ErrScheduleCycle):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrProjectNameRequired), errors.Is(err, services.ErrInvalidProjectRole):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Println("Project error:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// CreateProject handles creating a new project owned by the authenticated user
+func (p *ProjectController) CreateProject(c *gin.Context) {
+	var projectRequest dto.ProjectCreateRequest
+	if err := c.ShouldBindJSON(&projectRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	project := models.Project{
+		Name:        projectRequest.Name,
+		Description: projectRequest.Description,
+		OwnerID:     currentUserID(c),
+	}
+
+	newProject, err := p.ProjectService.CreateProject(&project)
+	if err != nil {
+		respondProjectError(c, err)
+		return
+	}
+
+	log.Println("Project created successfully:", newProject.Name)
+	c.JSON(http.StatusCreated, toProjectResponse(newProject))
+}
+
+// GetAllProjects handles listing the projects the authenticated user belongs to
+func (p *ProjectController) GetAllProjects(c *gin.Context) {
+	projects, err := p.ProjectService.GetProjectsByUser(currentUserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	projectResponses := make([]dto.ProjectResponse, 0, len(projects))
+	for i := range projects {
+		projectResponses = append(projectResponses, toProjectResponse(&projects[i]))
+	}
+
+	c.JSON(http.StatusOK, projectResponses)
+}
+
+// GetProjectByID handles retrieving a single project
+func (p *ProjectController) GetProjectByID(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	project, err := p.ProjectService.GetProjectByID(id, currentUserID(c))
+	if err != nil {
+		respondProjectError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toProjectResponse(project))
+}
+
+// UpdateProject handles updating a project's name and description
+func (p *ProjectController) UpdateProject(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var projectRequest dto.ProjectUpdateRequest
+	if err := c.ShouldBindJSON(&projectRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	userID := currentUserID(c)
+	project, err := p.ProjectService.GetProjectByID(id, userID)
+	if err != nil {
+		respondProjectError(c, err)
+		return
+	}
+
+	if projectRequest.Name != nil {
+		project.Name = *projectRequest.Name
+	}
+	if projectRequest.Description != nil {
+		project.Description = *projectRequest.Description
+	}
+
+	updatedProject, err := p.ProjectService.UpdateProject(project, userID)
+	if err != nil {
+		respondProjectError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toProjectResponse(updatedProject))
+}
+
+// DeleteProject handles deleting a project together with its tasks
+func (p *ProjectController) DeleteProject(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := p.ProjectService.DeleteProject(id, currentUserID(c)); err != nil {
+		respondProjectError(c, err)
+		return
+	}
+
+	log.Printf("Project with ID %d deleted successfully", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Project deleted successfully"})
+}
+
+// GetMembers handles listing the members of a project
+func (p *ProjectController) GetMembers(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	members, err := p.ProjectService.GetMembers(id, currentUserID(c))
+	if err != nil {
+		respondProjectError(c, err)
+		return
+	}
+
+	memberResponses := make([]dto.ProjectMemberResponse, 0, len(members))
+	for i := range members {
+		memberResponses = append(memberResponses, toProjectMemberResponse(&members[i]))
+	}
+
+	c.JSON(http.StatusOK, memberResponses)
+}
+
+// AddMember handles adding a user to a project with a role
+func (p *ProjectController) AddMember(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var memberRequest dto.ProjectMemberRequest
+	if err := c.ShouldBindJSON(&memberRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	member, err := p.ProjectService.AddMember(id, currentUserID(c), memberRequest.UserID, memberRequest.Role)
+	if err != nil {
+		respondProjectError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toProjectMemberResponse(member))
+}
+
+// UpdateMember handles changing a member's role
+func (p *ProjectController) UpdateMember(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	memberID, ok := parseIDParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	var roleRequest dto.ProjectMemberRoleRequest
+	if err := c.ShouldBindJSON(&roleRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	member, err := p.ProjectService.UpdateMemberRole(id, currentUserID(c), memberID, roleRequest.Role)
+	if err != nil {
+		respondProjectError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toProjectMemberResponse(member))
+}
+
+// RemoveMember handles removing a member from a project
+func (p *ProjectController) RemoveMember(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	memberID, ok := parseIDParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	if err := p.ProjectService.RemoveMember(id, currentUserID(c), memberID); err != nil {
+		respondProjectError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed successfully"})
+}
+
+// GetProjectTasks handles listing the tasks of a project; members only.
+// Custom fields filter with cf.<key>=value or cf.<key>.<op>=value (op is eq,
+// ne, lt, gt or contains), e.g. ?cf.points.gt=3; sort=[-]<column or key>
+// orders the tasks, e.g. ?sort=-points.
+func (p *ProjectController) GetProjectTasks(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	query := services.TaskListQuery{Sort: c.Query("sort")}
+	for param, values := range c.Request.URL.Query() {
+		if !strings.HasPrefix(param, "cf.") {
+			continue
+		}
+		key, op := strings.TrimPrefix(param, "cf."), ""
+		if i := strings.IndexByte(key, '.'); i >= 0 {
+			key, op = key[:i], key[i+1:]
+		}
+		for _, value := range values {
+			query.Filters = append(query.Filters, services.TaskFilter{Key: key, Op: op, Value: value})
+		}
+	}
+
+	tasks, err := p.ProjectService.QueryProjectTasks(id, currentUserID(c), query)
+	if err != nil {
+		respondProjectError(c, err)
+		return
+	}
+
+	taskResponses := make([]dto.TaskResponse, 0, len(tasks))
+	for i := range tasks {
+		taskResponses = append(taskResponses, toTaskResponse(&tasks[i]))
+	}
+
+	c.JSON(http.StatusOK, taskResponses)
+}
+
+// GetProjectSchedule handles computing the critical-path schedule of a project.
+// The optional start query parameter (RFC 3339) defaults to now.
+func (p *ProjectController) GetProjectSchedule(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	start := time.Now().UTC().Truncate(time.Minute)
+	if raw := c.Query("start"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start, expected RFC 3339"})
+			return
+		}
+		start = parsed
+	}
+
+	projectSchedule, err := p.ProjectService.GetProjectSchedule(id, currentUserID(c), start)
+	if err != nil {
+		respondProjectError(c, err)
+		return
+	}
+
+	result := projectSchedule.Schedule
+	response := dto.ScheduleResponse{
+		ProjectID:    projectSchedule.ProjectID,
+		Start:        result.Start,
+		Finish:       result.Finish,
+		CriticalPath: result.CriticalPath,
+		Tasks:        make([]dto.ScheduleTaskResponse, 0, len(result.Entries)),
+	}
+	if response.CriticalPath == nil {
+		response.CriticalPath = []uint{}
+	}
+
+	for _, entry := range result.Entries {
+		task := projectSchedule.Tasks[entry.ID]
+		dependsOn := entry.DependsOn
+		if dependsOn == nil {
+			dependsOn = []uint{}
+		}
+		response.Tasks = append(response.Tasks, dto.ScheduleTaskResponse{
+			ID:             entry.ID,
+			Title:          task.Title,
+			Status:         task.Status,
+			DueDate:        task.DueDate,
+			DependsOn:      dependsOn,
+			Duration:       int(entry.Duration / time.Minute),
+			EarliestStart:  entry.EarliestStart,
+			EarliestFinish: entry.EarliestFinish,
+			LatestStart:    entry.LatestStart,
+			LatestFinish:   entry.LatestFinish,
+			Slack:          int(entry.Slack / time.Minute),
+			Critical:       entry.Critical,
+			Late:           entry.Late,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
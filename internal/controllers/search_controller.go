@@ -0,0 +1,66 @@
+// internal/controllers/search_controller.go
+package controllers
+
+import (
+	"TaskManager/internal/repositories"
+	"TaskManager/internal/services"
+	dto "TaskManager/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchController handles HTTP requests related to full-text search
+type SearchController struct {
+	SearchService services.SearchService
+}
+
+// NewSearchController creates and returns a new SearchController instance
+func NewSearchController(searchService services.SearchService) *SearchController {
+	return &SearchController{
+		SearchService: searchService,
+	}
+}
+
+// toSearchHitResponses maps search hits to their API representation
+func toSearchHitResponses(hits []repositories.SearchHit) []dto.SearchHitResponse {
+	responses := make([]dto.SearchHitResponse, len(hits))
+	for i, hit := range hits {
+		responses[i] = dto.SearchHitResponse{
+			Kind:      hit.Kind,
+			TaskID:    hit.TaskID,
+			CommentID: hit.CommentID,
+			ProjectID: hit.ProjectID,
+			Title:     hit.Title,
+			Snippet:   hit.Snippet,
+			Rank:      hit.Rank,
+			UpdatedAt: hit.UpdatedAt,
+		}
+	}
+	return responses
+}
+
+// Search handles searching the titles, descriptions and comments of the
+// tasks the authenticated user can see (?q=login crash&limit=20)
+func (s *SearchController) Search(c *gin.Context) {
+	limit := 0
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "fields": gin.H{"limit": "must be a positive number"}})
+			return
+		}
+		limit = n
+	}
+
+	hits, err := s.SearchService.Search(c.Query("q"), limit, currentUserID(c))
+	if err != nil {
+		if !respondValidationError(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toSearchHitResponses(hits))
+}
@@ -0,0 +1,792 @@
+// internal/controllers/task_controller.go
+package controllers
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	"TaskManager/pkg/tql"
+	dto "TaskManager/pkg/utils"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TaskController handles HTTP requests related to task operations
+type TaskController struct {
+	TaskService services.TaskService
+}
+
+// NewTaskController creates and returns a new TaskController instance
+func NewTaskController(taskService services.TaskService) *TaskController {
+	return &TaskController{
+		TaskService: taskService,
+	}
+}
+
+// toTaskUserResponses maps a task's assignees or watchers to their API representation
+func toTaskUserResponses(users []models.User) []dto.TaskUserResponse {
+	if len(users) == 0 {
+		return nil
+	}
+	responses := make([]dto.TaskUserResponse, 0, len(users))
+	for _, user := range users {
+		responses = append(responses, dto.TaskUserResponse{ID: user.ID, Username: user.Username})
+	}
+	return responses
+}
+
+// toTaskLabelResponses maps a task's labels to their API representation
+func toTaskLabelResponses(labels []models.Label) []dto.TaskLabelResponse {
+	if len(labels) == 0 {
+		return nil
+	}
+	responses := make([]dto.TaskLabelResponse, 0, len(labels))
+	for _, label := range labels {
+		responses = append(responses, dto.TaskLabelResponse{ID: label.ID, Name: label.Name, Color: label.Color})
+	}
+	return responses
+}
+
+// toTaskResponse maps a task model to its API representation
+func toTaskResponse(task *models.Task) dto.TaskResponse {
+	return dto.TaskResponse{
+		ID:                 task.ID,
+		Title:              task.Title,
+		Description:        task.Description,
+		Status:             task.Status,
+		Closed:             task.Closed,
+		Resolution:         task.Resolution,
+		Priority:           task.Priority,
+		DueDate:            task.DueDate,
+		EstimateMinutes:    task.EstimateMinutes,
+		UserID:             task.UserID,
+		ProjectID:          task.ProjectID,
+		ParentID:           task.ParentID,
+		Progress:           task.Progress,
+		Recurrence:         task.Recurrence,
+		RecurrenceTimezone: task.RecurrenceTimezone,
+		RecurrenceStart:    task.RecurrenceStart,
+		Assignees:          toTaskUserResponses(task.Assignees),
+		Watchers:           toTaskUserResponses(task.Watchers),
+		Labels:             toTaskLabelResponses(task.Labels),
+		Checklist:          toChecklistSummary(task),
+		ChecklistAdvanceTo: task.ChecklistAdvanceTo,
+		CustomFields:       toCustomFieldValues(task.CustomValues),
+		CreatedAt:          task.CreatedAt,
+		UpdatedAt:          task.UpdatedAt,
+	}
+}
+
+// toTaskTreeResponse maps a task node and its subtasks to their API representation
+func toTaskTreeResponse(node *services.TaskNode) dto.TaskTreeResponse {
+	response := dto.TaskTreeResponse{
+		TaskResponse: toTaskResponse(&node.Task),
+		Subtasks:     make([]dto.TaskTreeResponse, 0, len(node.Subtasks)),
+	}
+	for _, subtask := range node.Subtasks {
+		response.Subtasks = append(response.Subtasks, toTaskTreeResponse(subtask))
+	}
+	return response
+}
+
+// respondTaskError writes the HTTP response matching a task service error
+func respondTaskError(c *gin.Context, err error) {
+	if respondTransitionError(c, err) || respondValidationError(c, err) {
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrTaskNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+	case errors.Is(err, services.ErrProjectNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+	case errors.Is(err, services.ErrProjectForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrTaskTitleRequired),
+		errors.Is(err, services.ErrInvalidTaskStatus),
+		errors.Is(err, services.ErrInvalidTaskPriority),
+		errors.Is(err, services.ErrInvalidTaskEstimate),
+		errors.Is(err, services.ErrTaskProjectMismatch),
+		errors.Is(err, services.ErrInvalidRecurrence),
+		errors.Is(err, services.ErrInvalidTimezone),
+		errors.Is(err, services.ErrRecurrenceStartRequired),
+		errors.Is(err, services.ErrChecklistTextRequired),
+		errors.Is(err, services.ErrChecklistTextTooLong),
+		errors.Is(err, services.ErrChecklistFull),
+		errors.Is(err, services.ErrInvalidChecklistAdvanceTo):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAssigneeNoAccess):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrDependencyNotFound),
+		errors.Is(err, services.ErrUserNotFound),
+		errors.Is(err, services.ErrNotAssigned),
+		errors.Is(err, services.ErrChecklistItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrTaskCycle),
+		errors.Is(err, services.ErrTaskInHierarchy),
+		errors.Is(err, services.ErrDependencyCycle),
+		errors.Is(err, services.ErrDependencyExists),
+		errors.Is(err, services.ErrTaskBlocked),
+		errors.Is(err, services.ErrWipLimitReached),
+		errors.Is(err, services.ErrAlreadyAssigned):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Println("Task error:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// CreateTask handles creating a new task for the authenticated user
+func (t *TaskController) CreateTask(c *gin.Context) {
+	var taskRequest dto.TaskCreateRequest
+	if !bindJSON(c, &taskRequest) {
+		return
+	}
+
+	task := models.Task{
+		Title:              taskRequest.Title,
+		Description:        taskRequest.Description,
+		Status:             taskRequest.Status,
+		Priority:           taskRequest.Priority,
+		DueDate:            taskRequest.DueDate,
+		ProjectID:          taskRequest.ProjectID,
+		ParentID:           taskRequest.ParentID,
+		EstimateMinutes:    taskRequest.EstimateMinutes,
+		UserID:             currentUserID(c),
+		Recurrence:         taskRequest.Recurrence,
+		RecurrenceTimezone: taskRequest.RecurrenceTimezone,
+		ChecklistAdvanceTo: taskRequest.ChecklistAdvanceTo,
+		CustomFieldInput:   taskRequest.CustomFields,
+	}
+	for _, text := range taskRequest.Checklist {
+		task.Checklist = append(task.Checklist, models.ChecklistItem{Text: text})
+	}
+
+	newTask, err := t.TaskService.CreateTask(&task)
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toTaskResponse(newTask))
+}
+
+// GetTaskByID handles retrieving a task visible to the authenticated user
+func (t *TaskController) GetTaskByID(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	task, err := t.TaskService.GetTaskByID(id, currentUserID(c))
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTaskResponse(task))
+}
+
+// toTaskResponses maps a list of tasks to their API representation
+func toTaskResponses(tasks []models.Task) []dto.TaskResponse {
+	taskResponses := make([]dto.TaskResponse, 0, len(tasks))
+	for i := range tasks {
+		taskResponses = append(taskResponses, toTaskResponse(&tasks[i]))
+	}
+	return taskResponses
+}
+
+// GetAllTasks handles retrieving all tasks of the authenticated user;
+// ?labels=a,b&match=any|all keeps the tasks tagged with any or all of the labels
+func (t *TaskController) GetAllTasks(c *gin.Context) {
+	var labels []string
+	if raw := c.Query("labels"); raw != "" {
+		labels = strings.Split(raw, ",")
+	}
+
+	matchAll := false
+	switch c.DefaultQuery("match", "any") {
+	case "any":
+	case "all":
+		matchAll = true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match, use any or all"})
+		return
+	}
+
+	tasks, err := t.TaskService.GetTasksByUser(currentUserID(c), labels, matchAll)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, toTaskResponses(tasks))
+}
+
+// GetAssignedTasks handles listing the tasks assigned to the authenticated user
+func (t *TaskController) GetAssignedTasks(c *gin.Context) {
+	tasks, err := t.TaskService.GetAssignedTasks(currentUserID(c))
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTaskResponses(tasks))
+}
+
+// GetWatchedTasks handles listing the tasks the authenticated user watches
+func (t *TaskController) GetWatchedTasks(c *gin.Context) {
+	tasks, err := t.TaskService.GetWatchedTasks(currentUserID(c))
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTaskResponses(tasks))
+}
+
+// respondSearchError writes a 400 response locating the problem of a task
+// query and reports whether err was one
+func respondSearchError(c *gin.Context, err error) bool {
+	var queryErr *tql.Error
+	if !errors.As(err, &queryErr) {
+		return false
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query", "message": queryErr.Message, "position": queryErr.Pos})
+	return true
+}
+
+// SearchTasks handles searching the tasks the authenticated user can see
+// with the task query language (?q=status:open assignee:me sort:priority).
+// Invalid queries get a 400 response with the position of the problem.
+func (t *TaskController) SearchTasks(c *gin.Context) {
+	tasks, err := t.TaskService.SearchTasks(c.Query("q"), currentUserID(c))
+	if err != nil {
+		if !respondSearchError(c, err) {
+			respondTaskError(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toTaskResponses(tasks))
+}
+
+// UpdateTask handles updating a task the authenticated user may edit
+func (t *TaskController) UpdateTask(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var taskRequest dto.TaskUpdateRequest
+	if !bindJSON(c, &taskRequest) {
+		return
+	}
+
+	userID := currentUserID(c)
+	task, err := t.TaskService.GetTaskByID(id, userID)
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	if taskRequest.Title != nil {
+		task.Title = *taskRequest.Title
+	}
+	if taskRequest.Description != nil {
+		task.Description = *taskRequest.Description
+	}
+	if taskRequest.Status != nil {
+		task.Status = *taskRequest.Status
+	}
+	if taskRequest.Resolution != nil {
+		task.Resolution = *taskRequest.Resolution
+	}
+	if taskRequest.Priority != nil {
+		task.Priority = *taskRequest.Priority
+	}
+	if taskRequest.DueDate != nil {
+		task.DueDate = taskRequest.DueDate
+	}
+	if taskRequest.ProjectID != nil {
+		task.ProjectID = taskRequest.ProjectID
+	}
+	if taskRequest.EstimateMinutes != nil {
+		task.EstimateMinutes = *taskRequest.EstimateMinutes
+	}
+	if taskRequest.Recurrence != nil {
+		task.Recurrence = *taskRequest.Recurrence
+	}
+	if taskRequest.RecurrenceTimezone != nil {
+		task.RecurrenceTimezone = *taskRequest.RecurrenceTimezone
+	}
+	if taskRequest.ChecklistAdvanceTo != nil {
+		task.ChecklistAdvanceTo = *taskRequest.ChecklistAdvanceTo
+	}
+	task.CustomFieldInput = taskRequest.CustomFields
+
+	updatedTask, err := t.TaskService.UpdateTask(task, userID)
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	log.Printf("Task updated successfully: %s (ID: %d)", updatedTask.Title, updatedTask.ID)
+	c.JSON(http.StatusOK, toTaskResponse(updatedTask))
+}
+
+// DeleteTask handles deleting a task the authenticated user may edit
+func (t *TaskController) DeleteTask(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := t.TaskService.DeleteTask(id, currentUserID(c)); err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	log.Printf("Task with ID %d deleted successfully", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Task deleted successfully"})
+}
+
+// GetTaskTree handles retrieving a task together with all of its nested subtasks
+func (t *TaskController) GetTaskTree(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	tree, err := t.TaskService.GetTaskTree(id, currentUserID(c))
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTaskTreeResponse(tree))
+}
+
+// MoveTask handles moving a task and its subtree under a new parent
+func (t *TaskController) MoveTask(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var moveRequest dto.TaskMoveRequest
+	if err := c.ShouldBindJSON(&moveRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	task, err := t.TaskService.MoveTask(id, moveRequest.ParentID, currentUserID(c))
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTaskResponse(task))
+}
+
+// GetBlockers handles listing the tasks that block a task
+func (t *TaskController) GetBlockers(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	blockers, err := t.TaskService.GetBlockers(id, currentUserID(c))
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	taskResponses := make([]dto.TaskResponse, 0, len(blockers))
+	for i := range blockers {
+		taskResponses = append(taskResponses, toTaskResponse(&blockers[i]))
+	}
+
+	c.JSON(http.StatusOK, taskResponses)
+}
+
+// AddDependency handles marking a task as blocked by another task
+func (t *TaskController) AddDependency(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var dependencyRequest dto.TaskDependencyRequest
+	if err := c.ShouldBindJSON(&dependencyRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	dependency, err := t.TaskService.AddDependency(id, dependencyRequest.BlockedByID, currentUserID(c))
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dependency)
+}
+
+// RemoveDependency handles removing a blocker from a task
+func (t *TaskController) RemoveDependency(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	blockerID, ok := parseIDParam(c, "blockerId")
+	if !ok {
+		return
+	}
+
+	if err := t.TaskService.RemoveDependency(id, blockerID, currentUserID(c)); err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Dependency removed successfully"})
+}
+
+// GetNextTasks handles listing the authenticated user's open tasks in dependency order
+func (t *TaskController) GetNextTasks(c *gin.Context) {
+	nextTasks, err := t.TaskService.GetNextTasks(currentUserID(c))
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	responses := make([]dto.NextTaskResponse, 0, len(nextTasks))
+	for i := range nextTasks {
+		blockedBy := nextTasks[i].BlockedBy
+		if blockedBy == nil {
+			blockedBy = []uint{}
+		}
+		responses = append(responses, dto.NextTaskResponse{
+			TaskResponse: toTaskResponse(&nextTasks[i].Task),
+			Ready:        nextTasks[i].Ready,
+			BlockedBy:    blockedBy,
+		})
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// toRecurrencePreviewResponse maps a recurrence preview to its API representation
+func toRecurrencePreviewResponse(preview *services.RecurrencePreview) dto.RecurrencePreviewResponse {
+	occurrences := preview.Occurrences
+	if occurrences == nil {
+		occurrences = []time.Time{}
+	}
+	return dto.RecurrencePreviewResponse{
+		Recurrence:  preview.Rule,
+		Timezone:    preview.Timezone,
+		Occurrences: occurrences,
+	}
+}
+
+// PreviewRecurrence handles listing the first occurrences of a rule before it's saved
+func (t *TaskController) PreviewRecurrence(c *gin.Context) {
+	var previewRequest dto.RecurrencePreviewRequest
+	if err := c.ShouldBindJSON(&previewRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	preview, err := t.TaskService.PreviewRecurrence(previewRequest.Recurrence, previewRequest.Timezone, previewRequest.Start, previewRequest.Count)
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toRecurrencePreviewResponse(preview))
+}
+
+// GetUpcomingOccurrences handles listing the next occurrences of a recurring task;
+// ?count= sets how many (5 by default, at most 100)
+func (t *TaskController) GetUpcomingOccurrences(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	count := 0
+	if raw := c.Query("count"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid count"})
+			return
+		}
+		count = n
+	}
+
+	preview, err := t.TaskService.GetUpcomingOccurrences(id, currentUserID(c), count)
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toRecurrencePreviewResponse(preview))
+}
+
+// AssignTask handles assigning a user to a task
+func (t *TaskController) AssignTask(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var assigneeRequest dto.TaskAssigneeRequest
+	if err := c.ShouldBindJSON(&assigneeRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	task, err := t.TaskService.AssignTask(id, assigneeRequest.UserID, currentUserID(c))
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTaskResponse(task))
+}
+
+// UnassignTask handles removing an assignee from a task
+func (t *TaskController) UnassignTask(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	assigneeID, ok := parseIDParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	task, err := t.TaskService.UnassignTask(id, assigneeID, currentUserID(c))
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTaskResponse(task))
+}
+
+// WatchTask handles the authenticated user starting to watch a task
+func (t *TaskController) WatchTask(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	task, err := t.TaskService.WatchTask(id, currentUserID(c))
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTaskResponse(task))
+}
+
+// UnwatchTask handles the authenticated user no longer watching a task
+func (t *TaskController) UnwatchTask(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	task, err := t.TaskService.UnwatchTask(id, currentUserID(c))
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTaskResponse(task))
+}
+
+// toChecklistSummary summarises a task's checklist, e.g. "3/5 done"; nil without a checklist
+func toChecklistSummary(task *models.Task) *dto.ChecklistSummary {
+	if task.ChecklistTotal == 0 {
+		return nil
+	}
+	return &dto.ChecklistSummary{
+		Done:    task.ChecklistDone,
+		Total:   task.ChecklistTotal,
+		Summary: fmt.Sprintf("%d/%d done", task.ChecklistDone, task.ChecklistTotal),
+	}
+}
+
+// toChecklistItemResponse maps a checklist item to its API representation
+func toChecklistItemResponse(item *models.ChecklistItem) dto.ChecklistItemResponse {
+	return dto.ChecklistItemResponse{
+		ID:        item.ID,
+		Text:      item.Text,
+		Done:      item.Done,
+		DoneBy:    item.DoneBy,
+		DoneAt:    item.DoneAt,
+		CreatedAt: item.CreatedAt,
+	}
+}
+
+// toChecklistResponses maps an ordered checklist to its API representation
+func toChecklistResponses(items []models.ChecklistItem) []dto.ChecklistItemResponse {
+	responses := make([]dto.ChecklistItemResponse, 0, len(items))
+	for i := range items {
+		responses = append(responses, toChecklistItemResponse(&items[i]))
+	}
+	return responses
+}
+
+// GetChecklist handles listing the checklist of a task in order
+func (t *TaskController) GetChecklist(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	items, err := t.TaskService.GetChecklist(id, currentUserID(c))
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toChecklistResponses(items))
+}
+
+// AddChecklistItem handles appending an item to a task's checklist
+func (t *TaskController) AddChecklistItem(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input dto.ChecklistItemCreateRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	item, err := t.TaskService.AddChecklistItem(id, input.Text, currentUserID(c))
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toChecklistItemResponse(item))
+}
+
+// UpdateChecklistItem handles renaming a checklist item
+func (t *TaskController) UpdateChecklistItem(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	itemID, ok := parseIDParam(c, "itemId")
+	if !ok {
+		return
+	}
+
+	var input dto.ChecklistItemUpdateRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	item, err := t.TaskService.RenameChecklistItem(id, itemID, input.Text, currentUserID(c))
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toChecklistItemResponse(item))
+}
+
+// ToggleChecklistItem handles checking or unchecking a checklist item
+func (t *TaskController) ToggleChecklistItem(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	itemID, ok := parseIDParam(c, "itemId")
+	if !ok {
+		return
+	}
+
+	// Without a body the item is flipped
+	var input dto.ChecklistToggleRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+			return
+		}
+	}
+
+	toggle, err := t.TaskService.ToggleChecklistItem(id, itemID, input.Done, currentUserID(c))
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	response := dto.ChecklistToggleResponse{
+		Item:     toChecklistItemResponse(toggle.Item),
+		Task:     toTaskResponse(toggle.Task),
+		Advanced: toggle.Advanced,
+	}
+	if toggle.AdvanceError != nil {
+		response.AdvanceError = toggle.AdvanceError.Error()
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// MoveChecklistItem handles reordering a checklist item
+func (t *TaskController) MoveChecklistItem(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	itemID, ok := parseIDParam(c, "itemId")
+	if !ok {
+		return
+	}
+
+	var input dto.ChecklistMoveRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	items, err := t.TaskService.MoveChecklistItem(id, itemID, *input.Position, currentUserID(c))
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toChecklistResponses(items))
+}
+
+// DeleteChecklistItem handles removing an item from a task's checklist
+func (t *TaskController) DeleteChecklistItem(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	itemID, ok := parseIDParam(c, "itemId")
+	if !ok {
+		return
+	}
+
+	if err := t.TaskService.DeleteChecklistItem(id, itemID, currentUserID(c)); err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Checklist item deleted successfully"})
+}
@@ -0,0 +1,261 @@
+// internal/controllers/template_controller.go
+package controllers
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	dto "TaskManager/pkg/utils"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TemplateController handles HTTP requests related to task templates
+type TemplateController struct {
+	TemplateService services.TemplateService
+}
+
+// NewTemplateController creates and returns a new TemplateController instance
+func NewTemplateController(templateService services.TemplateService) *TemplateController {
+	return &TemplateController{
+		TemplateService: templateService,
+	}
+}
+
+// toTemplateTasks maps a template's task tree to its API representation
+func toTemplateTasks(tasks []models.TemplateTask) []dto.TemplateTask {
+	result := make([]dto.TemplateTask, 0, len(tasks))
+	for _, task := range tasks {
+		result = append(result, dto.TemplateTask{
+			Title:           task.Title,
+			Description:     task.Description,
+			Priority:        task.Priority,
+			EstimateMinutes: task.EstimateMinutes,
+			DueOffset:       task.DueOffset,
+			Checklist:       task.Checklist,
+			Labels:          task.Labels,
+			CustomFields:    task.CustomFields,
+			Subtasks:        toTemplateTasks(task.Subtasks),
+		})
+	}
+	return result
+}
+
+// fromTemplateTasks maps a requested task tree to the template model
+func fromTemplateTasks(tasks []dto.TemplateTask) []models.TemplateTask {
+	result := make([]models.TemplateTask, 0, len(tasks))
+	for _, task := range tasks {
+		result = append(result, models.TemplateTask{
+			Title:           task.Title,
+			Description:     task.Description,
+			Priority:        task.Priority,
+			EstimateMinutes: task.EstimateMinutes,
+			DueOffset:       task.DueOffset,
+			Checklist:       task.Checklist,
+			Labels:          task.Labels,
+			CustomFields:    task.CustomFields,
+			Subtasks:        fromTemplateTasks(task.Subtasks),
+		})
+	}
+	return result
+}
+
+// toTemplateResponse maps a task template to its API representation
+func toTemplateResponse(template *models.TaskTemplate) dto.TemplateResponse {
+	return dto.TemplateResponse{
+		ID:          template.ID,
+		Name:        template.Name,
+		Description: template.Description,
+		UserID:      template.UserID,
+		ProjectID:   template.ProjectID,
+		Tasks:       toTemplateTasks(template.Tasks),
+		CreatedAt:   template.CreatedAt,
+		UpdatedAt:   template.UpdatedAt,
+	}
+}
+
+// toTemplateResponses maps a list of templates to their API representation
+func toTemplateResponses(templates []models.TaskTemplate) []dto.TemplateResponse {
+	responses := make([]dto.TemplateResponse, 0, len(templates))
+	for i := range templates {
+		responses = append(responses, toTemplateResponse(&templates[i]))
+	}
+	return responses
+}
+
+// respondTemplateError writes the HTTP response matching a template service
+// error; instantiation errors of the task rules are answered like task errors
+func respondTemplateError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrTemplateNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+	case errors.Is(err, services.ErrTemplateNameRequired), errors.Is(err, services.ErrInvalidTemplate):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		respondTaskError(c, err)
+	}
+}
+
+// CreateTemplate handles creating a personal or project task template
+func (t *TemplateController) CreateTemplate(c *gin.Context) {
+	var templateRequest dto.TemplateCreateRequest
+	if !bindJSON(c, &templateRequest) {
+		return
+	}
+
+	template := models.TaskTemplate{
+		Name:        templateRequest.Name,
+		Description: templateRequest.Description,
+		ProjectID:   templateRequest.ProjectID,
+		Tasks:       fromTemplateTasks(templateRequest.Tasks),
+	}
+
+	newTemplate, err := t.TemplateService.CreateTemplate(&template, currentUserID(c))
+	if err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toTemplateResponse(newTemplate))
+}
+
+// GetPersonalTemplates handles listing the authenticated user's personal templates
+func (t *TemplateController) GetPersonalTemplates(c *gin.Context) {
+	templates, err := t.TemplateService.GetPersonalTemplates(currentUserID(c))
+	if err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTemplateResponses(templates))
+}
+
+// GetProjectTemplates handles listing the templates of a project
+func (t *TemplateController) GetProjectTemplates(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	templates, err := t.TemplateService.GetProjectTemplates(projectID, currentUserID(c))
+	if err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTemplateResponses(templates))
+}
+
+// GetTemplate handles retrieving a template visible to the authenticated user
+func (t *TemplateController) GetTemplate(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	template, err := t.TemplateService.GetTemplateByID(id, currentUserID(c))
+	if err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTemplateResponse(template))
+}
+
+// UpdateTemplate handles renaming a template or replacing its task tree
+func (t *TemplateController) UpdateTemplate(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var templateRequest dto.TemplateUpdateRequest
+	if !bindJSON(c, &templateRequest) {
+		return
+	}
+
+	userID := currentUserID(c)
+	template, err := t.TemplateService.GetTemplateByID(id, userID)
+	if err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+
+	if templateRequest.Name != nil {
+		template.Name = *templateRequest.Name
+	}
+	if templateRequest.Description != nil {
+		template.Description = *templateRequest.Description
+	}
+	if templateRequest.Tasks != nil {
+		template.Tasks = fromTemplateTasks(templateRequest.Tasks)
+	}
+
+	updatedTemplate, err := t.TemplateService.UpdateTemplate(template, userID)
+	if err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTemplateResponse(updatedTemplate))
+}
+
+// DeleteTemplate handles deleting a template the authenticated user may edit
+func (t *TemplateController) DeleteTemplate(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := t.TemplateService.DeleteTemplate(id, currentUserID(c)); err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Template deleted successfully"})
+}
+
+// InstantiateTemplate handles creating the tasks of a template in a project
+// (or as personal tasks), with due dates counted from the anchor date
+func (t *TemplateController) InstantiateTemplate(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var instantiateRequest dto.TemplateInstantiateRequest
+	if c.Request.ContentLength != 0 && !bindJSON(c, &instantiateRequest) {
+		return
+	}
+
+	target := services.TemplateTarget{
+		ProjectID: instantiateRequest.ProjectID,
+		Anchor:    time.Now().UTC().Truncate(24 * time.Hour),
+	}
+	if raw := instantiateRequest.Anchor; raw != "" {
+		anchor, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			if anchor, err = time.Parse(time.RFC3339, raw); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "fields": gin.H{"anchor": "must be a date like 2024-01-31 or an RFC 3339 time"}})
+				return
+			}
+		}
+		target.Anchor = anchor
+	}
+
+	roots, err := t.TemplateService.InstantiateTemplate(id, target, currentUserID(c))
+	if err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+
+	responses := make([]dto.TaskTreeResponse, 0, len(roots))
+	for _, root := range roots {
+		responses = append(responses, toTaskTreeResponse(root))
+	}
+	log.Printf("Template %d instantiated with %d root tasks", id, len(roots))
+	c.JSON(http.StatusCreated, responses)
+}
@@ -15,12 +15,14 @@ import (
 // UserController handles HTTP requests related to user operations
 type UserController struct {
 	UserService services.UserService
+	AuthService services.AuthService
 }
 
 // NewUserController creates and returns a new UserController instance
-func NewUserController(userService services.UserService) *UserController {
+func NewUserController(userService services.UserService, authService services.AuthService) *UserController {
 	return &UserController{
 		UserService: userService,
+		AuthService: authService,
 	}
 }
 
@@ -54,11 +56,13 @@ func (u *UserController) CreateUser(c *gin.Context) {
 	}
 
 	userResponse := dto.UserResponse{
-		ID:        newUser.ID,
-		Email:     newUser.Email,
-		Username:  newUser.Username,
-		CreatedAt: newUser.CreatedAt,
-		UpdatedAt: newUser.UpdatedAt,
+		ID:            newUser.ID,
+		Email:         newUser.Email,
+		EmailVerified: newUser.EmailVerified,
+		PendingEmail:  newUser.PendingEmail,
+		Username:      newUser.Username,
+		CreatedAt:     newUser.CreatedAt,
+		UpdatedAt:     newUser.UpdatedAt,
 	}
 
 	c.JSON(http.StatusCreated, userResponse)
@@ -80,36 +84,46 @@ func (u *UserController) GetUserByID(c *gin.Context) {
 	}
 
 	userResponse := dto.UserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		Username:  user.Username,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:            user.ID,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+		PendingEmail:  user.PendingEmail,
+		Username:      user.Username,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
 	}
 
 	c.JSON(http.StatusOK, userResponse)
 }
 
-// GetAllUsers handles retrieving all users
+// GetAllUsers handles listing users one page at a time, with the filters and
+// sort order of the query string (see services.UserListSpec)
 func (u *UserController) GetAllUsers(c *gin.Context) {
-	users, err := u.UserService.GetAllUsers()
+	q, ok := parseListQuery(c, services.UserListSpec)
+	if !ok {
+		return
+	}
+
+	users, next, err := u.UserService.GetAllUsers(q)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	var userResponses []dto.UserResponse
+	userResponses := make([]dto.UserResponse, 0, len(users))
 	for _, user := range users {
 		userResponses = append(userResponses, dto.UserResponse{
-			ID:        user.ID,
-			Email:     user.Email,
-			Username:  user.Username,
-			CreatedAt: user.CreatedAt,
-			UpdatedAt: user.UpdatedAt,
+			ID:            user.ID,
+			Email:         user.Email,
+			EmailVerified: user.EmailVerified,
+			PendingEmail:  user.PendingEmail,
+			Username:      user.Username,
+			CreatedAt:     user.CreatedAt,
+			UpdatedAt:     user.UpdatedAt,
 		})
 	}
 
-	c.JSON(http.StatusOK, userResponses)
+	respondPage(c, userResponses, next)
 }
 
 // UpdateUser handles updating an existing user
@@ -120,6 +134,12 @@ func (u *UserController) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	// Users may only update their own account
+	if uint(id) != currentUserID(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only update your own account"})
+		return
+	}
+
 	var userRequest dto.UserCreateRequest
 	if err := c.ShouldBindJSON(&userRequest); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
@@ -132,8 +152,15 @@ func (u *UserController) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	if userRequest.Email != "" {
-		user.Email = userRequest.Email
+	// a new email stays pending until it is verified, so password reset
+	// links keep going to the current one; asking for the current email
+	// again cancels the change
+	emailChanged := false
+	if userRequest.Email == user.Email {
+		user.PendingEmail = ""
+	} else if userRequest.Email != "" && userRequest.Email != user.PendingEmail {
+		user.PendingEmail = userRequest.Email
+		emailChanged = true
 	}
 	if userRequest.Username != "" {
 		user.Username = userRequest.Username
@@ -145,12 +172,22 @@ func (u *UserController) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	// The update stands even if the verification mail can't be sent, or
+	// another one was sent too recently; it can be resent later
+	if emailChanged {
+		if err := u.AuthService.ResendVerification(updatedUser.ID); err != nil {
+			log.Println("Error sending email verification:", err)
+		}
+	}
+
 	userResponse := dto.UserResponse{
-		ID:        updatedUser.ID,
-		Email:     updatedUser.Email,
-		Username:  updatedUser.Username,
-		CreatedAt: updatedUser.CreatedAt,
-		UpdatedAt: updatedUser.UpdatedAt,
+		ID:            updatedUser.ID,
+		Email:         updatedUser.Email,
+		EmailVerified: updatedUser.EmailVerified,
+		PendingEmail:  updatedUser.PendingEmail,
+		Username:      updatedUser.Username,
+		CreatedAt:     updatedUser.CreatedAt,
+		UpdatedAt:     updatedUser.UpdatedAt,
 	}
 
 	log.Printf("User updated successfully: %s (ID: %d)", updatedUser.Username, updatedUser.ID)
@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"TaskManager/internal/services"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// bindJSON binds the request body into obj. Invalid bodies get a 400
+// response that names each offending field by its JSON key where possible.
+func bindJSON(c *gin.Context, obj interface{}) bool {
+	err := c.ShouldBindJSON(obj)
+	if err == nil {
+		return true
+	}
+	log.Println("Error binding request:", err)
+
+	response := gin.H{"error": "Invalid input"}
+	if fields := bindingErrorFields(obj, err); len(fields) > 0 {
+		response["fields"] = fields
+	}
+	c.JSON(http.StatusBadRequest, response)
+	return false
+}
+
+// respondValidationError writes a 400 response listing the problems of a
+// services.ValidationError and reports whether err was one
+func respondValidationError(c *gin.Context, err error) bool {
+	var validationErr *services.ValidationError
+	if !errors.As(err, &validationErr) {
+		return false
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "fields": validationErr.Fields})
+	return true
+}
+
+// bindingErrorFields maps a binding error to problems per JSON key (internal helper)
+func bindingErrorFields(obj interface{}, err error) map[string]string {
+	fields := map[string]string{}
+
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &validationErrs):
+		for _, fe := range validationErrs {
+			fields[jsonFieldPath(obj, fe.StructNamespace())] = validationMessage(fe)
+		}
+	case errors.As(err, &typeErr):
+		name := typeErr.Field
+		if name == "" {
+			name = "body"
+		}
+		fields[name] = "must be of type " + jsonTypeName(typeErr.Type)
+	case errors.As(err, &syntaxErr):
+		fields["body"] = fmt.Sprintf("malformed JSON at byte %d", syntaxErr.Offset)
+	}
+	return fields
+}
+
+// jsonFieldPath turns a validator namespace such as
+// "TemplateCreateRequest.Tasks[0].Title" into the JSON path of the field,
+// "tasks[0].title" (internal helper)
+func jsonFieldPath(obj interface{}, namespace string) string {
+	parts := strings.Split(namespace, ".")
+	if len(parts) > 1 {
+		parts = parts[1:]
+	}
+
+	t := reflect.TypeOf(obj)
+	path := make([]string, 0, len(parts))
+	for _, part := range parts {
+		name, index := part, ""
+		if i := strings.IndexByte(part, '['); i >= 0 {
+			name, index = part[:i], part[i:]
+		}
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		key := strings.ToLower(name)
+		var next reflect.Type
+		if t != nil && t.Kind() == reflect.Struct {
+			if field, ok := t.FieldByName(name); ok {
+				if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" && tag != "-" {
+					key = tag
+				}
+				next = field.Type
+			}
+		}
+		// An index steps into the elements of a slice or map
+		if next != nil && index != "" {
+			switch next.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				next = next.Elem()
+			}
+		}
+		t = next
+		path = append(path, key+index)
+	}
+	return strings.Join(path, ".")
+}
+
+// validationMessage describes a failed binding rule (internal helper)
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "min":
+		return "must be at least " + fe.Param() + " long"
+	case "max":
+		return "must be at most " + fe.Param() + " long"
+	default:
+		return "failed the " + fe.Tag() + " check"
+	}
+}
+
+// jsonTypeName names a Go type the way a JSON client thinks of it (internal helper)
+func jsonTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
@@ -0,0 +1,196 @@
+// internal/controllers/view_controller.go
+package controllers
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	dto "TaskManager/pkg/utils"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ViewController handles HTTP requests related to saved views
+type ViewController struct {
+	ViewService services.ViewService
+}
+
+// NewViewController creates and returns a new ViewController instance
+func NewViewController(viewService services.ViewService) *ViewController {
+	return &ViewController{
+		ViewService: viewService,
+	}
+}
+
+// toViewResponse maps a saved view to its API representation
+func toViewResponse(view *models.SavedView) dto.ViewResponse {
+	return dto.ViewResponse{
+		ID:        view.ID,
+		Name:      view.Name,
+		UserID:    view.UserID,
+		ProjectID: view.ProjectID,
+		Query:     view.Query,
+		Sort:      view.Sort,
+		Columns:   view.Columns,
+		IsDefault: view.IsDefault,
+		CreatedAt: view.CreatedAt,
+		UpdatedAt: view.UpdatedAt,
+	}
+}
+
+// respondViewError writes the HTTP response matching a view service error;
+// errors of the task rules are answered like task errors
+func respondViewError(c *gin.Context, err error) {
+	if respondSearchError(c, err) {
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrViewNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "View not found"})
+	case errors.Is(err, services.ErrViewForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		respondTaskError(c, err)
+	}
+}
+
+// CreateView handles saving a personal view or one shared with a project
+func (v *ViewController) CreateView(c *gin.Context) {
+	var viewRequest dto.ViewCreateRequest
+	if !bindJSON(c, &viewRequest) {
+		return
+	}
+
+	view := models.SavedView{
+		Name:      viewRequest.Name,
+		ProjectID: viewRequest.ProjectID,
+		Query:     viewRequest.Query,
+		Sort:      viewRequest.Sort,
+		Columns:   viewRequest.Columns,
+		IsDefault: viewRequest.IsDefault,
+	}
+
+	newView, err := v.ViewService.CreateView(&view, currentUserID(c))
+	if err != nil {
+		respondViewError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toViewResponse(newView))
+}
+
+// GetViews handles listing the authenticated user's views and the views
+// shared with their projects
+func (v *ViewController) GetViews(c *gin.Context) {
+	views, err := v.ViewService.GetViews(currentUserID(c))
+	if err != nil {
+		respondViewError(c, err)
+		return
+	}
+
+	responses := make([]dto.ViewResponse, 0, len(views))
+	for i := range views {
+		responses = append(responses, toViewResponse(&views[i]))
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetView handles retrieving a view visible to the authenticated user
+func (v *ViewController) GetView(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	view, err := v.ViewService.GetViewByID(id, currentUserID(c))
+	if err != nil {
+		respondViewError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toViewResponse(view))
+}
+
+// UpdateView handles changing a view the authenticated user owns
+func (v *ViewController) UpdateView(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var viewRequest dto.ViewUpdateRequest
+	if !bindJSON(c, &viewRequest) {
+		return
+	}
+
+	userID := currentUserID(c)
+	view, err := v.ViewService.GetViewByID(id, userID)
+	if err != nil {
+		respondViewError(c, err)
+		return
+	}
+
+	if viewRequest.Name != nil {
+		view.Name = *viewRequest.Name
+	}
+	if viewRequest.Query != nil {
+		view.Query = *viewRequest.Query
+	}
+	if viewRequest.Sort != nil {
+		view.Sort = *viewRequest.Sort
+	}
+	if viewRequest.Columns != nil {
+		view.Columns = viewRequest.Columns
+	}
+	if viewRequest.ProjectID != nil {
+		view.ProjectID = viewRequest.ProjectID
+		if *viewRequest.ProjectID == 0 {
+			view.ProjectID = nil
+		}
+	}
+	if viewRequest.IsDefault != nil {
+		view.IsDefault = *viewRequest.IsDefault
+	}
+
+	updatedView, err := v.ViewService.UpdateView(view, userID)
+	if err != nil {
+		respondViewError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toViewResponse(updatedView))
+}
+
+// DeleteView handles deleting a view the authenticated user owns
+func (v *ViewController) DeleteView(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := v.ViewService.DeleteView(id, currentUserID(c)); err != nil {
+		respondViewError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "View deleted successfully"})
+}
+
+// GetViewTasks handles running a view: the tasks matching its query that
+// the authenticated user can see, in the view's order
+func (v *ViewController) GetViewTasks(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	tasks, err := v.ViewService.GetViewTasks(id, currentUserID(c))
+	if err != nil {
+		respondViewError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTaskResponses(tasks))
+}
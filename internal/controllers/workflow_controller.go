@@ -0,0 +1,147 @@
+// internal/controllers/workflow_controller.go
+package controllers
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	dto "TaskManager/pkg/utils"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WorkflowController handles HTTP requests related to project workflows
+type WorkflowController struct {
+	WorkflowService services.WorkflowService
+}
+
+// NewWorkflowController creates and returns a new WorkflowController instance
+func NewWorkflowController(workflowService services.WorkflowService) *WorkflowController {
+	return &WorkflowController{
+		WorkflowService: workflowService,
+	}
+}
+
+// toWorkflowResponse maps a workflow model to its API representation
+func toWorkflowResponse(workflow *models.Workflow) dto.WorkflowResponse {
+	response := dto.WorkflowResponse{
+		ProjectID:    workflow.ProjectID,
+		Custom:       workflow.ID != 0,
+		InitialState: workflow.InitialState,
+		States:       make([]dto.WorkflowStateResponse, 0, len(workflow.States)),
+		Transitions:  make([]dto.WorkflowTransitionResponse, 0, len(workflow.Transitions)),
+	}
+	for _, state := range workflow.States {
+		response.States = append(response.States, dto.WorkflowStateResponse{
+			Name:  state.Name,
+			Final: state.Final,
+		})
+	}
+	for i := range workflow.Transitions {
+		transition := &workflow.Transitions[i]
+		guards := transition.GuardList()
+		if guards == nil {
+			guards = []string{}
+		}
+		response.Transitions = append(response.Transitions, dto.WorkflowTransitionResponse{
+			Name:   transition.Name,
+			From:   transition.From,
+			To:     transition.To,
+			Guards: guards,
+		})
+	}
+	return response
+}
+
+// respondWorkflowError writes the HTTP response matching a workflow service error
+func respondWorkflowError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrProjectNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+	case errors.Is(err, services.ErrProjectForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidWorkflow):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrWorkflowStateInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Println("Workflow error:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// GetWorkflow handles retrieving the workflow a project's tasks follow
+func (w *WorkflowController) GetWorkflow(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	workflow, err := w.WorkflowService.GetWorkflow(projectID, currentUserID(c))
+	if err != nil {
+		respondWorkflowError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toWorkflowResponse(workflow))
+}
+
+// UpdateWorkflow handles replacing a project's workflow
+func (w *WorkflowController) UpdateWorkflow(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var workflowRequest dto.WorkflowRequest
+	if err := c.ShouldBindJSON(&workflowRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	workflow := models.Workflow{
+		ProjectID:    projectID,
+		InitialState: workflowRequest.InitialState,
+	}
+	for _, state := range workflowRequest.States {
+		workflow.States = append(workflow.States, models.WorkflowState{
+			Name:  state.Name,
+			Final: state.Final,
+		})
+	}
+	for _, transition := range workflowRequest.Transitions {
+		workflow.Transitions = append(workflow.Transitions, models.WorkflowTransition{
+			Name:   transition.Name,
+			From:   transition.From,
+			To:     transition.To,
+			Guards: strings.Join(transition.Guards, ","),
+		})
+	}
+
+	updatedWorkflow, err := w.WorkflowService.UpdateWorkflow(&workflow, currentUserID(c))
+	if err != nil {
+		respondWorkflowError(c, err)
+		return
+	}
+
+	log.Printf("Workflow of project %d updated successfully", projectID)
+	c.JSON(http.StatusOK, toWorkflowResponse(updatedWorkflow))
+}
+
+// ResetWorkflow handles switching a project back to the default workflow
+func (w *WorkflowController) ResetWorkflow(c *gin.Context) {
+	projectID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := w.WorkflowService.ResetWorkflow(projectID, currentUserID(c)); err != nil {
+		respondWorkflowError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Workflow reset to default"})
+}
@@ -0,0 +1,371 @@
+// internal/controllers/worklog_controller.go
+package controllers
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	dto "TaskManager/pkg/utils"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WorklogController handles HTTP requests related to time tracking
+type WorklogController struct {
+	WorklogService services.WorklogService
+}
+
+// NewWorklogController creates and returns a new WorklogController instance
+func NewWorklogController(worklogService services.WorklogService) *WorklogController {
+	return &WorklogController{
+		WorklogService: worklogService,
+	}
+}
+
+// toWorklogResponse maps a worklog model to its API representation
+func toWorklogResponse(worklog *models.Worklog) dto.WorklogResponse {
+	response := dto.WorklogResponse{
+		ID:        worklog.ID,
+		TaskID:    worklog.TaskID,
+		StartedAt: worklog.StartedAt,
+		EndedAt:   worklog.EndedAt,
+		Seconds:   worklog.Seconds,
+		Running:   worklog.EndedAt == nil,
+		Note:      worklog.Note,
+	}
+	if worklog.User != nil {
+		response.User = &dto.TaskUserResponse{ID: worklog.User.ID, Username: worklog.User.Username}
+	}
+	if response.Running {
+		response.Seconds = int64(time.Since(worklog.StartedAt) / time.Second)
+	}
+	return response
+}
+
+// hours converts seconds to hours rounded to two decimals
+func hours(seconds int64) float64 {
+	return math.Round(float64(seconds)/36) / 100
+}
+
+// varianceMinutes compares logged time with an estimate; nil without an estimate
+func varianceMinutes(loggedSeconds int64, estimateMinutes int) *int64 {
+	if estimateMinutes <= 0 {
+		return nil
+	}
+	variance := loggedSeconds/60 - int64(estimateMinutes)
+	return &variance
+}
+
+// respondWorklogError writes the HTTP response matching a worklog service error
+func respondWorklogError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrWorklogNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Worklog not found"})
+	case errors.Is(err, services.ErrTaskNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+	case errors.Is(err, services.ErrNoRunningTimer):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrWorklogForbidden),
+		errors.Is(err, services.ErrProjectForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrTimerRunning),
+		errors.Is(err, services.ErrWorklogRunning):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidWorklog),
+		errors.Is(err, services.ErrInvalidTimesheet):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Println("Worklog error:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// StartTimer handles starting the authenticated user's timer on a task
+func (w *WorklogController) StartTimer(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	// The note is optional, so an empty body is fine
+	var input dto.TimerStartRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+			return
+		}
+	}
+
+	worklog, err := w.WorklogService.StartTimer(taskID, currentUserID(c), input.Note)
+	if err != nil {
+		respondWorklogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toWorklogResponse(worklog))
+}
+
+// StopTimer handles stopping the authenticated user's running timer
+func (w *WorklogController) StopTimer(c *gin.Context) {
+	worklog, err := w.WorklogService.StopTimer(currentUserID(c))
+	if err != nil {
+		respondWorklogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toWorklogResponse(worklog))
+}
+
+// GetTimer handles retrieving the authenticated user's running timer
+func (w *WorklogController) GetTimer(c *gin.Context) {
+	worklog, err := w.WorklogService.GetRunningTimer(currentUserID(c))
+	if err != nil {
+		respondWorklogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toWorklogResponse(worklog))
+}
+
+// LogWork handles logging time on a task manually
+func (w *WorklogController) LogWork(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input dto.WorklogCreateRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	worklog := models.Worklog{
+		TaskID:  taskID,
+		Seconds: int64(input.Minutes) * 60,
+		Note:    input.Note,
+	}
+	if input.StartedAt != nil {
+		worklog.StartedAt = *input.StartedAt
+	}
+
+	created, err := w.WorklogService.LogWork(&worklog, currentUserID(c))
+	if err != nil {
+		respondWorklogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toWorklogResponse(created))
+}
+
+// GetTaskWorklogs handles listing the worklogs of a task with its logged time against the estimate
+func (w *WorklogController) GetTaskWorklogs(c *gin.Context) {
+	taskID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	summary, err := w.WorklogService.GetTaskWorklogs(taskID, currentUserID(c))
+	if err != nil {
+		respondWorklogError(c, err)
+		return
+	}
+
+	worklogs := make([]dto.WorklogResponse, 0, len(summary.Worklogs))
+	for i := range summary.Worklogs {
+		worklogs = append(worklogs, toWorklogResponse(&summary.Worklogs[i]))
+	}
+	c.JSON(http.StatusOK, dto.TaskWorklogsResponse{
+		TaskID:          taskID,
+		EstimateMinutes: summary.EstimateMinutes,
+		LoggedMinutes:   summary.LoggedSeconds / 60,
+		VarianceMinutes: varianceMinutes(summary.LoggedSeconds, summary.EstimateMinutes),
+		Worklogs:        worklogs,
+	})
+}
+
+// UpdateWorklog handles correcting one of the authenticated user's worklogs
+func (w *WorklogController) UpdateWorklog(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input dto.WorklogUpdateRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	worklog := models.Worklog{
+		ID:        id,
+		StartedAt: input.StartedAt,
+		Seconds:   int64(input.Minutes) * 60,
+		Note:      input.Note,
+	}
+	updated, err := w.WorklogService.UpdateWorklog(&worklog, currentUserID(c))
+	if err != nil {
+		respondWorklogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toWorklogResponse(updated))
+}
+
+// DeleteWorklog handles deleting one of the authenticated user's worklogs
+func (w *WorklogController) DeleteWorklog(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := w.WorklogService.DeleteWorklog(id, currentUserID(c)); err != nil {
+		respondWorklogError(c, err)
+		return
+	}
+
+	log.Printf("Worklog with ID %d deleted successfully", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Worklog deleted successfully"})
+}
+
+// toTimesheetRowResponse maps a timesheet row to its API representation
+func toTimesheetRowResponse(row services.TimesheetRow) dto.TimesheetRowResponse {
+	response := dto.TimesheetRowResponse{
+		Key:       row.Key,
+		Label:     row.Label,
+		Seconds:   row.Seconds,
+		Hours:     hours(row.Seconds),
+		TaskID:    row.TaskID,
+		ProjectID: row.ProjectID,
+	}
+	if row.TaskID != nil {
+		estimate := row.EstimateMinutes
+		logged := row.TotalLoggedSeconds / 60
+		response.EstimateMinutes = &estimate
+		response.TotalLoggedMinutes = &logged
+		response.VarianceMinutes = varianceMinutes(row.TotalLoggedSeconds, row.EstimateMinutes)
+	}
+	return response
+}
+
+// GetTimesheet handles reporting the authenticated user's logged time,
+// as JSON or, with format=csv, as a CSV download
+func (w *WorklogController) GetTimesheet(c *gin.Context) {
+	query := services.TimesheetQuery{
+		From:     c.Query("from"),
+		To:       c.Query("to"),
+		Group:    c.Query("group"),
+		Timezone: c.Query("tz"),
+	}
+	timesheet, err := w.WorklogService.GetTimesheet(currentUserID(c), query)
+	if err != nil {
+		respondWorklogError(c, err)
+		return
+	}
+
+	response := dto.TimesheetResponse{
+		From:         timesheet.From.Format("2006-01-02"),
+		To:           timesheet.To.Format("2006-01-02"),
+		Group:        timesheet.Group,
+		Timezone:     timesheet.Timezone,
+		TotalSeconds: timesheet.TotalSeconds,
+		TotalHours:   hours(timesheet.TotalSeconds),
+		Rows:         make([]dto.TimesheetRowResponse, 0, len(timesheet.Rows)),
+	}
+	for _, row := range timesheet.Rows {
+		response.Rows = append(response.Rows, toTimesheetRowResponse(row))
+	}
+
+	if c.Query("format") == "csv" {
+		writeTimesheetCSV(c, &response)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// csvCell neutralises values a spreadsheet would evaluate as a formula
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// formatOptionalUint renders an optional ID for CSV, empty when absent
+func formatOptionalUint(id *uint) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*id), 10)
+}
+
+// formatOptionalInt renders an optional number for CSV, empty when absent
+func formatOptionalInt(n *int64) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.FormatInt(*n, 10)
+}
+
+// writeTimesheetCSV writes a timesheet as a CSV attachment with a closing total row
+func writeTimesheetCSV(c *gin.Context, timesheet *dto.TimesheetResponse) {
+	filename := fmt.Sprintf("timesheet-%s-%s-by-%s.csv", timesheet.From, timesheet.To, timesheet.Group)
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	hoursCell := func(seconds int64) string { return strconv.FormatFloat(hours(seconds), 'f', 2, 64) }
+
+	switch timesheet.Group {
+	case services.TimesheetByTask:
+		writer.Write([]string{"task_id", "task", "project_id", "hours", "seconds", "estimate_minutes", "total_logged_minutes", "variance_minutes"})
+	case services.TimesheetByProject:
+		writer.Write([]string{"project_id", "project", "hours", "seconds"})
+	case services.TimesheetByWeek:
+		writer.Write([]string{"week", "week_start", "hours", "seconds"})
+	default:
+		writer.Write([]string{"date", "hours", "seconds"})
+	}
+
+	for _, row := range timesheet.Rows {
+		seconds := strconv.FormatInt(row.Seconds, 10)
+		switch timesheet.Group {
+		case services.TimesheetByTask:
+			estimate := ""
+			if row.EstimateMinutes != nil && *row.EstimateMinutes > 0 {
+				estimate = strconv.Itoa(*row.EstimateMinutes)
+			}
+			writer.Write([]string{row.Key, csvCell(row.Label), formatOptionalUint(row.ProjectID), hoursCell(row.Seconds), seconds,
+				estimate, formatOptionalInt(row.TotalLoggedMinutes), formatOptionalInt(row.VarianceMinutes)})
+		case services.TimesheetByProject:
+			writer.Write([]string{formatOptionalUint(row.ProjectID), csvCell(row.Label), hoursCell(row.Seconds), seconds})
+		case services.TimesheetByWeek:
+			writer.Write([]string{row.Key, row.Label, hoursCell(row.Seconds), seconds})
+		default:
+			writer.Write([]string{row.Key, hoursCell(row.Seconds), seconds})
+		}
+	}
+
+	total := []string{"total", "", hoursCell(timesheet.TotalSeconds), strconv.FormatInt(timesheet.TotalSeconds, 10)}
+	switch timesheet.Group {
+	case services.TimesheetByTask:
+		total = []string{"total", "", "", total[2], total[3], "", "", ""}
+	case services.TimesheetByDay:
+		total = []string{"total", total[2], total[3]}
+	}
+	writer.Write(total)
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		log.Println("Error writing timesheet CSV:", err)
+	}
+}
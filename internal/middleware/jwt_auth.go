@@ -2,14 +2,64 @@ package middleware
 
 import (
 	"TaskManager/pkg/utils"
+	"log"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
+// TokenChecker tells whether a valid access token was revoked before it expired
+type TokenChecker interface {
+	IsRevoked(claims *utils.TokenClaims) (bool, error)
+}
+
+// tokenChecker is consulted by AuthRequired; without one tokens are only
+// checked for their signature and expiry
+var tokenChecker TokenChecker
+
+// SetTokenChecker sets the revocation check of AuthRequired
+func SetTokenChecker(checker TokenChecker) {
+	tokenChecker = checker
+}
+
+// SessionTracker records that the session of a token family was used from
+// an IP address; it decides itself how often to write
+type SessionTracker interface {
+	SessionSeen(familyID, ip string) error
+}
+
+// sessionTracker is told by AuthRequired about every authenticated request
+var sessionTracker SessionTracker
+
+// SetSessionTracker sets the session tracking of AuthRequired
+func SetSessionTracker(tracker SessionTracker) {
+	sessionTracker = tracker
+}
+
+// requireVerifiedEmail makes AuthRequired reject the tokens of users whose
+// email isn't verified
+var requireVerifiedEmail bool
+
+// SetRequireVerifiedEmail sets whether AuthRequired requires a verified email
+func SetRequireVerifiedEmail(required bool) {
+	requireVerifiedEmail = required
+}
+
 // AuthRequired middleware validates JWT tokens for protected routes
 func AuthRequired() gin.HandlerFunc {
+	return authRequired(true)
+}
+
+// AuthRequiredUnverified is AuthRequired letting users whose email isn't
+// verified through, for the routes they need before verifying
+func AuthRequiredUnverified() gin.HandlerFunc {
+	return authRequired(false)
+}
+
+// authRequired validates JWT tokens, and if checkVerified is set applies
+// the verified email policy
+func authRequired(checkVerified bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get the token from the Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -23,15 +73,47 @@ func AuthRequired() gin.HandlerFunc {
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 
 		// Validate the token
-		userID, err := utils.ValidateToken(tokenString)
+		claims, err := utils.ParseToken(tokenString)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
 			c.Abort()
 			return
 		}
 
-		// Set the user ID in the context to use in handlers
-		c.Set("user_id", userID)
+		// Reject tokens revoked by a logout
+		if tokenChecker != nil {
+			revoked, err := tokenChecker.IsRevoked(claims)
+			if err != nil {
+				log.Println("Error checking token revocation:", err)
+				c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Could not verify token"})
+				c.Abort()
+				return
+			}
+			if revoked {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+				c.Abort()
+				return
+			}
+		}
+
+		// The token tells whether the email was verified when it was issued;
+		// after verifying, clients refresh their token
+		if checkVerified && requireVerifiedEmail && !claims.EmailVerified {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Email not verified; verify it, then refresh your token"})
+			c.Abort()
+			return
+		}
+
+		// Keep the session's last-seen time up to date; failing to doesn't fail the request
+		if sessionTracker != nil && claims.SessionID != "" {
+			if err := sessionTracker.SessionSeen(claims.SessionID, c.ClientIP()); err != nil {
+				log.Println("Error tracking session:", err)
+			}
+		}
+
+		// Set the user ID and the token's claims in the context to use in handlers
+		c.Set("user_id", claims.UserID)
+		c.Set("token_claims", claims)
 
 		// Continue with the request
 		c.Next()
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Attachment is a file uploaded to a task. The content lives in the blob
+// store under StorageKey; its size counts against the uploader's quota.
+type Attachment struct {
+	ID          uint      `json:"id" gorm:"primarykey"`
+	TaskID      uint      `json:"task_id" gorm:"not null;index"`
+	UserID      uint      `json:"user_id" gorm:"not null;index"`
+	Filename    string    `json:"filename" gorm:"not null"`
+	ContentType string    `json:"content_type" gorm:"not null"`
+	Size        int64     `json:"size" gorm:"not null"`
+	Checksum    string    `json:"checksum" gorm:"not null"`
+	StorageKey  string    `json:"-" gorm:"not null;uniqueIndex"`
+	CreatedAt   time.Time `json:"created_at"`
+}
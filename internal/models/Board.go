@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Board is a Kanban view of a project's tasks, split into columns by status
+type Board struct {
+	gorm.Model
+	ProjectID uint          `json:"project_id" gorm:"not null;index"`
+	Name      string        `json:"name" gorm:"not null"`
+	Columns   []BoardColumn `json:"columns,omitempty"`
+}
+
+// BoardColumn shows the tasks of one status. A WipLimit of zero means unlimited.
+type BoardColumn struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	BoardID   uint      `json:"board_id" gorm:"not null;index"`
+	Name      string    `json:"name" gorm:"not null"`
+	Status    string    `json:"status" gorm:"not null"`
+	Position  int       `json:"position" gorm:"not null;default:0"`
+	WipLimit  int       `json:"wip_limit" gorm:"not null;default:0"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// ChecklistItem is a lightweight to-do inside a task. Items are ordered by
+// Rank, a lexicographic key like the board rank of tasks.
+type ChecklistItem struct {
+	ID        uint       `json:"id" gorm:"primarykey"`
+	TaskID    uint       `json:"task_id" gorm:"not null;index"`
+	Text      string     `json:"text" gorm:"not null"`
+	Done      bool       `json:"done" gorm:"not null;default:false"`
+	DoneBy    *uint      `json:"done_by"`
+	DoneAt    *time.Time `json:"done_at"`
+	Rank      string     `json:"rank" gorm:"not null;default:''"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
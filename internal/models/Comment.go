@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Comment is a Markdown message on a task. Replies point at a top-level
+// comment through ParentID, so threads are one level deep. Deleting a
+// comment soft-deletes it, letting its replies keep their thread.
+type Comment struct {
+	gorm.Model
+	TaskID   uint             `json:"task_id" gorm:"not null;index"`
+	Task     *Task            `json:"-"`
+	UserID   uint             `json:"user_id" gorm:"not null;index"`
+	User     *User            `json:"-"`
+	ParentID *uint            `json:"parent_id" gorm:"index"`
+	Body     string           `json:"body" gorm:"type:text;not null"`
+	EditedAt *time.Time       `json:"edited_at"`
+	Mentions []CommentMention `json:"mentions,omitempty"`
+	Replies  []Comment        `json:"replies,omitempty" gorm:"-"`
+}
+
+// CommentRevision keeps the previous body of a comment each time it is edited
+type CommentRevision struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CommentID uint      `json:"comment_id" gorm:"not null;index"`
+	Body      string    `json:"body" gorm:"type:text;not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CommentMention records that a comment mentions a user, so the user can be notified
+type CommentMention struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CommentID uint      `json:"comment_id" gorm:"not null;uniqueIndex:idx_comment_mention"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_comment_mention;index"`
+	User      *User     `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Custom field types
+const (
+	CustomFieldText        = "text"
+	CustomFieldNumber      = "number"
+	CustomFieldDate        = "date"
+	CustomFieldSelect      = "select"
+	CustomFieldMultiSelect = "multi_select"
+	CustomFieldUser        = "user"
+)
+
+// CustomField is a piece of metadata a project tracks on its tasks, e.g.
+// story points or the customer. Key identifies the field in requests and
+// listings; select fields choose from Options.
+type CustomField struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	ProjectID uint      `json:"project_id" gorm:"not null;uniqueIndex:idx_custom_field_key"`
+	Key       string    `json:"key" gorm:"not null;uniqueIndex:idx_custom_field_key"`
+	Name      string    `json:"name" gorm:"not null"`
+	Type      string    `json:"type" gorm:"not null"`
+	Required  bool      `json:"required" gorm:"not null;default:false"`
+	Options   []string  `json:"options" gorm:"serializer:json"`
+	Position  int       `json:"position" gorm:"not null;default:0"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CustomFieldValue is a task's value for a custom field, stored in the
+// column matching the field's type. Multi-select fields have one row per
+// chosen option.
+type CustomFieldValue struct {
+	ID          uint         `json:"id" gorm:"primarykey"`
+	TaskID      uint         `json:"task_id" gorm:"not null;uniqueIndex:idx_custom_field_value"`
+	FieldID     uint         `json:"field_id" gorm:"not null;uniqueIndex:idx_custom_field_value;index"`
+	Field       *CustomField `json:"-"`
+	TextValue   string       `json:"text_value" gorm:"not null;default:'';uniqueIndex:idx_custom_field_value"`
+	NumberValue *float64     `json:"number_value"`
+	DateValue   *time.Time   `json:"date_value"`
+	UserValue   *uint        `json:"user_value"`
+}
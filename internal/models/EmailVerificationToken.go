@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// EmailVerificationToken proves that a user receives mail at Email once
+// its link is opened before ExpiresAt. Only the hash of its value is
+// stored; UsedAt is set when it is used or superseded by a verification.
+type EmailVerificationToken struct {
+	ID        uint       `json:"id" gorm:"primarykey"`
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	Email     string     `json:"email" gorm:"not null"`
+	TokenHash string     `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Label tags tasks, e.g. "bug" or "frontend". A label belongs either to a
+// user, for their personal tasks, or to a project, for the project's tasks;
+// exactly one of UserID and ProjectID is set.
+type Label struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	Name      string    `json:"name" gorm:"not null"`
+	Color     string    `json:"color" gorm:"not null"`
+	UserID    *uint     `json:"user_id" gorm:"index"`
+	ProjectID *uint     `json:"project_id" gorm:"index"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
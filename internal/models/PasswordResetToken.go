@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// PasswordResetToken lets the holder of a password reset link set a new
+// password once before ExpiresAt. Only the hash of its value is stored;
+// UsedAt is set when it is used or superseded by a reset.
+type PasswordResetToken struct {
+	ID        uint       `json:"id" gorm:"primarykey"`
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Project member roles, from most to least privileged
+const (
+	ProjectRoleOwner  = "owner"
+	ProjectRoleEditor = "editor"
+	ProjectRoleViewer = "viewer"
+)
+
+// Project groups tasks that a set of members work on together
+type Project struct {
+	gorm.Model
+	Name        string          `json:"name" gorm:"not null"`
+	Description string          `json:"description"`
+	OwnerID     uint            `json:"owner_id" gorm:"not null;index"`
+	Members     []ProjectMember `json:"members,omitempty"`
+}
+
+// ProjectMember grants a user a role within a project
+type ProjectMember struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	ProjectID uint      `json:"project_id" gorm:"not null;uniqueIndex:idx_project_member"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_project_member;index"`
+	User      *User     `json:"-"`
+	Role      string    `json:"role" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// RefreshToken is an opaque token traded for a new access token, stored as
+// the hash of its value. Each refresh replaces the token with a new one of
+// the same family; a family is a Session, started at login. UsedAt is set
+// when a token is traded in, RevokedAt when its family is revoked.
+type RefreshToken struct {
+	ID        uint       `json:"id" gorm:"primarykey"`
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	FamilyID  string     `json:"family_id" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// RevokedToken is an access token revoked before it expired, identified by
+// its jti. Rows are only needed until ExpiresAt, when the token would be
+// rejected anyway.
+type RevokedToken struct {
+	ID        string    `json:"id" gorm:"primarykey"`
+	UserID    uint      `json:"user_id" gorm:"not null;index"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null;index"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// SavedView is a named task search: a query of the task query language,
+// a sort order and the columns to show. Views belong to the user who saved
+// them; views with a ProjectID are shared with the project's members and
+// only ever show tasks of that project. A user can mark one of their views
+// as their default.
+type SavedView struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	Name      string    `json:"name" gorm:"not null"`
+	UserID    uint      `json:"user_id" gorm:"not null;index"`
+	ProjectID *uint     `json:"project_id" gorm:"index"`
+	Query     string    `json:"query"`
+	Sort      string    `json:"sort"`
+	Columns   []string  `json:"columns" gorm:"serializer:json"`
+	IsDefault bool      `json:"is_default" gorm:"not null;default:false"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Session is a login of a user on some device. Its refresh tokens share
+// FamilyID, and so do the access tokens issued with them (as their sid).
+// RevokedAt is set when the session is terminated.
+type Session struct {
+	ID         uint       `json:"id" gorm:"primarykey"`
+	UserID     uint       `json:"user_id" gorm:"not null;index"`
+	FamilyID   string     `json:"-" gorm:"not null;uniqueIndex"`
+	UserAgent  string     `json:"user_agent"`
+	IP         string     `json:"ip"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	RevokedAt  *time.Time `json:"-"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
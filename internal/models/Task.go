@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Task statuses of the default workflow; projects can define their own
+const (
+	TaskStatusTodo       = "todo"
+	TaskStatusInProgress = "in_progress"
+	TaskStatusDone       = "done"
+)
+
+// Task priorities
+const (
+	TaskPriorityLow    = "low"
+	TaskPriorityMedium = "medium"
+	TaskPriorityHigh   = "high"
+	TaskPriorityUrgent = "urgent"
+)
+
+// Task represents a unit of work owned by a user
+type Task struct {
+	gorm.Model
+	Title           string     `json:"title" gorm:"not null"`
+	Description     string     `json:"description"`
+	Status          string     `json:"status" gorm:"not null;default:todo;index"`
+	Closed          bool       `json:"closed" gorm:"not null;default:false;index"`
+	Resolution      string     `json:"resolution"`
+	Priority        string     `json:"priority" gorm:"not null;default:medium"`
+	DueDate         *time.Time `json:"due_date"`
+	EstimateMinutes int        `json:"estimate_minutes" gorm:"not null;default:0"`
+	UserID          uint       `json:"user_id" gorm:"not null;index"`
+	ProjectID       *uint      `json:"project_id" gorm:"index"`
+	ParentID        *uint      `json:"parent_id" gorm:"index"`
+	Progress        int        `json:"progress" gorm:"not null;default:0"`
+	Rank            string     `json:"rank" gorm:"not null;default:'';index"`
+
+	// Recurrence is an RRULE (e.g. FREQ=WEEKLY;BYDAY=MO) expanded from
+	// RecurrenceStart in RecurrenceTimezone. Completing the task creates the
+	// next occurrence, which takes the rule over.
+	Recurrence         string     `json:"recurrence"`
+	RecurrenceTimezone string     `json:"recurrence_timezone"`
+	RecurrenceStart    *time.Time `json:"recurrence_start"`
+
+	// Assignees work on the task; watchers just follow it
+	Assignees []User `json:"assignees,omitempty" gorm:"many2many:task_assignees;"`
+	Watchers  []User `json:"watchers,omitempty" gorm:"many2many:task_watchers;"`
+
+	Labels []Label `json:"labels,omitempty" gorm:"many2many:task_labels;"`
+
+	// Checklist counts are kept in step with the task's checklist items so
+	// listings can show "3/5 done" without loading them. Once every item is
+	// done the task moves to ChecklistAdvanceTo, if set.
+	Checklist          []ChecklistItem `json:"checklist,omitempty" gorm:"foreignKey:TaskID"`
+	ChecklistTotal     int             `json:"checklist_total" gorm:"not null;default:0"`
+	ChecklistDone      int             `json:"checklist_done" gorm:"not null;default:0"`
+	ChecklistAdvanceTo string          `json:"checklist_advance_to"`
+
+	// CustomValues hold the task's values for its project's custom fields.
+	// CustomFieldInput carries changes keyed by field key, as decoded from
+	// JSON; nil leaves the values alone and a nil entry clears a field.
+	CustomValues     []CustomFieldValue     `json:"custom_values,omitempty" gorm:"foreignKey:TaskID"`
+	CustomFieldInput map[string]interface{} `json:"-" gorm:"-"`
+}
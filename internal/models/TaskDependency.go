@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// TaskDependency records that a task is blocked by another task
+type TaskDependency struct {
+	ID          uint      `json:"id" gorm:"primarykey"`
+	TaskID      uint      `json:"task_id" gorm:"not null;uniqueIndex:idx_task_dependency"`
+	BlockedByID uint      `json:"blocked_by_id" gorm:"not null;uniqueIndex:idx_task_dependency;index"`
+	BlockedBy   *Task     `json:"-" gorm:"foreignKey:BlockedByID"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// TaskTemplate is a reusable tree of tasks, e.g. an onboarding or a release
+// checklist. Personal templates belong to the user who created them;
+// templates with a ProjectID are shared with the project's members.
+type TaskTemplate struct {
+	ID          uint           `json:"id" gorm:"primarykey"`
+	Name        string         `json:"name" gorm:"not null"`
+	Description string         `json:"description"`
+	UserID      uint           `json:"user_id" gorm:"not null;index"`
+	ProjectID   *uint          `json:"project_id" gorm:"index"`
+	Tasks       []TemplateTask `json:"tasks" gorm:"serializer:json"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// TemplateTask is a task of a template together with its subtasks.
+// DueOffset places the due date relative to the anchor date given when the
+// template is instantiated, e.g. "+3d"; without it the task has no due
+// date. Labels are names, resolved in the scope the tasks are created in.
+type TemplateTask struct {
+	Title           string                 `json:"title"`
+	Description     string                 `json:"description,omitempty"`
+	Priority        string                 `json:"priority,omitempty"`
+	EstimateMinutes int                    `json:"estimate_minutes,omitempty"`
+	DueOffset       string                 `json:"due_offset,omitempty"`
+	Checklist       []string               `json:"checklist,omitempty"`
+	Labels          []string               `json:"labels,omitempty"`
+	CustomFields    map[string]interface{} `json:"custom_fields,omitempty"`
+	Subtasks        []TemplateTask         `json:"subtasks,omitempty"`
+}
@@ -8,4 +8,12 @@ type User struct {
 	Email    string `json:"email" gorm:"uniqueIndex;not null" `
 	Username string `json:"username" gorm:"uniqueIndex;not null" `
 	Password string `json:"-" `
+	// EmailVerified is set once the user opens the verification link mailed
+	// to Email
+	EmailVerified bool `json:"email_verified" gorm:"not null;default:false"`
+	// PendingEmail is a new email the user asked for; it replaces Email
+	// only once its verification link is opened
+	PendingEmail string `json:"pending_email,omitempty"`
+	// TokenVersion is bumped to invalidate every access token issued before
+	TokenVersion int `json:"-" gorm:"not null;default:0"`
 }
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Guards a workflow transition can require
+const (
+	WorkflowGuardAssigneeOnly       = "assignee_only"
+	WorkflowGuardOwnerOnly          = "owner_only"
+	WorkflowGuardResolutionRequired = "resolution_required"
+)
+
+// WorkflowAnyState as a transition's source matches every state
+const WorkflowAnyState = "*"
+
+// Workflow defines the states a project's tasks move through and the
+// transitions allowed between them
+type Workflow struct {
+	ID           uint                 `json:"id" gorm:"primarykey"`
+	ProjectID    uint                 `json:"project_id" gorm:"not null;uniqueIndex"`
+	InitialState string               `json:"initial_state" gorm:"not null"`
+	States       []WorkflowState      `json:"states,omitempty"`
+	Transitions  []WorkflowTransition `json:"transitions,omitempty"`
+	CreatedAt    time.Time            `json:"created_at"`
+	UpdatedAt    time.Time            `json:"updated_at"`
+}
+
+// WorkflowState is a named task status; tasks in a final state count as done
+type WorkflowState struct {
+	ID         uint   `json:"id" gorm:"primarykey"`
+	WorkflowID uint   `json:"workflow_id" gorm:"not null;index"`
+	Name       string `json:"name" gorm:"not null"`
+	Final      bool   `json:"final" gorm:"not null;default:false"`
+	Position   int    `json:"position" gorm:"not null;default:0"`
+}
+
+// WorkflowTransition allows tasks to move from one state to another.
+// Guards is a comma-separated list of conditions the move must satisfy.
+type WorkflowTransition struct {
+	ID         uint   `json:"id" gorm:"primarykey"`
+	WorkflowID uint   `json:"workflow_id" gorm:"not null;index"`
+	Name       string `json:"name"`
+	From       string `json:"from" gorm:"column:from_state;not null"`
+	To         string `json:"to" gorm:"column:to_state;not null"`
+	Guards     string `json:"guards"`
+}
+
+// GuardList returns the transition's guards as a slice
+func (t *WorkflowTransition) GuardList() []string {
+	if t.Guards == "" {
+		return nil
+	}
+	return strings.Split(t.Guards, ",")
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Worklog is time a user spent on a task. A worklog without EndedAt is the
+// user's running timer; each user has at most one.
+type Worklog struct {
+	ID        uint       `json:"id" gorm:"primarykey"`
+	TaskID    uint       `json:"task_id" gorm:"not null;index"`
+	Task      *Task      `json:"-"`
+	UserID    uint       `json:"user_id" gorm:"not null;index;uniqueIndex:idx_running_timer,where:ended_at IS NULL"`
+	User      *User      `json:"-"`
+	StartedAt time.Time  `json:"started_at" gorm:"not null;index"`
+	EndedAt   *time.Time `json:"ended_at"`
+	Seconds   int64      `json:"seconds" gorm:"not null;default:0"`
+	Note      string     `json:"note"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
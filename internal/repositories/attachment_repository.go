@@ -0,0 +1,127 @@
+// internal/repositories/attachment_repository.go
+package repositories
+
+import (
+	"TaskManager/internal/models"
+	"errors"
+	"log"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrQuotaExceeded is returned when an attachment doesn't fit its uploader's quota
+var ErrQuotaExceeded = errors.New("attachment quota exceeded")
+
+// AttachmentRepository interface defines the methods for attachment-related DB operations
+type AttachmentRepository interface {
+	CreateAttachment(attachment *models.Attachment, quota int64) (*models.Attachment, error)
+	GetAttachmentByID(id uint) (*models.Attachment, error)
+	GetAttachmentsByTaskID(taskID uint) ([]models.Attachment, error)
+	DeleteAttachment(id uint) error
+	GetUsageByUserID(userID uint) (int64, error)
+}
+
+// AttachmentRepositoryImpl is the concrete implementation of the AttachmentRepository interface
+type AttachmentRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// NewAttachmentRepository creates and returns a new AttachmentRepository instance
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &AttachmentRepositoryImpl{
+		DB: db,
+	}
+}
+
+// CreateAttachment adds a new attachment record to the database unless it
+// takes the uploader's usage past the quota. The uploader's row is locked so
+// concurrent uploads are checked one after another.
+func (repo *AttachmentRepositoryImpl) CreateAttachment(attachment *models.Attachment, quota int64) (*models.Attachment, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&user, attachment.UserID).Error; err != nil {
+			return err
+		}
+		used, err := usage(tx, attachment.UserID)
+		if err != nil {
+			return err
+		}
+		if used+attachment.Size > quota {
+			return ErrQuotaExceeded
+		}
+		return tx.Create(attachment).Error
+	})
+	if err != nil {
+		log.Println("Error creating attachment:", err)
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// GetAttachmentByID retrieves an attachment by its ID
+func (repo *AttachmentRepositoryImpl) GetAttachmentByID(id uint) (*models.Attachment, error) {
+	var attachment models.Attachment
+	if err := repo.DB.First(&attachment, id).Error; err != nil {
+		log.Println("Error fetching attachment by ID:", err)
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// GetAttachmentsByTaskID retrieves the attachments of a task, newest first
+func (repo *AttachmentRepositoryImpl) GetAttachmentsByTaskID(taskID uint) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	if err := repo.DB.Where("task_id = ?", taskID).Order("created_at DESC, id DESC").Find(&attachments).Error; err != nil {
+		log.Println("Error fetching attachments by task:", err)
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// DeleteAttachment deletes an attachment record
+func (repo *AttachmentRepositoryImpl) DeleteAttachment(id uint) error {
+	result := repo.DB.Delete(&models.Attachment{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetUsageByUserID sums the sizes of the attachments a user uploaded to tasks that still exist
+func (repo *AttachmentRepositoryImpl) GetUsageByUserID(userID uint) (int64, error) {
+	used, err := usage(repo.DB, userID)
+	if err != nil {
+		log.Println("Error fetching attachment usage:", err)
+		return 0, err
+	}
+	return used, nil
+}
+
+// usage sums the sizes of a user's attachments on live tasks (internal helper)
+func usage(db *gorm.DB, userID uint) (int64, error) {
+	var used int64
+	live := db.Model(&models.Task{}).Select("id")
+	err := db.Model(&models.Attachment{}).
+		Where("user_id = ? AND task_id IN (?)", userID, live).
+		Select("COALESCE(SUM(size), 0)").
+		Scan(&used).Error
+	return used, err
+}
+
+// deleteTaskAttachments deletes the attachment records of tasks inside a
+// transaction and returns their storage keys, so the caller can remove the
+// blobs once the transaction commits
+func deleteTaskAttachments(tx *gorm.DB, tasks interface{}) ([]string, error) {
+	var keys []string
+	if err := tx.Model(&models.Attachment{}).Where("task_id IN (?)", tasks).Pluck("storage_key", &keys).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("task_id IN (?)", tasks).Delete(&models.Attachment{}).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
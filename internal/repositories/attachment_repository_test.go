@@ -0,0 +1,95 @@
+package repositories_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// createAttachment stores an attachment record of the given size
+func createAttachment(t *testing.T, db *gorm.DB, uploader *models.User, task *models.Task, key string, size int64) *models.Attachment {
+	attachment := &models.Attachment{TaskID: task.ID, UserID: uploader.ID, Filename: key, ContentType: "text/plain", Size: size, Checksum: key, StorageKey: key}
+	require.NoError(t, db.Create(attachment).Error)
+	return attachment
+}
+
+func TestCreateAttachment_Quota(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewAttachmentRepository(db)
+
+	john := createUser(t, db, "john")
+	task := createTask(t, db, john, nil, "Task", "")
+	createAttachment(t, db, john, task, "first", 600)
+
+	_, err := repo.CreateAttachment(&models.Attachment{TaskID: task.ID, UserID: john.ID, Filename: "a", Size: 400, StorageKey: "fits"}, 1000)
+	require.NoError(t, err)
+
+	_, err = repo.CreateAttachment(&models.Attachment{TaskID: task.ID, UserID: john.ID, Filename: "b", Size: 1, StorageKey: "over"}, 1000)
+	assert.ErrorIs(t, err, repositories.ErrQuotaExceeded)
+
+	used, err := repo.GetUsageByUserID(john.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), used)
+}
+
+func TestDeleteTasks_RemovesAttachments(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewTaskRepository(db)
+	attachments := repositories.NewAttachmentRepository(db)
+
+	john := createUser(t, db, "john")
+	deleted := createTask(t, db, john, nil, "Deleted", "")
+	kept := createTask(t, db, john, nil, "Kept", "")
+	createAttachment(t, db, john, deleted, "deleted", 300)
+	createAttachment(t, db, john, kept, "kept", 200)
+
+	keys, err := repo.DeleteTasks([]uint{deleted.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deleted"}, keys)
+
+	remaining, err := attachments.GetAttachmentsByTaskID(deleted.ID)
+	require.NoError(t, err)
+	assert.Empty(t, remaining)
+	used, err := attachments.GetUsageByUserID(john.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(200), used)
+}
+
+func TestDeleteProject_RemovesAttachments(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewProjectRepository(db)
+	attachments := repositories.NewAttachmentRepository(db)
+
+	john := createUser(t, db, "john")
+	jane := createUser(t, db, "jane")
+	project := createProject(t, db, john, jane)
+	createAttachment(t, db, jane, createTask(t, db, john, project, "Project task", ""), "project", 300)
+	createAttachment(t, db, jane, createTask(t, db, jane, nil, "Personal", ""), "personal", 200)
+
+	keys, err := repo.DeleteProject(project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"project"}, keys)
+
+	used, err := attachments.GetUsageByUserID(jane.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(200), used)
+}
+
+func TestGetUsageByUserID_IgnoresDeletedTasks(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewAttachmentRepository(db)
+
+	// Attachments left behind on a task deleted without them don't count
+	john := createUser(t, db, "john")
+	task := createTask(t, db, john, nil, "Task", "")
+	createAttachment(t, db, john, task, "orphan", 300)
+	require.NoError(t, db.Delete(task).Error)
+
+	used, err := repo.GetUsageByUserID(john.ID)
+	require.NoError(t, err)
+	assert.Zero(t, used)
+}
@@ -0,0 +1,100 @@
+// internal/repositories/board_repository.go
+package repositories
+
+import (
+	"TaskManager/internal/models"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// BoardRepository interface defines the methods for board-related DB operations
+type BoardRepository interface {
+	CreateBoard(board *models.Board) (*models.Board, error)
+	GetBoardByID(id uint) (*models.Board, error)
+	GetBoardsByProjectID(projectID uint) ([]models.Board, error)
+	UpdateBoard(board *models.Board) (*models.Board, error)
+	DeleteBoard(id uint) error
+}
+
+// BoardRepositoryImpl is the concrete implementation of the BoardRepository interface
+type BoardRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// NewBoardRepository creates and returns a new BoardRepository instance
+func NewBoardRepository(db *gorm.DB) BoardRepository {
+	return &BoardRepositoryImpl{
+		DB: db,
+	}
+}
+
+// orderedColumns preloads a board's columns in display order
+func orderedColumns(db *gorm.DB) *gorm.DB {
+	return db.Order("position, id")
+}
+
+// CreateBoard adds a new board together with its columns
+func (repo *BoardRepositoryImpl) CreateBoard(board *models.Board) (*models.Board, error) {
+	if err := repo.DB.Create(board).Error; err != nil {
+		log.Println("Error creating board:", err)
+		return nil, err
+	}
+	return board, nil
+}
+
+// GetBoardByID retrieves a board and its columns by the board's ID
+func (repo *BoardRepositoryImpl) GetBoardByID(id uint) (*models.Board, error) {
+	var board models.Board
+	if err := repo.DB.Preload("Columns", orderedColumns).First(&board, id).Error; err != nil {
+		log.Println("Error fetching board by ID:", err)
+		return nil, err
+	}
+	return &board, nil
+}
+
+// GetBoardsByProjectID retrieves all boards of a project with their columns
+func (repo *BoardRepositoryImpl) GetBoardsByProjectID(projectID uint) ([]models.Board, error) {
+	var boards []models.Board
+	err := repo.DB.Preload("Columns", orderedColumns).Where("project_id = ?", projectID).Order("id").Find(&boards).Error
+	if err != nil {
+		log.Println("Error fetching boards by project:", err)
+		return nil, err
+	}
+	return boards, nil
+}
+
+// UpdateBoard saves a board's name and replaces its columns in one transaction
+func (repo *BoardRepositoryImpl) UpdateBoard(board *models.Board) (*models.Board, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Columns").Save(board).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("board_id = ?", board.ID).Delete(&models.BoardColumn{}).Error; err != nil {
+			return err
+		}
+		for i := range board.Columns {
+			board.Columns[i].ID = 0
+			board.Columns[i].BoardID = board.ID
+		}
+		if len(board.Columns) == 0 {
+			return nil
+		}
+		return tx.Create(&board.Columns).Error
+	})
+	if err != nil {
+		log.Println("Error updating board:", err)
+		return nil, err
+	}
+	return board, nil
+}
+
+// DeleteBoard deletes a board and its columns; the tasks on it are untouched
+func (repo *BoardRepositoryImpl) DeleteBoard(id uint) error {
+	return repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("board_id = ?", id).Delete(&models.BoardColumn{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Board{}, id).Error
+	})
+}
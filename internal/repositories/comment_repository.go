@@ -0,0 +1,165 @@
+// internal/repositories/comment_repository.go
+package repositories
+
+import (
+	"TaskManager/internal/models"
+	"log"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CommentRepository interface defines the methods for comment-related DB operations
+type CommentRepository interface {
+	CreateComment(comment *models.Comment) (*models.Comment, error)
+	GetCommentByID(id uint) (*models.Comment, error)
+	GetCommentsByTaskID(taskID uint) ([]models.Comment, error)
+	UpdateComment(comment *models.Comment, previousBody string) (*models.Comment, error)
+	DeleteComment(id uint) error
+	GetRevisions(commentID uint) ([]models.CommentRevision, error)
+	GetCommentsMentioning(userID uint, limit int) ([]models.Comment, error)
+}
+
+// CommentRepositoryImpl is the concrete implementation of the CommentRepository interface
+type CommentRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// NewCommentRepository creates and returns a new CommentRepository instance
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &CommentRepositoryImpl{
+		DB: db,
+	}
+}
+
+// CreateComment adds a new comment together with its mentions
+func (repo *CommentRepositoryImpl) CreateComment(comment *models.Comment) (*models.Comment, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(comment).Error; err != nil {
+			return err
+		}
+		return saveMentions(tx, comment)
+	})
+	if err != nil {
+		log.Println("Error creating comment:", err)
+		return nil, err
+	}
+	return comment, nil
+}
+
+// GetCommentByID retrieves a comment that hasn't been deleted, with its author and mentions
+func (repo *CommentRepositoryImpl) GetCommentByID(id uint) (*models.Comment, error) {
+	var comment models.Comment
+	if err := repo.DB.Preload("User").Preload("Mentions.User").First(&comment, id).Error; err != nil {
+		log.Println("Error fetching comment by ID:", err)
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// GetCommentsByTaskID retrieves all comments of a task in posting order,
+// deleted ones included so threads can be rebuilt around them
+func (repo *CommentRepositoryImpl) GetCommentsByTaskID(taskID uint) ([]models.Comment, error) {
+	var comments []models.Comment
+	err := repo.DB.Unscoped().
+		Preload("User").Preload("Mentions.User").
+		Where("task_id = ?", taskID).
+		Order("created_at, id").
+		Find(&comments).Error
+	if err != nil {
+		log.Println("Error fetching comments by task:", err)
+		return nil, err
+	}
+	return comments, nil
+}
+
+// UpdateComment saves a comment's new body, keeping the previous one as a
+// revision and syncing its mentions, in one transaction
+func (repo *CommentRepositoryImpl) UpdateComment(comment *models.Comment, previousBody string) (*models.Comment, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		revision := models.CommentRevision{CommentID: comment.ID, Body: previousBody}
+		if err := tx.Create(&revision).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(comment).Select("body", "edited_at").Updates(comment).Error; err != nil {
+			return err
+		}
+
+		// Keep the mentions that remain so their users aren't notified twice
+		kept := make([]uint, 0, len(comment.Mentions))
+		for _, mention := range comment.Mentions {
+			kept = append(kept, mention.UserID)
+		}
+		stale := tx.Where("comment_id = ?", comment.ID)
+		if len(kept) > 0 {
+			stale = stale.Where("user_id NOT IN ?", kept)
+		}
+		if err := stale.Delete(&models.CommentMention{}).Error; err != nil {
+			return err
+		}
+		return saveMentions(tx, comment)
+	})
+	if err != nil {
+		log.Println("Error updating comment:", err)
+		return nil, err
+	}
+	return comment, nil
+}
+
+// saveMentions inserts the mentions of a comment that aren't recorded yet
+func saveMentions(tx *gorm.DB, comment *models.Comment) error {
+	if len(comment.Mentions) == 0 {
+		return nil
+	}
+	for i := range comment.Mentions {
+		comment.Mentions[i].CommentID = comment.ID
+	}
+	return tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&comment.Mentions).Error
+}
+
+// DeleteComment soft-deletes a comment and drops its mentions
+func (repo *CommentRepositoryImpl) DeleteComment(id uint) error {
+	return repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("comment_id = ?", id).Delete(&models.CommentMention{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Comment{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// GetRevisions retrieves the previous bodies of a comment, oldest first
+func (repo *CommentRepositoryImpl) GetRevisions(commentID uint) ([]models.CommentRevision, error) {
+	var revisions []models.CommentRevision
+	if err := repo.DB.Where("comment_id = ?", commentID).Order("id").Find(&revisions).Error; err != nil {
+		log.Println("Error fetching comment revisions:", err)
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// GetCommentsMentioning retrieves the latest comments mentioning a user, with their tasks.
+// Comments on tasks the user can no longer see, e.g. in projects the user has left, are left out.
+func (repo *CommentRepositoryImpl) GetCommentsMentioning(userID uint, limit int) ([]models.Comment, error) {
+	var comments []models.Comment
+	err := repo.DB.
+		Preload("User").Preload("Mentions.User").Preload("Task").
+		Joins("JOIN comment_mentions ON comment_mentions.comment_id = comments.id").
+		Joins("JOIN tasks ON tasks.id = comments.task_id AND tasks.deleted_at IS NULL").
+		Where("comment_mentions.user_id = ?", userID).
+		Where(taskVisibleTo, userID, userID).
+		Order("comments.created_at DESC").
+		Limit(limit).
+		Find(&comments).Error
+	if err != nil {
+		log.Println("Error fetching mentions:", err)
+		return nil, err
+	}
+	return comments, nil
+}
@@ -0,0 +1,59 @@
+package repositories_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// mention records that a comment mentions the user
+func mention(t *testing.T, db *gorm.DB, comment *models.Comment, user *models.User) {
+	require.NoError(t, db.Create(&models.CommentMention{CommentID: comment.ID, UserID: user.ID}).Error)
+}
+
+func TestGetCommentsMentioning_LeavesOutInvisibleTasks(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewCommentRepository(db)
+	projects := repositories.NewProjectRepository(db)
+
+	john := createUser(t, db, "john")
+	jane := createUser(t, db, "jane")
+	shared := createProject(t, db, jane, john)
+	left := createProject(t, db, jane, john)
+
+	sharedComment := createComment(t, db, jane, createTask(t, db, jane, shared, "Shared", ""), "@john look")
+	leftComment := createComment(t, db, jane, createTask(t, db, jane, left, "Left", ""), "@john look")
+	deletedTask := createTask(t, db, jane, shared, "Deleted", "")
+	deletedComment := createComment(t, db, jane, deletedTask, "@john look")
+	otherComment := createComment(t, db, jane, createTask(t, db, jane, shared, "Other", ""), "@jane look")
+	for _, comment := range []*models.Comment{sharedComment, leftComment, deletedComment} {
+		mention(t, db, comment, john)
+	}
+	mention(t, db, otherComment, jane)
+	require.NoError(t, db.Delete(deletedTask).Error)
+
+	comments, err := repo.GetCommentsMentioning(john.ID, 10)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uint{sharedComment.ID, leftComment.ID}, commentIDs(comments))
+
+	// Once removed from a project, john no longer gets mentions on its tasks
+	require.NoError(t, projects.RemoveMember(left.ID, john.ID))
+	comments, err = repo.GetCommentsMentioning(john.ID, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{sharedComment.ID}, commentIDs(comments))
+	require.NotNil(t, comments[0].Task)
+	assert.Equal(t, "Shared", comments[0].Task.Title)
+}
+
+// commentIDs lists the IDs of comments in order
+func commentIDs(comments []models.Comment) []uint {
+	ids := make([]uint, len(comments))
+	for i, comment := range comments {
+		ids[i] = comment.ID
+	}
+	return ids
+}
@@ -0,0 +1,179 @@
+// internal/repositories/label_repository.go
+package repositories
+
+import (
+	"TaskManager/internal/models"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// taskLabelsTable is the join table between tasks and labels
+const taskLabelsTable = "task_labels"
+
+// LabelRepository interface defines the methods for label-related DB operations
+type LabelRepository interface {
+	CreateLabel(label *models.Label) (*models.Label, error)
+	GetLabelByID(id uint) (*models.Label, error)
+	GetLabelsByUserID(userID uint) ([]models.Label, error)
+	GetLabelsByProjectID(projectID uint) ([]models.Label, error)
+	GetLabelByName(userID, projectID *uint, name string) (*models.Label, error)
+	UpdateLabel(label *models.Label) (*models.Label, error)
+	DeleteLabel(id uint) error
+	MergeLabels(sourceID, targetID uint) error
+	AddTaskLabel(taskID, labelID uint) error
+	RemoveTaskLabel(taskID, labelID uint) error
+}
+
+// LabelRepositoryImpl is the concrete implementation of the LabelRepository interface
+type LabelRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// NewLabelRepository creates and returns a new LabelRepository instance
+func NewLabelRepository(db *gorm.DB) LabelRepository {
+	return &LabelRepositoryImpl{
+		DB: db,
+	}
+}
+
+// CreateLabel adds a new label to the database
+func (repo *LabelRepositoryImpl) CreateLabel(label *models.Label) (*models.Label, error) {
+	if err := repo.DB.Create(label).Error; err != nil {
+		log.Println("Error creating label:", err)
+		return nil, err
+	}
+	return label, nil
+}
+
+// GetLabelByID retrieves a label by its ID
+func (repo *LabelRepositoryImpl) GetLabelByID(id uint) (*models.Label, error) {
+	var label models.Label
+	if err := repo.DB.First(&label, id).Error; err != nil {
+		log.Println("Error fetching label by ID:", err)
+		return nil, err
+	}
+	return &label, nil
+}
+
+// GetLabelsByUserID retrieves a user's personal labels
+func (repo *LabelRepositoryImpl) GetLabelsByUserID(userID uint) ([]models.Label, error) {
+	var labels []models.Label
+	if err := repo.DB.Where("user_id = ?", userID).Order("name").Find(&labels).Error; err != nil {
+		log.Println("Error fetching labels by user:", err)
+		return nil, err
+	}
+	return labels, nil
+}
+
+// GetLabelsByProjectID retrieves the labels of a project
+func (repo *LabelRepositoryImpl) GetLabelsByProjectID(projectID uint) ([]models.Label, error) {
+	var labels []models.Label
+	if err := repo.DB.Where("project_id = ?", projectID).Order("name").Find(&labels).Error; err != nil {
+		log.Println("Error fetching labels by project:", err)
+		return nil, err
+	}
+	return labels, nil
+}
+
+// GetLabelByName retrieves the label of a user or project with the given
+// name, ignoring case
+func (repo *LabelRepositoryImpl) GetLabelByName(userID, projectID *uint, name string) (*models.Label, error) {
+	query := repo.DB.Where("LOWER(name) = LOWER(?)", name)
+	if projectID != nil {
+		query = query.Where("project_id = ?", *projectID)
+	} else {
+		query = query.Where("user_id = ? AND project_id IS NULL", userID)
+	}
+
+	var label models.Label
+	if err := query.First(&label).Error; err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+// touchLabelledTasks bumps the update time of the tasks carrying a label so
+// clients syncing on it notice the change (internal helper)
+func touchLabelledTasks(tx *gorm.DB, labelID uint) error {
+	tagged := tx.Table(taskLabelsTable).Select("task_id").Where("label_id = ?", labelID)
+	return tx.Model(&models.Task{}).Where("id IN (?)", tagged).UpdateColumn("updated_at", time.Now()).Error
+}
+
+// UpdateLabel renames or recolours a label and updates its tasks in one transaction
+func (repo *LabelRepositoryImpl) UpdateLabel(label *models.Label) (*models.Label, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(label).Select("name", "color").Updates(label).Error; err != nil {
+			return err
+		}
+		return touchLabelledTasks(tx, label.ID)
+	})
+	if err != nil {
+		log.Println("Error updating label:", err)
+		return nil, err
+	}
+	return label, nil
+}
+
+// DeleteLabel removes a label from its tasks and deletes it in one transaction
+func (repo *LabelRepositoryImpl) DeleteLabel(id uint) error {
+	return repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := touchLabelledTasks(tx, id); err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM "+taskLabelsTable+" WHERE label_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Label{}, id).Error
+	})
+}
+
+// MergeLabels retags every task carrying the source label with the target
+// label and deletes the source, all in one transaction
+func (repo *LabelRepositoryImpl) MergeLabels(sourceID, targetID uint) error {
+	return repo.DB.Transaction(func(tx *gorm.DB) error {
+		var taskIDs []uint
+		if err := tx.Table(taskLabelsTable).Where("label_id = ?", sourceID).Pluck("task_id", &taskIDs).Error; err != nil {
+			return err
+		}
+
+		if len(taskIDs) > 0 {
+			links := make([]map[string]interface{}, 0, len(taskIDs))
+			for _, taskID := range taskIDs {
+				links = append(links, map[string]interface{}{"task_id": taskID, "label_id": targetID})
+			}
+			// Tasks that already carry the target keep a single link
+			if err := tx.Table(taskLabelsTable).Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&models.Task{}).Where("id IN ?", taskIDs).UpdateColumn("updated_at", time.Now()).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Exec("DELETE FROM "+taskLabelsTable+" WHERE label_id = ?", sourceID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Label{}, sourceID).Error
+	})
+}
+
+// AddTaskLabel tags a task with a label; tagging twice is a no-op
+func (repo *LabelRepositoryImpl) AddTaskLabel(taskID, labelID uint) error {
+	link := map[string]interface{}{"task_id": taskID, "label_id": labelID}
+	return repo.DB.Table(taskLabelsTable).Clauses(clause.OnConflict{DoNothing: true}).Create(link).Error
+}
+
+// RemoveTaskLabel removes a label from a task
+func (repo *LabelRepositoryImpl) RemoveTaskLabel(taskID, labelID uint) error {
+	result := repo.DB.Exec("DELETE FROM "+taskLabelsTable+" WHERE task_id = ? AND label_id = ?", taskID, labelID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,378 @@
+// internal/repositories/project_repository.go
+package repositories
+
+import (
+	"TaskManager/internal/models"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// ProjectRepository interface defines the methods for project-related DB operations
+type ProjectRepository interface {
+	CreateProject(project *models.Project) (*models.Project, error)
+	GetProjectByID(id uint) (*models.Project, error)
+	GetProjectsByUserID(userID uint) ([]models.Project, error)
+	UpdateProject(project *models.Project) (*models.Project, error)
+	DeleteProject(id uint) ([]string, error)
+	GetMember(projectID, userID uint) (*models.ProjectMember, error)
+	GetMembers(projectID uint) ([]models.ProjectMember, error)
+	AddMember(member *models.ProjectMember) (*models.ProjectMember, error)
+	UpdateMember(member *models.ProjectMember) (*models.ProjectMember, error)
+	RemoveMember(projectID, userID uint) error
+	GetWorkflow(projectID uint) (*models.Workflow, error)
+	SaveWorkflow(workflow *models.Workflow) (*models.Workflow, []uint, error)
+	DeleteWorkflow(projectID uint, finalStates []string) ([]uint, error)
+	GetCustomFields(projectID uint) ([]models.CustomField, error)
+	GetCustomField(projectID, id uint) (*models.CustomField, error)
+	CreateCustomField(field *models.CustomField) (*models.CustomField, error)
+	UpdateCustomField(field *models.CustomField, removedOptions []string) (*models.CustomField, error)
+	DeleteCustomField(field *models.CustomField) error
+}
+
+// ProjectRepositoryImpl is the concrete implementation of the ProjectRepository interface
+type ProjectRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// NewProjectRepository creates and returns a new ProjectRepository instance
+func NewProjectRepository(db *gorm.DB) ProjectRepository {
+	return &ProjectRepositoryImpl{
+		DB: db,
+	}
+}
+
+// CreateProject adds a new project together with its owner membership
+func (repo *ProjectRepositoryImpl) CreateProject(project *models.Project) (*models.Project, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Members").Create(project).Error; err != nil {
+			return err
+		}
+		owner := models.ProjectMember{
+			ProjectID: project.ID,
+			UserID:    project.OwnerID,
+			Role:      models.ProjectRoleOwner,
+		}
+		if err := tx.Create(&owner).Error; err != nil {
+			return err
+		}
+		project.Members = []models.ProjectMember{owner}
+		return nil
+	})
+	if err != nil {
+		log.Println("Error creating project:", err)
+		return nil, err
+	}
+	return project, nil
+}
+
+// GetProjectByID retrieves a project by its ID
+func (repo *ProjectRepositoryImpl) GetProjectByID(id uint) (*models.Project, error) {
+	var project models.Project
+	if err := repo.DB.First(&project, id).Error; err != nil {
+		log.Println("Error fetching project by ID:", err)
+		return nil, err
+	}
+	return &project, nil
+}
+
+// GetProjectsByUserID retrieves all projects the given user is a member of
+func (repo *ProjectRepositoryImpl) GetProjectsByUserID(userID uint) ([]models.Project, error) {
+	var projects []models.Project
+	err := repo.DB.
+		Joins("JOIN project_members ON project_members.project_id = projects.id").
+		Where("project_members.user_id = ?", userID).
+		Order("projects.created_at DESC").
+		Find(&projects).Error
+	if err != nil {
+		log.Println("Error fetching projects by user:", err)
+		return nil, err
+	}
+	return projects, nil
+}
+
+// UpdateProject updates an existing project's information
+func (repo *ProjectRepositoryImpl) UpdateProject(project *models.Project) (*models.Project, error) {
+	if err := repo.DB.Omit("Members").Save(project).Error; err != nil {
+		log.Println("Error updating project:", err)
+		return nil, err
+	}
+	return project, nil
+}
+
+// DeleteProject deletes a project along with its memberships, boards, workflow, custom fields, templates,
+// saved views, labels, tasks and attachments. It returns the storage keys of the deleted attachments.
+func (repo *ProjectRepositoryImpl) DeleteProject(id uint) ([]string, error) {
+	var keys []string
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := deleteWorkflow(tx, id); err != nil {
+			return err
+		}
+		fields := tx.Model(&models.CustomField{}).Select("id").Where("project_id = ?", id)
+		if err := tx.Where("field_id IN (?)", fields).Delete(&models.CustomFieldValue{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&models.CustomField{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&models.TaskTemplate{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&models.SavedView{}).Error; err != nil {
+			return err
+		}
+		boards := tx.Model(&models.Board{}).Select("id").Where("project_id = ?", id)
+		if err := tx.Where("board_id IN (?)", boards).Delete(&models.BoardColumn{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&models.Board{}).Error; err != nil {
+			return err
+		}
+		tasks := tx.Model(&models.Task{}).Select("id").Where("project_id = ?", id)
+		var err error
+		if keys, err = deleteTaskAttachments(tx, tasks); err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&models.Task{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM "+taskLabelsTable+" WHERE label_id IN (SELECT id FROM labels WHERE project_id = ?)", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&models.Label{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&models.ProjectMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Project{}, id).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// GetMember retrieves the membership of a user in a project
+func (repo *ProjectRepositoryImpl) GetMember(projectID, userID uint) (*models.ProjectMember, error) {
+	var member models.ProjectMember
+	if err := repo.DB.Where("project_id = ? AND user_id = ?", projectID, userID).First(&member).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// GetMembers retrieves all members of a project with their user records
+func (repo *ProjectRepositoryImpl) GetMembers(projectID uint) ([]models.ProjectMember, error) {
+	var members []models.ProjectMember
+	if err := repo.DB.Preload("User").Where("project_id = ?", projectID).Order("id").Find(&members).Error; err != nil {
+		log.Println("Error fetching project members:", err)
+		return nil, err
+	}
+	return members, nil
+}
+
+// AddMember adds a user to a project
+func (repo *ProjectRepositoryImpl) AddMember(member *models.ProjectMember) (*models.ProjectMember, error) {
+	if err := repo.DB.Create(member).Error; err != nil {
+		log.Println("Error adding project member:", err)
+		return nil, err
+	}
+	return member, nil
+}
+
+// UpdateMember updates a member's role
+func (repo *ProjectRepositoryImpl) UpdateMember(member *models.ProjectMember) (*models.ProjectMember, error) {
+	if err := repo.DB.Save(member).Error; err != nil {
+		log.Println("Error updating project member:", err)
+		return nil, err
+	}
+	return member, nil
+}
+
+// RemoveMember removes a user from a project
+func (repo *ProjectRepositoryImpl) RemoveMember(projectID, userID uint) error {
+	return repo.DB.Where("project_id = ? AND user_id = ?", projectID, userID).Delete(&models.ProjectMember{}).Error
+}
+
+// GetWorkflow retrieves a project's custom workflow with its states and transitions
+func (repo *ProjectRepositoryImpl) GetWorkflow(projectID uint) (*models.Workflow, error) {
+	var workflow models.Workflow
+	err := repo.DB.
+		Preload("States", func(db *gorm.DB) *gorm.DB { return db.Order("position, id") }).
+		Preload("Transitions", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Where("project_id = ?", projectID).
+		First(&workflow).Error
+	if err != nil {
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+// SaveWorkflow replaces a project's workflow in one transaction and closes or
+// reopens the project's tasks to match its final states. It returns the IDs of
+// the tasks that changed.
+func (repo *ProjectRepositoryImpl) SaveWorkflow(workflow *models.Workflow) (*models.Workflow, []uint, error) {
+	var changed []uint
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := deleteWorkflow(tx, workflow.ProjectID); err != nil {
+			return err
+		}
+		workflow.ID = 0
+		for i := range workflow.States {
+			workflow.States[i].ID = 0
+		}
+		for i := range workflow.Transitions {
+			workflow.Transitions[i].ID = 0
+		}
+		if err := tx.Create(workflow).Error; err != nil {
+			return err
+		}
+
+		var finalStates []string
+		for _, state := range workflow.States {
+			if state.Final {
+				finalStates = append(finalStates, state.Name)
+			}
+		}
+		var err error
+		changed, err = syncClosedTasks(tx, workflow.ProjectID, finalStates)
+		return err
+	})
+	if err != nil {
+		log.Println("Error saving workflow:", err)
+		return nil, nil, err
+	}
+	return workflow, changed, nil
+}
+
+// DeleteWorkflow removes a project's custom workflow, if any, and closes or
+// reopens the project's tasks to match the final states of the workflow that
+// applies instead. It returns the IDs of the tasks that changed.
+func (repo *ProjectRepositoryImpl) DeleteWorkflow(projectID uint, finalStates []string) ([]uint, error) {
+	var changed []uint
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := deleteWorkflow(tx, projectID); err != nil {
+			return err
+		}
+		var err error
+		changed, err = syncClosedTasks(tx, projectID, finalStates)
+		return err
+	})
+	if err != nil {
+		log.Println("Error deleting workflow:", err)
+		return nil, err
+	}
+	return changed, nil
+}
+
+// syncClosedTasks marks a project's tasks closed exactly when their status is
+// one of the final states, inside a transaction. Reopened tasks lose their
+// resolution, as they do when moved out of a final state.
+func syncClosedTasks(tx *gorm.DB, projectID uint, finalStates []string) ([]uint, error) {
+	var closing, reopening []uint
+	err := tx.Model(&models.Task{}).
+		Where("project_id = ? AND closed = ? AND status IN ?", projectID, false, finalStates).
+		Pluck("id", &closing).Error
+	if err != nil {
+		return nil, err
+	}
+	err = tx.Model(&models.Task{}).
+		Where("project_id = ? AND closed = ? AND status NOT IN ?", projectID, true, finalStates).
+		Pluck("id", &reopening).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(closing) > 0 {
+		if err := tx.Model(&models.Task{}).Where("id IN ?", closing).UpdateColumn("closed", true).Error; err != nil {
+			return nil, err
+		}
+	}
+	if len(reopening) > 0 {
+		err := tx.Model(&models.Task{}).Where("id IN ?", reopening).
+			UpdateColumns(map[string]interface{}{"closed": false, "resolution": ""}).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	return append(closing, reopening...), nil
+}
+
+// deleteWorkflow deletes a project's workflow rows inside a transaction
+func deleteWorkflow(tx *gorm.DB, projectID uint) error {
+	workflows := tx.Model(&models.Workflow{}).Select("id").Where("project_id = ?", projectID)
+	if err := tx.Where("workflow_id IN (?)", workflows).Delete(&models.WorkflowState{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("workflow_id IN (?)", workflows).Delete(&models.WorkflowTransition{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("project_id = ?", projectID).Delete(&models.Workflow{}).Error
+}
+
+// GetCustomFields retrieves a project's custom fields in order
+func (repo *ProjectRepositoryImpl) GetCustomFields(projectID uint) ([]models.CustomField, error) {
+	var fields []models.CustomField
+	if err := repo.DB.Where("project_id = ?", projectID).Order("position, id").Find(&fields).Error; err != nil {
+		log.Println("Error fetching custom fields:", err)
+		return nil, err
+	}
+	return fields, nil
+}
+
+// GetCustomField retrieves a custom field of a project
+func (repo *ProjectRepositoryImpl) GetCustomField(projectID, id uint) (*models.CustomField, error) {
+	var field models.CustomField
+	if err := repo.DB.Where("project_id = ?", projectID).First(&field, id).Error; err != nil {
+		log.Println("Error fetching custom field:", err)
+		return nil, err
+	}
+	return &field, nil
+}
+
+// CreateCustomField adds a custom field to a project
+func (repo *ProjectRepositoryImpl) CreateCustomField(field *models.CustomField) (*models.CustomField, error) {
+	if err := repo.DB.Create(field).Error; err != nil {
+		log.Println("Error creating custom field:", err)
+		return nil, err
+	}
+	return field, nil
+}
+
+// UpdateCustomField saves a custom field and clears task values that chose
+// one of the removed options
+func (repo *ProjectRepositoryImpl) UpdateCustomField(field *models.CustomField, removedOptions []string) (*models.CustomField, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(field).Error; err != nil {
+			return err
+		}
+		if len(removedOptions) == 0 {
+			return nil
+		}
+		return tx.Where("field_id = ? AND text_value IN ?", field.ID, removedOptions).Delete(&models.CustomFieldValue{}).Error
+	})
+	if err != nil {
+		log.Println("Error updating custom field:", err)
+		return nil, err
+	}
+	return field, nil
+}
+
+// DeleteCustomField removes a custom field together with every task's value for it
+func (repo *ProjectRepositoryImpl) DeleteCustomField(field *models.CustomField) error {
+	return repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("field_id = ?", field.ID).Delete(&models.CustomFieldValue{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.CustomField{}, field.ID)
+		if result.Error != nil {
+			log.Println("Error deleting custom field:", result.Error)
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
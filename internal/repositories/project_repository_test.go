@@ -0,0 +1,67 @@
+package repositories_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// setStatus moves a stored task to a status with the given closed flag
+func setStatus(t *testing.T, db *gorm.DB, task *models.Task, status string, closed bool, resolution string) {
+	err := db.Model(task).UpdateColumns(map[string]interface{}{"status": status, "closed": closed, "resolution": resolution}).Error
+	require.NoError(t, err)
+}
+
+// closedFlag reads the stored closed flag and resolution of a task
+func closedFlag(t *testing.T, db *gorm.DB, id uint) (bool, string) {
+	var task models.Task
+	require.NoError(t, db.First(&task, id).Error)
+	return task.Closed, task.Resolution
+}
+
+func TestSaveWorkflow_SyncsClosedTasks(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewProjectRepository(db)
+
+	john := createUser(t, db, "john")
+	project := createProject(t, db, john)
+	other := createProject(t, db, john)
+
+	review := createTask(t, db, john, project, "Review", "")
+	setStatus(t, db, review, "in_review", false, "")
+	shipped := createTask(t, db, john, project, "Shipped", "")
+	setStatus(t, db, shipped, "shipped", true, "fixed")
+	untouched := createTask(t, db, john, other, "Other project", "")
+	setStatus(t, db, untouched, "in_review", false, "")
+
+	// in_review becomes final and shipped stops being final
+	_, changed, err := repo.SaveWorkflow(&models.Workflow{
+		ProjectID:    project.ID,
+		InitialState: "in_review",
+		States:       []models.WorkflowState{{Name: "in_review", Final: true}, {Name: "shipped"}},
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uint{review.ID, shipped.ID}, changed)
+
+	closed, _ := closedFlag(t, db, review.ID)
+	assert.True(t, closed)
+	closed, resolution := closedFlag(t, db, shipped.ID)
+	assert.False(t, closed)
+	assert.Empty(t, resolution)
+	closed, _ = closedFlag(t, db, untouched.ID)
+	assert.False(t, closed)
+
+	// Back on the default workflow only done is final
+	changed, err = repo.DeleteWorkflow(project.ID, []string{models.TaskStatusDone})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{review.ID}, changed)
+	closed, _ = closedFlag(t, db, review.ID)
+	assert.False(t, closed)
+
+	_, err = repo.GetWorkflow(project.ID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
@@ -0,0 +1,93 @@
+package repositories_test
+
+import (
+	"TaskManager/internal/models"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB opens a fresh SQLite database with every table migrated
+func newTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.User{},
+		&models.Project{},
+		&models.ProjectMember{},
+		&models.Task{},
+		&models.ChecklistItem{},
+		&models.TaskDependency{},
+		&models.Board{},
+		&models.BoardColumn{},
+		&models.Workflow{},
+		&models.WorkflowState{},
+		&models.WorkflowTransition{},
+		&models.Label{},
+		&models.Comment{},
+		&models.CommentRevision{},
+		&models.CommentMention{},
+		&models.Attachment{},
+		&models.Worklog{},
+		&models.CustomField{},
+		&models.CustomFieldValue{},
+		&models.TaskTemplate{},
+		&models.SavedView{},
+		&models.Session{},
+		&models.RefreshToken{},
+		&models.RevokedToken{},
+		&models.PasswordResetToken{},
+		&models.EmailVerificationToken{},
+	))
+	return db
+}
+
+// createUser adds a user with the given name
+func createUser(t *testing.T, db *gorm.DB, name string) *models.User {
+	user := &models.User{Username: name, Email: name + "@example.com", Password: "hashed"}
+	require.NoError(t, db.Create(user).Error)
+	return user
+}
+
+// createProject adds a project owned by owner with the other users as editors
+func createProject(t *testing.T, db *gorm.DB, owner *models.User, editors ...*models.User) *models.Project {
+	project := &models.Project{Name: "Project of " + owner.Username, OwnerID: owner.ID}
+	require.NoError(t, db.Omit("Members").Create(project).Error)
+	require.NoError(t, db.Create(&models.ProjectMember{ProjectID: project.ID, UserID: owner.ID, Role: models.ProjectRoleOwner}).Error)
+	for _, editor := range editors {
+		require.NoError(t, db.Create(&models.ProjectMember{ProjectID: project.ID, UserID: editor.ID, Role: models.ProjectRoleEditor}).Error)
+	}
+	return project
+}
+
+// createTask adds a task of owner, in the project if one is given
+func createTask(t *testing.T, db *gorm.DB, owner *models.User, project *models.Project, title, description string) *models.Task {
+	task := &models.Task{Title: title, Description: description, UserID: owner.ID, Status: models.TaskStatusTodo}
+	if project != nil {
+		task.ProjectID = &project.ID
+	}
+	require.NoError(t, db.Create(task).Error)
+	return task
+}
+
+// createComment adds a comment of author on a task
+func createComment(t *testing.T, db *gorm.DB, author *models.User, task *models.Task, body string) *models.Comment {
+	comment := &models.Comment{TaskID: task.ID, UserID: author.ID, Body: body}
+	require.NoError(t, db.Create(comment).Error)
+	return comment
+}
+
+// taskIDs lists the IDs of tasks in order
+func taskIDs(tasks []models.Task) []uint {
+	ids := make([]uint, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
@@ -0,0 +1,237 @@
+// internal/repositories/search_repository.go
+package repositories
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/pkg/textsearch"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Kinds of search hits
+const (
+	SearchHitTask    = "task"
+	SearchHitComment = "comment"
+)
+
+// SearchSnippetWords is about how many words of a description or comment
+// a search hit shows
+const SearchSnippetWords = 30
+
+// likeSearchCandidates caps the tasks and the comments the LIKE search
+// ranks, newest first
+const likeSearchCandidates = 500
+
+// SearchHit is a task or comment matching a full-text search. Title is the
+// title of the task, Snippet the part of its description or of the comment
+// that matched; both mark the words found.
+type SearchHit struct {
+	Kind      string
+	TaskID    uint
+	CommentID *uint
+	ProjectID *uint
+	Title     textsearch.Text
+	Snippet   textsearch.Text
+	Rank      float64
+	UpdatedAt time.Time
+}
+
+// SearchRepository interface defines the methods for full-text search over
+// tasks and comments
+type SearchRepository interface {
+	Migrate() error
+	Search(userID uint, search string, limit int) ([]SearchHit, error)
+}
+
+// NewSearchRepository creates and returns a new SearchRepository instance:
+// Postgres full-text search on Postgres, a LIKE search on other databases
+func NewSearchRepository(db *gorm.DB) SearchRepository {
+	if db.Dialector.Name() == "postgres" {
+		return &PostgresSearchRepository{DB: db}
+	}
+	return &LikeSearchRepository{DB: db}
+}
+
+// PostgresSearchRepository searches generated tsvector columns, ranking hits
+// with ts_rank_cd and marking them with ts_headline
+type PostgresSearchRepository struct {
+	DB *gorm.DB
+}
+
+// postgresSearchMigrations add the generated search columns and their GIN
+// indexes; titles weigh more than descriptions
+var postgresSearchMigrations = []string{
+	`ALTER TABLE tasks ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (` +
+		`setweight(to_tsvector('english', coalesce(title, '')), 'A') || ` +
+		`setweight(to_tsvector('english', coalesce(description, '')), 'B')) STORED`,
+	`CREATE INDEX IF NOT EXISTS idx_tasks_search_vector ON tasks USING GIN (search_vector)`,
+	`ALTER TABLE comments ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (` +
+		`to_tsvector('english', coalesce(body, ''))) STORED`,
+	`CREATE INDEX IF NOT EXISTS idx_comments_search_vector ON comments USING GIN (search_vector)`,
+}
+
+// postgresSearchSQL ranks the matching tasks and comments the user can see,
+// then marks the best of them. The bind variables are the search, the
+// user twice for each kind of hit, the limit and the ts_headline options
+// of the title and the snippet.
+const postgresSearchSQL = `WITH search AS (SELECT websearch_to_tsquery('english', ?) AS query), hits AS (
+SELECT 'task' AS kind, tasks.id AS task_id, NULL::bigint AS comment_id, tasks.project_id, tasks.title, tasks.description AS body,
+ts_rank_cd(tasks.search_vector, search.query) AS rank, tasks.updated_at
+FROM tasks, search
+WHERE tasks.search_vector @@ search.query AND tasks.deleted_at IS NULL AND (` + taskVisibleTo + `)
+UNION ALL
+SELECT 'comment', comments.task_id, comments.id, tasks.project_id, tasks.title, comments.body,
+ts_rank_cd(comments.search_vector, search.query), comments.updated_at
+FROM comments JOIN tasks ON tasks.id = comments.task_id, search
+WHERE comments.search_vector @@ search.query AND comments.deleted_at IS NULL AND tasks.deleted_at IS NULL AND (` + taskVisibleTo + `)
+ORDER BY rank DESC, updated_at DESC
+LIMIT ?)
+SELECT hits.kind, hits.task_id, hits.comment_id, hits.project_id,
+ts_headline('english', hits.title, search.query, ?) AS title,
+ts_headline('english', hits.body, search.query, ?) AS snippet,
+hits.rank, hits.updated_at
+FROM hits, search
+ORDER BY hits.rank DESC, hits.updated_at DESC`
+
+// searchRow is a search hit as Postgres returns it, highlights marked
+type searchRow struct {
+	Kind      string
+	TaskID    uint
+	CommentID *uint
+	ProjectID *uint
+	Title     string
+	Snippet   string
+	Rank      float64
+	UpdatedAt time.Time
+}
+
+// Migrate adds the search columns and indexes, if missing
+func (repo *PostgresSearchRepository) Migrate() error {
+	return repo.DB.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range postgresSearchMigrations {
+			if err := tx.Exec(statement).Error; err != nil {
+				log.Println("Error migrating search columns:", err)
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Search retrieves up to limit tasks and comments the user can see that
+// match a web-style search ("login crash", "\"exact phrase\"", "-draft"),
+// best ranked first
+func (repo *PostgresSearchRepository) Search(userID uint, search string, limit int) ([]SearchHit, error) {
+	marks := fmt.Sprintf("StartSel=%s, StopSel=%s", textsearch.MarkStart, textsearch.MarkStop)
+	titleOptions := marks + ", HighlightAll=true"
+	snippetOptions := fmt.Sprintf(`%s, MaxWords=%d, MinWords=%d, MaxFragments=2, FragmentDelimiter="%s"`,
+		marks, SearchSnippetWords, SearchSnippetWords/2, " "+textsearch.Ellipsis+" ")
+
+	var rows []searchRow
+	err := repo.DB.Raw(postgresSearchSQL, search, userID, userID, userID, userID, limit, titleOptions, snippetOptions).
+		Scan(&rows).Error
+	if err != nil {
+		log.Println("Error searching:", err)
+		return nil, err
+	}
+
+	hits := make([]SearchHit, len(rows))
+	for i, row := range rows {
+		hits[i] = SearchHit{
+			Kind:      row.Kind,
+			TaskID:    row.TaskID,
+			CommentID: row.CommentID,
+			ProjectID: row.ProjectID,
+			Title:     textsearch.Unmark(row.Title),
+			Snippet:   textsearch.Unmark(row.Snippet),
+			Rank:      row.Rank,
+			UpdatedAt: row.UpdatedAt,
+		}
+	}
+	return hits, nil
+}
+
+// LikeSearchRepository searches with LIKE, for databases without full-text
+// search such as SQLite. Every word of the search must occur in the title
+// or description of a task, or in a comment; hits rank by how often the
+// words occur, in titles twice as much.
+type LikeSearchRepository struct {
+	DB *gorm.DB
+}
+
+// Migrate does nothing: the LIKE search needs no columns or indexes
+func (repo *LikeSearchRepository) Migrate() error {
+	return nil
+}
+
+// Search retrieves up to limit tasks and comments the user can see that
+// contain every word of the search, best ranked first
+func (repo *LikeSearchRepository) Search(userID uint, search string, limit int) ([]SearchHit, error) {
+	words := textsearch.Words(search)
+	if len(words) == 0 {
+		return []SearchHit{}, nil
+	}
+
+	taskQuery := repo.DB.Model(&models.Task{}).Where(taskVisibleTo, userID, userID)
+	commentQuery := repo.DB.Model(&models.Comment{}).Preload("Task").
+		Joins("JOIN tasks ON tasks.id = comments.task_id AND tasks.deleted_at IS NULL").
+		Where(taskVisibleTo, userID, userID)
+	for _, word := range words {
+		pattern := "%" + escapeLike(word) + "%"
+		taskQuery = taskQuery.Where(`LOWER(tasks.title) LIKE ? ESCAPE '\' OR LOWER(tasks.description) LIKE ? ESCAPE '\'`, pattern, pattern)
+		commentQuery = commentQuery.Where(`LOWER(comments.body) LIKE ? ESCAPE '\'`, pattern)
+	}
+
+	var tasks []models.Task
+	if err := taskQuery.Order("tasks.updated_at DESC").Limit(likeSearchCandidates).Find(&tasks).Error; err != nil {
+		log.Println("Error searching tasks:", err)
+		return nil, err
+	}
+	var comments []models.Comment
+	if err := commentQuery.Order("comments.updated_at DESC").Limit(likeSearchCandidates).Find(&comments).Error; err != nil {
+		log.Println("Error searching comments:", err)
+		return nil, err
+	}
+
+	snippetSize := SearchSnippetWords * 6
+	hits := make([]SearchHit, 0, len(tasks)+len(comments))
+	for _, task := range tasks {
+		hits = append(hits, SearchHit{
+			Kind:      SearchHitTask,
+			TaskID:    task.ID,
+			ProjectID: task.ProjectID,
+			Title:     textsearch.Highlight(task.Title, words),
+			Snippet:   textsearch.Snippet(task.Description, words, snippetSize),
+			Rank:      float64(2*textsearch.Count(task.Title, words) + textsearch.Count(task.Description, words)),
+			UpdatedAt: task.UpdatedAt,
+		})
+	}
+	for _, comment := range comments {
+		commentID := comment.ID
+		hits = append(hits, SearchHit{
+			Kind:      SearchHitComment,
+			TaskID:    comment.TaskID,
+			CommentID: &commentID,
+			ProjectID: comment.Task.ProjectID,
+			Title:     textsearch.Highlight(comment.Task.Title, words),
+			Snippet:   textsearch.Snippet(comment.Body, words, snippetSize),
+			Rank:      float64(textsearch.Count(comment.Body, words)),
+			UpdatedAt: comment.UpdatedAt,
+		})
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Rank != hits[j].Rank {
+			return hits[i].Rank > hits[j].Rank
+		}
+		return hits[i].UpdatedAt.After(hits[j].UpdatedAt)
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
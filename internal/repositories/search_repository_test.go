@@ -0,0 +1,91 @@
+package repositories_test
+
+import (
+	"TaskManager/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLikeSearch_Visibility(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewSearchRepository(db)
+	require.IsType(t, &repositories.LikeSearchRepository{}, repo)
+	require.NoError(t, repo.Migrate())
+
+	john := createUser(t, db, "john")
+	jane := createUser(t, db, "jane")
+	shared := createProject(t, db, jane, john)
+	foreign := createProject(t, db, jane)
+
+	personal := createTask(t, db, john, nil, "Crash on login", "")
+	sharedTask := createTask(t, db, jane, shared, "Fix the crash", "")
+	sharedComment := createComment(t, db, jane, sharedTask, "crash reproduced")
+	// Neither jane's personal tasks nor those of projects john isn't a
+	// member of show up, nor comments on them
+	janesTask := createTask(t, db, jane, nil, "Crash in my notes", "")
+	createComment(t, db, jane, janesTask, "crash again")
+	foreignTask := createTask(t, db, jane, foreign, "Crash report", "")
+	createComment(t, db, john, foreignTask, "crash seen too")
+
+	hits, err := repo.Search(john.ID, "crash", 10)
+	require.NoError(t, err)
+
+	found := map[uint]bool{}
+	for _, hit := range hits {
+		found[hit.TaskID] = true
+		if hit.Kind == repositories.SearchHitComment {
+			require.NotNil(t, hit.CommentID)
+			assert.Equal(t, sharedComment.ID, *hit.CommentID)
+		}
+	}
+	assert.Equal(t, map[uint]bool{personal.ID: true, sharedTask.ID: true}, found)
+	assert.Len(t, hits, 3)
+}
+
+func TestLikeSearch_RanksAndHighlights(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewSearchRepository(db)
+
+	john := createUser(t, db, "john")
+	inDescription := createTask(t, db, john, nil, "Login page", "The page shows a crash report when saving")
+	inTitle := createTask(t, db, john, nil, "Crash when saving", "")
+	createTask(t, db, john, nil, "Crash on start", "Unrelated")
+	// Every word must occur; wildcards in the search are literal
+	createTask(t, db, john, nil, "100% done", "")
+
+	hits, err := repo.Search(john.ID, "crash saving", 10)
+	require.NoError(t, err)
+	require.Len(t, hits, 2)
+
+	// Titles weigh twice as much
+	assert.Equal(t, inTitle.ID, hits[0].TaskID)
+	assert.Equal(t, inDescription.ID, hits[1].TaskID)
+	assert.Greater(t, hits[0].Rank, hits[1].Rank)
+	assert.Equal(t, "Crash when saving", hits[0].Title.Text)
+	assert.Len(t, hits[0].Title.Highlights, 2)
+	assert.NotEmpty(t, hits[1].Snippet.Highlights)
+
+	hits, err = repo.Search(john.ID, "0%", 10)
+	require.NoError(t, err)
+	assert.Len(t, hits, 1)
+	hits, err = repo.Search(john.ID, "_", 10)
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+}
+
+func TestLikeSearch_SkipsDeleted(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewSearchRepository(db)
+
+	john := createUser(t, db, "john")
+	task := createTask(t, db, john, nil, "Crash", "")
+	comment := createComment(t, db, john, createTask(t, db, john, nil, "Other", ""), "crash")
+	require.NoError(t, db.Delete(task).Error)
+	require.NoError(t, db.Delete(comment).Error)
+
+	hits, err := repo.Search(john.ID, "crash", 10)
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+}
@@ -0,0 +1,780 @@
+// internal/repositories/task_repository.go
+package repositories
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/pkg/rank"
+	"TaskManager/pkg/tql"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrWipLimitReached is returned when a task would enter a board column that is full
+	ErrWipLimitReached = errors.New("column WIP limit reached")
+	// ErrTaskStatusChanged is returned when a task moved on while it was being changed
+	ErrTaskStatusChanged = errors.New("task status changed in the meantime")
+)
+
+// TaskRepository interface defines the methods for task-related DB operations
+type TaskRepository interface {
+	CreateTask(task *models.Task) (*models.Task, error)
+	GetTaskByID(id uint) (*models.Task, error)
+	GetTasksByUserID(userID uint, labels LabelFilter) ([]models.Task, error)
+	GetTasksByProjectID(projectID uint) ([]models.Task, error)
+	GetSubtasks(parentIDs []uint) ([]models.Task, error)
+	UpdateTask(task *models.Task) (*models.Task, error)
+	UpdateTaskProgress(id uint, progress int) error
+	DeleteTasks(ids []uint) ([]string, error)
+	AddDependency(dependency *models.TaskDependency) (*models.TaskDependency, error)
+	RemoveDependency(taskID, blockedByID uint) error
+	GetDependencies(taskIDs []uint) ([]models.TaskDependency, error)
+	GetOpenTasksByUserID(userID uint) ([]models.Task, error)
+	GetLastTaskRank(projectID uint) (string, error)
+	MoveTaskCard(task *models.Task, from string, position int) error
+	GetTaskStatusesByProjectID(projectID uint) ([]string, error)
+	AddAssignee(taskID, userID uint) error
+	RemoveAssignee(taskID, userID uint) error
+	GetTasksAssignedTo(userID uint) ([]models.Task, error)
+	AddWatcher(taskID, userID uint) error
+	RemoveWatcher(taskID, userID uint) error
+	GetTasksWatchedBy(userID uint) ([]models.Task, error)
+	GetChecklistItems(taskID uint) ([]models.ChecklistItem, error)
+	GetChecklistItem(taskID, id uint) (*models.ChecklistItem, error)
+	CreateChecklistItem(item *models.ChecklistItem) (*models.ChecklistItem, error)
+	UpdateChecklistItem(item *models.ChecklistItem) (*models.ChecklistItem, error)
+	DeleteChecklistItem(item *models.ChecklistItem) error
+	UpdateChecklistRanks(ranks map[uint]string) error
+	ReplaceCustomFieldValues(taskID uint, values []models.CustomFieldValue) error
+	QueryProjectTasks(projectID uint, filters []CustomFieldFilter, sort TaskSort) ([]models.Task, error)
+	CreateTaskTree(tasks []TreeTask) error
+	SearchTasks(userID uint, search *tql.Compiled, limit int) ([]models.Task, error)
+}
+
+// TreeTask is a task created by CreateTaskTree. Parent is the index of its
+// parent among the tasks before it, or -1 for a root task.
+type TreeTask struct {
+	Task   *models.Task
+	Parent int
+}
+
+// LabelFilter narrows a task listing to tasks tagged with the given label
+// names, compared case-insensitively. An empty filter matches every task.
+type LabelFilter struct {
+	Names []string
+	// MatchAll requires every label instead of any of them
+	MatchAll bool
+}
+
+// apply adds the filter's conditions to a task query
+func (f LabelFilter) apply(db *gorm.DB) *gorm.DB {
+	if len(f.Names) == 0 {
+		return db
+	}
+	names := make([]string, len(f.Names))
+	for i, name := range f.Names {
+		names[i] = strings.ToLower(name)
+	}
+
+	tagged := db.Session(&gorm.Session{NewDB: true}).Table(taskLabelsTable).
+		Select(taskLabelsTable+".task_id").
+		Joins("JOIN labels ON labels.id = "+taskLabelsTable+".label_id").
+		Where("LOWER(labels.name) IN ?", names)
+	if f.MatchAll {
+		tagged = tagged.Group(taskLabelsTable+".task_id").
+			Having("COUNT(DISTINCT LOWER(labels.name)) = ?", len(uniqueStrings(names)))
+	}
+	return db.Where("tasks.id IN (?)", tagged)
+}
+
+// uniqueStrings drops duplicate strings, keeping the first of each
+func uniqueStrings(values []string) []string {
+	seen := map[string]bool{}
+	unique := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+// CustomFieldFilter narrows a task listing by a custom field value. Column
+// is the value column matching the field's type and Op one of the
+// FilterOp constants.
+type CustomFieldFilter struct {
+	FieldID uint
+	Column  string
+	Op      string
+	Value   interface{}
+}
+
+// Comparison operators of a CustomFieldFilter
+const (
+	FilterOpEq       = "eq"
+	FilterOpNe       = "ne"
+	FilterOpLt       = "lt"
+	FilterOpGt       = "gt"
+	FilterOpContains = "contains"
+)
+
+// customValueColumns are the value columns filters and sorts may use
+var customValueColumns = map[string]bool{
+	"text_value":   true,
+	"number_value": true,
+	"date_value":   true,
+	"user_value":   true,
+}
+
+// TaskSort orders a task listing by a task column or, with a FieldID, by a
+// custom field's value column. Tasks without a value come last either way.
+type TaskSort struct {
+	Column  string
+	FieldID uint
+	Desc    bool
+}
+
+// taskSortColumns are the task columns a listing may be sorted by
+var taskSortColumns = map[string]bool{
+	"title":      true,
+	"due_date":   true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// apply adds the filter's condition to a task query
+func (f CustomFieldFilter) apply(db *gorm.DB) *gorm.DB {
+	matching := db.Session(&gorm.Session{NewDB: true}).Model(&models.CustomFieldValue{}).
+		Select("task_id").Where("field_id = ?", f.FieldID)
+
+	// Text compares case-insensitively
+	column := f.Column
+	value := f.Value
+	if column == "text_value" {
+		column = "LOWER(text_value)"
+		if s, ok := value.(string); ok {
+			value = strings.ToLower(s)
+		}
+	}
+
+	switch f.Op {
+	case FilterOpNe:
+		return db.Where("tasks.id NOT IN (?)", matching.Where(column+" = ?", value))
+	case FilterOpLt:
+		return db.Where("tasks.id IN (?)", matching.Where(column+" < ?", value))
+	case FilterOpGt:
+		return db.Where("tasks.id IN (?)", matching.Where(column+" > ?", value))
+	case FilterOpContains:
+		return db.Where("tasks.id IN (?)", matching.Where(column+` LIKE ? ESCAPE '\'`, "%"+escapeLike(value.(string))+"%"))
+	default:
+		return db.Where("tasks.id IN (?)", matching.Where(column+" = ?", value))
+	}
+}
+
+// Join tables of the task's many-to-many relations with users
+const (
+	taskAssigneesTable = "task_assignees"
+	taskWatchersTable  = "task_watchers"
+)
+
+// TaskRepositoryImpl is the concrete implementation of the TaskRepository interface
+type TaskRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// NewTaskRepository creates and returns a new TaskRepository instance
+func NewTaskRepository(db *gorm.DB) TaskRepository {
+	return &TaskRepositoryImpl{
+		DB: db,
+	}
+}
+
+// CreateTask adds a new task to the database together with its checklist and
+// custom values, linking (but never creating) its assignees, watchers and labels.
+// A project task must fit the WIP limits of the board columns showing its status.
+func (repo *TaskRepositoryImpl) CreateTask(task *models.Task) (*models.Task, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if task.ProjectID != nil {
+			if err := lockProject(tx, *task.ProjectID); err != nil {
+				return err
+			}
+			if err := checkWipLimit(tx, *task.ProjectID, task.Status, 0); err != nil {
+				return err
+			}
+		}
+		return tx.Omit("Assignees.*", "Watchers.*", "Labels.*", "CustomValues.Field").Create(task).Error
+	})
+	if err != nil {
+		log.Println("Error creating task:", err)
+		return nil, err
+	}
+	return task, nil
+}
+
+// GetTaskByID retrieves a task by its ID
+func (repo *TaskRepositoryImpl) GetTaskByID(id uint) (*models.Task, error) {
+	var task models.Task
+	if err := repo.DB.Preload("Assignees").Preload("Watchers").Preload("Labels").Preload("CustomValues.Field").First(&task, id).Error; err != nil {
+		log.Println("Error fetching task by ID:", err)
+		return nil, err
+	}
+	return &task, nil
+}
+
+// GetTasksByUserID retrieves all tasks owned by the given user that match the label filter. Tasks in
+// projects the user is no longer a member of are left out.
+func (repo *TaskRepositoryImpl) GetTasksByUserID(userID uint, labels LabelFilter) ([]models.Task, error) {
+	var tasks []models.Task
+	query := labels.apply(repo.DB.Preload("Assignees").Preload("Labels").Preload("CustomValues.Field").
+		Where("tasks.user_id = ?", userID).
+		Where(taskVisibleTo, userID, userID))
+	if err := query.Order("created_at DESC").Find(&tasks).Error; err != nil {
+		log.Println("Error fetching tasks by user:", err)
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetTasksByProjectID retrieves all tasks belonging to the given project
+func (repo *TaskRepositoryImpl) GetTasksByProjectID(projectID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	if err := repo.DB.Preload("Assignees").Preload("Labels").Preload("CustomValues.Field").Where("project_id = ?", projectID).Order("created_at DESC").Find(&tasks).Error; err != nil {
+		log.Println("Error fetching tasks by project:", err)
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetSubtasks retrieves the direct children of the given parent tasks
+func (repo *TaskRepositoryImpl) GetSubtasks(parentIDs []uint) ([]models.Task, error) {
+	var tasks []models.Task
+	if len(parentIDs) == 0 {
+		return tasks, nil
+	}
+	if err := repo.DB.Where("parent_id IN ?", parentIDs).Order("created_at").Find(&tasks).Error; err != nil {
+		log.Println("Error fetching subtasks:", err)
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// UpdateTask updates an existing task's information; its assignees, watchers
+// and checklist are left alone. A task entering another status or project must
+// fit the WIP limits of the board columns showing it there.
+func (repo *TaskRepositoryImpl) UpdateTask(task *models.Task) (*models.Task, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if task.ProjectID != nil {
+			if err := lockProject(tx, *task.ProjectID); err != nil {
+				return err
+			}
+			var stored models.Task
+			if err := tx.Select("status", "project_id").First(&stored, task.ID).Error; err != nil {
+				return err
+			}
+			if stored.Status != task.Status || stored.ProjectID == nil || *stored.ProjectID != *task.ProjectID {
+				if err := checkWipLimit(tx, *task.ProjectID, task.Status, task.ID); err != nil {
+					return err
+				}
+			}
+		}
+		return tx.Omit(clause.Associations, "ChecklistTotal", "ChecklistDone").Save(task).Error
+	})
+	if err != nil {
+		log.Println("Error updating task:", err)
+		return nil, err
+	}
+	return task, nil
+}
+
+// UpdateTaskProgress stores a task's rolled-up completion percentage
+func (repo *TaskRepositoryImpl) UpdateTaskProgress(id uint, progress int) error {
+	return repo.DB.Model(&models.Task{}).Where("id = ?", id).UpdateColumn("progress", progress).Error
+}
+
+// DeleteTasks deletes the tasks with the given IDs, their comments and attachments and any dependency, assignee,
+// watcher and label links touching them. It returns the storage keys of the deleted attachments.
+func (repo *TaskRepositoryImpl) DeleteTasks(ids []uint) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var keys []string
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("task_id IN ? OR blocked_by_id IN ?", ids, ids).Delete(&models.TaskDependency{}).Error; err != nil {
+			return err
+		}
+		for _, table := range []string{taskAssigneesTable, taskWatchersTable, taskLabelsTable} {
+			if err := tx.Exec("DELETE FROM "+table+" WHERE task_id IN ?", ids).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("task_id IN ?", ids).Delete(&models.Comment{}).Error; err != nil {
+			return err
+		}
+		var err error
+		if keys, err = deleteTaskAttachments(tx, ids); err != nil {
+			return err
+		}
+		return tx.Delete(&models.Task{}, ids).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// AddDependency records that a task is blocked by another task
+func (repo *TaskRepositoryImpl) AddDependency(dependency *models.TaskDependency) (*models.TaskDependency, error) {
+	if err := repo.DB.Create(dependency).Error; err != nil {
+		log.Println("Error adding task dependency:", err)
+		return nil, err
+	}
+	return dependency, nil
+}
+
+// RemoveDependency deletes the link between a task and one of its blockers
+func (repo *TaskRepositoryImpl) RemoveDependency(taskID, blockedByID uint) error {
+	result := repo.DB.Where("task_id = ? AND blocked_by_id = ?", taskID, blockedByID).Delete(&models.TaskDependency{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetDependencies retrieves the blocker links of the given tasks, with the blocking tasks loaded
+func (repo *TaskRepositoryImpl) GetDependencies(taskIDs []uint) ([]models.TaskDependency, error) {
+	var dependencies []models.TaskDependency
+	if len(taskIDs) == 0 {
+		return dependencies, nil
+	}
+	if err := repo.DB.Preload("BlockedBy").Where("task_id IN ?", taskIDs).Order("id").Find(&dependencies).Error; err != nil {
+		log.Println("Error fetching task dependencies:", err)
+		return nil, err
+	}
+	return dependencies, nil
+}
+
+// GetOpenTasksByUserID retrieves the user's tasks that are not in a final state yet.
+// Tasks in projects the user is no longer a member of are left out.
+func (repo *TaskRepositoryImpl) GetOpenTasksByUserID(userID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	err := repo.DB.Where("tasks.user_id = ? AND tasks.closed = ?", userID, false).
+		Where(taskVisibleTo, userID, userID).
+		Order("id").Find(&tasks).Error
+	if err != nil {
+		log.Println("Error fetching open tasks by user:", err)
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetLastTaskRank returns the highest board rank used in a project, or "" if none
+func (repo *TaskRepositoryImpl) GetLastTaskRank(projectID uint) (string, error) {
+	var ranks []string
+	err := repo.DB.Model(&models.Task{}).Where("project_id = ?", projectID).
+		Order("rank DESC").Limit(1).Pluck("rank", &ranks).Error
+	if err != nil {
+		log.Println("Error fetching last task rank:", err)
+		return "", err
+	}
+	if len(ranks) == 0 {
+		return "", nil
+	}
+	return ranks[0], nil
+}
+
+// MoveTaskCard saves a task moved on a board in one transaction: its status,
+// resolution, progress and recurrence (completing a recurring task hands it on)
+// and a rank placing it at position among the cards of its column. The task must
+// still be in status from, and a task changing column must fit the WIP limits.
+// When the column's ranks have no room left they are spread out first.
+func (repo *TaskRepositoryImpl) MoveTaskCard(task *models.Task, from string, position int) error {
+	return repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockProject(tx, *task.ProjectID); err != nil {
+			return err
+		}
+		if task.Status != from {
+			if err := checkWipLimit(tx, *task.ProjectID, task.Status, task.ID); err != nil {
+				return err
+			}
+		}
+
+		var cards []models.Task
+		err := tx.Select("id", "rank").
+			Where("project_id = ? AND status = ? AND id <> ?", *task.ProjectID, task.Status, task.ID).
+			Find(&cards).Error
+		if err != nil {
+			return err
+		}
+		// Ranks compare byte-wise, whatever the database's collation
+		sort.SliceStable(cards, func(i, j int) bool {
+			if cards[i].Rank != cards[j].Rank {
+				return cards[i].Rank < cards[j].Rank
+			}
+			return cards[i].ID < cards[j].ID
+		})
+		ranks := make([]string, len(cards))
+		for i, card := range cards {
+			ranks[i] = card.Rank
+		}
+
+		var respread []string
+		task.Rank, respread = rank.Insert(ranks, position)
+		for i, r := range respread {
+			if err := tx.Model(&models.Task{}).Where("id = ?", cards[i].ID).UpdateColumn("rank", r).Error; err != nil {
+				return err
+			}
+		}
+
+		result := tx.Model(task).Where("status = ?", from).
+			Select("status", "closed", "resolution", "rank", "progress", "recurrence", "recurrence_timezone", "recurrence_start").
+			Updates(task)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrTaskStatusChanged
+		}
+		return nil
+	})
+}
+
+// lockProject locks a project's row until the transaction ends, so that
+// tasks entering its board columns are checked one at a time (internal helper)
+func lockProject(tx *gorm.DB, projectID uint) error {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.Project{}, projectID).Error
+}
+
+// checkWipLimit fails with ErrWipLimitReached when a board column of the
+// project showing the status is full without the given task (internal helper)
+func checkWipLimit(tx *gorm.DB, projectID uint, status string, taskID uint) error {
+	var column models.BoardColumn
+	err := tx.Joins("JOIN boards ON boards.id = board_columns.board_id AND boards.deleted_at IS NULL").
+		Where("boards.project_id = ? AND board_columns.status = ? AND board_columns.wip_limit > 0", projectID, status).
+		Order("board_columns.wip_limit").
+		Take(&column).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var count int64
+	err = tx.Model(&models.Task{}).Where("project_id = ? AND status = ? AND id <> ?", projectID, status, taskID).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count >= int64(column.WipLimit) {
+		return fmt.Errorf("%w: %q allows %d tasks", ErrWipLimitReached, column.Name, column.WipLimit)
+	}
+	return nil
+}
+
+// GetTaskStatusesByProjectID returns the distinct statuses used by a project's tasks
+func (repo *TaskRepositoryImpl) GetTaskStatusesByProjectID(projectID uint) ([]string, error) {
+	var statuses []string
+	err := repo.DB.Model(&models.Task{}).Where("project_id = ?", projectID).Distinct("status").Pluck("status", &statuses).Error
+	if err != nil {
+		log.Println("Error fetching task statuses by project:", err)
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// addTaskUser links a user to a task through a join table; existing links are kept (internal helper)
+func (repo *TaskRepositoryImpl) addTaskUser(table string, taskID, userID uint) error {
+	link := map[string]interface{}{"task_id": taskID, "user_id": userID}
+	return repo.DB.Table(table).Clauses(clause.OnConflict{DoNothing: true}).Create(link).Error
+}
+
+// removeTaskUser unlinks a user from a task, reporting a missing link as not found (internal helper)
+func (repo *TaskRepositoryImpl) removeTaskUser(table string, taskID, userID uint) error {
+	result := repo.DB.Exec("DELETE FROM "+table+" WHERE task_id = ? AND user_id = ?", taskID, userID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// getTasksLinkedTo retrieves the tasks a user is linked to through a join table and
+// can still see, e.g. leaving out tasks of projects the user has left since (internal helper)
+func (repo *TaskRepositoryImpl) getTasksLinkedTo(table string, userID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	err := repo.DB.Preload("Assignees").Preload("Labels").Preload("CustomValues.Field").
+		Joins("JOIN "+table+" ON "+table+".task_id = tasks.id").
+		Where(table+".user_id = ?", userID).
+		Where(taskVisibleTo, userID, userID).
+		Order("tasks.created_at DESC").Find(&tasks).Error
+	return tasks, err
+}
+
+// AddAssignee assigns a user to a task
+func (repo *TaskRepositoryImpl) AddAssignee(taskID, userID uint) error {
+	return repo.addTaskUser(taskAssigneesTable, taskID, userID)
+}
+
+// RemoveAssignee unassigns a user from a task
+func (repo *TaskRepositoryImpl) RemoveAssignee(taskID, userID uint) error {
+	return repo.removeTaskUser(taskAssigneesTable, taskID, userID)
+}
+
+// GetTasksAssignedTo retrieves the tasks the given user is assigned to and can see
+func (repo *TaskRepositoryImpl) GetTasksAssignedTo(userID uint) ([]models.Task, error) {
+	tasks, err := repo.getTasksLinkedTo(taskAssigneesTable, userID)
+	if err != nil {
+		log.Println("Error fetching tasks assigned to user:", err)
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// AddWatcher makes a user watch a task
+func (repo *TaskRepositoryImpl) AddWatcher(taskID, userID uint) error {
+	return repo.addTaskUser(taskWatchersTable, taskID, userID)
+}
+
+// RemoveWatcher stops a user from watching a task
+func (repo *TaskRepositoryImpl) RemoveWatcher(taskID, userID uint) error {
+	return repo.removeTaskUser(taskWatchersTable, taskID, userID)
+}
+
+// GetTasksWatchedBy retrieves the tasks the given user watches and can see
+func (repo *TaskRepositoryImpl) GetTasksWatchedBy(userID uint) ([]models.Task, error) {
+	tasks, err := repo.getTasksLinkedTo(taskWatchersTable, userID)
+	if err != nil {
+		log.Println("Error fetching tasks watched by user:", err)
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetChecklistItems retrieves a task's checklist in order
+func (repo *TaskRepositoryImpl) GetChecklistItems(taskID uint) ([]models.ChecklistItem, error) {
+	var items []models.ChecklistItem
+	if err := repo.DB.Where("task_id = ?", taskID).Order("rank, id").Find(&items).Error; err != nil {
+		log.Println("Error fetching checklist:", err)
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetChecklistItem retrieves an item of a task's checklist
+func (repo *TaskRepositoryImpl) GetChecklistItem(taskID, id uint) (*models.ChecklistItem, error) {
+	var item models.ChecklistItem
+	if err := repo.DB.Where("task_id = ?", taskID).First(&item, id).Error; err != nil {
+		log.Println("Error fetching checklist item:", err)
+		return nil, err
+	}
+	return &item, nil
+}
+
+// refreshChecklistCounts recounts a task's checklist items inside a transaction (internal helper)
+func refreshChecklistCounts(tx *gorm.DB, taskID uint) error {
+	items := tx.Session(&gorm.Session{NewDB: true}).Model(&models.ChecklistItem{}).Select("COUNT(*)").Where("task_id = ?", taskID)
+	done := tx.Session(&gorm.Session{NewDB: true}).Model(&models.ChecklistItem{}).Select("COUNT(*)").Where("task_id = ? AND done", taskID)
+	return tx.Model(&models.Task{}).Where("id = ?", taskID).UpdateColumns(map[string]interface{}{
+		"checklist_total": items,
+		"checklist_done":  done,
+	}).Error
+}
+
+// CreateChecklistItem adds an item to a task's checklist
+func (repo *TaskRepositoryImpl) CreateChecklistItem(item *models.ChecklistItem) (*models.ChecklistItem, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(item).Error; err != nil {
+			return err
+		}
+		return refreshChecklistCounts(tx, item.TaskID)
+	})
+	if err != nil {
+		log.Println("Error creating checklist item:", err)
+		return nil, err
+	}
+	return item, nil
+}
+
+// UpdateChecklistItem saves an item's text and done state
+func (repo *TaskRepositoryImpl) UpdateChecklistItem(item *models.ChecklistItem) (*models.ChecklistItem, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(item).Error; err != nil {
+			return err
+		}
+		return refreshChecklistCounts(tx, item.TaskID)
+	})
+	if err != nil {
+		log.Println("Error updating checklist item:", err)
+		return nil, err
+	}
+	return item, nil
+}
+
+// DeleteChecklistItem removes an item from a task's checklist
+func (repo *TaskRepositoryImpl) DeleteChecklistItem(item *models.ChecklistItem) error {
+	return repo.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.ChecklistItem{}, item.ID)
+		if result.Error != nil {
+			log.Println("Error deleting checklist item:", result.Error)
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return refreshChecklistCounts(tx, item.TaskID)
+	})
+}
+
+// UpdateChecklistRanks rewrites the ranks of several checklist items in one transaction
+func (repo *TaskRepositoryImpl) UpdateChecklistRanks(ranks map[uint]string) error {
+	return repo.DB.Transaction(func(tx *gorm.DB) error {
+		for id, rank := range ranks {
+			if err := tx.Model(&models.ChecklistItem{}).Where("id = ?", id).UpdateColumn("rank", rank).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ReplaceCustomFieldValues replaces all custom field values of a task in one transaction
+func (repo *TaskRepositoryImpl) ReplaceCustomFieldValues(taskID uint, values []models.CustomFieldValue) error {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("task_id = ?", taskID).Delete(&models.CustomFieldValue{}).Error; err != nil {
+			return err
+		}
+		if len(values) == 0 {
+			return nil
+		}
+		for i := range values {
+			values[i].ID = 0
+			values[i].TaskID = taskID
+		}
+		return tx.Omit("Field").Create(&values).Error
+	})
+	if err != nil {
+		log.Println("Error saving custom field values:", err)
+	}
+	return err
+}
+
+// QueryProjectTasks retrieves a project's tasks matching every filter, in
+// the given order; without a sort column the newest tasks come first
+func (repo *TaskRepositoryImpl) QueryProjectTasks(projectID uint, filters []CustomFieldFilter, sort TaskSort) ([]models.Task, error) {
+	query := repo.DB.Preload("Assignees").Preload("Labels").Preload("CustomValues.Field").Where("tasks.project_id = ?", projectID)
+	for _, filter := range filters {
+		if !customValueColumns[filter.Column] {
+			return nil, fmt.Errorf("unknown custom value column %q", filter.Column)
+		}
+		query = filter.apply(query)
+	}
+
+	direction := "ASC"
+	if sort.Desc {
+		direction = "DESC"
+	}
+	switch {
+	case sort.FieldID != 0:
+		if !customValueColumns[sort.Column] {
+			return nil, fmt.Errorf("unknown custom value column %q", sort.Column)
+		}
+		query = query.
+			Joins("LEFT JOIN custom_field_values AS sort_value ON sort_value.task_id = tasks.id AND sort_value.field_id = ?", sort.FieldID).
+			Order("sort_value." + sort.Column + " " + direction + " NULLS LAST").
+			Order("tasks.id")
+	case sort.Column != "":
+		if !taskSortColumns[sort.Column] {
+			return nil, fmt.Errorf("unknown sort column %q", sort.Column)
+		}
+		query = query.Order("tasks." + sort.Column + " " + direction + " NULLS LAST").Order("tasks.id")
+	default:
+		query = query.Order("tasks.created_at DESC")
+	}
+
+	var tasks []models.Task
+	if err := query.Find(&tasks).Error; err != nil {
+		log.Println("Error querying project tasks:", err)
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// CreateTaskTree creates a tree of tasks in one transaction, parents before
+// their subtasks, together with their checklists and custom values. Labels
+// without an ID are created first, once per name, and linked like the others.
+func (repo *TaskRepositoryImpl) CreateTaskTree(tasks []TreeTask) error {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		created := map[string]uint{}
+		for i, node := range tasks {
+			task := node.Task
+			if node.Parent >= 0 {
+				if node.Parent >= i {
+					return fmt.Errorf("task %d comes before its parent", i)
+				}
+				parentID := tasks[node.Parent].Task.ID
+				task.ParentID = &parentID
+			}
+
+			for j := range task.Labels {
+				label := &task.Labels[j]
+				if label.ID != 0 {
+					continue
+				}
+				name := strings.ToLower(label.Name)
+				if id, ok := created[name]; ok {
+					label.ID = id
+					continue
+				}
+				if err := tx.Create(label).Error; err != nil {
+					return err
+				}
+				created[name] = label.ID
+			}
+
+			if err := tx.Omit("Assignees.*", "Watchers.*", "Labels.*", "CustomValues.Field").Create(task).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Println("Error creating task tree:", err)
+	}
+	return err
+}
+
+// taskVisibleTo matches the tasks a user can see: their personal tasks and
+// the tasks of their projects. It binds the user's ID twice.
+const taskVisibleTo = "(tasks.project_id IS NULL AND tasks.user_id = ?) OR tasks.project_id IN (SELECT project_id FROM project_members WHERE user_id = ?)"
+
+// SearchTasks retrieves up to limit tasks the user can see (their personal
+// tasks and the tasks of their projects) matching a compiled task search,
+// in its order; the newest tasks come first otherwise
+func (repo *TaskRepositoryImpl) SearchTasks(userID uint, search *tql.Compiled, limit int) ([]models.Task, error) {
+	query := repo.DB.Preload("Assignees").Preload("Labels").Preload("CustomValues.Field").
+		Where(taskVisibleTo, userID, userID)
+	query = search.Apply(query).Order("tasks.created_at DESC").Order("tasks.id DESC").Limit(limit)
+
+	var tasks []models.Task
+	if err := query.Find(&tasks).Error; err != nil {
+		log.Println("Error searching tasks:", err)
+		return nil, err
+	}
+	return tasks, nil
+}
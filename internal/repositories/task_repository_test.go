@@ -0,0 +1,189 @@
+package repositories_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// createBoard adds a board to the project whose in-progress column allows limit tasks
+func createBoard(t *testing.T, db *gorm.DB, project *models.Project, limit int) {
+	board := &models.Board{ProjectID: project.ID, Name: "Sprint", Columns: []models.BoardColumn{
+		{Name: "To do", Status: models.TaskStatusTodo},
+		{Name: "Doing", Status: models.TaskStatusInProgress, WipLimit: limit, Position: 1},
+	}}
+	require.NoError(t, db.Create(board).Error)
+}
+
+// createCard stores a project task in a status with a board rank
+func createCard(t *testing.T, db *gorm.DB, owner *models.User, project *models.Project, status, rank string) *models.Task {
+	task := createTask(t, db, owner, project, "Card", "")
+	require.NoError(t, db.Model(task).UpdateColumns(map[string]interface{}{"status": status, "rank": rank}).Error)
+	task.Status, task.Rank = status, rank
+	return task
+}
+
+// storedRank reads a task's board rank
+func storedRank(t *testing.T, db *gorm.DB, id uint) string {
+	var task models.Task
+	require.NoError(t, db.First(&task, id).Error)
+	return task.Rank
+}
+
+func TestMoveTaskCard_EnforcesWipLimit(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewTaskRepository(db)
+
+	john := createUser(t, db, "john")
+	project := createProject(t, db, john)
+	createBoard(t, db, project, 2)
+	createCard(t, db, john, project, models.TaskStatusInProgress, "c")
+	doing := createCard(t, db, john, project, models.TaskStatusInProgress, "m")
+	card := createCard(t, db, john, project, models.TaskStatusTodo, "i")
+
+	card.Status = models.TaskStatusInProgress
+	err := repo.MoveTaskCard(card, models.TaskStatusTodo, 0)
+	assert.ErrorIs(t, err, repositories.ErrWipLimitReached)
+	assert.Contains(t, err.Error(), `"Doing" allows 2 tasks`)
+
+	// Reordering a card already in the full column is fine
+	require.NoError(t, repo.MoveTaskCard(doing, models.TaskStatusInProgress, 0))
+	assert.Less(t, storedRank(t, db, doing.ID), "c")
+}
+
+func TestMoveTaskCard_RequiresPreviousStatus(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewTaskRepository(db)
+
+	john := createUser(t, db, "john")
+	project := createProject(t, db, john)
+	card := createCard(t, db, john, project, models.TaskStatusInProgress, "i")
+
+	// The card was read in todo, but someone moved it in the meantime
+	card.Status = models.TaskStatusDone
+	err := repo.MoveTaskCard(card, models.TaskStatusTodo, 0)
+	assert.ErrorIs(t, err, repositories.ErrTaskStatusChanged)
+
+	var stored models.Task
+	require.NoError(t, db.First(&stored, card.ID).Error)
+	assert.Equal(t, models.TaskStatusInProgress, stored.Status)
+}
+
+func TestMoveTaskCard_Ranks(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewTaskRepository(db)
+
+	john := createUser(t, db, "john")
+	project := createProject(t, db, john)
+	first := createCard(t, db, john, project, models.TaskStatusDone, "c")
+	second := createCard(t, db, john, project, models.TaskStatusDone, "m")
+	card := createCard(t, db, john, project, models.TaskStatusTodo, "x")
+
+	card.Status, card.Closed = models.TaskStatusDone, true
+	require.NoError(t, repo.MoveTaskCard(card, models.TaskStatusTodo, 1))
+	assert.Greater(t, card.Rank, "c")
+	assert.Less(t, card.Rank, "m")
+	assert.Equal(t, card.Rank, storedRank(t, db, card.ID))
+	assert.Equal(t, "c", storedRank(t, db, first.ID))
+	assert.Equal(t, "m", storedRank(t, db, second.ID))
+
+	// Tasks ranked before boards existed are spread out first
+	unrankedA := createCard(t, db, john, project, models.TaskStatusInProgress, "")
+	unrankedB := createCard(t, db, john, project, models.TaskStatusInProgress, "")
+	card.Status, card.Closed = models.TaskStatusInProgress, false
+	require.NoError(t, repo.MoveTaskCard(card, models.TaskStatusDone, 1))
+	assert.Less(t, storedRank(t, db, unrankedA.ID), card.Rank)
+	assert.Less(t, card.Rank, storedRank(t, db, unrankedB.ID))
+}
+
+func TestUpdateTask_EnforcesWipLimit(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewTaskRepository(db)
+
+	john := createUser(t, db, "john")
+	project := createProject(t, db, john)
+	createBoard(t, db, project, 1)
+	doing := createCard(t, db, john, project, models.TaskStatusInProgress, "c")
+	card := createCard(t, db, john, project, models.TaskStatusTodo, "i")
+
+	card.Status = models.TaskStatusInProgress
+	_, err := repo.UpdateTask(card)
+	assert.ErrorIs(t, err, repositories.ErrWipLimitReached)
+
+	// Edits that leave the status alone are not limited
+	doing.Title = "Renamed"
+	_, err = repo.UpdateTask(doing)
+	require.NoError(t, err)
+
+	_, err = repo.CreateTask(&models.Task{Title: "New", UserID: john.ID, ProjectID: &project.ID, Status: models.TaskStatusInProgress})
+	assert.ErrorIs(t, err, repositories.ErrWipLimitReached)
+	_, err = repo.CreateTask(&models.Task{Title: "New", UserID: john.ID, ProjectID: &project.ID, Status: models.TaskStatusTodo})
+	require.NoError(t, err)
+}
+
+func TestGetOpenTasksByUserID_LeavesOutFormerProjects(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewTaskRepository(db)
+	projects := repositories.NewProjectRepository(db)
+
+	john := createUser(t, db, "john")
+	jane := createUser(t, db, "jane")
+	project := createProject(t, db, jane, john)
+
+	personal := createTask(t, db, john, nil, "Personal", "")
+	created := createTask(t, db, john, project, "Created in project", "")
+	done := createTask(t, db, john, nil, "Done", "")
+	require.NoError(t, db.Model(done).Updates(map[string]interface{}{"status": models.TaskStatusDone, "closed": true}).Error)
+
+	tasks, err := repo.GetOpenTasksByUserID(john.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{personal.ID, created.ID}, taskIDs(tasks))
+
+	// Once removed from the project, john no longer gets the tasks created there
+	require.NoError(t, projects.RemoveMember(project.ID, john.ID))
+	tasks, err = repo.GetOpenTasksByUserID(john.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{personal.ID}, taskIDs(tasks))
+}
+
+func TestGetTasksLinkedTo_LeavesOutFormerProjects(t *testing.T) {
+	tests := []struct {
+		name string
+		link func(repo repositories.TaskRepository, taskID, userID uint) error
+		list func(repo repositories.TaskRepository, userID uint) ([]models.Task, error)
+	}{
+		{name: "assigned", link: repositories.TaskRepository.AddAssignee, list: repositories.TaskRepository.GetTasksAssignedTo},
+		{name: "watched", link: repositories.TaskRepository.AddWatcher, list: repositories.TaskRepository.GetTasksWatchedBy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			repo := repositories.NewTaskRepository(db)
+			projects := repositories.NewProjectRepository(db)
+
+			john := createUser(t, db, "john")
+			jane := createUser(t, db, "jane")
+			project := createProject(t, db, jane, john)
+
+			personal := createTask(t, db, john, nil, "Personal", "")
+			shared := createTask(t, db, jane, project, "Shared", "")
+			require.NoError(t, tt.link(repo, personal.ID, john.ID))
+			require.NoError(t, tt.link(repo, shared.ID, john.ID))
+
+			tasks, err := tt.list(repo, john.ID)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []uint{personal.ID, shared.ID}, taskIDs(tasks))
+
+			// Once removed from the project, john no longer gets its tasks
+			require.NoError(t, projects.RemoveMember(project.ID, john.ID))
+			tasks, err = tt.list(repo, john.ID)
+			require.NoError(t, err)
+			assert.Equal(t, []uint{personal.ID}, taskIDs(tasks))
+		})
+	}
+}
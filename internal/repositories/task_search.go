@@ -0,0 +1,225 @@
+// internal/repositories/task_search.go
+package repositories
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/pkg/reldate"
+	"TaskManager/pkg/tql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// taskPriorities lists the task priorities from lowest to highest
+var taskPriorities = []string{models.TaskPriorityLow, models.TaskPriorityMedium, models.TaskPriorityHigh, models.TaskPriorityUrgent}
+
+// searchDateLayout is the format of absolute dates in task searches
+const searchDateLayout = "2006-01-02"
+
+// searchExpr builds a search condition with bind variables
+func searchExpr(sql string, vars ...interface{}) clause.Expression {
+	return clause.Expr{SQL: sql, Vars: vars}
+}
+
+// TaskSearchSchema gives the fields of the task query language their
+// meaning for a user searching at the time now:
+//
+//	status:open, status:closed or status:<name>
+//	priority:high, priority>=high ...
+//	assignee:me, assignee:<username> or assignee:none
+//	owner:me or owner:<username>
+//	label:<name> or label:none
+//	project:<id> or project:none
+//	due, created and updated compared with a date (2024-01-31) or an offset
+//	from now (7d, -1w); due:none finds tasks without a due date
+//
+// Free text matches the title or description. Tasks sort by priority
+// (most urgent first), due, created, updated, title or status.
+func TaskSearchSchema(userID uint, now time.Time) tql.Schema {
+	return tql.Schema{
+		Fields: map[string]tql.FieldFunc{
+			"status":   searchStatus,
+			"priority": searchPriority,
+			"assignee": func(term tql.Term) (clause.Expression, error) {
+				return searchTaskUser(term, userID, "tasks.id IN (SELECT task_id FROM "+taskAssigneesTable+" WHERE user_id IN (%s))",
+					"tasks.id NOT IN (SELECT task_id FROM "+taskAssigneesTable+")")
+			},
+			"owner": func(term tql.Term) (clause.Expression, error) {
+				return searchTaskUser(term, userID, "tasks.user_id IN (%s)", "")
+			},
+			"label":   searchLabel,
+			"project": searchProject,
+			"due": func(term tql.Term) (clause.Expression, error) {
+				return searchTime(term, "tasks.due_date", now, true)
+			},
+			"created": func(term tql.Term) (clause.Expression, error) {
+				return searchTime(term, "tasks.created_at", now, false)
+			},
+			"updated": func(term tql.Term) (clause.Expression, error) {
+				return searchTime(term, "tasks.updated_at", now, false)
+			},
+		},
+		Text: searchText,
+		Sorts: map[string]string{
+			"priority": "CASE tasks.priority WHEN 'urgent' THEN 0 WHEN 'high' THEN 1 WHEN 'medium' THEN 2 ELSE 3 END",
+			"due":      "tasks.due_date",
+			"created":  "tasks.created_at",
+			"updated":  "tasks.updated_at",
+			"title":    "LOWER(tasks.title)",
+			"status":   "tasks.status",
+		},
+	}
+}
+
+// requireEq rejects comparisons on fields that only match values
+func requireEq(term tql.Term) error {
+	if term.Op != tql.OpEq {
+		return errors.New(term.Field + " only supports " + term.Field + ":value")
+	}
+	return nil
+}
+
+// searchStatus matches open or closed tasks, or tasks in a named status
+func searchStatus(term tql.Term) (clause.Expression, error) {
+	if err := requireEq(term); err != nil {
+		return nil, err
+	}
+	switch value := strings.ToLower(term.Value); value {
+	case "open":
+		return searchExpr("tasks.closed = ?", false), nil
+	case "closed":
+		return searchExpr("tasks.closed = ?", true), nil
+	default:
+		return searchExpr("LOWER(tasks.status) = ?", value), nil
+	}
+}
+
+// searchPriority matches a priority, or the priorities above or below it
+func searchPriority(term tql.Term) (clause.Expression, error) {
+	level := -1
+	for i, priority := range taskPriorities {
+		if strings.EqualFold(priority, term.Value) {
+			level = i
+		}
+	}
+	if level < 0 {
+		return nil, errors.New("priority must be one of " + strings.Join(taskPriorities, ", "))
+	}
+
+	var matching []string
+	for i, priority := range taskPriorities {
+		switch {
+		case term.Op == tql.OpEq && i == level,
+			term.Op == tql.OpLt && i < level,
+			term.Op == tql.OpLte && i <= level,
+			term.Op == tql.OpGt && i > level,
+			term.Op == tql.OpGte && i >= level:
+			matching = append(matching, priority)
+		}
+	}
+	if len(matching) == 0 {
+		return searchExpr("1 = 0"), nil
+	}
+	return searchExpr("tasks.priority IN ?", matching), nil
+}
+
+// searchTaskUser matches tasks linked to the searching user ("me"), to a
+// user by name or, with a none condition, to no user. linked is the
+// condition with %s standing for the matching user IDs (internal helper).
+func searchTaskUser(term tql.Term, userID uint, linked, none string) (clause.Expression, error) {
+	if err := requireEq(term); err != nil {
+		return nil, err
+	}
+	switch value := strings.ToLower(term.Value); {
+	case value == "me":
+		return searchExpr(fmt.Sprintf(linked, "?"), userID), nil
+	case value == "none" && none != "":
+		return searchExpr(none), nil
+	default:
+		return searchExpr(fmt.Sprintf(linked, "SELECT id FROM users WHERE LOWER(username) = ? AND deleted_at IS NULL"), value), nil
+	}
+}
+
+// searchLabel matches tasks tagged with a label, compared case-insensitively
+func searchLabel(term tql.Term) (clause.Expression, error) {
+	if err := requireEq(term); err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(term.Value, "none") {
+		return searchExpr("tasks.id NOT IN (SELECT task_id FROM " + taskLabelsTable + ")"), nil
+	}
+	return searchExpr("tasks.id IN (SELECT "+taskLabelsTable+".task_id FROM "+taskLabelsTable+
+		" JOIN labels ON labels.id = "+taskLabelsTable+".label_id WHERE LOWER(labels.name) = ?)", strings.ToLower(term.Value)), nil
+}
+
+// searchProject matches tasks of a project, or personal tasks
+func searchProject(term tql.Term) (clause.Expression, error) {
+	if err := requireEq(term); err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(term.Value, "none") {
+		return searchExpr("tasks.project_id IS NULL"), nil
+	}
+	id, err := strconv.ParseUint(term.Value, 10, 32)
+	if err != nil || id == 0 {
+		return nil, errors.New("project must be a project ID or none")
+	}
+	return searchExpr("tasks.project_id = ?", uint(id)), nil
+}
+
+// searchTime compares a time column with a date or an offset from now. A
+// date stands for the whole day (UTC): due<=2024-01-31 includes that day
+// and due:2024-01-31 matches it. nullable columns accept none.
+func searchTime(term tql.Term, column string, now time.Time, nullable bool) (clause.Expression, error) {
+	if nullable && strings.EqualFold(term.Value, "none") {
+		if term.Op != tql.OpEq {
+			return nil, errors.New(term.Field + ":none can't be compared")
+		}
+		return searchExpr(column + " IS NULL"), nil
+	}
+
+	var start, end time.Time
+	if date, err := time.Parse(searchDateLayout, term.Value); err == nil {
+		start, end = date, date.AddDate(0, 0, 1)
+	} else if offset, err := reldate.Parse(term.Value); err == nil {
+		start = offset.From(now)
+		end = start
+		if term.Op == tql.OpEq {
+			return nil, errors.New("compare offsets with < or >, e.g. " + term.Field + "<7d")
+		}
+	} else {
+		return nil, errors.New(term.Field + " must be a date like 2024-01-31 or an offset like 7d or -1w")
+	}
+
+	switch term.Op {
+	case tql.OpLt:
+		return searchExpr(column+" < ?", start), nil
+	case tql.OpLte:
+		if end.Equal(start) {
+			return searchExpr(column+" <= ?", start), nil
+		}
+		return searchExpr(column+" < ?", end), nil
+	case tql.OpGt:
+		if end.Equal(start) {
+			return searchExpr(column+" > ?", start), nil
+		}
+		return searchExpr(column+" >= ?", end), nil
+	case tql.OpGte:
+		return searchExpr(column+" >= ?", start), nil
+	default:
+		return searchExpr(column+" >= ? AND "+column+" < ?", start, end), nil
+	}
+}
+
+// searchText matches free text in the title or description
+func searchText(term tql.Term) (clause.Expression, error) {
+	if strings.TrimSpace(term.Value) == "" {
+		return nil, errors.New("search text is empty")
+	}
+	pattern := "%" + escapeLike(strings.ToLower(term.Value)) + "%"
+	return searchExpr(`LOWER(tasks.title) LIKE ? ESCAPE '\' OR LOWER(tasks.description) LIKE ? ESCAPE '\'`, pattern, pattern), nil
+}
@@ -0,0 +1,206 @@
+package repositories_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"TaskManager/pkg/tql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// searchTasks runs a task query for a user against the repository
+func searchTasks(t *testing.T, repo repositories.TaskRepository, userID uint, now time.Time, query string) []models.Task {
+	parsed, err := tql.Parse(query)
+	require.NoError(t, err)
+	compiled, err := repositories.TaskSearchSchema(userID, now).Compile(parsed)
+	require.NoError(t, err)
+	tasks, err := repo.SearchTasks(userID, compiled, 50)
+	require.NoError(t, err)
+	return tasks
+}
+
+func TestSearchTasks_Visibility(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewTaskRepository(db)
+
+	john := createUser(t, db, "john")
+	jane := createUser(t, db, "jane")
+	shared := createProject(t, db, jane, john)
+	foreign := createProject(t, db, jane)
+
+	personal := createTask(t, db, john, nil, "Mine", "")
+	sharedTask := createTask(t, db, jane, shared, "Shared", "")
+	createTask(t, db, jane, nil, "Jane's", "")
+	createTask(t, db, jane, foreign, "Foreign", "")
+	// Conditions combined with the visibility check can't widen it
+	createTask(t, db, jane, foreign, "Other", "")
+
+	tasks := searchTasks(t, repo, john.ID, time.Now(), "")
+	assert.ElementsMatch(t, []uint{personal.ID, sharedTask.ID}, taskIDs(tasks))
+
+	tasks = searchTasks(t, repo, john.ID, time.Now(), "Mine OR Other")
+	assert.Empty(t, tasks)
+	tasks = searchTasks(t, repo, john.ID, time.Now(), "-project:none")
+	assert.Equal(t, []uint{sharedTask.ID}, taskIDs(tasks))
+}
+
+func TestSearchTasks_Fields(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewTaskRepository(db)
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	john := createUser(t, db, "john")
+	jane := createUser(t, db, "jane")
+	project := createProject(t, db, john, jane)
+
+	soon := now.Add(48 * time.Hour)
+	later := now.AddDate(0, 1, 0)
+	bug := &models.Label{Name: "Bug", Color: "#ff0000", ProjectID: &project.ID}
+	require.NoError(t, db.Create(bug).Error)
+
+	urgent := &models.Task{Title: "Login crash", UserID: john.ID, ProjectID: &project.ID, Status: "todo", Priority: models.TaskPriorityUrgent, DueDate: &soon,
+		Labels: []models.Label{*bug}, Assignees: []models.User{*jane}}
+	closed := &models.Task{Title: "Old crash", UserID: jane.ID, ProjectID: &project.ID, Status: "done", Closed: true, Priority: models.TaskPriorityHigh, DueDate: &later}
+	low := &models.Task{Title: "Docs", Description: "100% coverage", UserID: john.ID, Status: "todo", Priority: models.TaskPriorityLow}
+	for _, task := range []*models.Task{urgent, closed, low} {
+		require.NoError(t, db.Omit("Labels.*", "Assignees.*").Create(task).Error)
+	}
+
+	tests := []struct {
+		query string
+		want  []uint
+	}{
+		{query: "status:open sort:title", want: []uint{low.ID, urgent.ID}},
+		{query: "status:closed", want: []uint{closed.ID}},
+		{query: "status:DONE", want: []uint{closed.ID}},
+		{query: "priority>=high sort:priority", want: []uint{urgent.ID, closed.ID}},
+		{query: "label:bug", want: []uint{urgent.ID}},
+		{query: "label:none sort:title", want: []uint{low.ID, closed.ID}},
+		{query: "assignee:jane", want: []uint{urgent.ID}},
+		{query: "assignee:none sort:title", want: []uint{low.ID, closed.ID}},
+		{query: "owner:me sort:title", want: []uint{low.ID, urgent.ID}},
+		{query: "due<7d", want: []uint{urgent.ID}},
+		{query: "due:none", want: []uint{low.ID}},
+		{query: "due:2024-04-01", want: []uint{closed.ID}},
+		{query: "sort:-due", want: []uint{closed.ID, urgent.ID, low.ID}},
+		{query: "crash -old", want: []uint{urgent.ID}},
+		{query: `"100%"`, want: []uint{low.ID}},
+		{query: `"10_%"`, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got := searchTasks(t, repo, john.ID, now, tt.query)
+			if tt.want == nil {
+				assert.Empty(t, got)
+				return
+			}
+			assert.Equal(t, tt.want, taskIDs(got))
+		})
+	}
+}
+
+func TestCreateTaskTree(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewTaskRepository(db)
+
+	john := createUser(t, db, "john")
+	existing := &models.Label{Name: "bug", Color: "#ff0000", UserID: &john.ID}
+	require.NoError(t, db.Create(existing).Error)
+
+	root := &models.Task{Title: "Release", UserID: john.ID, Status: "todo", Labels: []models.Label{{Name: "Release", Color: "#00ff00", UserID: &john.ID}}}
+	child := &models.Task{Title: "Changelog", UserID: john.ID, Status: "todo",
+		Labels:    []models.Label{*existing, {Name: "release", Color: "#00ff00", UserID: &john.ID}},
+		Checklist: []models.ChecklistItem{{Text: "Draft", Rank: "a"}}}
+	grandchild := &models.Task{Title: "Collect entries", UserID: john.ID, Status: "todo"}
+
+	require.NoError(t, repo.CreateTaskTree([]repositories.TreeTask{
+		{Task: root, Parent: -1},
+		{Task: child, Parent: 0},
+		{Task: grandchild, Parent: 1},
+	}))
+
+	require.NotNil(t, child.ParentID)
+	assert.Equal(t, root.ID, *child.ParentID)
+	require.NotNil(t, grandchild.ParentID)
+	assert.Equal(t, child.ID, *grandchild.ParentID)
+
+	// A new label is created once, existing labels are only linked
+	var labels []models.Label
+	require.NoError(t, db.Order("id").Find(&labels).Error)
+	require.Len(t, labels, 2)
+	stored, err := repo.GetTaskByID(child.ID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uint{existing.ID, labels[1].ID}, []uint{stored.Labels[0].ID, stored.Labels[1].ID})
+
+	items, err := repo.GetChecklistItems(child.ID)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Draft", items[0].Text)
+}
+
+func TestCreateTaskTree_RollsBack(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewTaskRepository(db)
+
+	john := createUser(t, db, "john")
+	err := repo.CreateTaskTree([]repositories.TreeTask{
+		{Task: &models.Task{Title: "Root", UserID: john.ID, Status: "todo"}, Parent: -1},
+		{Task: &models.Task{Title: "Orphan", UserID: john.ID, Status: "todo"}, Parent: 1},
+	})
+	require.Error(t, err)
+
+	var count int64
+	require.NoError(t, db.Model(&models.Task{}).Count(&count).Error)
+	assert.Zero(t, count)
+	assert.ErrorIs(t, db.First(&models.Task{}).Error, gorm.ErrRecordNotFound)
+}
+
+func TestGetTasksByUserID_LeavesOutFormerProjects(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewTaskRepository(db)
+	projects := repositories.NewProjectRepository(db)
+
+	john := createUser(t, db, "john")
+	jane := createUser(t, db, "jane")
+	project := createProject(t, db, jane, john)
+
+	personal := createTask(t, db, john, nil, "Personal", "")
+	created := createTask(t, db, john, project, "Created in project", "")
+	createTask(t, db, jane, nil, "Jane's", "")
+
+	tasks, err := repo.GetTasksByUserID(john.ID, repositories.LabelFilter{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uint{personal.ID, created.ID}, taskIDs(tasks))
+
+	// Once removed from the project, john no longer sees the tasks created there
+	require.NoError(t, projects.RemoveMember(project.ID, john.ID))
+	tasks, err = repo.GetTasksByUserID(john.ID, repositories.LabelFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{personal.ID}, taskIDs(tasks))
+}
+
+func TestSearchTasks_LeavesOutFormerProjects(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewTaskRepository(db)
+	projects := repositories.NewProjectRepository(db)
+
+	john := createUser(t, db, "john")
+	jane := createUser(t, db, "jane")
+	project := createProject(t, db, jane, john)
+
+	personal := createTask(t, db, john, nil, "Personal", "")
+	created := createTask(t, db, john, project, "Created in project", "")
+
+	tasks := searchTasks(t, repo, john.ID, time.Now(), "")
+	assert.ElementsMatch(t, []uint{personal.ID, created.ID}, taskIDs(tasks))
+
+	// Once removed from the project, john no longer finds the tasks created there
+	require.NoError(t, projects.RemoveMember(project.ID, john.ID))
+	tasks = searchTasks(t, repo, john.ID, time.Now(), "")
+	assert.Equal(t, []uint{personal.ID}, taskIDs(tasks))
+}
@@ -0,0 +1,92 @@
+// internal/repositories/template_repository.go
+package repositories
+
+import (
+	"TaskManager/internal/models"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// TemplateRepository interface defines the methods for task-template-related DB operations
+type TemplateRepository interface {
+	CreateTemplate(template *models.TaskTemplate) (*models.TaskTemplate, error)
+	GetTemplateByID(id uint) (*models.TaskTemplate, error)
+	GetTemplatesByUserID(userID uint) ([]models.TaskTemplate, error)
+	GetTemplatesByProjectID(projectID uint) ([]models.TaskTemplate, error)
+	UpdateTemplate(template *models.TaskTemplate) (*models.TaskTemplate, error)
+	DeleteTemplate(id uint) error
+}
+
+// TemplateRepositoryImpl is the concrete implementation of the TemplateRepository interface
+type TemplateRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// NewTemplateRepository creates and returns a new TemplateRepository instance
+func NewTemplateRepository(db *gorm.DB) TemplateRepository {
+	return &TemplateRepositoryImpl{
+		DB: db,
+	}
+}
+
+// CreateTemplate adds a new task template to the database
+func (repo *TemplateRepositoryImpl) CreateTemplate(template *models.TaskTemplate) (*models.TaskTemplate, error) {
+	if err := repo.DB.Create(template).Error; err != nil {
+		log.Println("Error creating task template:", err)
+		return nil, err
+	}
+	return template, nil
+}
+
+// GetTemplateByID retrieves a task template by its ID
+func (repo *TemplateRepositoryImpl) GetTemplateByID(id uint) (*models.TaskTemplate, error) {
+	var template models.TaskTemplate
+	if err := repo.DB.First(&template, id).Error; err != nil {
+		log.Println("Error fetching task template by ID:", err)
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GetTemplatesByUserID retrieves the personal templates of a user by name
+func (repo *TemplateRepositoryImpl) GetTemplatesByUserID(userID uint) ([]models.TaskTemplate, error) {
+	var templates []models.TaskTemplate
+	if err := repo.DB.Where("user_id = ? AND project_id IS NULL", userID).Order("name, id").Find(&templates).Error; err != nil {
+		log.Println("Error fetching task templates by user:", err)
+		return nil, err
+	}
+	return templates, nil
+}
+
+// GetTemplatesByProjectID retrieves the templates shared in a project by name
+func (repo *TemplateRepositoryImpl) GetTemplatesByProjectID(projectID uint) ([]models.TaskTemplate, error) {
+	var templates []models.TaskTemplate
+	if err := repo.DB.Where("project_id = ?", projectID).Order("name, id").Find(&templates).Error; err != nil {
+		log.Println("Error fetching task templates by project:", err)
+		return nil, err
+	}
+	return templates, nil
+}
+
+// UpdateTemplate saves a template's name, description and task tree
+func (repo *TemplateRepositoryImpl) UpdateTemplate(template *models.TaskTemplate) (*models.TaskTemplate, error) {
+	if err := repo.DB.Save(template).Error; err != nil {
+		log.Println("Error updating task template:", err)
+		return nil, err
+	}
+	return template, nil
+}
+
+// DeleteTemplate removes a task template by its ID
+func (repo *TemplateRepositoryImpl) DeleteTemplate(id uint) error {
+	result := repo.DB.Delete(&models.TaskTemplate{}, id)
+	if result.Error != nil {
+		log.Println("Error deleting task template:", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
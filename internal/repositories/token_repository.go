@@ -0,0 +1,362 @@
+// internal/repositories/token_repository.go
+package repositories
+
+import (
+	"TaskManager/internal/models"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TokenRepository interface defines the methods for token-related DB operations:
+// sessions and their refresh tokens, revoked access tokens, the users'
+// token versions, and password reset and email verification tokens
+type TokenRepository interface {
+	CreateSession(session *models.Session, token *models.RefreshToken) (*models.Session, error)
+	GetSession(id uint) (*models.Session, error)
+	GetActiveSessions(userID uint) ([]models.Session, error)
+	IsSessionRevoked(familyID string) (bool, error)
+	TouchSession(familyID, ip string, seenAt time.Time) error
+	GetRefreshTokenByHash(hash string) (*models.RefreshToken, error)
+	RotateRefreshToken(usedID uint, next *models.RefreshToken) (*models.RefreshToken, error)
+	RevokeTokenFamily(familyID string) error
+	RevokeUserTokenFamilies(userID uint) error
+	RevokeAccessToken(token *models.RevokedToken) error
+	IsAccessTokenRevoked(id string) (bool, error)
+	GetTokenVersion(userID uint) (int, error)
+	IncrementTokenVersion(userID uint) (int, error)
+	CreatePasswordResetToken(token *models.PasswordResetToken) (*models.PasswordResetToken, error)
+	GetPasswordResetTokenByHash(hash string) (*models.PasswordResetToken, error)
+	ResetPassword(tokenID, userID uint, hashedPassword string) error
+	CreateEmailVerificationToken(token *models.EmailVerificationToken) (*models.EmailVerificationToken, error)
+	GetEmailVerificationTokenByHash(hash string) (*models.EmailVerificationToken, error)
+	GetLatestEmailVerificationToken(userID uint) (*models.EmailVerificationToken, error)
+	VerifyEmail(tokenID, userID uint, email string) error
+}
+
+// TokenRepositoryImpl is the concrete implementation of the TokenRepository interface
+type TokenRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// NewTokenRepository creates and returns a new TokenRepository instance
+func NewTokenRepository(db *gorm.DB) TokenRepository {
+	return &TokenRepositoryImpl{
+		DB: db,
+	}
+}
+
+// CreateSession stores a new session together with its first refresh token
+func (repo *TokenRepositoryImpl) CreateSession(session *models.Session, token *models.RefreshToken) (*models.Session, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+	if err != nil {
+		log.Println("Error creating session:", err)
+		return nil, err
+	}
+	return session, nil
+}
+
+// GetSession retrieves a session that hasn't been terminated by its ID
+func (repo *TokenRepositoryImpl) GetSession(id uint) (*models.Session, error) {
+	var session models.Session
+	if err := repo.DB.Where("revoked_at IS NULL").First(&session, id).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetActiveSessions retrieves the sessions of a user that can still be
+// renewed: not terminated, with an unused refresh token that hasn't
+// expired. The most recently seen come first.
+func (repo *TokenRepositoryImpl) GetActiveSessions(userID uint) ([]models.Session, error) {
+	renewable := repo.DB.Session(&gorm.Session{NewDB: true}).Model(&models.RefreshToken{}).
+		Select("1").
+		Where("refresh_tokens.family_id = sessions.family_id AND refresh_tokens.used_at IS NULL AND refresh_tokens.revoked_at IS NULL AND refresh_tokens.expires_at > ?", time.Now())
+
+	var sessions []models.Session
+	err := repo.DB.
+		Where("user_id = ? AND revoked_at IS NULL AND EXISTS (?)", userID, renewable).
+		Order("last_seen_at DESC").Order("id DESC").
+		Find(&sessions).Error
+	if err != nil {
+		log.Println("Error fetching sessions:", err)
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// IsSessionRevoked reports whether the session of a token family was terminated
+func (repo *TokenRepositoryImpl) IsSessionRevoked(familyID string) (bool, error) {
+	var count int64
+	err := repo.DB.Model(&models.Session{}).
+		Where("family_id = ? AND revoked_at IS NOT NULL", familyID).
+		Count(&count).Error
+	if err != nil {
+		log.Println("Error checking session:", err)
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// TouchSession records when and from which IP address a session was last seen
+func (repo *TokenRepositoryImpl) TouchSession(familyID, ip string, seenAt time.Time) error {
+	err := repo.DB.Model(&models.Session{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Updates(map[string]interface{}{"last_seen_at": seenAt, "ip": ip}).Error
+	if err != nil {
+		log.Println("Error updating session:", err)
+	}
+	return err
+}
+
+// GetRefreshTokenByHash retrieves a refresh token by the hash of its value
+func (repo *TokenRepositoryImpl) GetRefreshTokenByHash(hash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	if err := repo.DB.Where("token_hash = ?", hash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken marks a refresh token used and stores its successor in
+// one transaction. It returns gorm.ErrRecordNotFound when the token was
+// used or revoked meanwhile, so a token is only ever traded in once.
+func (repo *TokenRepositoryImpl) RotateRefreshToken(usedID uint, next *models.RefreshToken) (*models.RefreshToken, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", usedID).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Create(next).Error
+	})
+	if err != nil {
+		log.Println("Error rotating refresh token:", err)
+		return nil, err
+	}
+	return next, nil
+}
+
+// RevokeTokenFamily terminates the session of a token family and revokes
+// its refresh tokens
+func (repo *TokenRepositoryImpl) RevokeTokenFamily(familyID string) error {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Model(&models.Session{}).Where("family_id = ? AND revoked_at IS NULL", familyID).Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.RefreshToken{}).Where("family_id = ? AND revoked_at IS NULL", familyID).Update("revoked_at", now).Error
+	})
+	if err != nil {
+		log.Println("Error revoking refresh token family:", err)
+	}
+	return err
+}
+
+// RevokeUserTokenFamilies terminates every session of a user and revokes
+// their refresh tokens
+func (repo *TokenRepositoryImpl) RevokeUserTokenFamilies(userID uint) error {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Model(&models.Session{}).Where("user_id = ? AND revoked_at IS NULL", userID).Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.RefreshToken{}).Where("user_id = ? AND revoked_at IS NULL", userID).Update("revoked_at", now).Error
+	})
+	if err != nil {
+		log.Println("Error revoking refresh tokens of user:", err)
+	}
+	return err
+}
+
+// RevokeAccessToken records a revoked access token, clearing out the
+// records of tokens that have expired since
+func (repo *TokenRepositoryImpl) RevokeAccessToken(token *models.RevokedToken) error {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expires_at < ?", time.Now()).Delete(&models.RevokedToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+	})
+	if err != nil {
+		log.Println("Error revoking access token:", err)
+	}
+	return err
+}
+
+// IsAccessTokenRevoked reports whether the access token with the given jti was revoked
+func (repo *TokenRepositoryImpl) IsAccessTokenRevoked(id string) (bool, error) {
+	var count int64
+	if err := repo.DB.Model(&models.RevokedToken{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		log.Println("Error checking revoked access token:", err)
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetTokenVersion retrieves a user's token version
+func (repo *TokenRepositoryImpl) GetTokenVersion(userID uint) (int, error) {
+	var user models.User
+	if err := repo.DB.Select("id", "token_version").First(&user, userID).Error; err != nil {
+		return 0, err
+	}
+	return user.TokenVersion, nil
+}
+
+// IncrementTokenVersion bumps a user's token version and returns the new one
+func (repo *TokenRepositoryImpl) IncrementTokenVersion(userID uint) (int, error) {
+	var version int
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.User{}).Where("id = ?", userID).
+			UpdateColumn("token_version", gorm.Expr("token_version + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Model(&models.User{}).Select("token_version").Where("id = ?", userID).Scan(&version).Error
+	})
+	if err != nil {
+		log.Println("Error incrementing token version:", err)
+		return 0, err
+	}
+	return version, nil
+}
+
+// CreatePasswordResetToken stores a new password reset token
+func (repo *TokenRepositoryImpl) CreatePasswordResetToken(token *models.PasswordResetToken) (*models.PasswordResetToken, error) {
+	if err := repo.DB.Create(token).Error; err != nil {
+		log.Println("Error creating password reset token:", err)
+		return nil, err
+	}
+	return token, nil
+}
+
+// GetPasswordResetTokenByHash retrieves a password reset token by the hash of its value
+func (repo *TokenRepositoryImpl) GetPasswordResetTokenByHash(hash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	if err := repo.DB.Where("token_hash = ?", hash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// ResetPassword uses a password reset token to set a user's password in one
+// transaction, also using up the user's other reset tokens. It returns
+// gorm.ErrRecordNotFound when the token was used or expired meanwhile, or
+// the user was deleted, so a token only ever sets one password.
+func (repo *TokenRepositoryImpl) ResetPassword(tokenID, userID uint, hashedPassword string) error {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.PasswordResetToken{}).
+			Where("id = ? AND user_id = ? AND used_at IS NULL AND expires_at > ?", tokenID, userID, now).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Model(&models.PasswordResetToken{}).Where("user_id = ? AND used_at IS NULL", userID).Update("used_at", now).Error; err != nil {
+			return err
+		}
+
+		result = tx.Model(&models.User{}).Where("id = ?", userID).Update("password", hashedPassword)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		log.Println("Error resetting password:", err)
+	}
+	return err
+}
+
+// CreateEmailVerificationToken stores a new email verification token
+func (repo *TokenRepositoryImpl) CreateEmailVerificationToken(token *models.EmailVerificationToken) (*models.EmailVerificationToken, error) {
+	if err := repo.DB.Create(token).Error; err != nil {
+		log.Println("Error creating email verification token:", err)
+		return nil, err
+	}
+	return token, nil
+}
+
+// GetEmailVerificationTokenByHash retrieves an email verification token by the hash of its value
+func (repo *TokenRepositoryImpl) GetEmailVerificationTokenByHash(hash string) (*models.EmailVerificationToken, error) {
+	var token models.EmailVerificationToken
+	if err := repo.DB.Where("token_hash = ?", hash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetLatestEmailVerificationToken retrieves the email verification token
+// issued to a user last
+func (repo *TokenRepositoryImpl) GetLatestEmailVerificationToken(userID uint) (*models.EmailVerificationToken, error) {
+	var token models.EmailVerificationToken
+	if err := repo.DB.Where("user_id = ?", userID).Order("created_at DESC").Order("id DESC").First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// VerifyEmail uses an email verification token to mark a user's email
+// verified in one transaction, also using up the user's other verification
+// tokens. A verified pending email becomes the user's email. It returns
+// gorm.ErrRecordNotFound when the token was used or expired meanwhile, or
+// the email verified is neither the user's email nor their pending one.
+func (repo *TokenRepositoryImpl) VerifyEmail(tokenID, userID uint, email string) error {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.EmailVerificationToken{}).
+			Where("id = ? AND user_id = ? AND used_at IS NULL AND expires_at > ?", tokenID, userID, now).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Model(&models.EmailVerificationToken{}).Where("user_id = ? AND used_at IS NULL", userID).Update("used_at", now).Error; err != nil {
+			return err
+		}
+
+		// A verified pending email replaces the user's email
+		result = tx.Model(&models.User{}).Where("id = ? AND pending_email = ?", userID, email).
+			Updates(map[string]interface{}{"email": email, "pending_email": "", "email_verified": true})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			return nil
+		}
+		result = tx.Model(&models.User{}).Where("id = ? AND email = ?", userID, email).Update("email_verified", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		log.Println("Error verifying email:", err)
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+package repositories_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// createVerificationToken adds an unused email verification token of user for email
+func createVerificationToken(t *testing.T, db *gorm.DB, user *models.User, email, hash string) *models.EmailVerificationToken {
+	token := &models.EmailVerificationToken{UserID: user.ID, Email: email, TokenHash: hash, ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(t, db.Create(token).Error)
+	return token
+}
+
+func TestVerifyEmail_PendingEmailReplacesEmail(t *testing.T) {
+	db := newTestDB(t)
+	tokens := repositories.NewTokenRepository(db)
+	users := repositories.NewUserRepository(db)
+
+	john := createUser(t, db, "john")
+	require.NoError(t, db.Model(john).Updates(map[string]interface{}{"email_verified": true, "pending_email": "johnny@example.com"}).Error)
+	token := createVerificationToken(t, db, john, "johnny@example.com", "pending")
+
+	// Until then the pending email isn't the account's email
+	_, err := users.GetUserByEmail("johnny@example.com")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	require.NoError(t, tokens.VerifyEmail(token.ID, john.ID, "johnny@example.com"))
+	verified, err := users.GetUserByEmail("johnny@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, john.ID, verified.ID)
+	assert.Empty(t, verified.PendingEmail)
+	assert.True(t, verified.EmailVerified)
+}
+
+func TestVerifyEmail_StaleEmail(t *testing.T) {
+	db := newTestDB(t)
+	tokens := repositories.NewTokenRepository(db)
+
+	// The link went to an email the user asked for and then replaced
+	john := createUser(t, db, "john")
+	require.NoError(t, db.Model(john).Update("pending_email", "johnny@example.com").Error)
+	token := createVerificationToken(t, db, john, "jhonny@example.com", "stale")
+
+	assert.ErrorIs(t, tokens.VerifyEmail(token.ID, john.ID, "jhonny@example.com"), gorm.ErrRecordNotFound)
+	var stored models.User
+	require.NoError(t, db.First(&stored, john.ID).Error)
+	assert.Equal(t, "john@example.com", stored.Email)
+	assert.False(t, stored.EmailVerified)
+}
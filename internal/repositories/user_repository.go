@@ -3,6 +3,7 @@ package repositories
 
 import (
 	"TaskManager/internal/models"
+	"TaskManager/pkg/query"
 	"fmt"
 	"log"
 
@@ -13,9 +14,9 @@ import (
 type UserRepository interface {
 	CreateUser(user *models.User) (*models.User, error)
 	GetUserByID(id uint) (*models.User, error)
-	GetUserByEmail(email string) (*models.User, error)       // Get user by email
-	GetUserByUsername(username string) (*models.User, error) // Get user by username
-	GetAllUsers() ([]models.User, error)
+	GetUserByEmail(email string) (*models.User, error)         // Get user by email
+	GetUserByUsername(username string) (*models.User, error)   // Get user by username
+	GetAllUsers(q *query.Query) ([]models.User, string, error) // One page of users and the cursor of the next
 	UpdateUser(user *models.User) (*models.User, error)
 	DeleteUser(id uint) error
 }
@@ -71,22 +72,38 @@ func (repo *UserRepositoryImpl) GetUserByUsername(username string) (*models.User
 	return &user, nil
 }
 
-// GetAllUsers retrieves all users from the database
-func (repo *UserRepositoryImpl) GetAllUsers() ([]models.User, error) {
+// GetAllUsers retrieves the page of users selected by the list query
+func (repo *UserRepositoryImpl) GetAllUsers(q *query.Query) ([]models.User, string, error) {
 	var users []models.User
-	if err := repo.DB.Find(&users).Error; err != nil {
+	if err := q.Apply(repo.DB.Model(&models.User{})).Find(&users).Error; err != nil {
 		log.Println("Error fetching all users:", err)
-		return nil, err
+		return nil, "", err
+	}
+	n, next, err := q.Page(users)
+	if err != nil {
+		log.Println("Error building users cursor:", err)
+		return nil, "", err
 	}
-	return users, nil
+	return users[:n], next, nil
 }
 
-// UpdateUser updates an existing user's information
+// UpdateUser saves a user's username and pending email. Other columns, like
+// the password and token version, only change through their own operations,
+// so a stale copy of the user can't undo them. The email itself only changes
+// once the pending email is verified.
 func (repo *UserRepositoryImpl) UpdateUser(user *models.User) (*models.User, error) {
-	if err := repo.DB.Save(user).Error; err != nil {
+	err := repo.DB.Model(user).Updates(map[string]interface{}{
+		"username":      user.Username,
+		"pending_email": user.PendingEmail,
+	}).Error
+	if err != nil {
 		log.Println("Error updating user:", err)
 		return nil, err
 	}
+	if err := repo.DB.First(user, user.ID).Error; err != nil {
+		log.Println("Error fetching updated user:", err)
+		return nil, err
+	}
 	return user, nil
 }
 
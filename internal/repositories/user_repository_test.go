@@ -0,0 +1,40 @@
+package repositories_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateUser_KeepsConcurrentChanges(t *testing.T) {
+	db := newTestDB(t)
+	repo := repositories.NewUserRepository(db)
+
+	john := createUser(t, db, "john")
+	stale, err := repo.GetUserByID(john.ID)
+	require.NoError(t, err)
+
+	// The password is reset and the email verified after the copy was read
+	require.NoError(t, db.Model(&models.User{}).Where("id = ?", john.ID).
+		UpdateColumns(map[string]interface{}{"password": "new-hash", "token_version": 4, "email_verified": true}).Error)
+
+	stale.Username = "johnny"
+	updated, err := repo.UpdateUser(stale)
+	require.NoError(t, err)
+	assert.Equal(t, "johnny", updated.Username)
+	assert.Equal(t, "new-hash", updated.Password)
+	assert.Equal(t, 4, updated.TokenVersion)
+	assert.True(t, updated.EmailVerified)
+
+	// A new email only waits to be verified
+	updated.Email = "johnny@example.com"
+	updated.PendingEmail = "johnny@example.com"
+	updated, err = repo.UpdateUser(updated)
+	require.NoError(t, err)
+	assert.Equal(t, "john@example.com", updated.Email)
+	assert.Equal(t, "johnny@example.com", updated.PendingEmail)
+	assert.True(t, updated.EmailVerified)
+}
@@ -0,0 +1,108 @@
+// internal/repositories/view_repository.go
+package repositories
+
+import (
+	"TaskManager/internal/models"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// ViewRepository interface defines the methods for saved-view-related DB operations
+type ViewRepository interface {
+	CreateView(view *models.SavedView) (*models.SavedView, error)
+	GetViewByID(id uint) (*models.SavedView, error)
+	GetViewsForUser(userID uint) ([]models.SavedView, error)
+	UpdateView(view *models.SavedView) (*models.SavedView, error)
+	DeleteView(id uint) error
+}
+
+// ViewRepositoryImpl is the concrete implementation of the ViewRepository interface
+type ViewRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// NewViewRepository creates and returns a new ViewRepository instance
+func NewViewRepository(db *gorm.DB) ViewRepository {
+	return &ViewRepositoryImpl{
+		DB: db,
+	}
+}
+
+// clearOtherDefaults unmarks the user's other default views, so a view
+// saved as default is the only one
+func clearOtherDefaults(tx *gorm.DB, view *models.SavedView) error {
+	if !view.IsDefault {
+		return nil
+	}
+	return tx.Model(&models.SavedView{}).
+		Where("user_id = ? AND is_default = ? AND id <> ?", view.UserID, true, view.ID).
+		Update("is_default", false).Error
+}
+
+// CreateView adds a new saved view; a default view replaces the user's previous default
+func (repo *ViewRepositoryImpl) CreateView(view *models.SavedView) (*models.SavedView, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(view).Error; err != nil {
+			return err
+		}
+		return clearOtherDefaults(tx, view)
+	})
+	if err != nil {
+		log.Println("Error creating saved view:", err)
+		return nil, err
+	}
+	return view, nil
+}
+
+// GetViewByID retrieves a saved view by its ID
+func (repo *ViewRepositoryImpl) GetViewByID(id uint) (*models.SavedView, error) {
+	var view models.SavedView
+	if err := repo.DB.First(&view, id).Error; err != nil {
+		log.Println("Error fetching saved view by ID:", err)
+		return nil, err
+	}
+	return &view, nil
+}
+
+// GetViewsForUser retrieves the user's own views and the views shared with
+// projects the user belongs to, by name
+func (repo *ViewRepositoryImpl) GetViewsForUser(userID uint) ([]models.SavedView, error) {
+	var views []models.SavedView
+	err := repo.DB.
+		Where("user_id = ? OR project_id IN (SELECT project_id FROM project_members WHERE user_id = ?)", userID, userID).
+		Order("name, id").Find(&views).Error
+	if err != nil {
+		log.Println("Error fetching saved views by user:", err)
+		return nil, err
+	}
+	return views, nil
+}
+
+// UpdateView saves a view; a default view replaces the user's previous default
+func (repo *ViewRepositoryImpl) UpdateView(view *models.SavedView) (*models.SavedView, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(view).Error; err != nil {
+			return err
+		}
+		return clearOtherDefaults(tx, view)
+	})
+	if err != nil {
+		log.Println("Error updating saved view:", err)
+		return nil, err
+	}
+	return view, nil
+}
+
+// DeleteView removes a saved view by its ID
+func (repo *ViewRepositoryImpl) DeleteView(id uint) error {
+	result := repo.DB.Delete(&models.SavedView{}, id)
+	if result.Error != nil {
+		log.Println("Error deleting saved view:", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
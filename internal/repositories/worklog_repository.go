@@ -0,0 +1,135 @@
+// internal/repositories/worklog_repository.go
+package repositories
+
+import (
+	"TaskManager/internal/models"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WorklogRepository interface defines the methods for worklog-related DB operations
+type WorklogRepository interface {
+	CreateWorklog(worklog *models.Worklog) (*models.Worklog, error)
+	GetWorklogByID(id uint) (*models.Worklog, error)
+	GetRunningWorklog(userID uint) (*models.Worklog, error)
+	GetWorklogsByTaskID(taskID uint) ([]models.Worklog, error)
+	GetWorklogsByUserBetween(userID uint, from, to time.Time) ([]models.Worklog, error)
+	GetLoggedSecondsByTask(taskIDs []uint) (map[uint]int64, error)
+	UpdateWorklog(worklog *models.Worklog) (*models.Worklog, error)
+	DeleteWorklog(id uint) error
+}
+
+// WorklogRepositoryImpl is the concrete implementation of the WorklogRepository interface
+type WorklogRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// NewWorklogRepository creates and returns a new WorklogRepository instance
+func NewWorklogRepository(db *gorm.DB) WorklogRepository {
+	return &WorklogRepositoryImpl{
+		DB: db,
+	}
+}
+
+// CreateWorklog adds a new worklog or timer to the database
+func (repo *WorklogRepositoryImpl) CreateWorklog(worklog *models.Worklog) (*models.Worklog, error) {
+	if err := repo.DB.Omit("Task", "User").Create(worklog).Error; err != nil {
+		log.Println("Error creating worklog:", err)
+		return nil, err
+	}
+	return worklog, nil
+}
+
+// GetWorklogByID retrieves a worklog by its ID
+func (repo *WorklogRepositoryImpl) GetWorklogByID(id uint) (*models.Worklog, error) {
+	var worklog models.Worklog
+	if err := repo.DB.First(&worklog, id).Error; err != nil {
+		log.Println("Error fetching worklog by ID:", err)
+		return nil, err
+	}
+	return &worklog, nil
+}
+
+// GetRunningWorklog retrieves the user's running timer
+func (repo *WorklogRepositoryImpl) GetRunningWorklog(userID uint) (*models.Worklog, error) {
+	var worklog models.Worklog
+	if err := repo.DB.Where("user_id = ? AND ended_at IS NULL", userID).First(&worklog).Error; err != nil {
+		return nil, err
+	}
+	return &worklog, nil
+}
+
+// GetWorklogsByTaskID retrieves the worklogs of a task with their users, latest first
+func (repo *WorklogRepositoryImpl) GetWorklogsByTaskID(taskID uint) ([]models.Worklog, error) {
+	var worklogs []models.Worklog
+	if err := repo.DB.Preload("User").Where("task_id = ?", taskID).Order("started_at DESC, id DESC").Find(&worklogs).Error; err != nil {
+		log.Println("Error fetching worklogs by task:", err)
+		return nil, err
+	}
+	return worklogs, nil
+}
+
+// GetWorklogsByUserBetween retrieves a user's finished worklogs started in
+// [from, to), with their tasks, deleted ones included
+func (repo *WorklogRepositoryImpl) GetWorklogsByUserBetween(userID uint, from, to time.Time) ([]models.Worklog, error) {
+	var worklogs []models.Worklog
+	err := repo.DB.
+		Preload("Task", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("user_id = ? AND ended_at IS NOT NULL AND started_at >= ? AND started_at < ?", userID, from, to).
+		Order("started_at, id").
+		Find(&worklogs).Error
+	if err != nil {
+		log.Println("Error fetching worklogs by user:", err)
+		return nil, err
+	}
+	return worklogs, nil
+}
+
+// GetLoggedSecondsByTask sums the finished worklogs of every user on the given tasks
+func (repo *WorklogRepositoryImpl) GetLoggedSecondsByTask(taskIDs []uint) (map[uint]int64, error) {
+	logged := make(map[uint]int64, len(taskIDs))
+	if len(taskIDs) == 0 {
+		return logged, nil
+	}
+
+	var rows []struct {
+		TaskID  uint
+		Seconds int64
+	}
+	err := repo.DB.Model(&models.Worklog{}).
+		Select("task_id, SUM(seconds) AS seconds").
+		Where("task_id IN ? AND ended_at IS NOT NULL", taskIDs).
+		Group("task_id").
+		Scan(&rows).Error
+	if err != nil {
+		log.Println("Error summing worklogs:", err)
+		return nil, err
+	}
+	for _, row := range rows {
+		logged[row.TaskID] = row.Seconds
+	}
+	return logged, nil
+}
+
+// UpdateWorklog saves changes to a worklog
+func (repo *WorklogRepositoryImpl) UpdateWorklog(worklog *models.Worklog) (*models.Worklog, error) {
+	if err := repo.DB.Omit("Task", "User").Save(worklog).Error; err != nil {
+		log.Println("Error updating worklog:", err)
+		return nil, err
+	}
+	return worklog, nil
+}
+
+// DeleteWorklog deletes a worklog
+func (repo *WorklogRepositoryImpl) DeleteWorklog(id uint) error {
+	result := repo.DB.Delete(&models.Worklog{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"TaskManager/internal/controllers"
+	"TaskManager/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupAttachmentRoutes sets up the routes related to task attachments
+func SetupAttachmentRoutes(router *gin.Engine, attachmentController *controllers.AttachmentController) {
+	taskAttachmentRoutes := router.Group("/tasks")
+	{
+		// applying jwt middleware
+		taskAttachmentRoutes.Use(middleware.AuthRequired())
+
+		// GET the attachments of a task / POST a multipart upload with a "file" field
+		taskAttachmentRoutes.GET("/:id/attachments", attachmentController.GetTaskAttachments)
+		taskAttachmentRoutes.POST("/:id/attachments", attachmentController.UploadAttachment)
+	}
+
+	attachmentRoutes := router.Group("/attachments")
+	{
+		// applying jwt middleware
+		attachmentRoutes.Use(middleware.AuthRequired())
+
+		// GET the authenticated user's quota usage
+		attachmentRoutes.GET("/usage", attachmentController.GetUsage)
+
+		// GET the metadata of an attachment / DELETE it
+		attachmentRoutes.GET("/:id", attachmentController.GetAttachment)
+		attachmentRoutes.DELETE("/:id", attachmentController.DeleteAttachment)
+
+		// GET the content of an attachment; supports Range requests
+		attachmentRoutes.GET("/:id/download", attachmentController.DownloadAttachment)
+	}
+}
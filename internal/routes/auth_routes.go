@@ -2,6 +2,7 @@ package routes
 
 import (
 	"TaskManager/internal/controllers"
+	"TaskManager/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
@@ -12,5 +13,24 @@ func SetupAuthRoutes(router *gin.Engine, authController *controllers.AuthControl
 	{
 		authRoutes.POST("/register", authController.Register)
 		authRoutes.POST("/login", authController.Login)
+
+		// POST a refresh token to rotate it and get a new access token
+		authRoutes.POST("/refresh", authController.Refresh)
+
+		// POST an email to get a password reset link, and the link's token with a new password to reset it
+		authRoutes.POST("/password/forgot", authController.ForgotPassword)
+		authRoutes.POST("/password/reset", authController.ResetPassword)
+
+		// GET with the token of a verification link to verify an email, and POST to have the link resent (jwt required)
+		authRoutes.GET("/verify", authController.VerifyEmail)
+		authRoutes.POST("/verify/resend", middleware.AuthRequiredUnverified(), authController.ResendVerification)
+
+		// POST to log out of the current session, or of every session of the user (jwt required)
+		authRoutes.POST("/logout", middleware.AuthRequiredUnverified(), authController.Logout)
+		authRoutes.POST("/logout-all", middleware.AuthRequiredUnverified(), authController.LogoutEverywhere)
+
+		// GET the sessions of the user, and DELETE one to log it out (jwt required)
+		authRoutes.GET("/sessions", middleware.AuthRequiredUnverified(), authController.GetSessions)
+		authRoutes.DELETE("/sessions/:id", middleware.AuthRequiredUnverified(), authController.TerminateSession)
 	}
 }
@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"TaskManager/internal/controllers"
+	"TaskManager/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupBoardRoutes sets up the routes related to Kanban boards
+func SetupBoardRoutes(router *gin.Engine, boardController *controllers.BoardController) {
+	projectBoardRoutes := router.Group("/projects")
+	{
+		// applying jwt middleware
+		projectBoardRoutes.Use(middleware.AuthRequired())
+
+		// GET the boards of a project / POST to create one (editors)
+		projectBoardRoutes.GET("/:id/boards", boardController.GetProjectBoards)
+		projectBoardRoutes.POST("/:id/boards", boardController.CreateBoard)
+	}
+
+	boardRoutes := router.Group("/boards")
+	{
+		// applying jwt middleware
+		boardRoutes.Use(middleware.AuthRequired())
+
+		// GET a board with its cards laid out in columns
+		boardRoutes.GET("/:id", boardController.GetBoard)
+
+		// PUT to rename a board or replace its columns
+		boardRoutes.PUT("/:id", boardController.UpdateBoard)
+
+		// DELETE a board (its tasks are kept)
+		boardRoutes.DELETE("/:id", boardController.DeleteBoard)
+
+		// POST to move a card to another column and/or position
+		boardRoutes.POST("/:id/cards/:taskId/move", boardController.MoveCard)
+	}
+}
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"TaskManager/internal/controllers"
+	"TaskManager/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupCommentRoutes sets up the routes related to task comments
+func SetupCommentRoutes(router *gin.Engine, commentController *controllers.CommentController) {
+	taskCommentRoutes := router.Group("/tasks")
+	{
+		// applying jwt middleware
+		taskCommentRoutes.Use(middleware.AuthRequired())
+
+		// GET the comment threads of a task / POST a comment or reply
+		taskCommentRoutes.GET("/:id/comments", commentController.GetTaskComments)
+		taskCommentRoutes.POST("/:id/comments", commentController.CreateComment)
+	}
+
+	commentRoutes := router.Group("/comments")
+	{
+		// applying jwt middleware
+		commentRoutes.Use(middleware.AuthRequired())
+
+		// GET the latest comments mentioning the authenticated user
+		commentRoutes.GET("/mentions", commentController.GetMentions)
+
+		// PUT to edit and DELETE a comment by ID
+		commentRoutes.PUT("/:id", commentController.UpdateComment)
+		commentRoutes.DELETE("/:id", commentController.DeleteComment)
+
+		// GET the previous versions of a comment
+		commentRoutes.GET("/:id/history", commentController.GetCommentHistory)
+	}
+}
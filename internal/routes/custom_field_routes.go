@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"TaskManager/internal/controllers"
+	"TaskManager/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupCustomFieldRoutes sets up the routes related to a project's custom fields
+func SetupCustomFieldRoutes(router *gin.Engine, customFieldController *controllers.CustomFieldController) {
+	fieldRoutes := router.Group("/projects")
+	{
+		// applying jwt middleware
+		fieldRoutes.Use(middleware.AuthRequired())
+
+		// GET the custom fields of a project (members only)
+		fieldRoutes.GET("/:id/fields", customFieldController.GetFields)
+
+		// POST to add a custom field (owners only)
+		fieldRoutes.POST("/:id/fields", customFieldController.CreateField)
+
+		// PUT to change and DELETE to remove a custom field (owners only)
+		fieldRoutes.PUT("/:id/fields/:fieldId", customFieldController.UpdateField)
+		fieldRoutes.DELETE("/:id/fields/:fieldId", customFieldController.DeleteField)
+	}
+}
@@ -0,0 +1,50 @@
+package routes
+
+import (
+	"TaskManager/internal/controllers"
+	"TaskManager/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupLabelRoutes sets up the routes related to labels
+func SetupLabelRoutes(router *gin.Engine, labelController *controllers.LabelController) {
+	labelRoutes := router.Group("/labels")
+	{
+		// applying jwt middleware
+		labelRoutes.Use(middleware.AuthRequired())
+
+		// POST to create a label (personal, or of the project given in the body)
+		labelRoutes.POST("/", labelController.CreateLabel)
+
+		// GET the authenticated user's personal labels
+		labelRoutes.GET("/", labelController.GetPersonalLabels)
+
+		// GET, PUT (rename/recolour) and DELETE a label by ID
+		labelRoutes.GET("/:id", labelController.GetLabel)
+		labelRoutes.PUT("/:id", labelController.UpdateLabel)
+		labelRoutes.DELETE("/:id", labelController.DeleteLabel)
+
+		// POST to merge a label into another one; its tasks are retagged
+		labelRoutes.POST("/:id/merge", labelController.MergeLabel)
+	}
+
+	projectLabelRoutes := router.Group("/projects")
+	{
+		// applying jwt middleware
+		projectLabelRoutes.Use(middleware.AuthRequired())
+
+		// GET the labels of a project
+		projectLabelRoutes.GET("/:id/labels", labelController.GetProjectLabels)
+	}
+
+	taskLabelRoutes := router.Group("/tasks")
+	{
+		// applying jwt middleware
+		taskLabelRoutes.Use(middleware.AuthRequired())
+
+		// POST to tag a task with a label / DELETE to remove it
+		taskLabelRoutes.POST("/:id/labels", labelController.AddTaskLabel)
+		taskLabelRoutes.DELETE("/:id/labels/:labelId", labelController.RemoveTaskLabel)
+	}
+}
@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"TaskManager/internal/controllers"
+	"TaskManager/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupProjectRoutes sets up the routes related to projects and their members
+func SetupProjectRoutes(router *gin.Engine, projectController *controllers.ProjectController) {
+	projectRoutes := router.Group("/projects")
+	{
+		// applying jwt middleware
+		projectRoutes.Use(middleware.AuthRequired())
+
+		// POST to create a new project owned by the caller
+		projectRoutes.POST("/", projectController.CreateProject)
+
+		// GET all projects the caller is a member of
+		projectRoutes.GET("/", projectController.GetAllProjects)
+
+		// GET a single project by ID
+		projectRoutes.GET("/:id", projectController.GetProjectByID)
+
+		// PUT to update a project by ID (owners only)
+		projectRoutes.PUT("/:id", projectController.UpdateProject)
+
+		// DELETE a project by ID (owners only)
+		projectRoutes.DELETE("/:id", projectController.DeleteProject)
+
+		// GET the tasks of a project (members only)
+		projectRoutes.GET("/:id/tasks", projectController.GetProjectTasks)
+
+		// GET the critical-path schedule of the project's tasks (Gantt data)
+		projectRoutes.GET("/:id/schedule", projectController.GetProjectSchedule)
+
+		// Membership management
+		projectRoutes.GET("/:id/members", projectController.GetMembers)
+		projectRoutes.POST("/:id/members", projectController.AddMember)
+		projectRoutes.PUT("/:id/members/:userId", projectController.UpdateMember)
+		projectRoutes.DELETE("/:id/members/:userId", projectController.RemoveMember)
+	}
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"TaskManager/internal/controllers"
+	"TaskManager/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupSearchRoutes sets up the routes related to full-text search
+func SetupSearchRoutes(router *gin.Engine, searchController *controllers.SearchController) {
+	searchRoutes := router.Group("/search")
+	{
+		// applying jwt middleware
+		searchRoutes.Use(middleware.AuthRequired())
+
+		// GET the tasks and comments matching ?q=, best first, with highlighted snippets
+		searchRoutes.GET("/", searchController.Search)
+	}
+}
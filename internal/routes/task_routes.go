@@ -0,0 +1,81 @@
+package routes
+
+import (
+	"TaskManager/internal/controllers"
+	"TaskManager/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupTaskRoutes sets up the routes related to tasks
+func SetupTaskRoutes(router *gin.Engine, taskController *controllers.TaskController) {
+	taskRoutes := router.Group("/tasks")
+	{
+		// applying jwt middleware; every task route is scoped to the authenticated user
+		taskRoutes.Use(middleware.AuthRequired())
+
+		// POST to create a new task
+		taskRoutes.POST("/", taskController.CreateTask)
+
+		// GET all tasks of the authenticated user
+		taskRoutes.GET("/", taskController.GetAllTasks)
+
+		// GET the caller's open tasks in dependency order ("what can I work on next")
+		taskRoutes.GET("/next", taskController.GetNextTasks)
+
+		// GET the tasks assigned to the authenticated user
+		taskRoutes.GET("/assigned", taskController.GetAssignedTasks)
+
+		// GET the tasks the authenticated user watches
+		taskRoutes.GET("/watching", taskController.GetWatchedTasks)
+
+		// GET the tasks matching a task query (?q=status:open assignee:me due<7d sort:priority)
+		taskRoutes.GET("/search", taskController.SearchTasks)
+
+		// POST to preview the occurrences of a recurrence rule before saving it
+		taskRoutes.POST("/recurrence/preview", taskController.PreviewRecurrence)
+
+		// GET a single task by ID
+		taskRoutes.GET("/:id", taskController.GetTaskByID)
+
+		// PUT to update a task by ID
+		taskRoutes.PUT("/:id", taskController.UpdateTask)
+
+		// DELETE a task by ID together with its subtasks
+		taskRoutes.DELETE("/:id", taskController.DeleteTask)
+
+		// GET a task with its whole subtask tree
+		taskRoutes.GET("/:id/tree", taskController.GetTaskTree)
+
+		// POST to move a task (and its subtree) under a new parent
+		taskRoutes.POST("/:id/move", taskController.MoveTask)
+
+		// GET the next occurrences of a recurring task (?count=N)
+		taskRoutes.GET("/:id/occurrences", taskController.GetUpcomingOccurrences)
+
+		// Assignment: assignees must be able to see the task
+		taskRoutes.POST("/:id/assignees", taskController.AssignTask)
+		taskRoutes.DELETE("/:id/assignees/:userId", taskController.UnassignTask)
+
+		// POST/DELETE to start or stop watching a task
+		taskRoutes.POST("/:id/watch", taskController.WatchTask)
+		taskRoutes.DELETE("/:id/watch", taskController.UnwatchTask)
+
+		// Dependency management: a task is blocked by the tasks listed here
+		taskRoutes.GET("/:id/dependencies", taskController.GetBlockers)
+		taskRoutes.POST("/:id/dependencies", taskController.AddDependency)
+		taskRoutes.DELETE("/:id/dependencies/:blockerId", taskController.RemoveDependency)
+
+		// Checklist: ordered to-do items inside the task
+		taskRoutes.GET("/:id/checklist", taskController.GetChecklist)
+		taskRoutes.POST("/:id/checklist", taskController.AddChecklistItem)
+		taskRoutes.PUT("/:id/checklist/:itemId", taskController.UpdateChecklistItem)
+		taskRoutes.DELETE("/:id/checklist/:itemId", taskController.DeleteChecklistItem)
+
+		// POST to check, uncheck or (without a body) flip an item
+		taskRoutes.POST("/:id/checklist/:itemId/toggle", taskController.ToggleChecklistItem)
+
+		// POST to move an item to another position
+		taskRoutes.POST("/:id/checklist/:itemId/move", taskController.MoveChecklistItem)
+	}
+}
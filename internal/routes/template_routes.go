@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"TaskManager/internal/controllers"
+	"TaskManager/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupTemplateRoutes sets up the routes related to task templates
+func SetupTemplateRoutes(router *gin.Engine, templateController *controllers.TemplateController) {
+	templateRoutes := router.Group("/templates")
+	{
+		// applying jwt middleware
+		templateRoutes.Use(middleware.AuthRequired())
+
+		// POST to create a template (personal, or of the project given in the body)
+		templateRoutes.POST("/", templateController.CreateTemplate)
+
+		// GET the authenticated user's personal templates
+		templateRoutes.GET("/", templateController.GetPersonalTemplates)
+
+		// GET, PUT (rename or replace the task tree) and DELETE a template by ID
+		templateRoutes.GET("/:id", templateController.GetTemplate)
+		templateRoutes.PUT("/:id", templateController.UpdateTemplate)
+		templateRoutes.DELETE("/:id", templateController.DeleteTemplate)
+
+		// POST to create the template's tasks, due dates counted from an anchor date
+		templateRoutes.POST("/:id/instantiate", templateController.InstantiateTemplate)
+	}
+
+	projectTemplateRoutes := router.Group("/projects")
+	{
+		// applying jwt middleware
+		projectTemplateRoutes.Use(middleware.AuthRequired())
+
+		// GET the templates shared in a project
+		projectTemplateRoutes.GET("/:id/templates", templateController.GetProjectTemplates)
+	}
+}
@@ -8,6 +8,17 @@ import (
 )
 
 func SetupUserRoutes(router *gin.Engine, userController *controllers.UserController) {
+	// Users whose email isn't verified yet can still correct it; each user
+	// can only update their own account
+	accountRoutes := router.Group("/users")
+	{
+		// applying jwt middleware without the verified email policy
+		accountRoutes.Use(middleware.AuthRequiredUnverified())
+
+		// PUT to update the authenticated user by ID
+		accountRoutes.PUT("/:id", userController.UpdateUser)
+	}
+
 	userRoutes := router.Group("/users")
 	{
 		// applying jwt middleware
@@ -22,9 +33,6 @@ func SetupUserRoutes(router *gin.Engine, userController *controllers.UserControl
 		// GET all users (make sure to place this route before the :id route)
 		userRoutes.GET("/", userController.GetAllUsers)
 
-		// PUT to update a user by ID
-		userRoutes.PUT("/:id", userController.UpdateUser)
-
 		// DELETE a user by ID
 		userRoutes.DELETE("/:id", userController.DeleteUser)
 	}
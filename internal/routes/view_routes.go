@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"TaskManager/internal/controllers"
+	"TaskManager/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupViewRoutes sets up the routes related to saved views
+func SetupViewRoutes(router *gin.Engine, viewController *controllers.ViewController) {
+	viewRoutes := router.Group("/views")
+	{
+		// applying jwt middleware
+		viewRoutes.Use(middleware.AuthRequired())
+
+		// POST to save a view (personal, or shared with the project given in the body)
+		viewRoutes.POST("/", viewController.CreateView)
+
+		// GET the authenticated user's views and the views shared with their projects
+		viewRoutes.GET("/", viewController.GetViews)
+
+		// GET, PUT and DELETE a view by ID
+		viewRoutes.GET("/:id", viewController.GetView)
+		viewRoutes.PUT("/:id", viewController.UpdateView)
+		viewRoutes.DELETE("/:id", viewController.DeleteView)
+
+		// GET the tasks a view shows, checked against the caller's permissions
+		viewRoutes.GET("/:id/tasks", viewController.GetViewTasks)
+	}
+}
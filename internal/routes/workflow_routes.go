@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"TaskManager/internal/controllers"
+	"TaskManager/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupWorkflowRoutes sets up the routes related to project workflows
+func SetupWorkflowRoutes(router *gin.Engine, workflowController *controllers.WorkflowController) {
+	workflowRoutes := router.Group("/projects")
+	{
+		// applying jwt middleware
+		workflowRoutes.Use(middleware.AuthRequired())
+
+		// GET the workflow (states and transitions) a project's tasks follow
+		workflowRoutes.GET("/:id/workflow", workflowController.GetWorkflow)
+
+		// PUT to replace the project's workflow (owners only)
+		workflowRoutes.PUT("/:id/workflow", workflowController.UpdateWorkflow)
+
+		// DELETE to go back to the default workflow (owners only)
+		workflowRoutes.DELETE("/:id/workflow", workflowController.ResetWorkflow)
+	}
+}
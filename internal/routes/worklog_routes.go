@@ -0,0 +1,53 @@
+package routes
+
+import (
+	"TaskManager/internal/controllers"
+	"TaskManager/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupWorklogRoutes sets up the routes related to time tracking
+func SetupWorklogRoutes(router *gin.Engine, worklogController *controllers.WorklogController) {
+	taskWorklogRoutes := router.Group("/tasks")
+	{
+		// applying jwt middleware
+		taskWorklogRoutes.Use(middleware.AuthRequired())
+
+		// POST to start the authenticated user's timer on a task
+		taskWorklogRoutes.POST("/:id/timer/start", worklogController.StartTimer)
+
+		// GET the worklogs of a task against its estimate / POST a manual worklog
+		taskWorklogRoutes.GET("/:id/worklogs", worklogController.GetTaskWorklogs)
+		taskWorklogRoutes.POST("/:id/worklogs", worklogController.LogWork)
+	}
+
+	timerRoutes := router.Group("/timer")
+	{
+		// applying jwt middleware
+		timerRoutes.Use(middleware.AuthRequired())
+
+		// GET the running timer / POST to stop it
+		timerRoutes.GET("", worklogController.GetTimer)
+		timerRoutes.POST("/stop", worklogController.StopTimer)
+	}
+
+	worklogRoutes := router.Group("/worklogs")
+	{
+		// applying jwt middleware
+		worklogRoutes.Use(middleware.AuthRequired())
+
+		// PUT to correct a worklog / DELETE it
+		worklogRoutes.PUT("/:id", worklogController.UpdateWorklog)
+		worklogRoutes.DELETE("/:id", worklogController.DeleteWorklog)
+	}
+
+	timesheetRoutes := router.Group("/timesheet")
+	{
+		// applying jwt middleware
+		timesheetRoutes.Use(middleware.AuthRequired())
+
+		// GET the user's logged time; query: from, to, group=day|week|task|project, tz, format=csv
+		timesheetRoutes.GET("", worklogController.GetTimesheet)
+	}
+}
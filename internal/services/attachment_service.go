@@ -0,0 +1,291 @@
+// internal/services/attachment_service.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"TaskManager/pkg/blobstore"
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAttachmentNotFound  = errors.New("attachment not found")
+	ErrAttachmentEmpty     = errors.New("attachment is empty")
+	ErrAttachmentTooLarge  = errors.New("attachment exceeds the maximum file size")
+	ErrQuotaExceeded       = errors.New("attachment quota exceeded")
+	ErrAttachmentForbidden = errors.New("only the uploader or a project editor can delete this attachment")
+)
+
+// sniffLength is how many leading bytes are inspected to detect the MIME type
+const sniffLength = 512
+
+// maxFilenameLength caps stored file names, in bytes
+const maxFilenameLength = 255
+
+// AttachmentLimits bounds uploads; sizes are in bytes
+type AttachmentLimits struct {
+	MaxFileSize int64
+	Quota       int64
+}
+
+// AttachmentUsage reports how much of their quota a user has used
+type AttachmentUsage struct {
+	Used        int64
+	Quota       int64
+	MaxFileSize int64
+}
+
+// AttachmentService interface defines the methods for attachment-related business operations
+type AttachmentService interface {
+	UploadAttachment(taskID, userID uint, filename string, content io.Reader) (*models.Attachment, error)
+	GetAttachment(id, userID uint) (*models.Attachment, error)
+	GetTaskAttachments(taskID, userID uint) ([]models.Attachment, error)
+	OpenAttachment(id, userID uint) (*models.Attachment, io.ReadSeekCloser, error)
+	DeleteAttachment(id, userID uint) error
+	GetUsage(userID uint) (*AttachmentUsage, error)
+}
+
+// AttachmentServiceImpl is the concrete implementation of the AttachmentService interface
+type AttachmentServiceImpl struct {
+	AttachmentRepo repositories.AttachmentRepository
+	TaskRepo       repositories.TaskRepository
+	ProjectRepo    repositories.ProjectRepository
+	Blobs          blobstore.BlobStore
+	Limits         AttachmentLimits
+	tasks          *TaskServiceImpl
+}
+
+// NewAttachmentService creates and returns a new AttachmentService instance
+func NewAttachmentService(attachmentRepo repositories.AttachmentRepository, taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository, blobs blobstore.BlobStore, limits AttachmentLimits, tasks *TaskServiceImpl) AttachmentService {
+	return &AttachmentServiceImpl{
+		AttachmentRepo: attachmentRepo,
+		TaskRepo:       taskRepo,
+		ProjectRepo:    projectRepo,
+		Blobs:          blobs,
+		Limits:         limits,
+		tasks:          tasks,
+	}
+}
+
+// cleanFilename reduces a client-supplied file name to its base name without
+// control characters (internal helper)
+func cleanFilename(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	for len(name) > maxFilenameLength {
+		// Trim whole runes so the name stays valid UTF-8
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
+
+// newStorageKey returns a random blob key (internal helper)
+func newStorageKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// deleteBlob removes a blob, logging failures; the record is what counts
+func (s *AttachmentServiceImpl) deleteBlob(key string) {
+	deleteBlobs(s.Blobs, []string{key})
+}
+
+// deleteBlobs removes the blobs of deleted attachment records, logging failures
+func deleteBlobs(blobs blobstore.BlobStore, keys []string) {
+	for _, key := range keys {
+		if err := blobs.Delete(key); err != nil && !errors.Is(err, blobstore.ErrNotFound) {
+			log.Println("Error deleting attachment blob:", err)
+		}
+	}
+}
+
+// UploadAttachment stores a file on a task the user may edit. The MIME type
+// is sniffed from the content and the SHA-256 checksum computed while the
+// file streams to the blob store. Uploads beyond the maximum file size or
+// the user's remaining quota are rejected and discarded; the quota is checked
+// again when the record is created, so concurrent uploads can't overrun it.
+func (s *AttachmentServiceImpl) UploadAttachment(taskID, userID uint, filename string, content io.Reader) (*models.Attachment, error) {
+	task, err := s.tasks.loadTask(taskID, userID, models.ProjectRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := s.AttachmentRepo.GetUsageByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch attachment usage: %v", err)
+	}
+	remaining := s.Limits.Quota - used
+	if remaining <= 0 {
+		return nil, ErrQuotaExceeded
+	}
+	limit := s.Limits.MaxFileSize
+	if remaining < limit {
+		limit = remaining
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to read attachment: %v", err)
+	}
+	if n == 0 {
+		return nil, ErrAttachmentEmpty
+	}
+	head = head[:n]
+
+	key, err := newStorageKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate storage key: %v", err)
+	}
+
+	// Read one byte past the limit to tell a file of exactly the limit from a larger one
+	hash := sha256.New()
+	body := io.LimitReader(io.MultiReader(bytes.NewReader(head), content), limit+1)
+	size, err := s.Blobs.Put(key, io.TeeReader(body, hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to store attachment: %v", err)
+	}
+	if size > limit {
+		s.deleteBlob(key)
+		if size > s.Limits.MaxFileSize {
+			return nil, ErrAttachmentTooLarge
+		}
+		return nil, ErrQuotaExceeded
+	}
+
+	attachment := models.Attachment{
+		TaskID:      task.ID,
+		UserID:      userID,
+		Filename:    cleanFilename(filename),
+		ContentType: http.DetectContentType(head),
+		Size:        size,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+		StorageKey:  key,
+	}
+	created, err := s.AttachmentRepo.CreateAttachment(&attachment, s.Limits.Quota)
+	if err != nil {
+		s.deleteBlob(key)
+		if errors.Is(err, repositories.ErrQuotaExceeded) {
+			return nil, ErrQuotaExceeded
+		}
+		return nil, fmt.Errorf("failed to create attachment: %v", err)
+	}
+	return created, nil
+}
+
+// loadAttachment fetches an attachment and makes sure the user may see its
+// task (internal helper)
+func (s *AttachmentServiceImpl) loadAttachment(id, userID uint) (*models.Attachment, *models.Task, error) {
+	attachment, err := s.AttachmentRepo.GetAttachmentByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrAttachmentNotFound
+		}
+		return nil, nil, fmt.Errorf("unexpected error fetching attachment: %v", err)
+	}
+
+	task, err := s.tasks.loadTask(attachment.TaskID, userID, models.ProjectRoleViewer)
+	if err != nil {
+		if errors.Is(err, ErrTaskNotFound) {
+			return nil, nil, ErrAttachmentNotFound
+		}
+		return nil, nil, err
+	}
+	return attachment, task, nil
+}
+
+// GetAttachment retrieves the metadata of an attachment the user may see
+func (s *AttachmentServiceImpl) GetAttachment(id, userID uint) (*models.Attachment, error) {
+	attachment, _, err := s.loadAttachment(id, userID)
+	return attachment, err
+}
+
+// GetTaskAttachments retrieves the attachments of a task the user may see
+func (s *AttachmentServiceImpl) GetTaskAttachments(taskID, userID uint) ([]models.Attachment, error) {
+	if _, err := s.tasks.loadTask(taskID, userID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+	return s.AttachmentRepo.GetAttachmentsByTaskID(taskID)
+}
+
+// OpenAttachment returns an attachment with a reader over its content; the
+// caller must close the reader
+func (s *AttachmentServiceImpl) OpenAttachment(id, userID uint) (*models.Attachment, io.ReadSeekCloser, error) {
+	attachment, _, err := s.loadAttachment(id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.Blobs.Open(attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			log.Printf("Blob of attachment %d is missing", attachment.ID)
+			return nil, nil, ErrAttachmentNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to open attachment: %v", err)
+	}
+	return attachment, content, nil
+}
+
+// DeleteAttachment deletes an attachment and its content. The uploader may
+// delete their own attachments, editors any attachment of their project.
+func (s *AttachmentServiceImpl) DeleteAttachment(id, userID uint) error {
+	attachment, task, err := s.loadAttachment(id, userID)
+	if err != nil {
+		return err
+	}
+
+	if attachment.UserID != userID {
+		if err := s.tasks.checkTaskAccess(task, userID, models.ProjectRoleEditor); err != nil {
+			if errors.Is(err, ErrProjectForbidden) {
+				return ErrAttachmentForbidden
+			}
+			return err
+		}
+	}
+
+	if err := s.AttachmentRepo.DeleteAttachment(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAttachmentNotFound
+		}
+		return fmt.Errorf("failed to delete attachment: %v", err)
+	}
+	s.deleteBlob(attachment.StorageKey)
+	return nil
+}
+
+// GetUsage reports the user's attachment usage against their quota
+func (s *AttachmentServiceImpl) GetUsage(userID uint) (*AttachmentUsage, error) {
+	used, err := s.AttachmentRepo.GetUsageByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch attachment usage: %v", err)
+	}
+	return &AttachmentUsage{Used: used, Quota: s.Limits.Quota, MaxFileSize: s.Limits.MaxFileSize}, nil
+}
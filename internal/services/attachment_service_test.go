@@ -0,0 +1,198 @@
+package services_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"TaskManager/pkg/blobstore"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// pngHeader is the signature http.DetectContentType recognises as PNG
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+func attachmentLimits() services.AttachmentLimits {
+	return services.AttachmentLimits{MaxFileSize: 1024, Quota: 4096}
+}
+
+func TestUploadAttachment_SniffsAndChecksums(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	blobs := blobstore.NewMemoryStore()
+	attachmentSvc := services.NewAttachmentService(mockAttachments, mockTasks, mockProjects, blobs, attachmentLimits(), services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	content := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{7}, 600)...)
+	sum := sha256.Sum256(content)
+
+	mockTasks.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleEditor), nil)
+	mockAttachments.EXPECT().GetUsageByUserID(uint(1)).Return(int64(0), nil)
+	mockAttachments.EXPECT().CreateAttachment(gomock.Any(), int64(4096)).DoAndReturn(
+		func(attachment *models.Attachment, quota int64) (*models.Attachment, error) {
+			attachment.ID = 9
+			return attachment, nil
+		},
+	)
+
+	// The client's file name and declared type aren't trusted
+	attachment, err := attachmentSvc.UploadAttachment(5, 1, `C:\Users\me\..\shot.png`+"\x00", bytes.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, "shot.png", attachment.Filename)
+	assert.Equal(t, "image/png", attachment.ContentType)
+	assert.Equal(t, int64(len(content)), attachment.Size)
+	assert.Equal(t, hex.EncodeToString(sum[:]), attachment.Checksum)
+
+	stored, err := blobs.Open(attachment.StorageKey)
+	require.NoError(t, err)
+	data, _ := io.ReadAll(stored)
+	assert.Equal(t, content, data)
+}
+
+func TestUploadAttachment_Limits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	blobs := blobstore.NewMemoryStore()
+	attachmentSvc := services.NewAttachmentService(mockAttachments, mockTasks, mockProjects, blobs, attachmentLimits(), services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+	mockTasks.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil).AnyTimes()
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleEditor), nil).AnyTimes()
+	mockAttachments.EXPECT().CreateAttachment(gomock.Any(), int64(4096)).Times(0)
+
+	// Larger than the maximum file size
+	mockAttachments.EXPECT().GetUsageByUserID(uint(1)).Return(int64(0), nil)
+	_, err := attachmentSvc.UploadAttachment(5, 1, "big.log", strings.NewReader(strings.Repeat("x", 1025)))
+	assert.ErrorIs(t, err, services.ErrAttachmentTooLarge)
+
+	// Fits the file size but not the remaining quota
+	mockAttachments.EXPECT().GetUsageByUserID(uint(1)).Return(int64(3500), nil)
+	_, err = attachmentSvc.UploadAttachment(5, 1, "app.log", strings.NewReader(strings.Repeat("x", 700)))
+	assert.ErrorIs(t, err, services.ErrQuotaExceeded)
+
+	// Quota already used up
+	mockAttachments.EXPECT().GetUsageByUserID(uint(1)).Return(int64(4096), nil)
+	_, err = attachmentSvc.UploadAttachment(5, 1, "app.log", strings.NewReader("x"))
+	assert.ErrorIs(t, err, services.ErrQuotaExceeded)
+
+	mockAttachments.EXPECT().GetUsageByUserID(uint(1)).Return(int64(0), nil)
+	_, err = attachmentSvc.UploadAttachment(5, 1, "empty.txt", strings.NewReader(""))
+	assert.ErrorIs(t, err, services.ErrAttachmentEmpty)
+
+	// Rejected uploads leave no blobs behind
+	assert.Equal(t, 0, blobs.Len())
+}
+
+func TestUploadAttachment_RequiresEditor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	attachmentSvc := services.NewAttachmentService(mockAttachments, mockTasks, mockProjects, blobstore.NewMemoryStore(), attachmentLimits(), services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockTasks.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(2)).Return(memberWithRole(models.ProjectRoleViewer), nil)
+
+	_, err := attachmentSvc.UploadAttachment(5, 2, "a.txt", strings.NewReader("hi"))
+	assert.ErrorIs(t, err, services.ErrProjectForbidden)
+}
+
+func TestUploadAttachment_RemovesBlobWhenRecordFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	blobs := blobstore.NewMemoryStore()
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	attachmentSvc := services.NewAttachmentService(mockAttachments, mockTasks, mockProjects, blobs, attachmentLimits(), services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockTasks.EXPECT().GetTaskByID(uint(4)).Return(&models.Task{Model: gorm.Model{ID: 4}, UserID: 1}, nil)
+	mockAttachments.EXPECT().GetUsageByUserID(uint(1)).Return(int64(0), nil)
+	mockAttachments.EXPECT().CreateAttachment(gomock.Any(), int64(4096)).Return(nil, gorm.ErrInvalidDB)
+
+	_, err := attachmentSvc.UploadAttachment(4, 1, "a.txt", strings.NewReader("hi"))
+	assert.Error(t, err)
+	assert.Equal(t, 0, blobs.Len())
+}
+
+func TestUploadAttachment_QuotaTakenConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	blobs := blobstore.NewMemoryStore()
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	attachmentSvc := services.NewAttachmentService(mockAttachments, mockTasks, mockProjects, blobs, attachmentLimits(), services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	// Another upload used the quota up after it was first checked
+	mockTasks.EXPECT().GetTaskByID(uint(4)).Return(&models.Task{Model: gorm.Model{ID: 4}, UserID: 1}, nil)
+	mockAttachments.EXPECT().GetUsageByUserID(uint(1)).Return(int64(3500), nil)
+	mockAttachments.EXPECT().CreateAttachment(gomock.Any(), int64(4096)).Return(nil, repositories.ErrQuotaExceeded)
+
+	_, err := attachmentSvc.UploadAttachment(4, 1, "a.txt", strings.NewReader("hi"))
+	assert.ErrorIs(t, err, services.ErrQuotaExceeded)
+	assert.Equal(t, 0, blobs.Len())
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	blobs := blobstore.NewMemoryStore()
+	attachmentSvc := services.NewAttachmentService(mockAttachments, mockTasks, mockProjects, blobs, attachmentLimits(), services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	_, err := blobs.Put("abcd", strings.NewReader("log"))
+	require.NoError(t, err)
+	stored := &models.Attachment{ID: 9, TaskID: 5, UserID: 2, StorageKey: "abcd"}
+	mockAttachments.EXPECT().GetAttachmentByID(uint(9)).Return(stored, nil).AnyTimes()
+	mockTasks.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil).AnyTimes()
+	mockProjects.EXPECT().GetMember(uint(3), uint(4)).Return(memberWithRole(models.ProjectRoleViewer), nil).AnyTimes()
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleEditor), nil).AnyTimes()
+
+	// Viewers can't delete other people's attachments
+	assert.ErrorIs(t, attachmentSvc.DeleteAttachment(9, 4), services.ErrAttachmentForbidden)
+
+	mockAttachments.EXPECT().DeleteAttachment(uint(9)).Return(nil)
+	require.NoError(t, attachmentSvc.DeleteAttachment(9, 1))
+	assert.Equal(t, 0, blobs.Len())
+}
+
+func TestOpenAttachment_HiddenFromNonMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	attachmentSvc := services.NewAttachmentService(mockAttachments, mockTasks, mockProjects, blobstore.NewMemoryStore(), attachmentLimits(), services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockAttachments.EXPECT().GetAttachmentByID(uint(9)).Return(&models.Attachment{ID: 9, TaskID: 5, StorageKey: "abcd"}, nil)
+	mockTasks.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(8)).Return(nil, gorm.ErrRecordNotFound)
+
+	_, _, err := attachmentSvc.OpenAttachment(9, 8)
+	assert.ErrorIs(t, err, services.ErrAttachmentNotFound)
+}
@@ -3,38 +3,96 @@ package services
 import (
 	"errors"
 	"fmt"
+	"log"
+	"net/url"
 	"time"
 
 	"TaskManager/internal/models"
 	"TaskManager/internal/repositories"
+	"TaskManager/pkg/mailer"
 	"TaskManager/pkg/utils"
 
 	"gorm.io/gorm"
 )
 
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used, please log in again")
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrInvalidResetToken   = errors.New("invalid or expired password reset token")
+)
+
+// MaxUserAgentLength caps the user agent recorded for a session
+const MaxUserAgentLength = 512
+
+// SessionClient describes the device a user logs in from
+type SessionClient struct {
+	UserAgent string
+	IP        string
+}
+
+// PasswordResetSettings configure password reset mails: how long their
+// links are valid and the page the links lead to
+type PasswordResetSettings struct {
+	TTL time.Duration
+	URL string
+}
+
+// AuthTokens are the tokens issued at login and on every refresh: a
+// short-lived access token (a JWT expiring at ExpiresAt) and an opaque
+// refresh token, good for one refresh
+type AuthTokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
 // AuthService interface defines the methods for authentication-related operations
 type AuthService interface {
-	RegisterUser(username, password, email string) (*models.User, string, error)
-	LoginUser(username, email, password string) (*models.User, string, error)
+	RegisterUser(username, password, email string, client SessionClient) (*models.User, *AuthTokens, error)
+	LoginUser(username, email, password string, client SessionClient) (*models.User, *AuthTokens, error)
+	RefreshTokens(refreshToken string) (*AuthTokens, error)
+	Logout(claims *utils.TokenClaims) error
+	LogoutEverywhere(userID uint) error
+	GetSessions(userID uint) ([]models.Session, error)
+	TerminateSession(id, userID uint) error
+	ForgotPassword(email string) error
+	ResetPassword(token, password string) error
+	VerifyEmail(token string) error
+	ResendVerification(userID uint) error
 }
 
 // AuthServiceImpl is the concrete implementation of the AuthService interface
 type AuthServiceImpl struct {
 	AuthRepo        repositories.UserRepository
+	TokenRepo       repositories.TokenRepository
+	Revocations     *TokenRevocationStore
 	HashPassword    func(string) (string, error)
 	ComparePassword func(string, string) error
-	GenerateJWT     func(uint, time.Duration) (string, error)
+	GenerateJWT     func(utils.AccessClaims, time.Duration) (string, error)
 	TokenTTL        time.Duration
+	RefreshTTL      time.Duration
+	Mailer          mailer.Mailer
+	Reset           PasswordResetSettings
+	Verification    EmailVerificationSettings
 }
 
-// NewAuthService creates and returns a new AuthService instance
-func NewAuthService(authRepo repositories.UserRepository) AuthService {
+// NewAuthService creates and returns a new AuthService instance issuing
+// access tokens valid for tokenTTL and refresh tokens valid for refreshTTL,
+// and sending password reset and email verification links through mail
+func NewAuthService(authRepo repositories.UserRepository, tokenRepo repositories.TokenRepository, revocations *TokenRevocationStore, mail mailer.Mailer, tokenTTL, refreshTTL time.Duration, reset PasswordResetSettings, verification EmailVerificationSettings) AuthService {
 	return &AuthServiceImpl{
 		AuthRepo:        authRepo,
+		TokenRepo:       tokenRepo,
+		Revocations:     revocations,
 		HashPassword:    utils.HashPassword,
 		ComparePassword: utils.ComparePasswords,
 		GenerateJWT:     utils.GenerateJWT,
-		TokenTTL:        24 * time.Hour,
+		TokenTTL:        tokenTTL,
+		RefreshTTL:      refreshTTL,
+		Mailer:          mail,
+		Reset:           reset,
+		Verification:    verification,
 	}
 }
 
@@ -61,17 +119,18 @@ func (s *AuthServiceImpl) userExists(username, email string) error {
 	return nil
 }
 
-// RegisterUser registers a new user, hashes their password, and returns a JWT token
-func (s *AuthServiceImpl) RegisterUser(username, password, email string) (*models.User, string, error) {
+// RegisterUser registers a new user, hashes their password, mails them a
+// link to verify their email and logs them in
+func (s *AuthServiceImpl) RegisterUser(username, password, email string, client SessionClient) (*models.User, *AuthTokens, error) {
 	// ensure username/email are not already in use
 	if err := s.userExists(username, email); err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	// hash password
 	hashedPassword, err := s.HashPassword(password)
 	if err != nil {
-		return nil, "", errors.New("failed to hash password")
+		return nil, nil, errors.New("failed to hash password")
 	}
 
 	// create and persist user
@@ -82,20 +141,25 @@ func (s *AuthServiceImpl) RegisterUser(username, password, email string) (*model
 	}
 	createdUser, err := s.AuthRepo.CreateUser(user)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to create user: %v", err)
+		return nil, nil, fmt.Errorf("failed to create user: %v", err)
 	}
 
-	// generate token
+	// the user can have the link resent, so a failed mail doesn't fail the registration
+	if err := s.sendVerification(createdUser); err != nil {
+		log.Println("Error sending verification mail:", err)
+	}
 
-	token, err := s.GenerateJWT(createdUser.ID, s.TokenTTL)
+	// issue tokens
+	tokens, err := s.startSession(createdUser, client)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to generate token: %v", err)
+		return nil, nil, err
 	}
-	return createdUser, token, nil
+	return createdUser, tokens, nil
 }
 
-// LoginUser authenticates a user and returns a JWT token
-func (s *AuthServiceImpl) LoginUser(username, email, password string) (*models.User, string, error) {
+// LoginUser authenticates a user and issues their tokens, starting a new
+// session on the client's device
+func (s *AuthServiceImpl) LoginUser(username, email, password string, client SessionClient) (*models.User, *AuthTokens, error) {
 	var (
 		user *models.User
 		err  error
@@ -109,18 +173,273 @@ func (s *AuthServiceImpl) LoginUser(username, email, password string) (*models.U
 	}
 
 	if err != nil || user == nil {
-		return nil, "", errors.New("invalid username/email or password")
+		return nil, nil, errors.New("invalid username/email or password")
 	}
 
 	// verify password
 	if err := s.ComparePassword(user.Password, password); err != nil {
-		return nil, "", errors.New("invalid username/email or password")
+		return nil, nil, errors.New("invalid username/email or password")
+	}
+
+	// issue tokens
+	tokens, err := s.startSession(user, client)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, tokens, nil
+}
+
+// signAccessToken generates an access token of a user's login, returning
+// it with its expiry (internal helper)
+func (s *AuthServiceImpl) signAccessToken(user *models.User, familyID string) (*AuthTokens, error) {
+	expiresAt := time.Now().Add(s.TokenTTL)
+	claims := utils.AccessClaims{UserID: user.ID, SessionID: familyID, Version: user.TokenVersion, EmailVerified: user.EmailVerified}
+	token, err := s.GenerateJWT(claims, s.TokenTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %v", err)
+	}
+	return &AuthTokens{AccessToken: token, ExpiresAt: expiresAt}, nil
+}
+
+// newRefreshToken builds the next refresh token of a family, returning its
+// value and the record storing its hash (internal helper)
+func (s *AuthServiceImpl) newRefreshToken(userID uint, familyID string) (string, *models.RefreshToken, error) {
+	value, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return "", nil, err
+	}
+	return value, &models.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: utils.HashToken(value),
+		ExpiresAt: time.Now().Add(s.RefreshTTL),
+	}, nil
+}
+
+// startSession records a new session of a user and issues its tokens, with
+// a refresh token starting the session's family (internal helper)
+func (s *AuthServiceImpl) startSession(user *models.User, client SessionClient) (*AuthTokens, error) {
+	familyID, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
 	}
+	tokens, err := s.signAccessToken(user, familyID)
+	if err != nil {
+		return nil, err
+	}
+	value, refreshToken, err := s.newRefreshToken(user.ID, familyID)
+	if err != nil {
+		return nil, err
+	}
+	userAgent := client.UserAgent
+	if runes := []rune(userAgent); len(runes) > MaxUserAgentLength {
+		userAgent = string(runes[:MaxUserAgentLength])
+	}
+	session := &models.Session{
+		UserID:     user.ID,
+		FamilyID:   familyID,
+		UserAgent:  userAgent,
+		IP:         client.IP,
+		LastSeenAt: time.Now(),
+	}
+	if _, err := s.TokenRepo.CreateSession(session, refreshToken); err != nil {
+		return nil, fmt.Errorf("failed to store session: %v", err)
+	}
+	tokens.RefreshToken = value
+	return tokens, nil
+}
+
+// revokeFamily terminates the session of a refresh token family once one
+// of its tokens is traded in a second time: either the token leaked or the
+// client replayed it, and there's no telling the thief from the user
+// (internal helper)
+func (s *AuthServiceImpl) revokeFamily(familyID string) error {
+	if err := s.Revocations.RevokeSession(familyID); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
 
-	// generate token
-	token, err := s.GenerateJWT(user.ID, s.TokenTTL)
+// RefreshTokens trades a refresh token in for a new access token and a new
+// refresh token of the same family. Trading in a token that was already
+// used revokes its whole family, logging out whoever holds the newer one.
+func (s *AuthServiceImpl) RefreshTokens(refreshToken string) (*AuthTokens, error) {
+	used, err := s.TokenRepo.GetRefreshTokenByHash(utils.HashToken(refreshToken))
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to generate token: %v", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("unexpected error fetching refresh token: %v", err)
+	}
+	switch {
+	case used.RevokedAt != nil:
+		return nil, ErrInvalidRefreshToken
+	case used.UsedAt != nil:
+		return nil, s.revokeFamily(used.FamilyID)
+	case !time.Now().Before(used.ExpiresAt):
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Deleted users can't renew their tokens
+	user, err := s.AuthRepo.GetUserByID(used.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("unexpected error fetching user: %v", err)
+	}
+
+	tokens, err := s.signAccessToken(user, used.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	value, next, err := s.newRefreshToken(used.UserID, used.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.TokenRepo.RotateRefreshToken(used.ID, next); err != nil {
+		// Traded in by a concurrent request
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, s.revokeFamily(used.FamilyID)
+		}
+		return nil, fmt.Errorf("unexpected error rotating refresh token: %v", err)
+	}
+	tokens.RefreshToken = value
+	return tokens, nil
+}
+
+// Logout revokes the access token it is given and terminates its session,
+// so the session's refresh token can't renew it
+func (s *AuthServiceImpl) Logout(claims *utils.TokenClaims) error {
+	if err := s.Revocations.Revoke(claims); err != nil {
+		return err
+	}
+	if claims.SessionID == "" {
+		return nil
+	}
+	return s.Revocations.RevokeSession(claims.SessionID)
+}
+
+// LogoutEverywhere terminates every session of a user: their token version
+// is bumped, revoking all access tokens issued so far, and their refresh
+// tokens are revoked
+func (s *AuthServiceImpl) LogoutEverywhere(userID uint) error {
+	if _, err := s.Revocations.RevokeAll(userID); err != nil {
+		return err
+	}
+	if err := s.TokenRepo.RevokeUserTokenFamilies(userID); err != nil {
+		return fmt.Errorf("unexpected error revoking refresh tokens: %v", err)
+	}
+	return nil
+}
+
+// GetSessions retrieves the sessions a user is logged in with, most
+// recently seen first
+func (s *AuthServiceImpl) GetSessions(userID uint) ([]models.Session, error) {
+	sessions, err := s.TokenRepo.GetActiveSessions(userID)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error fetching sessions: %v", err)
+	}
+	return sessions, nil
+}
+
+// TerminateSession logs a user out of one of their sessions, revoking the
+// tokens issued to it
+func (s *AuthServiceImpl) TerminateSession(id, userID uint) error {
+	session, err := s.TokenRepo.GetSession(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSessionNotFound
+		}
+		return fmt.Errorf("unexpected error fetching session: %v", err)
+	}
+	if session.UserID != userID {
+		return ErrSessionNotFound
+	}
+	return s.Revocations.RevokeSession(session.FamilyID)
+}
+
+// tokenLink appends a token to a link as its "token" query parameter
+// (internal helper)
+func tokenLink(link, token string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return link + "?token=" + url.QueryEscape(token)
+	}
+	query := u.Query()
+	query.Set("token", token)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// ForgotPassword mails a password reset link to the user with the given
+// email. Unknown emails are no error, so callers can't tell which emails
+// have an account.
+func (s *AuthServiceImpl) ForgotPassword(email string) error {
+	user, err := s.AuthRepo.GetUserByEmail(email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("unexpected error fetching user: %v", err)
+	}
+	if user == nil {
+		return nil
+	}
+
+	value, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+	expiresAt := time.Now().Add(s.Reset.TTL)
+	_, err = s.TokenRepo.CreatePasswordResetToken(&models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(value),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("unexpected error storing password reset token: %v", err)
+	}
+
+	err = s.Mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your TaskManager password",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"someone asked to reset the password of your TaskManager account. "+
+			"To choose a new password, open this link before %s:\n\n%s\n\n"+
+			"If that wasn't you, ignore this mail and your password stays the same.\n",
+			user.Username, expiresAt.UTC().Format("2 Jan 2006 15:04 MST"), tokenLink(s.Reset.URL, value)),
+	})
+	if err != nil {
+		return fmt.Errorf("unexpected error sending password reset mail: %v", err)
+	}
+	return nil
+}
+
+// ResetPassword sets a new password with a password reset token, which can
+// be used only once. The user is logged out of every session.
+func (s *AuthServiceImpl) ResetPassword(token, password string) error {
+	reset, err := s.TokenRepo.GetPasswordResetTokenByHash(utils.HashToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidResetToken
+		}
+		return fmt.Errorf("unexpected error fetching password reset token: %v", err)
+	}
+	if reset.UsedAt != nil || !time.Now().Before(reset.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	hashedPassword, err := s.HashPassword(password)
+	if err != nil {
+		return errors.New("failed to hash password")
+	}
+	if err := s.TokenRepo.ResetPassword(reset.ID, reset.UserID, hashedPassword); err != nil {
+		// Used by a concurrent request, expired meanwhile or the user is gone
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidResetToken
+		}
+		return fmt.Errorf("unexpected error resetting password: %v", err)
 	}
-	return user, token, nil
+	return s.LogoutEverywhere(reset.UserID)
 }
@@ -4,12 +4,16 @@ import (
 	"TaskManager/internal/models"
 	"TaskManager/internal/services"
 	"TaskManager/mocks"
+	"TaskManager/pkg/mailer"
+	"TaskManager/pkg/utils"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
 
@@ -18,25 +22,96 @@ func TestRegisterUser_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockTokenRepository(ctrl)
+	mail := mailer.NewMemoryMailer()
 	svc := &services.AuthServiceImpl{
 		AuthRepo:     mockRepo,
+		TokenRepo:    mockTokens,
 		HashPassword: func(pw string) (string, error) { return "hashedPw", nil },
-		GenerateJWT:  func(id uint, ttl time.Duration) (string, error) { return "signedToken", nil },
+		GenerateJWT:  func(claims utils.AccessClaims, ttl time.Duration) (string, error) { return "signedToken", nil },
 		TokenTTL:     time.Hour,
+		RefreshTTL:   24 * time.Hour,
+		Mailer:       mail,
+		Verification: services.EmailVerificationSettings{TTL: time.Hour, URL: "https://api.example.com/auth/verify"},
 	}
 
 	mockRepo.EXPECT().GetUserByUsername("john").Return(nil, gorm.ErrRecordNotFound)
 	mockRepo.EXPECT().GetUserByEmail("john@example.com").Return(nil, gorm.ErrRecordNotFound)
 	mockRepo.EXPECT().CreateUser(gomock.Any()).DoAndReturn(
-		func(u *models.User) (*models.User, error) { return u, nil },
+		func(u *models.User) (*models.User, error) {
+			u.ID = 1
+			return u, nil
+		},
+	)
+	var verification *models.EmailVerificationToken
+	mockTokens.EXPECT().CreateEmailVerificationToken(gomock.Any()).DoAndReturn(
+		func(token *models.EmailVerificationToken) (*models.EmailVerificationToken, error) {
+			verification = token
+			return token, nil
+		},
+	)
+	mockTokens.EXPECT().CreateSession(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(session *models.Session, token *models.RefreshToken) (*models.Session, error) {
+			return session, nil
+		},
 	)
 
-	user, token, err := svc.RegisterUser("john", "pass123", "john@example.com")
+	user, tokens, err := svc.RegisterUser("john", "pass123", "john@example.com", services.SessionClient{})
 	assert.NoError(t, err)
-	assert.Equal(t, "signedToken", token)
+	assert.Equal(t, "signedToken", tokens.AccessToken)
+	assert.NotEmpty(t, tokens.RefreshToken)
 	assert.Equal(t, "john", user.Username)
 	assert.Equal(t, "john@example.com", user.Email)
 	assert.Equal(t, "hashedPw", user.Password)
+	assert.False(t, user.EmailVerified)
+
+	// A link verifying the email is mailed
+	sent := mail.Sent()
+	require.Len(t, sent, 1)
+	assert.Equal(t, "john@example.com", sent[0].To)
+	require.NotNil(t, verification)
+	assert.Equal(t, uint(1), verification.UserID)
+	assert.Equal(t, "john@example.com", verification.Email)
+	assert.Contains(t, sent[0].Body, "https://api.example.com/auth/verify?token=")
+}
+
+func TestRegisterUser_MailError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockTokenRepository(ctrl)
+	svc := &services.AuthServiceImpl{
+		AuthRepo:     mockRepo,
+		TokenRepo:    mockTokens,
+		HashPassword: func(pw string) (string, error) { return "hashedPw", nil },
+		GenerateJWT:  func(claims utils.AccessClaims, ttl time.Duration) (string, error) { return "signedToken", nil },
+		TokenTTL:     time.Hour,
+		RefreshTTL:   24 * time.Hour,
+		Mailer:       mailer.NewMemoryMailer(),
+	}
+
+	mockRepo.EXPECT().GetUserByUsername("john").Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.EXPECT().GetUserByEmail("john@example.com").Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.EXPECT().CreateUser(gomock.Any()).DoAndReturn(
+		func(u *models.User) (*models.User, error) {
+			u.ID = 1
+			return u, nil
+		},
+	)
+
+	// The user is registered even though the verification link can't be sent
+	mockTokens.EXPECT().CreateEmailVerificationToken(gomock.Any()).Return(nil, errors.New("connection reset"))
+	mockTokens.EXPECT().CreateSession(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(session *models.Session, token *models.RefreshToken) (*models.Session, error) {
+			return session, nil
+		},
+	)
+
+	user, tokens, err := svc.RegisterUser("john", "pass123", "john@example.com", services.SessionClient{})
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), user.ID)
+	assert.Equal(t, "signedToken", tokens.AccessToken)
 }
 
 func TestRegisterUser_DuplicateUsername(t *testing.T) {
@@ -48,10 +123,10 @@ func TestRegisterUser_DuplicateUsername(t *testing.T) {
 
 	mockRepo.EXPECT().GetUserByUsername("john").Return(&models.User{Username: "john"}, nil)
 
-	user, token, err := svc.RegisterUser("john", "pass123", "john@example.com")
+	user, tokens, err := svc.RegisterUser("john", "pass123", "john@example.com", services.SessionClient{})
 	assert.Error(t, err)
 	assert.Nil(t, user)
-	assert.Empty(t, token)
+	assert.Nil(t, tokens)
 	assert.Equal(t, "username already taken", err.Error())
 }
 
@@ -65,10 +140,10 @@ func TestRegisterUser_DuplicateEmail(t *testing.T) {
 	mockRepo.EXPECT().GetUserByUsername("john").Return(nil, gorm.ErrRecordNotFound)
 	mockRepo.EXPECT().GetUserByEmail("john@example.com").Return(&models.User{Email: "john@example.com"}, nil)
 
-	user, token, err := svc.RegisterUser("john", "pass123", "john@example.com")
+	user, tokens, err := svc.RegisterUser("john", "pass123", "john@example.com", services.SessionClient{})
 	assert.Error(t, err)
 	assert.Nil(t, user)
-	assert.Empty(t, token)
+	assert.Nil(t, tokens)
 	assert.Equal(t, "email already registered", err.Error())
 }
 
@@ -85,10 +160,10 @@ func TestRegisterUser_HashError(t *testing.T) {
 	mockRepo.EXPECT().GetUserByUsername("john").Return(nil, gorm.ErrRecordNotFound)
 	mockRepo.EXPECT().GetUserByEmail("john@example.com").Return(nil, gorm.ErrRecordNotFound)
 
-	user, token, err := svc.RegisterUser("john", "pass123", "john@example.com")
+	user, tokens, err := svc.RegisterUser("john", "pass123", "john@example.com", services.SessionClient{})
 	assert.Error(t, err)
 	assert.Nil(t, user)
-	assert.Empty(t, token)
+	assert.Nil(t, tokens)
 	assert.Equal(t, "failed to hash password", err.Error())
 }
 
@@ -97,21 +172,49 @@ func TestLoginUser_Success_ByUsername(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockTokenRepository(ctrl)
 	svc := &services.AuthServiceImpl{
 		AuthRepo:        mockRepo,
+		TokenRepo:       mockTokens,
 		ComparePassword: func(hash, pw string) error { return nil },
-		GenerateJWT:     func(id uint, ttl time.Duration) (string, error) { return "jwtToken", nil },
+		GenerateJWT:     func(claims utils.AccessClaims, ttl time.Duration) (string, error) { return "jwtToken", nil },
 		TokenTTL:        time.Hour,
+		RefreshTTL:      24 * time.Hour,
 	}
 
-	stored := &models.User{Username: "john", Password: "hashed"}
+	stored := &models.User{Model: gorm.Model{ID: 1}, Username: "john", Password: "hashed"}
 	mockRepo.EXPECT().GetUserByEmail(gomock.Any()).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
 	mockRepo.EXPECT().GetUserByUsername("john").Return(stored, nil)
 
-	user, token, err := svc.LoginUser("john", "", "pass123")
+	// A session is recorded with the first token of its family, of which
+	// only the hash is stored
+	var session *models.Session
+	var saved *models.RefreshToken
+	mockTokens.EXPECT().CreateSession(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(newSession *models.Session, token *models.RefreshToken) (*models.Session, error) {
+			session, saved = newSession, token
+			return newSession, nil
+		},
+	)
+
+	client := services.SessionClient{UserAgent: "Mozilla/5.0 " + strings.Repeat("x", services.MaxUserAgentLength), IP: "203.0.113.7"}
+	user, tokens, err := svc.LoginUser("john", "", "pass123", client)
 	assert.NoError(t, err)
 	assert.Equal(t, stored, user)
-	assert.Equal(t, "jwtToken", token)
+	assert.Equal(t, "jwtToken", tokens.AccessToken)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), tokens.ExpiresAt, time.Minute)
+	require.NotNil(t, saved)
+	assert.Equal(t, uint(1), saved.UserID)
+	assert.NotEmpty(t, saved.FamilyID)
+	assert.Equal(t, utils.HashToken(tokens.RefreshToken), saved.TokenHash)
+	assert.NotEqual(t, tokens.RefreshToken, saved.TokenHash)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), saved.ExpiresAt, time.Minute)
+	require.NotNil(t, session)
+	assert.Equal(t, uint(1), session.UserID)
+	assert.Equal(t, saved.FamilyID, session.FamilyID)
+	assert.Equal(t, client.UserAgent[:services.MaxUserAgentLength], session.UserAgent)
+	assert.Equal(t, "203.0.113.7", session.IP)
+	assert.WithinDuration(t, time.Now(), session.LastSeenAt, time.Minute)
 }
 
 func TestLoginUser_Fail_InvalidCredentials(t *testing.T) {
@@ -124,10 +227,10 @@ func TestLoginUser_Fail_InvalidCredentials(t *testing.T) {
 	mockRepo.EXPECT().GetUserByEmail(gomock.Any()).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
 	mockRepo.EXPECT().GetUserByUsername("john").Return(nil, gorm.ErrRecordNotFound)
 
-	user, token, err := svc.LoginUser("john", "", "pass123")
+	user, tokens, err := svc.LoginUser("john", "", "pass123", services.SessionClient{})
 	assert.Error(t, err)
 	assert.Nil(t, user)
-	assert.Empty(t, token)
+	assert.Nil(t, tokens)
 }
 
 func TestLoginUser_Fail_BadPassword(t *testing.T) {
@@ -144,10 +247,10 @@ func TestLoginUser_Fail_BadPassword(t *testing.T) {
 	mockRepo.EXPECT().GetUserByEmail(gomock.Any()).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
 	mockRepo.EXPECT().GetUserByUsername("john").Return(stored, nil)
 
-	user, token, err := svc.LoginUser("john", "", "pass123")
+	user, tokens, err := svc.LoginUser("john", "", "pass123", services.SessionClient{})
 	assert.Error(t, err)
 	assert.Nil(t, user)
-	assert.Empty(t, token)
+	assert.Nil(t, tokens)
 }
 
 func TestLoginUser_JWTError(t *testing.T) {
@@ -159,13 +262,269 @@ func TestLoginUser_JWTError(t *testing.T) {
 	svc := &services.AuthServiceImpl{
 		AuthRepo:        mockRepo,
 		ComparePassword: func(hash, pw string) error { return nil },
-		GenerateJWT:     func(id uint, ttl time.Duration) (string, error) { return "", errors.New("jwt fail") },
+		GenerateJWT:     func(claims utils.AccessClaims, ttl time.Duration) (string, error) { return "", errors.New("jwt fail") },
 	}
 
 	mockRepo.EXPECT().GetUserByEmail("john@example.com").Return(stored, nil)
 
-	user, token, err := svc.LoginUser("", "john@example.com", "pass123")
+	user, tokens, err := svc.LoginUser("", "john@example.com", "pass123", services.SessionClient{})
 	assert.Error(t, err)
 	assert.Nil(t, user)
-	assert.Empty(t, token)
+	assert.Nil(t, tokens)
+}
+
+// refreshTokenRecord is the stored, unused refresh token "refresh-me" of user 1
+func refreshTokenRecord() *models.RefreshToken {
+	return &models.RefreshToken{
+		ID:        3,
+		UserID:    1,
+		FamilyID:  "family",
+		TokenHash: utils.HashToken("refresh-me"),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+func TestRefreshTokens_Rotates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockTokenRepository(ctrl)
+	var signed utils.AccessClaims
+	svc := &services.AuthServiceImpl{
+		AuthRepo:  mockRepo,
+		TokenRepo: mockTokens,
+		GenerateJWT: func(claims utils.AccessClaims, ttl time.Duration) (string, error) {
+			signed = claims
+			return "jwtToken", nil
+		},
+		TokenTTL:   time.Hour,
+		RefreshTTL: 24 * time.Hour,
+	}
+
+	var next *models.RefreshToken
+	gomock.InOrder(
+		mockTokens.EXPECT().GetRefreshTokenByHash(utils.HashToken("refresh-me")).Return(refreshTokenRecord(), nil),
+		mockRepo.EXPECT().GetUserByID(uint(1)).Return(&models.User{Model: gorm.Model{ID: 1}, TokenVersion: 2, EmailVerified: true}, nil),
+		mockTokens.EXPECT().RotateRefreshToken(uint(3), gomock.Any()).DoAndReturn(
+			func(usedID uint, token *models.RefreshToken) (*models.RefreshToken, error) {
+				next = token
+				return token, nil
+			},
+		),
+	)
+
+	tokens, err := svc.RefreshTokens("refresh-me")
+	require.NoError(t, err)
+	assert.Equal(t, "jwtToken", tokens.AccessToken)
+	// The new access token carries the user's current state
+	assert.Equal(t, utils.AccessClaims{UserID: 1, SessionID: "family", Version: 2, EmailVerified: true}, signed)
+	assert.NotEqual(t, "refresh-me", tokens.RefreshToken)
+	// The new token continues the family
+	require.NotNil(t, next)
+	assert.Equal(t, "family", next.FamilyID)
+	assert.Equal(t, uint(1), next.UserID)
+	assert.Equal(t, utils.HashToken(tokens.RefreshToken), next.TokenHash)
+}
+
+func TestRefreshTokens_ReuseRevokesFamily(t *testing.T) {
+	tests := []struct {
+		name   string
+		used   bool
+		rotate error
+	}{
+		{name: "token used before", used: true},
+		{name: "token used concurrently", rotate: gorm.ErrRecordNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockUserRepository(ctrl)
+			mockTokens := mocks.NewMockTokenRepository(ctrl)
+			svc := &services.AuthServiceImpl{
+				AuthRepo:    mockRepo,
+				TokenRepo:   mockTokens,
+				Revocations: services.NewTokenRevocationStore(mockTokens, time.Minute, time.Minute),
+				GenerateJWT: func(claims utils.AccessClaims, ttl time.Duration) (string, error) { return "jwtToken", nil },
+				TokenTTL:    time.Hour,
+				RefreshTTL:  24 * time.Hour,
+			}
+
+			record := refreshTokenRecord()
+			if tt.used {
+				usedAt := time.Now().Add(-time.Minute)
+				record.UsedAt = &usedAt
+			}
+			mockTokens.EXPECT().GetRefreshTokenByHash(utils.HashToken("refresh-me")).Return(record, nil)
+			if tt.rotate != nil {
+				mockRepo.EXPECT().GetUserByID(uint(1)).Return(&models.User{Model: gorm.Model{ID: 1}}, nil)
+				mockTokens.EXPECT().RotateRefreshToken(uint(3), gomock.Any()).Return(nil, tt.rotate)
+			}
+			mockTokens.EXPECT().RevokeTokenFamily("family").Return(nil)
+
+			tokens, err := svc.RefreshTokens("refresh-me")
+			assert.ErrorIs(t, err, services.ErrRefreshTokenReused)
+			assert.Nil(t, tokens)
+		})
+	}
+}
+
+func TestRefreshTokens_Invalid(t *testing.T) {
+	revokedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name   string
+		record *models.RefreshToken
+		err    error
+		user   error
+	}{
+		{name: "unknown token", err: gorm.ErrRecordNotFound},
+		{name: "revoked family", record: &models.RefreshToken{ID: 3, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}},
+		{name: "expired", record: &models.RefreshToken{ID: 3, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(-time.Second)}},
+		{name: "deleted user", record: refreshTokenRecord(), user: gorm.ErrRecordNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Nothing is rotated or revoked
+			mockRepo := mocks.NewMockUserRepository(ctrl)
+			mockTokens := mocks.NewMockTokenRepository(ctrl)
+			svc := &services.AuthServiceImpl{AuthRepo: mockRepo, TokenRepo: mockTokens}
+
+			mockTokens.EXPECT().GetRefreshTokenByHash(utils.HashToken("refresh-me")).Return(tt.record, tt.err)
+			if tt.user != nil {
+				mockRepo.EXPECT().GetUserByID(uint(1)).Return(nil, tt.user)
+			}
+
+			tokens, err := svc.RefreshTokens("refresh-me")
+			assert.ErrorIs(t, err, services.ErrInvalidRefreshToken)
+			assert.Nil(t, tokens)
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTokens := mocks.NewMockTokenRepository(ctrl)
+	svc := &services.AuthServiceImpl{
+		TokenRepo:   mockTokens,
+		Revocations: services.NewTokenRevocationStore(mockTokens, time.Minute, time.Minute),
+	}
+
+	claims := &utils.TokenClaims{
+		AccessClaims: utils.AccessClaims{UserID: 1, SessionID: "family"},
+		ID:           "jti",
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}
+	gomock.InOrder(
+		mockTokens.EXPECT().RevokeAccessToken(&models.RevokedToken{ID: "jti", UserID: 1, ExpiresAt: claims.ExpiresAt}).Return(nil),
+		mockTokens.EXPECT().RevokeTokenFamily("family").Return(nil),
+	)
+
+	require.NoError(t, svc.Logout(claims))
+
+	// The revocations are cached: checking the token only reads the version
+	mockTokens.EXPECT().GetTokenVersion(uint(1)).Return(0, nil)
+	revoked, err := svc.Revocations.IsRevoked(claims)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestLogoutEverywhere(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTokens := mocks.NewMockTokenRepository(ctrl)
+	svc := &services.AuthServiceImpl{
+		TokenRepo:   mockTokens,
+		Revocations: services.NewTokenRevocationStore(mockTokens, time.Minute, time.Minute),
+	}
+
+	gomock.InOrder(
+		mockTokens.EXPECT().IncrementTokenVersion(uint(1)).Return(3, nil),
+		mockTokens.EXPECT().RevokeUserTokenFamilies(uint(1)).Return(nil),
+	)
+
+	require.NoError(t, svc.LogoutEverywhere(1))
+
+	// Tokens of older versions are revoked without asking the database
+	revoked, err := svc.Revocations.IsRevoked(&utils.TokenClaims{AccessClaims: utils.AccessClaims{UserID: 1, Version: 2}, ID: "jti"})
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestGetSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTokens := mocks.NewMockTokenRepository(ctrl)
+	svc := &services.AuthServiceImpl{TokenRepo: mockTokens}
+
+	found := []models.Session{{ID: 4, UserID: 1, FamilyID: "family"}}
+	mockTokens.EXPECT().GetActiveSessions(uint(1)).Return(found, nil)
+
+	sessions, err := svc.GetSessions(1)
+	require.NoError(t, err)
+	assert.Equal(t, found, sessions)
+}
+
+func TestTerminateSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTokens := mocks.NewMockTokenRepository(ctrl)
+	svc := &services.AuthServiceImpl{
+		TokenRepo:   mockTokens,
+		Revocations: services.NewTokenRevocationStore(mockTokens, time.Minute, time.Minute),
+	}
+
+	gomock.InOrder(
+		mockTokens.EXPECT().GetSession(uint(4)).Return(&models.Session{ID: 4, UserID: 1, FamilyID: "family"}, nil),
+		mockTokens.EXPECT().RevokeTokenFamily("family").Return(nil),
+	)
+
+	require.NoError(t, svc.TerminateSession(4, 1))
+
+	// Access tokens of the session are rejected at once
+	mockTokens.EXPECT().GetTokenVersion(uint(1)).Return(0, nil)
+	revoked, err := svc.Revocations.IsRevoked(&utils.TokenClaims{AccessClaims: utils.AccessClaims{UserID: 1, SessionID: "family"}, ID: "jti"})
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestTerminateSession_NotFound(t *testing.T) {
+	tests := []struct {
+		name    string
+		session *models.Session
+		err     error
+	}{
+		{name: "unknown or terminated session", err: gorm.ErrRecordNotFound},
+		{name: "session of another user", session: &models.Session{ID: 4, UserID: 2, FamilyID: "family"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Nothing is revoked
+			mockTokens := mocks.NewMockTokenRepository(ctrl)
+			svc := &services.AuthServiceImpl{
+				TokenRepo:   mockTokens,
+				Revocations: services.NewTokenRevocationStore(mockTokens, time.Minute, time.Minute),
+			}
+
+			mockTokens.EXPECT().GetSession(uint(4)).Return(tt.session, tt.err)
+
+			assert.ErrorIs(t, svc.TerminateSession(4, 1), services.ErrSessionNotFound)
+		})
+	}
 }
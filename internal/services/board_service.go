@@ -0,0 +1,285 @@
+// internal/services/board_service.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrBoardNotFound         = errors.New("board not found")
+	ErrBoardNameRequired     = errors.New("board name is required")
+	ErrBoardColumnsRequired  = errors.New("a board needs at least one column")
+	ErrColumnNameRequired    = errors.New("column name is required")
+	ErrDuplicateColumnStatus = errors.New("two columns of a board can't show the same status")
+	ErrInvalidWipLimit       = errors.New("WIP limit can't be negative")
+	ErrColumnNotFound        = errors.New("column not found")
+	ErrWipLimitReached       = repositories.ErrWipLimitReached
+	ErrCardMoved             = repositories.ErrTaskStatusChanged
+)
+
+// BoardColumnCards is a board column together with its cards in rank order
+type BoardColumnCards struct {
+	Column models.BoardColumn
+	Cards  []models.Task
+}
+
+// BoardView is a board with the project's tasks laid out in its columns
+type BoardView struct {
+	Board   models.Board
+	Columns []BoardColumnCards
+}
+
+// BoardService interface defines the methods for board-related business operations
+type BoardService interface {
+	CreateBoard(board *models.Board, userID uint) (*models.Board, error)
+	GetBoardByID(id, userID uint) (*models.Board, error)
+	GetBoardView(id, userID uint) (*BoardView, error)
+	GetBoardsByProject(projectID, userID uint) ([]models.Board, error)
+	UpdateBoard(board *models.Board, userID uint) (*models.Board, error)
+	DeleteBoard(id, userID uint) error
+	MoveCard(boardID, taskID, columnID uint, position int, userID uint) (*models.Task, error)
+}
+
+// BoardServiceImpl is the concrete implementation of the BoardService interface
+type BoardServiceImpl struct {
+	BoardRepo   repositories.BoardRepository
+	TaskRepo    repositories.TaskRepository
+	ProjectRepo repositories.ProjectRepository
+
+	// tasks gives access to the task rules (blockers, progress roll-up) a move must respect
+	tasks *TaskServiceImpl
+}
+
+// NewBoardService creates and returns a new BoardService instance
+func NewBoardService(boardRepo repositories.BoardRepository, taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository, tasks *TaskServiceImpl) BoardService {
+	return &BoardServiceImpl{
+		BoardRepo:   boardRepo,
+		TaskRepo:    taskRepo,
+		ProjectRepo: projectRepo,
+		tasks:       tasks,
+	}
+}
+
+// validateBoard normalises and checks a board and its columns against the
+// project's workflow (internal helper). Columns are positioned in the order
+// they are given.
+func validateBoard(board *models.Board, workflow *models.Workflow) error {
+	board.Name = strings.TrimSpace(board.Name)
+	if board.Name == "" {
+		return ErrBoardNameRequired
+	}
+	if len(board.Columns) == 0 {
+		return ErrBoardColumnsRequired
+	}
+
+	seen := map[string]bool{}
+	for i := range board.Columns {
+		column := &board.Columns[i]
+		column.Name = strings.TrimSpace(column.Name)
+		if column.Name == "" {
+			return ErrColumnNameRequired
+		}
+		if workflowState(workflow, column.Status) == nil {
+			return ErrInvalidTaskStatus
+		}
+		if seen[column.Status] {
+			return ErrDuplicateColumnStatus
+		}
+		seen[column.Status] = true
+		if column.WipLimit < 0 {
+			return ErrInvalidWipLimit
+		}
+		column.Position = i
+	}
+	return nil
+}
+
+// sortCards orders tasks by board rank, falling back to ID for equal ranks
+func sortCards(tasks []models.Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Rank != tasks[j].Rank {
+			return tasks[i].Rank < tasks[j].Rank
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+}
+
+// loadBoard fetches a board and makes sure the user holds minRole in its project (internal helper)
+func (s *BoardServiceImpl) loadBoard(id, userID uint, minRole string) (*models.Board, error) {
+	board, err := s.BoardRepo.GetBoardByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, fmt.Errorf("unexpected error fetching board: %v", err)
+	}
+
+	_, err = requireProjectRole(s.ProjectRepo, board.ProjectID, userID, minRole)
+	if errors.Is(err, ErrProjectNotFound) {
+		// Boards of foreign projects are reported as missing so IDs can't be probed
+		return nil, ErrBoardNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// CreateBoard validates and persists a new board; project editors only
+func (s *BoardServiceImpl) CreateBoard(board *models.Board, userID uint) (*models.Board, error) {
+	if _, err := requireProjectRole(s.ProjectRepo, board.ProjectID, userID, models.ProjectRoleEditor); err != nil {
+		return nil, err
+	}
+	workflow, err := loadWorkflow(s.ProjectRepo, &board.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateBoard(board, workflow); err != nil {
+		return nil, err
+	}
+
+	createdBoard, err := s.BoardRepo.CreateBoard(board)
+	if err != nil {
+		log.Println("Error creating board:", err)
+		return nil, fmt.Errorf("failed to create board: %v", err)
+	}
+	return createdBoard, nil
+}
+
+// GetBoardByID retrieves a board and its columns if the user may see it
+func (s *BoardServiceImpl) GetBoardByID(id, userID uint) (*models.Board, error) {
+	return s.loadBoard(id, userID, models.ProjectRoleViewer)
+}
+
+// GetBoardView retrieves a board with each column's cards in rank order.
+// Tasks whose status has no column are not shown.
+func (s *BoardServiceImpl) GetBoardView(id, userID uint) (*BoardView, error) {
+	board, err := s.loadBoard(id, userID, models.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.TaskRepo.GetTasksByProjectID(board.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	sortCards(tasks)
+
+	byStatus := map[string][]models.Task{}
+	for _, task := range tasks {
+		byStatus[task.Status] = append(byStatus[task.Status], task)
+	}
+
+	view := &BoardView{Board: *board, Columns: make([]BoardColumnCards, 0, len(board.Columns))}
+	for _, column := range board.Columns {
+		view.Columns = append(view.Columns, BoardColumnCards{
+			Column: column,
+			Cards:  byStatus[column.Status],
+		})
+	}
+	return view, nil
+}
+
+// GetBoardsByProject lists the boards of a project the user belongs to
+func (s *BoardServiceImpl) GetBoardsByProject(projectID, userID uint) ([]models.Board, error) {
+	if _, err := requireProjectRole(s.ProjectRepo, projectID, userID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+	return s.BoardRepo.GetBoardsByProjectID(projectID)
+}
+
+// UpdateBoard saves a board's name and column layout; project editors only
+func (s *BoardServiceImpl) UpdateBoard(board *models.Board, userID uint) (*models.Board, error) {
+	existing, err := s.loadBoard(board.ID, userID, models.ProjectRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Boards can't be moved between projects
+	board.ProjectID = existing.ProjectID
+	workflow, err := loadWorkflow(s.ProjectRepo, &board.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateBoard(board, workflow); err != nil {
+		return nil, err
+	}
+	return s.BoardRepo.UpdateBoard(board)
+}
+
+// DeleteBoard deletes a board; its tasks stay in the project
+func (s *BoardServiceImpl) DeleteBoard(id, userID uint) error {
+	if _, err := s.loadBoard(id, userID, models.ProjectRoleEditor); err != nil {
+		return err
+	}
+	return s.BoardRepo.DeleteBoard(id)
+}
+
+// MoveCard moves a task into a column at the given position (0 is the top).
+// Entering a column checks its WIP limit and, like any status change, the
+// project workflow and the task's blockers. The move is saved in one
+// transaction that re-checks the WIP limit, ranks the card among the column's
+// current cards and fails with ErrCardMoved if the task changed status meanwhile.
+func (s *BoardServiceImpl) MoveCard(boardID, taskID, columnID uint, position int, userID uint) (*models.Task, error) {
+	board, err := s.loadBoard(boardID, userID, models.ProjectRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	var column *models.BoardColumn
+	for i := range board.Columns {
+		if board.Columns[i].ID == columnID {
+			column = &board.Columns[i]
+			break
+		}
+	}
+	if column == nil {
+		return nil, ErrColumnNotFound
+	}
+
+	task, err := s.TaskRepo.GetTaskByID(taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, fmt.Errorf("unexpected error fetching task: %v", err)
+	}
+	if task.ProjectID == nil || *task.ProjectID != board.ProjectID {
+		return nil, ErrTaskNotFound
+	}
+
+	before := *task
+	task.Status = column.Status
+	if err := s.tasks.applyWorkflow(task, &before, userID); err != nil {
+		return nil, err
+	}
+
+	subtasks, err := s.TaskRepo.GetSubtasks([]uint{task.ID})
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error fetching subtasks: %v", err)
+	}
+
+	task.Progress = computeProgress(task, subtasks)
+	next, err := s.tasks.handOverRecurrence(task, &before)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.TaskRepo.MoveTaskCard(task, before.Status, position); err != nil {
+		return nil, err
+	}
+	if err := s.tasks.rollUpProgress(task.ParentID); err != nil {
+		log.Println("Error rolling up task progress:", err)
+	}
+	if err := s.tasks.scheduleOccurrence(next); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
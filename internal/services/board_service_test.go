@@ -0,0 +1,240 @@
+package services_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// testBoard is a three-column board of project 3; "Doing" allows two cards
+func testBoard() *models.Board {
+	return &models.Board{
+		Model:     gorm.Model{ID: 7},
+		ProjectID: 3,
+		Name:      "Sprint",
+		Columns: []models.BoardColumn{
+			{ID: 1, BoardID: 7, Name: "To do", Status: models.TaskStatusTodo, Position: 0},
+			{ID: 2, BoardID: 7, Name: "Doing", Status: models.TaskStatusInProgress, Position: 1, WipLimit: 2},
+			{ID: 3, BoardID: 7, Name: "Done", Status: models.TaskStatusDone, Position: 2},
+		},
+	}
+}
+
+func projectTask(id uint, status, rank string) models.Task {
+	return models.Task{
+		Model:     gorm.Model{ID: id},
+		Title:     "Task",
+		Status:    status,
+		Closed:    status == models.TaskStatusDone,
+		Rank:      rank,
+		UserID:    1,
+		ProjectID: uintPtr(3),
+	}
+}
+
+func TestCreateBoard_RejectsDuplicateStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBoards := mocks.NewMockBoardRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	boardSvc := services.NewBoardService(mockBoards, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleEditor}, nil)
+	mockProjects.EXPECT().GetWorkflow(uint(3)).Return(nil, gorm.ErrRecordNotFound)
+	mockBoards.EXPECT().CreateBoard(gomock.Any()).Times(0)
+
+	_, err := boardSvc.CreateBoard(&models.Board{
+		ProjectID: 3,
+		Name:      "Board",
+		Columns: []models.BoardColumn{
+			{Name: "Open", Status: models.TaskStatusTodo},
+			{Name: "Backlog", Status: models.TaskStatusTodo},
+		},
+	}, 1)
+	assert.ErrorIs(t, err, services.ErrDuplicateColumnStatus)
+}
+
+func TestCreateBoard_PositionsColumnsInOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBoards := mocks.NewMockBoardRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	boardSvc := services.NewBoardService(mockBoards, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleEditor}, nil)
+	mockProjects.EXPECT().GetWorkflow(uint(3)).Return(nil, gorm.ErrRecordNotFound)
+	mockBoards.EXPECT().CreateBoard(gomock.Any()).DoAndReturn(
+		func(b *models.Board) (*models.Board, error) { return b, nil },
+	)
+
+	board, err := boardSvc.CreateBoard(&models.Board{
+		ProjectID: 3,
+		Name:      " Board ",
+		Columns: []models.BoardColumn{
+			{Name: "Done", Status: models.TaskStatusDone},
+			{Name: "Open", Status: models.TaskStatusTodo, WipLimit: 5},
+		},
+	}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Board", board.Name)
+	assert.Equal(t, 0, board.Columns[0].Position)
+	assert.Equal(t, 1, board.Columns[1].Position)
+}
+
+func TestGetBoardView_GroupsCardsByColumnInRankOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBoards := mocks.NewMockBoardRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	boardSvc := services.NewBoardService(mockBoards, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockBoards.EXPECT().GetBoardByID(uint(7)).Return(testBoard(), nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleViewer}, nil)
+	mockTasks.EXPECT().GetTasksByProjectID(uint(3)).Return([]models.Task{
+		projectTask(1, models.TaskStatusTodo, "m"),
+		projectTask(2, models.TaskStatusTodo, "c"),
+		projectTask(3, models.TaskStatusDone, "i"),
+		projectTask(4, models.TaskStatusTodo, "x"),
+	}, nil)
+
+	view, err := boardSvc.GetBoardView(7, 1)
+	require.NoError(t, err)
+	require.Len(t, view.Columns, 3)
+
+	var todo []uint
+	for _, card := range view.Columns[0].Cards {
+		todo = append(todo, card.ID)
+	}
+	assert.Equal(t, []uint{2, 1, 4}, todo)
+	assert.Empty(t, view.Columns[1].Cards)
+	assert.Len(t, view.Columns[2].Cards, 1)
+}
+
+func TestGetBoardView_NonMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBoards := mocks.NewMockBoardRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	boardSvc := services.NewBoardService(mockBoards, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockBoards.EXPECT().GetBoardByID(uint(7)).Return(testBoard(), nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := boardSvc.GetBoardView(7, 9)
+	assert.ErrorIs(t, err, services.ErrBoardNotFound)
+}
+
+func TestMoveCard_EnforcesWipLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBoards := mocks.NewMockBoardRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	boardSvc := services.NewBoardService(mockBoards, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	// The limit is checked when the move is saved, together with the column's cards
+	card := projectTask(5, models.TaskStatusTodo, "i")
+	mockBoards.EXPECT().GetBoardByID(uint(7)).Return(testBoard(), nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleEditor}, nil)
+	mockTasks.EXPECT().GetTaskByID(uint(5)).Return(&card, nil)
+	mockProjects.EXPECT().GetWorkflow(uint(3)).Return(nil, gorm.ErrRecordNotFound)
+	mockTasks.EXPECT().GetSubtasks([]uint{5}).Return(nil, nil)
+	mockTasks.EXPECT().MoveTaskCard(gomock.Any(), models.TaskStatusTodo, 0).
+		Return(fmt.Errorf("%w: %q allows %d tasks", services.ErrWipLimitReached, "Doing", 2))
+
+	_, err := boardSvc.MoveCard(7, 5, 2, 0, 1)
+	assert.ErrorIs(t, err, services.ErrWipLimitReached)
+}
+
+func TestMoveCard_MovedMeanwhile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBoards := mocks.NewMockBoardRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	boardSvc := services.NewBoardService(mockBoards, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	card := projectTask(5, models.TaskStatusTodo, "i")
+	mockBoards.EXPECT().GetBoardByID(uint(7)).Return(testBoard(), nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleEditor}, nil)
+	mockTasks.EXPECT().GetTaskByID(uint(5)).Return(&card, nil)
+	mockProjects.EXPECT().GetWorkflow(uint(3)).Return(nil, gorm.ErrRecordNotFound)
+	mockTasks.EXPECT().GetSubtasks([]uint{5}).Return(nil, nil)
+	mockTasks.EXPECT().MoveTaskCard(gomock.Any(), models.TaskStatusTodo, 0).Return(repositories.ErrTaskStatusChanged)
+
+	_, err := boardSvc.MoveCard(7, 5, 2, 0, 1)
+	assert.ErrorIs(t, err, services.ErrCardMoved)
+}
+
+func TestMoveCard_SavesStatusAndProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBoards := mocks.NewMockBoardRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	boardSvc := services.NewBoardService(mockBoards, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	card := projectTask(5, models.TaskStatusTodo, "x")
+	mockBoards.EXPECT().GetBoardByID(uint(7)).Return(testBoard(), nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleEditor}, nil)
+	mockTasks.EXPECT().GetTaskByID(uint(5)).Return(&card, nil)
+	mockProjects.EXPECT().GetWorkflow(uint(3)).Return(nil, gorm.ErrRecordNotFound)
+	mockTasks.EXPECT().GetDependencies([]uint{5}).Return(nil, nil)
+	mockTasks.EXPECT().GetSubtasks([]uint{5}).Return(nil, nil)
+	mockTasks.EXPECT().MoveTaskCard(gomock.Any(), models.TaskStatusTodo, 1).DoAndReturn(
+		func(task *models.Task, from string, position int) error {
+			task.Rank = "h"
+			return nil
+		},
+	)
+
+	task, err := boardSvc.MoveCard(7, 5, 3, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.TaskStatusDone, task.Status)
+	assert.True(t, task.Closed)
+	assert.Equal(t, "h", task.Rank)
+	assert.Equal(t, 100, task.Progress)
+}
+
+func TestMoveCard_BlockedTaskCantBeDone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBoards := mocks.NewMockBoardRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	boardSvc := services.NewBoardService(mockBoards, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	card := projectTask(5, models.TaskStatusTodo, "x")
+	blocker := projectTask(6, models.TaskStatusTodo, "y")
+	mockBoards.EXPECT().GetBoardByID(uint(7)).Return(testBoard(), nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleEditor}, nil)
+	mockTasks.EXPECT().GetTaskByID(uint(5)).Return(&card, nil)
+	mockProjects.EXPECT().GetWorkflow(uint(3)).Return(nil, gorm.ErrRecordNotFound)
+	mockTasks.EXPECT().GetDependencies([]uint{5}).Return([]models.TaskDependency{
+		{TaskID: 5, BlockedByID: 6, BlockedBy: &blocker},
+	}, nil)
+	mockTasks.EXPECT().MoveTaskCard(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := boardSvc.MoveCard(7, 5, 3, 0, 1)
+	assert.ErrorIs(t, err, services.ErrTaskBlocked)
+}
@@ -0,0 +1,285 @@
+// internal/services/comment_service.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"TaskManager/pkg/markdown"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCommentNotFound      = errors.New("comment not found")
+	ErrCommentBodyRequired  = errors.New("comment body is required")
+	ErrCommentTooLong       = fmt.Errorf("comment body can't exceed %d characters", MaxCommentLength)
+	ErrInvalidParentComment = errors.New("replies must answer a top-level comment of the same task")
+	ErrCommentForbidden     = errors.New("only the author can change this comment")
+)
+
+// MaxCommentLength caps the length of a comment body, in characters
+const MaxCommentLength = 10000
+
+// MentionListLimit caps how many mentioning comments GetMentions returns
+const MentionListLimit = 100
+
+// CommentService interface defines the methods for comment-related business operations
+type CommentService interface {
+	CreateComment(comment *models.Comment, userID uint) (*models.Comment, error)
+	GetTaskComments(taskID, userID uint) ([]models.Comment, error)
+	UpdateComment(id uint, body string, userID uint) (*models.Comment, error)
+	DeleteComment(id, userID uint) error
+	GetCommentHistory(id, userID uint) ([]models.CommentRevision, error)
+	GetMentions(userID uint) ([]models.Comment, error)
+}
+
+// CommentServiceImpl is the concrete implementation of the CommentService interface
+type CommentServiceImpl struct {
+	CommentRepo repositories.CommentRepository
+	TaskRepo    repositories.TaskRepository
+	ProjectRepo repositories.ProjectRepository
+	UserRepo    repositories.UserRepository
+	tasks       *TaskServiceImpl
+}
+
+// NewCommentService creates and returns a new CommentService instance
+func NewCommentService(commentRepo repositories.CommentRepository, taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository, userRepo repositories.UserRepository, tasks *TaskServiceImpl) CommentService {
+	return &CommentServiceImpl{
+		CommentRepo: commentRepo,
+		TaskRepo:    taskRepo,
+		ProjectRepo: projectRepo,
+		UserRepo:    userRepo,
+		tasks:       tasks,
+	}
+}
+
+// validateCommentBody trims a comment body and checks its length (internal helper)
+func validateCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", ErrCommentBodyRequired
+	}
+	if utf8.RuneCountInString(body) > MaxCommentLength {
+		return "", ErrCommentTooLong
+	}
+	return body, nil
+}
+
+// resolveMentions looks up the users mentioned in a comment body. Unknown
+// usernames, the author and users who can't see the task are skipped, so
+// nobody is notified about a task they can't open.
+func (s *CommentServiceImpl) resolveMentions(task *models.Task, body string, authorID uint) ([]models.CommentMention, error) {
+	var mentions []models.CommentMention
+	for _, username := range markdown.Mentions(body) {
+		user, err := s.UserRepo.GetUserByUsername(username)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("unexpected error fetching user: %v", err)
+		}
+		if user.ID == authorID {
+			continue
+		}
+
+		err = s.tasks.checkTaskAccess(task, user.ID, models.ProjectRoleViewer)
+		if errors.Is(err, ErrTaskNotFound) || errors.Is(err, ErrProjectForbidden) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		mentions = append(mentions, models.CommentMention{UserID: user.ID, User: user})
+	}
+	return mentions, nil
+}
+
+// loadComment fetches a comment and makes sure the user may read its task
+// (internal helper)
+func (s *CommentServiceImpl) loadComment(id, userID uint) (*models.Comment, *models.Task, error) {
+	comment, err := s.CommentRepo.GetCommentByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrCommentNotFound
+		}
+		return nil, nil, fmt.Errorf("unexpected error fetching comment: %v", err)
+	}
+
+	task, err := s.tasks.loadTask(comment.TaskID, userID, models.ProjectRoleViewer)
+	if err != nil {
+		if errors.Is(err, ErrTaskNotFound) {
+			return nil, nil, ErrCommentNotFound
+		}
+		return nil, nil, err
+	}
+	return comment, task, nil
+}
+
+// CreateComment posts a comment, or a reply when ParentID is set, on a task
+// the user can see. Any project member may comment.
+func (s *CommentServiceImpl) CreateComment(comment *models.Comment, userID uint) (*models.Comment, error) {
+	body, err := validateCommentBody(comment.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.tasks.loadTask(comment.TaskID, userID, models.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.ParentID != nil {
+		parent, err := s.CommentRepo.GetCommentByID(*comment.ParentID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("unexpected error fetching comment: %v", err)
+		}
+		if err != nil || parent.TaskID != task.ID || parent.ParentID != nil {
+			return nil, ErrInvalidParentComment
+		}
+	}
+
+	mentions, err := s.resolveMentions(task, body, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	newComment := models.Comment{
+		TaskID:   task.ID,
+		UserID:   userID,
+		ParentID: comment.ParentID,
+		Body:     body,
+		Mentions: mentions,
+	}
+	created, err := s.CommentRepo.CreateComment(&newComment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comment: %v", err)
+	}
+	return s.CommentRepo.GetCommentByID(created.ID)
+}
+
+// GetTaskComments retrieves the comment threads of a task: top-level
+// comments in posting order, each with its replies. Deleted replies are
+// dropped; a deleted top-level comment stays, without its body, only while
+// it still has replies.
+func (s *CommentServiceImpl) GetTaskComments(taskID, userID uint) ([]models.Comment, error) {
+	if _, err := s.tasks.loadTask(taskID, userID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.CommentRepo.GetCommentsByTaskID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch comments: %v", err)
+	}
+
+	replies := map[uint][]models.Comment{}
+	for _, comment := range comments {
+		if comment.ParentID != nil && !comment.DeletedAt.Valid {
+			replies[*comment.ParentID] = append(replies[*comment.ParentID], comment)
+		}
+	}
+
+	threads := make([]models.Comment, 0, len(comments))
+	for _, comment := range comments {
+		if comment.ParentID != nil {
+			continue
+		}
+		comment.Replies = replies[comment.ID]
+		if comment.DeletedAt.Valid {
+			if len(comment.Replies) == 0 {
+				continue
+			}
+			comment.Body = ""
+			comment.Mentions = nil
+		}
+		threads = append(threads, comment)
+	}
+	return threads, nil
+}
+
+// UpdateComment edits the body of the user's own comment. The previous body
+// is kept in the comment's history and mentions are re-resolved; users
+// already mentioned stay recorded.
+func (s *CommentServiceImpl) UpdateComment(id uint, body string, userID uint) (*models.Comment, error) {
+	body, err := validateCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, task, err := s.loadComment(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.UserID != userID {
+		return nil, ErrCommentForbidden
+	}
+	if comment.Body == body {
+		return comment, nil
+	}
+
+	mentions, err := s.resolveMentions(task, body, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousBody := comment.Body
+	editedAt := time.Now()
+	comment.Body = body
+	comment.EditedAt = &editedAt
+	comment.Mentions = mentions
+	if _, err := s.CommentRepo.UpdateComment(comment, previousBody); err != nil {
+		return nil, fmt.Errorf("failed to update comment: %v", err)
+	}
+	return s.CommentRepo.GetCommentByID(id)
+}
+
+// DeleteComment soft-deletes a comment. Authors may delete their own
+// comments and project owners any comment of their project.
+func (s *CommentServiceImpl) DeleteComment(id, userID uint) error {
+	comment, task, err := s.loadComment(id, userID)
+	if err != nil {
+		return err
+	}
+
+	if comment.UserID != userID {
+		if task.ProjectID == nil {
+			return ErrCommentForbidden
+		}
+		if _, err := requireProjectRole(s.ProjectRepo, *task.ProjectID, userID, models.ProjectRoleOwner); err != nil {
+			if errors.Is(err, ErrProjectForbidden) {
+				return ErrCommentForbidden
+			}
+			return err
+		}
+	}
+
+	if err := s.CommentRepo.DeleteComment(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCommentNotFound
+		}
+		return fmt.Errorf("failed to delete comment: %v", err)
+	}
+	return nil
+}
+
+// GetCommentHistory retrieves the previous bodies of a comment, oldest first
+func (s *CommentServiceImpl) GetCommentHistory(id, userID uint) ([]models.CommentRevision, error) {
+	if _, _, err := s.loadComment(id, userID); err != nil {
+		return nil, err
+	}
+	return s.CommentRepo.GetRevisions(id)
+}
+
+// GetMentions retrieves the latest comments mentioning the user, skipping
+// those on tasks the user can no longer see
+func (s *CommentServiceImpl) GetMentions(userID uint) ([]models.Comment, error) {
+	comments, err := s.CommentRepo.GetCommentsMentioning(userID, MentionListLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch mentions: %v", err)
+	}
+	return comments, nil
+}
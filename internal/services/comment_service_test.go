@@ -0,0 +1,211 @@
+package services_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// commentOn is comment id on task 5, written by authorID
+func commentOn(id, authorID uint, parentID *uint) *models.Comment {
+	return &models.Comment{Model: gorm.Model{ID: id}, TaskID: 5, UserID: authorID, ParentID: parentID, Body: "Looks good"}
+}
+
+func TestCreateComment_ResolvesMentions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockComments := mocks.NewMockCommentRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockUsers := mocks.NewMockUserRepository(ctrl)
+	commentSvc := services.NewCommentService(mockComments, mockTasks, mockProjects, mockUsers, services.NewTaskService(mockTasks, mockProjects, mockUsers, nil))
+
+	mockTasks.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleViewer), nil)
+	mockUsers.EXPECT().GetUserByUsername("bob").Return(&models.User{Model: gorm.Model{ID: 2}, Username: "bob"}, nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(2)).Return(memberWithRole(models.ProjectRoleEditor), nil)
+	mockUsers.EXPECT().GetUserByUsername("ghost").Return(nil, gorm.ErrRecordNotFound)
+	mockUsers.EXPECT().GetUserByUsername("carol").Return(&models.User{Model: gorm.Model{ID: 4}, Username: "carol"}, nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(4)).Return(nil, gorm.ErrRecordNotFound)
+	mockUsers.EXPECT().GetUserByUsername("alice").Return(&models.User{Model: gorm.Model{ID: 1}, Username: "alice"}, nil)
+
+	var saved *models.Comment
+	mockComments.EXPECT().CreateComment(gomock.Any()).DoAndReturn(
+		func(comment *models.Comment) (*models.Comment, error) {
+			comment.ID = 11
+			saved = comment
+			return comment, nil
+		},
+	)
+	mockComments.EXPECT().GetCommentByID(uint(11)).DoAndReturn(
+		func(uint) (*models.Comment, error) { return saved, nil },
+	)
+
+	body := "  @bob and @ghost, see `@carol` ... @carol @alice  "
+	comment, err := commentSvc.CreateComment(&models.Comment{TaskID: 5, Body: body}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "@bob and @ghost, see `@carol` ... @carol @alice", comment.Body)
+	assert.Equal(t, uint(1), comment.UserID)
+	require.Len(t, comment.Mentions, 1)
+	assert.Equal(t, uint(2), comment.Mentions[0].UserID)
+}
+
+func TestCreateComment_Validation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockComments := mocks.NewMockCommentRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	commentSvc := services.NewCommentService(mockComments, mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+	mockComments.EXPECT().CreateComment(gomock.Any()).Times(0)
+
+	_, err := commentSvc.CreateComment(&models.Comment{TaskID: 5, Body: " \n "}, 1)
+	assert.ErrorIs(t, err, services.ErrCommentBodyRequired)
+
+	long := make([]rune, services.MaxCommentLength+1)
+	for i := range long {
+		long[i] = 'é'
+	}
+	_, err = commentSvc.CreateComment(&models.Comment{TaskID: 5, Body: string(long)}, 1)
+	assert.ErrorIs(t, err, services.ErrCommentTooLong)
+
+	// Only project members may post
+	mockTasks.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(9)).Return(nil, gorm.ErrRecordNotFound)
+	_, err = commentSvc.CreateComment(&models.Comment{TaskID: 5, Body: "hi"}, 9)
+	assert.ErrorIs(t, err, services.ErrTaskNotFound)
+}
+
+func TestCreateComment_RepliesAreOneLevelDeep(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockComments := mocks.NewMockCommentRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	commentSvc := services.NewCommentService(mockComments, mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+	mockTasks.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil).AnyTimes()
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleViewer), nil).AnyTimes()
+	mockComments.EXPECT().CreateComment(gomock.Any()).Times(0)
+
+	// Replying to a reply
+	mockComments.EXPECT().GetCommentByID(uint(12)).Return(commentOn(12, 2, uintPtr(11)), nil)
+	_, err := commentSvc.CreateComment(&models.Comment{TaskID: 5, ParentID: uintPtr(12), Body: "+1"}, 1)
+	assert.ErrorIs(t, err, services.ErrInvalidParentComment)
+
+	// Replying to a comment of another task
+	other := commentOn(20, 2, nil)
+	other.TaskID = 6
+	mockComments.EXPECT().GetCommentByID(uint(20)).Return(other, nil)
+	_, err = commentSvc.CreateComment(&models.Comment{TaskID: 5, ParentID: uintPtr(20), Body: "+1"}, 1)
+	assert.ErrorIs(t, err, services.ErrInvalidParentComment)
+
+	// Replying to a deleted comment
+	mockComments.EXPECT().GetCommentByID(uint(13)).Return(nil, gorm.ErrRecordNotFound)
+	_, err = commentSvc.CreateComment(&models.Comment{TaskID: 5, ParentID: uintPtr(13), Body: "+1"}, 1)
+	assert.ErrorIs(t, err, services.ErrInvalidParentComment)
+}
+
+func TestGetTaskComments_BuildsThreads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockComments := mocks.NewMockCommentRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	commentSvc := services.NewCommentService(mockComments, mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	deleted := gorm.DeletedAt{Time: time.Now(), Valid: true}
+	withReplies := commentOn(1, 1, nil)
+	withReplies.DeletedAt = deleted
+	lonelyDeleted := commentOn(2, 1, nil)
+	lonelyDeleted.DeletedAt = deleted
+	deletedReply := commentOn(5, 2, uintPtr(3))
+	deletedReply.DeletedAt = deleted
+
+	mockTasks.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(2)).Return(memberWithRole(models.ProjectRoleViewer), nil)
+	mockComments.EXPECT().GetCommentsByTaskID(uint(5)).Return([]models.Comment{
+		*withReplies, *lonelyDeleted, *commentOn(3, 2, nil), *commentOn(4, 1, uintPtr(1)),
+		*deletedReply, *commentOn(6, 2, uintPtr(3)), *commentOn(7, 1, uintPtr(1)),
+	}, nil)
+
+	threads, err := commentSvc.GetTaskComments(5, 2)
+	require.NoError(t, err)
+	require.Len(t, threads, 2)
+
+	// A deleted comment with replies stays as a placeholder
+	assert.Equal(t, uint(1), threads[0].ID)
+	assert.Empty(t, threads[0].Body)
+	require.Len(t, threads[0].Replies, 2)
+	assert.Equal(t, uint(4), threads[0].Replies[0].ID)
+	assert.Equal(t, uint(7), threads[0].Replies[1].ID)
+
+	assert.Equal(t, uint(3), threads[1].ID)
+	require.Len(t, threads[1].Replies, 1)
+	assert.Equal(t, uint(6), threads[1].Replies[0].ID)
+}
+
+func TestUpdateComment_KeepsHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockComments := mocks.NewMockCommentRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	commentSvc := services.NewCommentService(mockComments, mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+	mockTasks.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil).AnyTimes()
+	mockProjects.EXPECT().GetMember(uint(3), gomock.Any()).Return(memberWithRole(models.ProjectRoleEditor), nil).AnyTimes()
+
+	// Only the author may edit
+	mockComments.EXPECT().GetCommentByID(uint(11)).Return(commentOn(11, 2, nil), nil)
+	_, err := commentSvc.UpdateComment(11, "Changed", 1)
+	assert.ErrorIs(t, err, services.ErrCommentForbidden)
+
+	mockComments.EXPECT().GetCommentByID(uint(11)).Return(commentOn(11, 2, nil), nil)
+	mockComments.EXPECT().UpdateComment(gomock.Any(), "Looks good").DoAndReturn(
+		func(comment *models.Comment, previousBody string) (*models.Comment, error) {
+			assert.Equal(t, "Looks great", comment.Body)
+			assert.NotNil(t, comment.EditedAt)
+			return comment, nil
+		},
+	)
+	mockComments.EXPECT().GetCommentByID(uint(11)).Return(&models.Comment{Model: gorm.Model{ID: 11}, TaskID: 5, UserID: 2, Body: "Looks great"}, nil)
+
+	comment, err := commentSvc.UpdateComment(11, "Looks great", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "Looks great", comment.Body)
+
+	// Saving the same body doesn't add a revision
+	mockComments.EXPECT().GetCommentByID(uint(11)).Return(commentOn(11, 2, nil), nil)
+	_, err = commentSvc.UpdateComment(11, "Looks good ", 2)
+	require.NoError(t, err)
+}
+
+func TestDeleteComment_AuthorOrProjectOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockComments := mocks.NewMockCommentRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	commentSvc := services.NewCommentService(mockComments, mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+	mockTasks.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil).AnyTimes()
+	mockComments.EXPECT().GetCommentByID(uint(11)).Return(commentOn(11, 2, nil), nil).AnyTimes()
+	mockProjects.EXPECT().GetMember(uint(3), uint(4)).Return(memberWithRole(models.ProjectRoleEditor), nil).AnyTimes()
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleOwner), nil).AnyTimes()
+
+	assert.ErrorIs(t, commentSvc.DeleteComment(11, 4), services.ErrCommentForbidden)
+
+	mockComments.EXPECT().DeleteComment(uint(11)).Return(nil)
+	assert.NoError(t, commentSvc.DeleteComment(11, 1))
+}
@@ -0,0 +1,245 @@
+// internal/services/custom_field_service.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCustomFieldNotFound = errors.New("custom field not found")
+	ErrInvalidCustomField  = errors.New("invalid custom field")
+	ErrCustomFieldExists   = errors.New("a custom field with this key already exists")
+	ErrInvalidFieldValues  = errors.New("invalid field values")
+)
+
+// Bounds of custom field definitions and values
+const (
+	MaxCustomFields          = 50
+	MaxCustomFieldOptions    = 100
+	MaxCustomFieldTextLength = 500
+)
+
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,39}$`)
+
+// ValidationError reports invalid input per field, e.g. "custom_fields.points"
+// → "must be a number". It matches ErrInvalidFieldValues.
+type ValidationError struct {
+	Fields map[string]string
+}
+
+func (e *ValidationError) Error() string {
+	keys := make([]string, 0, len(e.Fields))
+	for key := range e.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+" "+e.Fields[key])
+	}
+	return ErrInvalidFieldValues.Error() + ": " + strings.Join(parts, "; ")
+}
+
+// Unwrap lets callers match the error with ErrInvalidFieldValues
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidFieldValues
+}
+
+// isSelectField reports whether a field type chooses from options
+func isSelectField(fieldType string) bool {
+	return fieldType == models.CustomFieldSelect || fieldType == models.CustomFieldMultiSelect
+}
+
+// customFieldKey derives a key from a field name, e.g. "Story points" → "story_points"
+func customFieldKey(name string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if underscore && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			underscore = false
+			b.WriteRune(r)
+		default:
+			underscore = true
+		}
+	}
+	key := strings.TrimLeft(b.String(), "0123456789_")
+	if len(key) > 40 {
+		key = strings.TrimRight(key[:40], "_")
+	}
+	return key
+}
+
+// validateCustomField normalises a field definition (internal helper)
+func validateCustomField(field *models.CustomField) error {
+	field.Name = strings.TrimSpace(field.Name)
+	if field.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidCustomField)
+	}
+	field.Key = strings.TrimSpace(field.Key)
+	if field.Key == "" {
+		field.Key = customFieldKey(field.Name)
+	}
+	if !customFieldKeyPattern.MatchString(field.Key) {
+		return fmt.Errorf("%w: key must be lower-case letters, digits and underscores, starting with a letter", ErrInvalidCustomField)
+	}
+
+	switch field.Type {
+	case models.CustomFieldText, models.CustomFieldNumber, models.CustomFieldDate, models.CustomFieldUser:
+		field.Options = nil
+		return nil
+	case models.CustomFieldSelect, models.CustomFieldMultiSelect:
+	default:
+		return fmt.Errorf("%w: type must be text, number, date, select, multi_select or user", ErrInvalidCustomField)
+	}
+
+	seen := map[string]bool{}
+	options := make([]string, 0, len(field.Options))
+	for _, option := range field.Options {
+		option = strings.TrimSpace(option)
+		if option == "" || seen[option] {
+			continue
+		}
+		if utf8.RuneCountInString(option) > 100 {
+			return fmt.Errorf("%w: options are limited to 100 characters", ErrInvalidCustomField)
+		}
+		seen[option] = true
+		options = append(options, option)
+	}
+	if len(options) == 0 {
+		return fmt.Errorf("%w: select fields need options", ErrInvalidCustomField)
+	}
+	if len(options) > MaxCustomFieldOptions {
+		return fmt.Errorf("%w: at most %d options", ErrInvalidCustomField, MaxCustomFieldOptions)
+	}
+	field.Options = options
+	return nil
+}
+
+// CustomFieldService interface defines the methods for managing a project's custom fields
+type CustomFieldService interface {
+	GetFields(projectID, userID uint) ([]models.CustomField, error)
+	CreateField(field *models.CustomField, userID uint) (*models.CustomField, error)
+	UpdateField(field *models.CustomField, userID uint) (*models.CustomField, error)
+	DeleteField(projectID, fieldID, userID uint) error
+}
+
+// CustomFieldServiceImpl is the concrete implementation of the CustomFieldService interface
+type CustomFieldServiceImpl struct {
+	ProjectRepo repositories.ProjectRepository
+}
+
+// NewCustomFieldService creates and returns a new CustomFieldService instance
+func NewCustomFieldService(projectRepo repositories.ProjectRepository) CustomFieldService {
+	return &CustomFieldServiceImpl{
+		ProjectRepo: projectRepo,
+	}
+}
+
+// GetFields lists the custom fields of a project the user belongs to
+func (s *CustomFieldServiceImpl) GetFields(projectID, userID uint) ([]models.CustomField, error) {
+	if _, err := requireProjectRole(s.ProjectRepo, projectID, userID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+	return s.ProjectRepo.GetCustomFields(projectID)
+}
+
+// CreateField adds a custom field to a project the user owns
+func (s *CustomFieldServiceImpl) CreateField(field *models.CustomField, userID uint) (*models.CustomField, error) {
+	if _, err := requireProjectRole(s.ProjectRepo, field.ProjectID, userID, models.ProjectRoleOwner); err != nil {
+		return nil, err
+	}
+	if err := validateCustomField(field); err != nil {
+		return nil, err
+	}
+
+	fields, err := s.ProjectRepo.GetCustomFields(field.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error fetching custom fields: %v", err)
+	}
+	if len(fields) >= MaxCustomFields {
+		return nil, fmt.Errorf("%w: a project can have at most %d custom fields", ErrInvalidCustomField, MaxCustomFields)
+	}
+	for _, existing := range fields {
+		if existing.Key == field.Key {
+			return nil, ErrCustomFieldExists
+		}
+	}
+
+	// New fields go last
+	field.ID = 0
+	field.Position = len(fields)
+	return s.ProjectRepo.CreateCustomField(field)
+}
+
+// loadField fetches a custom field of a project the user owns (internal helper)
+func (s *CustomFieldServiceImpl) loadField(projectID, fieldID, userID uint) (*models.CustomField, error) {
+	if _, err := requireProjectRole(s.ProjectRepo, projectID, userID, models.ProjectRoleOwner); err != nil {
+		return nil, err
+	}
+	field, err := s.ProjectRepo.GetCustomField(projectID, fieldID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCustomFieldNotFound
+		}
+		return nil, fmt.Errorf("unexpected error fetching custom field: %v", err)
+	}
+	return field, nil
+}
+
+// UpdateField changes the name, requirement, options or position of a
+// field. Its key and type are fixed; tasks that chose a removed option lose
+// that value.
+func (s *CustomFieldServiceImpl) UpdateField(field *models.CustomField, userID uint) (*models.CustomField, error) {
+	existing, err := s.loadField(field.ProjectID, field.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	field.Key = existing.Key
+	field.Type = existing.Type
+	field.CreatedAt = existing.CreatedAt
+	if err := validateCustomField(field); err != nil {
+		return nil, err
+	}
+
+	kept := map[string]bool{}
+	for _, option := range field.Options {
+		kept[option] = true
+	}
+	var removed []string
+	for _, option := range existing.Options {
+		if !kept[option] {
+			removed = append(removed, option)
+		}
+	}
+	return s.ProjectRepo.UpdateCustomField(field, removed)
+}
+
+// DeleteField removes a custom field and every task's value for it
+func (s *CustomFieldServiceImpl) DeleteField(projectID, fieldID, userID uint) error {
+	field, err := s.loadField(projectID, fieldID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.ProjectRepo.DeleteCustomField(field); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCustomFieldNotFound
+		}
+		return fmt.Errorf("failed to delete custom field: %v", err)
+	}
+	return nil
+}
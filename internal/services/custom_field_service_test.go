@@ -0,0 +1,401 @@
+package services_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// projectFields are the custom fields of project 3
+func projectFields() []models.CustomField {
+	return []models.CustomField{
+		{ID: 1, ProjectID: 3, Key: "points", Name: "Points", Type: models.CustomFieldNumber, Required: true},
+		{ID: 2, ProjectID: 3, Key: "customer", Name: "Customer", Type: models.CustomFieldText},
+		{ID: 3, ProjectID: 3, Key: "launch", Name: "Launch", Type: models.CustomFieldDate},
+		{ID: 4, ProjectID: 3, Key: "size", Name: "Size", Type: models.CustomFieldSelect, Options: []string{"S", "M", "L"}},
+		{ID: 5, ProjectID: 3, Key: "platforms", Name: "Platforms", Type: models.CustomFieldMultiSelect, Options: []string{"ios", "android", "web"}},
+		{ID: 6, ProjectID: 3, Key: "reviewer", Name: "Reviewer", Type: models.CustomFieldUser},
+	}
+}
+
+func TestCreateTask_CustomFieldValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil)
+
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleEditor), nil)
+	mockProjects.EXPECT().GetCustomFields(uint(3)).Return(projectFields(), nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(7)).Return(memberWithRole(models.ProjectRoleViewer), nil)
+	mockProjects.EXPECT().GetWorkflow(uint(3)).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.EXPECT().GetLastTaskRank(uint(3)).Return("", nil)
+	mockRepo.EXPECT().CreateTask(gomock.Any()).DoAndReturn(
+		func(task *models.Task) (*models.Task, error) { return task, nil },
+	)
+
+	task, err := taskSvc.CreateTask(&models.Task{
+		Title:     "Release",
+		UserID:    1,
+		ProjectID: uintPtr(3),
+		CustomFieldInput: map[string]interface{}{
+			"points":    float64(5),
+			"customer":  "  ACME ",
+			"launch":    "2025-03-01",
+			"size":      "M",
+			"platforms": []interface{}{"web", "ios", "web"},
+			"reviewer":  float64(7),
+		},
+	})
+	require.NoError(t, err)
+
+	// Values come in field order, one row per chosen option
+	require.Len(t, task.CustomValues, 7)
+	assert.Equal(t, 5.0, *task.CustomValues[0].NumberValue)
+	assert.Equal(t, "ACME", task.CustomValues[1].TextValue)
+	assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), *task.CustomValues[2].DateValue)
+	assert.Equal(t, "M", task.CustomValues[3].TextValue)
+	assert.Equal(t, "web", task.CustomValues[4].TextValue)
+	assert.Equal(t, "ios", task.CustomValues[5].TextValue)
+	assert.Equal(t, uint(7), *task.CustomValues[6].UserValue)
+	assert.Equal(t, "reviewer", task.CustomValues[6].Field.Key)
+}
+
+func TestCreateTask_InvalidCustomFieldValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil)
+
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleEditor), nil)
+	mockProjects.EXPECT().GetCustomFields(uint(3)).Return(projectFields(), nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(9)).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.EXPECT().CreateTask(gomock.Any()).Times(0)
+
+	_, err := taskSvc.CreateTask(&models.Task{
+		Title:     "Release",
+		UserID:    1,
+		ProjectID: uintPtr(3),
+		CustomFieldInput: map[string]interface{}{
+			"customer":  float64(12),
+			"launch":    "next week",
+			"size":      "XL",
+			"platforms": "web",
+			"reviewer":  float64(9),
+			"owner":     "bob",
+		},
+	})
+	require.ErrorIs(t, err, services.ErrInvalidFieldValues)
+
+	var validationErr *services.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, map[string]string{
+		"custom_fields.points":    "is required",
+		"custom_fields.customer":  "must be a string",
+		"custom_fields.launch":    "must be a date like 2024-01-31",
+		"custom_fields.size":      "must be one of S, M, L",
+		"custom_fields.platforms": "must be a list of options",
+		"custom_fields.reviewer":  "must be a member of the project",
+		"custom_fields.owner":     "is not a field of this project",
+	}, validationErr.Fields)
+}
+
+func TestCreateTask_PersonalTaskHasNoCustomFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+	mockRepo.EXPECT().CreateTask(gomock.Any()).Times(0)
+
+	_, err := taskSvc.CreateTask(&models.Task{Title: "Groceries", UserID: 1, CustomFieldInput: map[string]interface{}{"points": float64(1)}})
+	assert.ErrorIs(t, err, services.ErrInvalidFieldValues)
+}
+
+func TestUpdateTask_CustomFieldValues(t *testing.T) {
+	storedValues := func() []models.CustomFieldValue {
+		fields := projectFields()
+		points, reviewer := 3.0, uint(7)
+		return []models.CustomFieldValue{
+			{ID: 10, TaskID: 5, FieldID: 1, Field: &fields[0], NumberValue: &points},
+			{ID: 11, TaskID: 5, FieldID: 6, Field: &fields[5], UserValue: &reviewer},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		input        map[string]interface{}
+		wantFieldIDs []uint
+		problem      string
+	}{
+		{
+			name: "no custom fields given",
+		},
+		{
+			name:         "only the given field changes",
+			input:        map[string]interface{}{"size": "L"},
+			wantFieldIDs: []uint{1, 4, 6},
+		},
+		{
+			name:         "null clears an optional field",
+			input:        map[string]interface{}{"reviewer": nil},
+			wantFieldIDs: []uint{1},
+		},
+		{
+			name:    "required fields can't be cleared",
+			input:   map[string]interface{}{"points": nil},
+			problem: "custom_fields.points",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockTaskRepository(ctrl)
+			mockProjects := mocks.NewMockProjectRepository(ctrl)
+			taskSvc := services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil)
+
+			stored := projectTaskWithAssignees()
+			stored.CustomValues = storedValues()
+			mockRepo.EXPECT().GetTaskByID(uint(5)).Return(stored, nil)
+			mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleEditor), nil)
+			mockRepo.EXPECT().GetSubtasks([]uint{5}).Return(nil, nil)
+			if tt.input != nil {
+				mockProjects.EXPECT().GetCustomFields(uint(3)).Return(projectFields(), nil)
+			}
+			if tt.problem == "" {
+				mockProjects.EXPECT().GetWorkflow(uint(3)).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().UpdateTask(gomock.Any()).DoAndReturn(
+					func(task *models.Task) (*models.Task, error) { return task, nil },
+				)
+			}
+
+			var replaced []models.CustomFieldValue
+			if tt.input != nil && tt.problem == "" {
+				mockRepo.EXPECT().ReplaceCustomFieldValues(uint(5), gomock.Any()).DoAndReturn(
+					func(taskID uint, values []models.CustomFieldValue) error {
+						replaced = values
+						return nil
+					},
+				)
+			}
+
+			changed := projectTaskWithAssignees()
+			changed.CustomFieldInput = tt.input
+			task, err := taskSvc.UpdateTask(changed, 1)
+			if tt.problem != "" {
+				var validationErr *services.ValidationError
+				require.True(t, errors.As(err, &validationErr))
+				assert.Contains(t, validationErr.Fields, tt.problem)
+				return
+			}
+			require.NoError(t, err)
+			if tt.input == nil {
+				assert.Len(t, task.CustomValues, 2)
+				return
+			}
+
+			// Kept values are carried over next to the changed ones
+			var fieldIDs []uint
+			for _, value := range replaced {
+				fieldIDs = append(fieldIDs, value.FieldID)
+			}
+			assert.Equal(t, tt.wantFieldIDs, fieldIDs)
+		})
+	}
+}
+
+func TestCreateField_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   models.CustomField
+		wantKey string
+		wantErr error
+	}{
+		{
+			name:    "key derived from the name",
+			field:   models.CustomField{ProjectID: 3, Name: "Story points", Type: models.CustomFieldNumber},
+			wantKey: "story_points",
+		},
+		{
+			name:    "options are trimmed and deduplicated",
+			field:   models.CustomField{ProjectID: 3, Name: "T-shirt size", Type: models.CustomFieldSelect, Options: []string{" S ", "M", "S", ""}},
+			wantKey: "t_shirt_size",
+		},
+		{
+			name:    "unknown type",
+			field:   models.CustomField{ProjectID: 3, Name: "Mood", Type: "emoji"},
+			wantErr: services.ErrInvalidCustomField,
+		},
+		{
+			name:    "select without options",
+			field:   models.CustomField{ProjectID: 3, Name: "Size", Type: models.CustomFieldSelect},
+			wantErr: services.ErrInvalidCustomField,
+		},
+		{
+			name:    "invalid key",
+			field:   models.CustomField{ProjectID: 3, Name: "Points", Key: "Points!", Type: models.CustomFieldNumber},
+			wantErr: services.ErrInvalidCustomField,
+		},
+		{
+			name:    "key already taken",
+			field:   models.CustomField{ProjectID: 3, Name: "Points", Type: models.CustomFieldNumber},
+			wantErr: services.ErrCustomFieldExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockProjects := mocks.NewMockProjectRepository(ctrl)
+			fieldSvc := services.NewCustomFieldService(mockProjects)
+
+			mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleOwner), nil)
+			mockProjects.EXPECT().GetCustomFields(uint(3)).Return(projectFields(), nil).AnyTimes()
+			if tt.wantErr == nil {
+				mockProjects.EXPECT().CreateCustomField(gomock.Any()).DoAndReturn(
+					func(field *models.CustomField) (*models.CustomField, error) { return field, nil },
+				)
+			}
+
+			field, err := fieldSvc.CreateField(&tt.field, 1)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKey, field.Key)
+			assert.Equal(t, len(projectFields()), field.Position)
+			if field.Type == models.CustomFieldSelect {
+				assert.Equal(t, []string{"S", "M"}, field.Options)
+			}
+		})
+	}
+}
+
+func TestCreateField_OwnersOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	fieldSvc := services.NewCustomFieldService(mockProjects)
+
+	mockProjects.EXPECT().GetMember(uint(3), uint(2)).Return(memberWithRole(models.ProjectRoleEditor), nil)
+	mockProjects.EXPECT().CreateCustomField(gomock.Any()).Times(0)
+
+	_, err := fieldSvc.CreateField(&models.CustomField{ProjectID: 3, Name: "Points", Type: models.CustomFieldNumber}, 2)
+	assert.ErrorIs(t, err, services.ErrProjectForbidden)
+}
+
+func TestUpdateField_RemovedOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	fieldSvc := services.NewCustomFieldService(mockProjects)
+
+	stored := projectFields()[3]
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleOwner), nil)
+	mockProjects.EXPECT().GetCustomField(uint(3), uint(4)).Return(&stored, nil)
+	mockProjects.EXPECT().UpdateCustomField(gomock.Any(), []string{"S", "L"}).DoAndReturn(
+		func(field *models.CustomField, removed []string) (*models.CustomField, error) { return field, nil },
+	)
+
+	// Key and type stay as they were
+	field, err := fieldSvc.UpdateField(&models.CustomField{
+		ID: 4, ProjectID: 3, Key: "other", Type: models.CustomFieldText,
+		Name: "T-shirt size", Options: []string{"M", "XL"},
+	}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "size", field.Key)
+	assert.Equal(t, models.CustomFieldSelect, field.Type)
+	assert.Equal(t, []string{"M", "XL"}, field.Options)
+}
+
+func TestQueryProjectTasks(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       services.TaskListQuery
+		wantFilters []repositories.CustomFieldFilter
+		wantSort    repositories.TaskSort
+		problem     string
+	}{
+		{
+			name:  "filters and sorts by custom fields",
+			query: services.TaskListQuery{Filters: []services.TaskFilter{{Key: "points", Op: "gt", Value: "3"}, {Key: "platforms", Value: "web"}}, Sort: "-points"},
+			wantFilters: []repositories.CustomFieldFilter{
+				{FieldID: 1, Column: "number_value", Op: repositories.FilterOpGt, Value: 3.0},
+				{FieldID: 5, Column: "text_value", Op: repositories.FilterOpEq, Value: "web"},
+			},
+			wantSort: repositories.TaskSort{FieldID: 1, Column: "number_value", Desc: true},
+		},
+		{
+			name:     "sorts by a task column",
+			query:    services.TaskListQuery{Sort: "due_date"},
+			wantSort: repositories.TaskSort{Column: "due_date"},
+		},
+		{
+			name:    "unknown field",
+			query:   services.TaskListQuery{Filters: []services.TaskFilter{{Key: "owner", Value: "bob"}}},
+			problem: "cf.owner",
+		},
+		{
+			name:    "lt on a text field",
+			query:   services.TaskListQuery{Filters: []services.TaskFilter{{Key: "customer", Op: "lt", Value: "m"}}},
+			problem: "cf.customer.lt",
+		},
+		{
+			name:    "value of the wrong type",
+			query:   services.TaskListQuery{Filters: []services.TaskFilter{{Key: "launch", Op: "gt", Value: "soon"}}},
+			problem: "cf.launch.gt",
+		},
+		{
+			name:    "sort by a multi-select field",
+			query:   services.TaskListQuery{Sort: "platforms"},
+			problem: "sort",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockTaskRepository(ctrl)
+			mockProjects := mocks.NewMockProjectRepository(ctrl)
+			projectSvc := services.NewProjectService(mockProjects, mockRepo, mocks.NewMockUserRepository(ctrl), nil)
+
+			mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleViewer), nil)
+			mockProjects.EXPECT().GetCustomFields(uint(3)).Return(projectFields(), nil)
+			if tt.problem == "" {
+				mockRepo.EXPECT().QueryProjectTasks(uint(3), tt.wantFilters, tt.wantSort).Return([]models.Task{*projectTaskWithAssignees()}, nil)
+			}
+
+			tasks, err := projectSvc.QueryProjectTasks(3, 1, tt.query)
+			if tt.problem != "" {
+				var validationErr *services.ValidationError
+				require.True(t, errors.As(err, &validationErr))
+				assert.Contains(t, validationErr.Fields, tt.problem)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, tasks, 1)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// internal/services/email_verification.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/pkg/mailer"
+	"TaskManager/pkg/utils"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidVerificationToken = errors.New("invalid or expired email verification token")
+	ErrEmailAlreadyVerified     = errors.New("email is already verified")
+)
+
+// EmailVerificationSettings configure email verification mails: how long
+// their links are valid, the endpoint the links lead to and how long a
+// user waits before another mail can be sent
+type EmailVerificationSettings struct {
+	TTL            time.Duration
+	URL            string
+	ResendInterval time.Duration
+}
+
+// RetryLaterError is returned when an action was taken too recently to be
+// repeated yet
+type RetryLaterError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RetryLaterError) Error() string {
+	return fmt.Sprintf("please wait %s before trying again", e.RetryAfter.Round(time.Second))
+}
+
+// sendVerification mails a link verifying a user's pending email, or their
+// current one when no change is pending (internal helper)
+func (s *AuthServiceImpl) sendVerification(user *models.User) error {
+	email := user.Email
+	if user.PendingEmail != "" {
+		email = user.PendingEmail
+	}
+
+	value, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+	expiresAt := time.Now().Add(s.Verification.TTL)
+	_, err = s.TokenRepo.CreateEmailVerificationToken(&models.EmailVerificationToken{
+		UserID:    user.ID,
+		Email:     email,
+		TokenHash: utils.HashToken(value),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("unexpected error storing email verification token: %v", err)
+	}
+
+	err = s.Mailer.Send(mailer.Message{
+		To:      email,
+		Subject: "Verify your TaskManager email",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"please confirm that this is the email of your TaskManager account by opening this link before %s:\n\n%s\n\n"+
+			"If you didn't sign up, ignore this mail.\n",
+			user.Username, expiresAt.UTC().Format("2 Jan 2006 15:04 MST"), tokenLink(s.Verification.URL, value)),
+	})
+	if err != nil {
+		return fmt.Errorf("unexpected error sending email verification mail: %v", err)
+	}
+	return nil
+}
+
+// VerifyEmail marks the email a verification token was sent to verified,
+// provided it is still the user's email or pending email; a pending email
+// then replaces the user's email. Each token can be used once.
+// Access tokens issued before carry the old state until refreshed.
+func (s *AuthServiceImpl) VerifyEmail(token string) error {
+	verification, err := s.TokenRepo.GetEmailVerificationTokenByHash(utils.HashToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidVerificationToken
+		}
+		return fmt.Errorf("unexpected error fetching email verification token: %v", err)
+	}
+	if verification.UsedAt != nil || !time.Now().Before(verification.ExpiresAt) {
+		return ErrInvalidVerificationToken
+	}
+
+	if err := s.TokenRepo.VerifyEmail(verification.ID, verification.UserID, verification.Email); err != nil {
+		// Used by a concurrent request, expired meanwhile or the email changed
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidVerificationToken
+		}
+		return fmt.Errorf("unexpected error verifying email: %v", err)
+	}
+	return nil
+}
+
+// ResendVerification mails a user a new link verifying their pending or
+// unverified email, at most once per Verification.ResendInterval.
+func (s *AuthServiceImpl) ResendVerification(userID uint) error {
+	user, err := s.AuthRepo.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("unexpected error fetching user: %v", err)
+	}
+	if user.EmailVerified && user.PendingEmail == "" {
+		return ErrEmailAlreadyVerified
+	}
+
+	latest, err := s.TokenRepo.GetLatestEmailVerificationToken(userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("unexpected error fetching email verification token: %v", err)
+	}
+	// The limit holds whatever email the last link went to, so switching
+	// emails back and forth doesn't send more mail
+	if latest != nil {
+		if wait := time.Until(latest.CreatedAt.Add(s.Verification.ResendInterval)); wait > 0 {
+			return &RetryLaterError{RetryAfter: wait}
+		}
+	}
+
+	return s.sendVerification(user)
+}
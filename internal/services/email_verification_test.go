@@ -0,0 +1,224 @@
+package services_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"TaskManager/pkg/mailer"
+	"TaskManager/pkg/utils"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestVerifyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTokens := mocks.NewMockTokenRepository(ctrl)
+	svc := &services.AuthServiceImpl{TokenRepo: mockTokens}
+
+	verification := &models.EmailVerificationToken{ID: 7, UserID: 1, Email: "john@example.com", ExpiresAt: time.Now().Add(time.Hour)}
+	gomock.InOrder(
+		mockTokens.EXPECT().GetEmailVerificationTokenByHash(utils.HashToken("verifyToken")).Return(verification, nil),
+		mockTokens.EXPECT().VerifyEmail(uint(7), uint(1), "john@example.com").Return(nil),
+	)
+
+	require.NoError(t, svc.VerifyEmail("verifyToken"))
+}
+
+func TestVerifyEmail_InvalidToken(t *testing.T) {
+	usedAt := time.Now().Add(-time.Minute)
+	tests := []struct {
+		name         string
+		verification *models.EmailVerificationToken
+		err          error
+		verifyErr    error
+	}{
+		{name: "unknown token", err: gorm.ErrRecordNotFound},
+		{name: "used token", verification: &models.EmailVerificationToken{ID: 7, UserID: 1, Email: "john@example.com", ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}},
+		{name: "expired token", verification: &models.EmailVerificationToken{ID: 7, UserID: 1, Email: "john@example.com", ExpiresAt: time.Now().Add(-time.Second)}},
+		{name: "email changed or used concurrently", verification: &models.EmailVerificationToken{ID: 7, UserID: 1, Email: "john@example.com", ExpiresAt: time.Now().Add(time.Hour)}, verifyErr: gorm.ErrRecordNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTokens := mocks.NewMockTokenRepository(ctrl)
+			svc := &services.AuthServiceImpl{TokenRepo: mockTokens}
+
+			mockTokens.EXPECT().GetEmailVerificationTokenByHash(utils.HashToken("verifyToken")).Return(tt.verification, tt.err)
+			if tt.verifyErr != nil {
+				mockTokens.EXPECT().VerifyEmail(uint(7), uint(1), "john@example.com").Return(tt.verifyErr)
+			}
+
+			assert.ErrorIs(t, svc.VerifyEmail("verifyToken"), services.ErrInvalidVerificationToken)
+		})
+	}
+}
+
+func TestResendVerification(t *testing.T) {
+	tests := []struct {
+		name      string
+		latest    *models.EmailVerificationToken
+		latestErr error
+	}{
+		{name: "no earlier link", latestErr: gorm.ErrRecordNotFound},
+		{name: "earlier link long ago", latest: &models.EmailVerificationToken{Email: "john@example.com", CreatedAt: time.Now().Add(-time.Hour)}},
+		{name: "earlier link to a previous email long ago", latest: &models.EmailVerificationToken{Email: "jhon@example.com", CreatedAt: time.Now().Add(-time.Hour)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockUserRepository(ctrl)
+			mockTokens := mocks.NewMockTokenRepository(ctrl)
+			mail := mailer.NewMemoryMailer()
+			svc := &services.AuthServiceImpl{
+				AuthRepo:     mockRepo,
+				TokenRepo:    mockTokens,
+				Mailer:       mail,
+				Verification: services.EmailVerificationSettings{TTL: time.Hour, URL: "https://api.example.com/auth/verify", ResendInterval: time.Minute},
+			}
+
+			user := &models.User{Username: "john", Email: "john@example.com"}
+			user.ID = 1
+			gomock.InOrder(
+				mockRepo.EXPECT().GetUserByID(uint(1)).Return(user, nil),
+				mockTokens.EXPECT().GetLatestEmailVerificationToken(uint(1)).Return(tt.latest, tt.latestErr),
+				mockTokens.EXPECT().CreateEmailVerificationToken(gomock.Any()).DoAndReturn(
+					func(token *models.EmailVerificationToken) (*models.EmailVerificationToken, error) { return token, nil },
+				),
+			)
+
+			require.NoError(t, svc.ResendVerification(1))
+			sent := mail.Sent()
+			require.Len(t, sent, 1)
+			assert.Equal(t, "john@example.com", sent[0].To)
+		})
+	}
+}
+
+func TestResendVerification_PendingEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockTokenRepository(ctrl)
+	mail := mailer.NewMemoryMailer()
+	svc := &services.AuthServiceImpl{
+		AuthRepo:     mockRepo,
+		TokenRepo:    mockTokens,
+		Mailer:       mail,
+		Verification: services.EmailVerificationSettings{TTL: time.Hour, URL: "https://api.example.com/auth/verify", ResendInterval: time.Minute},
+	}
+
+	// A verified user asked for a new email
+	user := &models.User{Username: "john", Email: "john@example.com", EmailVerified: true, PendingEmail: "johnny@example.com"}
+	user.ID = 1
+	mockRepo.EXPECT().GetUserByID(uint(1)).Return(user, nil)
+	mockTokens.EXPECT().GetLatestEmailVerificationToken(uint(1)).Return(nil, gorm.ErrRecordNotFound)
+	mockTokens.EXPECT().CreateEmailVerificationToken(gomock.Any()).DoAndReturn(
+		func(token *models.EmailVerificationToken) (*models.EmailVerificationToken, error) {
+			assert.Equal(t, "johnny@example.com", token.Email)
+			return token, nil
+		},
+	)
+
+	require.NoError(t, svc.ResendVerification(1))
+	sent := mail.Sent()
+	require.Len(t, sent, 1)
+	assert.Equal(t, "johnny@example.com", sent[0].To)
+}
+
+func TestResendVerification_TooSoon(t *testing.T) {
+	tests := []struct {
+		name   string
+		user   *models.User
+		latest string
+	}{
+		{name: "same email", user: &models.User{Username: "john", Email: "john@example.com"}, latest: "john@example.com"},
+		// Switching emails back and forth doesn't get around the limit
+		{name: "email changed since", user: &models.User{Username: "john", Email: "john@example.com", EmailVerified: true, PendingEmail: "johnny@example.com"}, latest: "jhonny@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockUserRepository(ctrl)
+			mockTokens := mocks.NewMockTokenRepository(ctrl)
+			mail := mailer.NewMemoryMailer()
+			svc := &services.AuthServiceImpl{
+				AuthRepo:     mockRepo,
+				TokenRepo:    mockTokens,
+				Mailer:       mail,
+				Verification: services.EmailVerificationSettings{ResendInterval: time.Minute},
+			}
+
+			tt.user.ID = 1
+			mockRepo.EXPECT().GetUserByID(uint(1)).Return(tt.user, nil)
+			mockTokens.EXPECT().GetLatestEmailVerificationToken(uint(1)).Return(&models.EmailVerificationToken{Email: tt.latest, CreatedAt: time.Now().Add(-20 * time.Second)}, nil)
+
+			// Nothing is sent; the caller learns how long to wait
+			err := svc.ResendVerification(1)
+			var retryErr *services.RetryLaterError
+			require.ErrorAs(t, err, &retryErr)
+			assert.InDelta(t, 40*time.Second, retryErr.RetryAfter, float64(5*time.Second))
+			assert.Empty(t, mail.Sent())
+		})
+	}
+}
+
+func TestResendVerification_Refused(t *testing.T) {
+	verified := &models.User{Username: "john", Email: "john@example.com", EmailVerified: true}
+	tests := []struct {
+		name    string
+		user    *models.User
+		userErr error
+		want    error
+	}{
+		{name: "already verified", user: verified, want: services.ErrEmailAlreadyVerified},
+		{name: "unknown user", userErr: gorm.ErrRecordNotFound, want: services.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockUserRepository(ctrl)
+			svc := &services.AuthServiceImpl{AuthRepo: mockRepo, TokenRepo: mocks.NewMockTokenRepository(ctrl), Mailer: mailer.NewMemoryMailer()}
+
+			mockRepo.EXPECT().GetUserByID(uint(1)).Return(tt.user, tt.userErr)
+
+			assert.ErrorIs(t, svc.ResendVerification(1), tt.want)
+		})
+	}
+}
+
+func TestResendVerification_DatabaseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockTokenRepository(ctrl)
+	svc := &services.AuthServiceImpl{AuthRepo: mockRepo, TokenRepo: mockTokens, Mailer: mailer.NewMemoryMailer()}
+
+	mockRepo.EXPECT().GetUserByID(uint(1)).Return(&models.User{Email: "john@example.com"}, nil)
+	mockTokens.EXPECT().GetLatestEmailVerificationToken(uint(1)).Return(nil, errors.New("connection reset"))
+
+	err := svc.ResendVerification(1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected error fetching email verification token")
+}
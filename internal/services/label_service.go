@@ -0,0 +1,258 @@
+// internal/services/label_service.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrLabelNotFound      = errors.New("label not found")
+	ErrLabelNameRequired  = errors.New("label name is required")
+	ErrInvalidLabelColor  = errors.New("label colour must be a hex colour like #1e90ff")
+	ErrLabelExists        = errors.New("a label with this name already exists")
+	ErrLabelScopeMismatch = errors.New("label belongs to another project or user")
+	ErrLabelMergeSelf     = errors.New("a label can't be merged into itself")
+)
+
+// DefaultLabelColor is used for labels created without a colour
+const DefaultLabelColor = "#9e9e9e"
+
+var labelColorPattern = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+// LabelService interface defines the methods for label-related business operations
+type LabelService interface {
+	CreateLabel(label *models.Label, userID uint) (*models.Label, error)
+	GetLabelByID(id, userID uint) (*models.Label, error)
+	GetPersonalLabels(userID uint) ([]models.Label, error)
+	GetProjectLabels(projectID, userID uint) ([]models.Label, error)
+	UpdateLabel(label *models.Label, userID uint) (*models.Label, error)
+	DeleteLabel(id, userID uint) error
+	MergeLabels(sourceID, targetID, userID uint) (*models.Label, error)
+	AddTaskLabel(taskID, labelID, userID uint) (*models.Task, error)
+	RemoveTaskLabel(taskID, labelID, userID uint) (*models.Task, error)
+}
+
+// LabelServiceImpl is the concrete implementation of the LabelService interface
+type LabelServiceImpl struct {
+	LabelRepo   repositories.LabelRepository
+	TaskRepo    repositories.TaskRepository
+	ProjectRepo repositories.ProjectRepository
+	tasks       *TaskServiceImpl
+}
+
+// NewLabelService creates and returns a new LabelService instance
+func NewLabelService(labelRepo repositories.LabelRepository, taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository, tasks *TaskServiceImpl) LabelService {
+	return &LabelServiceImpl{
+		LabelRepo:   labelRepo,
+		TaskRepo:    taskRepo,
+		ProjectRepo: projectRepo,
+		tasks:       tasks,
+	}
+}
+
+// validateLabel normalises a label's name and colour (internal helper)
+func validateLabel(label *models.Label) error {
+	label.Name = strings.TrimSpace(label.Name)
+	if label.Name == "" {
+		return ErrLabelNameRequired
+	}
+
+	label.Color = strings.ToLower(strings.TrimSpace(label.Color))
+	if label.Color == "" {
+		label.Color = DefaultLabelColor
+	}
+	if !labelColorPattern.MatchString(label.Color) {
+		return ErrInvalidLabelColor
+	}
+	return nil
+}
+
+// checkLabelAccess verifies the user holds at least minRole on a label.
+// Personal labels are only accessible to their owner.
+func (s *LabelServiceImpl) checkLabelAccess(label *models.Label, userID uint, minRole string) error {
+	if label.ProjectID == nil {
+		if label.UserID == nil || *label.UserID != userID {
+			return ErrLabelNotFound
+		}
+		return nil
+	}
+
+	_, err := requireProjectRole(s.ProjectRepo, *label.ProjectID, userID, minRole)
+	if errors.Is(err, ErrProjectNotFound) {
+		return ErrLabelNotFound
+	}
+	return err
+}
+
+// loadLabel fetches a label and makes sure the user holds minRole on it (internal helper)
+func (s *LabelServiceImpl) loadLabel(id, userID uint, minRole string) (*models.Label, error) {
+	label, err := s.LabelRepo.GetLabelByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLabelNotFound
+		}
+		return nil, fmt.Errorf("unexpected error fetching label: %v", err)
+	}
+	if err := s.checkLabelAccess(label, userID, minRole); err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+// ensureNameFree makes sure no other label of the same scope has the name (internal helper)
+func (s *LabelServiceImpl) ensureNameFree(label *models.Label) error {
+	existing, err := s.LabelRepo.GetLabelByName(label.UserID, label.ProjectID, label.Name)
+	if err == nil && existing.ID != label.ID {
+		return ErrLabelExists
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("unexpected error fetching label: %v", err)
+	}
+	return nil
+}
+
+// sameLabelScope reports whether a label can tag the task: project tasks take
+// their project's labels, personal tasks their owner's personal labels
+func sameLabelScope(label *models.Label, task *models.Task) bool {
+	if task.ProjectID != nil {
+		return label.ProjectID != nil && *label.ProjectID == *task.ProjectID
+	}
+	return label.ProjectID == nil && label.UserID != nil && *label.UserID == task.UserID
+}
+
+// CreateLabel creates a project label when the label has a project, and a
+// personal label of the user otherwise. Project labels require an editor role.
+func (s *LabelServiceImpl) CreateLabel(label *models.Label, userID uint) (*models.Label, error) {
+	if err := validateLabel(label); err != nil {
+		return nil, err
+	}
+
+	label.ID = 0
+	if label.ProjectID != nil {
+		if _, err := requireProjectRole(s.ProjectRepo, *label.ProjectID, userID, models.ProjectRoleEditor); err != nil {
+			return nil, err
+		}
+		label.UserID = nil
+	} else {
+		label.UserID = &userID
+	}
+
+	if err := s.ensureNameFree(label); err != nil {
+		return nil, err
+	}
+	return s.LabelRepo.CreateLabel(label)
+}
+
+// GetLabelByID retrieves a label the user may see
+func (s *LabelServiceImpl) GetLabelByID(id, userID uint) (*models.Label, error) {
+	return s.loadLabel(id, userID, models.ProjectRoleViewer)
+}
+
+// GetPersonalLabels retrieves the user's personal labels
+func (s *LabelServiceImpl) GetPersonalLabels(userID uint) ([]models.Label, error) {
+	return s.LabelRepo.GetLabelsByUserID(userID)
+}
+
+// GetProjectLabels retrieves the labels of a project the user is a member of
+func (s *LabelServiceImpl) GetProjectLabels(projectID, userID uint) ([]models.Label, error) {
+	if _, err := requireProjectRole(s.ProjectRepo, projectID, userID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+	return s.LabelRepo.GetLabelsByProjectID(projectID)
+}
+
+// UpdateLabel renames or recolours a label; its scope can't change.
+// Renaming onto another label's name is refused, see MergeLabels.
+func (s *LabelServiceImpl) UpdateLabel(label *models.Label, userID uint) (*models.Label, error) {
+	existing, err := s.loadLabel(label.ID, userID, models.ProjectRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	label.UserID = existing.UserID
+	label.ProjectID = existing.ProjectID
+
+	if err := validateLabel(label); err != nil {
+		return nil, err
+	}
+	if err := s.ensureNameFree(label); err != nil {
+		return nil, err
+	}
+	return s.LabelRepo.UpdateLabel(label)
+}
+
+// DeleteLabel deletes a label, removing it from every task it tags
+func (s *LabelServiceImpl) DeleteLabel(id, userID uint) error {
+	if _, err := s.loadLabel(id, userID, models.ProjectRoleEditor); err != nil {
+		return err
+	}
+	return s.LabelRepo.DeleteLabel(id)
+}
+
+// MergeLabels moves every task tagged with the source label over to the
+// target label and deletes the source. Both labels must share their scope.
+func (s *LabelServiceImpl) MergeLabels(sourceID, targetID, userID uint) (*models.Label, error) {
+	if sourceID == targetID {
+		return nil, ErrLabelMergeSelf
+	}
+	source, err := s.loadLabel(sourceID, userID, models.ProjectRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.loadLabel(targetID, userID, models.ProjectRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	sameProject := source.ProjectID != nil && target.ProjectID != nil && *source.ProjectID == *target.ProjectID
+	bothPersonal := source.ProjectID == nil && target.ProjectID == nil
+	if !sameProject && !bothPersonal {
+		return nil, ErrLabelScopeMismatch
+	}
+
+	if err := s.LabelRepo.MergeLabels(sourceID, targetID); err != nil {
+		return nil, fmt.Errorf("failed to merge labels: %v", err)
+	}
+	return target, nil
+}
+
+// AddTaskLabel tags a task the user may edit with a label of the task's scope
+func (s *LabelServiceImpl) AddTaskLabel(taskID, labelID, userID uint) (*models.Task, error) {
+	task, err := s.tasks.loadTask(taskID, userID, models.ProjectRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	label, err := s.loadLabel(labelID, userID, models.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	if !sameLabelScope(label, task) {
+		return nil, ErrLabelScopeMismatch
+	}
+
+	if err := s.LabelRepo.AddTaskLabel(taskID, labelID); err != nil {
+		return nil, fmt.Errorf("failed to label task: %v", err)
+	}
+	return s.tasks.loadTask(taskID, userID, models.ProjectRoleViewer)
+}
+
+// RemoveTaskLabel removes a label from a task the user may edit
+func (s *LabelServiceImpl) RemoveTaskLabel(taskID, labelID, userID uint) (*models.Task, error) {
+	if _, err := s.tasks.loadTask(taskID, userID, models.ProjectRoleEditor); err != nil {
+		return nil, err
+	}
+
+	if err := s.LabelRepo.RemoveTaskLabel(taskID, labelID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLabelNotFound
+		}
+		return nil, fmt.Errorf("failed to unlabel task: %v", err)
+	}
+	return s.tasks.loadTask(taskID, userID, models.ProjectRoleViewer)
+}
@@ -0,0 +1,178 @@
+package services_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestCreateLabel_PersonalDefaultsAndValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLabels := mocks.NewMockLabelRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	labelSvc := services.NewLabelService(mockLabels, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockLabels.EXPECT().GetLabelByName(uintPtr(1), nil, "urgent").Return(nil, gorm.ErrRecordNotFound)
+	mockLabels.EXPECT().CreateLabel(gomock.Any()).DoAndReturn(
+		func(label *models.Label) (*models.Label, error) { return label, nil },
+	)
+
+	label, err := labelSvc.CreateLabel(&models.Label{Name: "  urgent "}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "urgent", label.Name)
+	assert.Equal(t, services.DefaultLabelColor, label.Color)
+	assert.Equal(t, uintPtr(1), label.UserID)
+
+	_, err = labelSvc.CreateLabel(&models.Label{Name: " "}, 1)
+	assert.ErrorIs(t, err, services.ErrLabelNameRequired)
+
+	_, err = labelSvc.CreateLabel(&models.Label{Name: "bug", Color: "red"}, 1)
+	assert.ErrorIs(t, err, services.ErrInvalidLabelColor)
+}
+
+func TestCreateLabel_DuplicateNameIgnoresCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLabels := mocks.NewMockLabelRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	labelSvc := services.NewLabelService(mockLabels, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleEditor), nil)
+	mockLabels.EXPECT().GetLabelByName(nil, uintPtr(3), "Bug").Return(&models.Label{ID: 4, Name: "bug", ProjectID: uintPtr(3)}, nil)
+	mockLabels.EXPECT().CreateLabel(gomock.Any()).Times(0)
+
+	_, err := labelSvc.CreateLabel(&models.Label{Name: "Bug", Color: "#FF0000", ProjectID: uintPtr(3)}, 1)
+	assert.ErrorIs(t, err, services.ErrLabelExists)
+}
+
+func TestCreateLabel_ProjectRequiresEditor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLabels := mocks.NewMockLabelRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	labelSvc := services.NewLabelService(mockLabels, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockProjects.EXPECT().GetMember(uint(3), uint(2)).Return(memberWithRole(models.ProjectRoleViewer), nil)
+	mockLabels.EXPECT().CreateLabel(gomock.Any()).Times(0)
+
+	_, err := labelSvc.CreateLabel(&models.Label{Name: "bug", ProjectID: uintPtr(3)}, 2)
+	assert.ErrorIs(t, err, services.ErrProjectForbidden)
+}
+
+func TestMergeLabels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLabels := mocks.NewMockLabelRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	labelSvc := services.NewLabelService(mockLabels, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleEditor), nil).AnyTimes()
+
+	mockLabels.EXPECT().GetLabelByID(uint(4)).Return(&models.Label{ID: 4, Name: "bugs", ProjectID: uintPtr(3)}, nil)
+	mockLabels.EXPECT().GetLabelByID(uint(5)).Return(&models.Label{ID: 5, Name: "bug", ProjectID: uintPtr(3)}, nil)
+	mockLabels.EXPECT().MergeLabels(uint(4), uint(5)).Return(nil)
+
+	target, err := labelSvc.MergeLabels(4, 5, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint(5), target.ID)
+
+	_, err = labelSvc.MergeLabels(5, 5, 1)
+	assert.ErrorIs(t, err, services.ErrLabelMergeSelf)
+}
+
+func TestMergeLabels_ScopeMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLabels := mocks.NewMockLabelRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	labelSvc := services.NewLabelService(mockLabels, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleEditor), nil)
+	mockLabels.EXPECT().GetLabelByID(uint(4)).Return(&models.Label{ID: 4, Name: "bug", ProjectID: uintPtr(3)}, nil)
+	mockLabels.EXPECT().GetLabelByID(uint(6)).Return(&models.Label{ID: 6, Name: "bug", UserID: uintPtr(1)}, nil)
+	mockLabels.EXPECT().MergeLabels(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := labelSvc.MergeLabels(4, 6, 1)
+	assert.ErrorIs(t, err, services.ErrLabelScopeMismatch)
+}
+
+func TestAddTaskLabel_ScopeMustMatchTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLabels := mocks.NewMockLabelRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	labelSvc := services.NewLabelService(mockLabels, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+	mockProjects.EXPECT().GetMember(gomock.Any(), uint(1)).Return(memberWithRole(models.ProjectRoleEditor), nil).AnyTimes()
+
+	// A label of project 7 can't tag a task of project 3
+	mockTasks.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil)
+	mockLabels.EXPECT().GetLabelByID(uint(9)).Return(&models.Label{ID: 9, Name: "infra", ProjectID: uintPtr(7)}, nil)
+	mockLabels.EXPECT().AddTaskLabel(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := labelSvc.AddTaskLabel(5, 9, 1)
+	assert.ErrorIs(t, err, services.ErrLabelScopeMismatch)
+
+	labelled := projectTaskWithAssignees()
+	labelled.Labels = []models.Label{{ID: 4, Name: "bug", ProjectID: uintPtr(3)}}
+	gomock.InOrder(
+		mockTasks.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil),
+		mockLabels.EXPECT().GetLabelByID(uint(4)).Return(&models.Label{ID: 4, Name: "bug", ProjectID: uintPtr(3)}, nil),
+		mockLabels.EXPECT().AddTaskLabel(uint(5), uint(4)).Return(nil),
+		mockTasks.EXPECT().GetTaskByID(uint(5)).Return(labelled, nil),
+	)
+
+	task, err := labelSvc.AddTaskLabel(5, 4, 1)
+	require.NoError(t, err)
+	require.Len(t, task.Labels, 1)
+	assert.Equal(t, "bug", task.Labels[0].Name)
+}
+
+func TestGetLabelByID_PersonalLabelsArePrivate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLabels := mocks.NewMockLabelRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	labelSvc := services.NewLabelService(mockLabels, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockLabels.EXPECT().GetLabelByID(uint(6)).Return(&models.Label{ID: 6, Name: "home", UserID: uintPtr(1)}, nil).Times(2)
+
+	_, err := labelSvc.GetLabelByID(6, 2)
+	assert.ErrorIs(t, err, services.ErrLabelNotFound)
+
+	label, err := labelSvc.GetLabelByID(6, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "home", label.Name)
+}
+
+func TestGetTasksByUser_LabelFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	mockRepo.EXPECT().GetTasksByUserID(uint(1), repositories.LabelFilter{Names: []string{"bug", "ui"}, MatchAll: true}).Return(nil, nil)
+	_, err := taskSvc.GetTasksByUser(1, []string{"bug", " ", " ui"}, true)
+	require.NoError(t, err)
+}
@@ -0,0 +1,153 @@
+package services_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"TaskManager/pkg/mailer"
+	"TaskManager/pkg/utils"
+	"errors"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// resetLink finds the password reset link in a mail body
+var resetLink = regexp.MustCompile(`https://app\.example\.com/reset\S*`)
+
+func TestForgotPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockTokenRepository(ctrl)
+	mail := mailer.NewMemoryMailer()
+	svc := &services.AuthServiceImpl{
+		AuthRepo:  mockRepo,
+		TokenRepo: mockTokens,
+		Mailer:    mail,
+		Reset:     services.PasswordResetSettings{TTL: time.Hour, URL: "https://app.example.com/reset?lang=en"},
+	}
+
+	user := &models.User{Username: "john", Email: "john@example.com"}
+	user.ID = 1
+	mockRepo.EXPECT().GetUserByEmail("john@example.com").Return(user, nil)
+	var saved *models.PasswordResetToken
+	mockTokens.EXPECT().CreatePasswordResetToken(gomock.Any()).DoAndReturn(
+		func(token *models.PasswordResetToken) (*models.PasswordResetToken, error) {
+			saved = token
+			return token, nil
+		},
+	)
+
+	require.NoError(t, svc.ForgotPassword("john@example.com"))
+
+	sent := mail.Sent()
+	require.Len(t, sent, 1)
+	assert.Equal(t, "john@example.com", sent[0].To)
+
+	// The mail carries the token, of which only the hash is stored
+	link, err := url.Parse(resetLink.FindString(sent[0].Body))
+	require.NoError(t, err)
+	assert.Equal(t, "en", link.Query().Get("lang"))
+	token := link.Query().Get("token")
+	require.NotEmpty(t, token)
+	require.NotNil(t, saved)
+	assert.Equal(t, uint(1), saved.UserID)
+	assert.Equal(t, utils.HashToken(token), saved.TokenHash)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), saved.ExpiresAt, time.Minute)
+}
+
+func TestForgotPassword_UnknownEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mail := mailer.NewMemoryMailer()
+	svc := &services.AuthServiceImpl{AuthRepo: mockRepo, TokenRepo: mocks.NewMockTokenRepository(ctrl), Mailer: mail}
+
+	// No token is stored and no mail sent, yet it isn't an error
+	mockRepo.EXPECT().GetUserByEmail("nobody@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+	require.NoError(t, svc.ForgotPassword("nobody@example.com"))
+	assert.Empty(t, mail.Sent())
+}
+
+func TestResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTokens := mocks.NewMockTokenRepository(ctrl)
+	svc := &services.AuthServiceImpl{
+		TokenRepo:    mockTokens,
+		Revocations:  services.NewTokenRevocationStore(mockTokens, time.Minute, time.Minute),
+		HashPassword: func(pw string) (string, error) { return "hashed:" + pw, nil },
+	}
+
+	reset := &models.PasswordResetToken{ID: 7, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}
+	gomock.InOrder(
+		mockTokens.EXPECT().GetPasswordResetTokenByHash(utils.HashToken("resetToken")).Return(reset, nil),
+		mockTokens.EXPECT().ResetPassword(uint(7), uint(1), "hashed:newPass").Return(nil),
+		// Every session of the user is logged out
+		mockTokens.EXPECT().IncrementTokenVersion(uint(1)).Return(4, nil),
+		mockTokens.EXPECT().RevokeUserTokenFamilies(uint(1)).Return(nil),
+	)
+
+	require.NoError(t, svc.ResetPassword("resetToken", "newPass"))
+}
+
+func TestResetPassword_InvalidToken(t *testing.T) {
+	usedAt := time.Now().Add(-time.Minute)
+	tests := []struct {
+		name     string
+		reset    *models.PasswordResetToken
+		err      error
+		resetErr error
+	}{
+		{name: "unknown token", err: gorm.ErrRecordNotFound},
+		{name: "used token", reset: &models.PasswordResetToken{ID: 7, UserID: 1, ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}},
+		{name: "expired token", reset: &models.PasswordResetToken{ID: 7, UserID: 1, ExpiresAt: time.Now().Add(-time.Second)}},
+		{name: "used concurrently", reset: &models.PasswordResetToken{ID: 7, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, resetErr: gorm.ErrRecordNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// No session is logged out
+			mockTokens := mocks.NewMockTokenRepository(ctrl)
+			svc := &services.AuthServiceImpl{
+				TokenRepo:    mockTokens,
+				HashPassword: func(pw string) (string, error) { return "hashed:" + pw, nil },
+			}
+
+			mockTokens.EXPECT().GetPasswordResetTokenByHash(utils.HashToken("resetToken")).Return(tt.reset, tt.err)
+			if tt.resetErr != nil {
+				mockTokens.EXPECT().ResetPassword(uint(7), uint(1), "hashed:newPass").Return(tt.resetErr)
+			}
+
+			assert.ErrorIs(t, svc.ResetPassword("resetToken", "newPass"), services.ErrInvalidResetToken)
+		})
+	}
+}
+
+func TestResetPassword_DatabaseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTokens := mocks.NewMockTokenRepository(ctrl)
+	svc := &services.AuthServiceImpl{TokenRepo: mockTokens}
+
+	mockTokens.EXPECT().GetPasswordResetTokenByHash(gomock.Any()).Return(nil, errors.New("connection reset"))
+
+	err := svc.ResetPassword("resetToken", "newPass")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, services.ErrInvalidResetToken)
+}
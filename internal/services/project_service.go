@@ -0,0 +1,347 @@
+// internal/services/project_service.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"TaskManager/pkg/blobstore"
+	"TaskManager/pkg/schedule"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrProjectNotFound     = errors.New("project not found")
+	ErrProjectForbidden    = errors.New("insufficient project permissions")
+	ErrProjectNameRequired = errors.New("project name is required")
+	ErrInvalidProjectRole  = errors.New("invalid project role")
+	ErrMemberNotFound      = errors.New("project member not found")
+	ErrMemberExists        = errors.New("user is already a project member")
+	ErrLastOwner           = errors.New("a project must keep at least one owner")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrScheduleCycle       = errors.New("project tasks have circular dependencies")
+)
+
+// ProjectSchedule is the critical-path schedule of a project's tasks
+type ProjectSchedule struct {
+	ProjectID uint
+	Tasks     map[uint]models.Task
+	Schedule  *schedule.Schedule
+}
+
+// projectRoleRank orders roles so permissions can be compared
+var projectRoleRank = map[string]int{
+	models.ProjectRoleViewer: 1,
+	models.ProjectRoleEditor: 2,
+	models.ProjectRoleOwner:  3,
+}
+
+// requireProjectRole returns the user's membership if it grants at least minRole.
+// Non-members get ErrProjectNotFound so project IDs can't be probed.
+func requireProjectRole(repo repositories.ProjectRepository, projectID, userID uint, minRole string) (*models.ProjectMember, error) {
+	member, err := repo.GetMember(projectID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, fmt.Errorf("unexpected error checking project membership: %v", err)
+	}
+	if projectRoleRank[member.Role] < projectRoleRank[minRole] {
+		return nil, ErrProjectForbidden
+	}
+	return member, nil
+}
+
+// ProjectService interface defines the methods for project-related business operations
+type ProjectService interface {
+	CreateProject(project *models.Project) (*models.Project, error)
+	GetProjectByID(id, userID uint) (*models.Project, error)
+	GetProjectsByUser(userID uint) ([]models.Project, error)
+	UpdateProject(project *models.Project, userID uint) (*models.Project, error)
+	DeleteProject(id, userID uint) error
+	GetMembers(projectID, userID uint) ([]models.ProjectMember, error)
+	AddMember(projectID, userID, memberID uint, role string) (*models.ProjectMember, error)
+	UpdateMemberRole(projectID, userID, memberID uint, role string) (*models.ProjectMember, error)
+	RemoveMember(projectID, userID, memberID uint) error
+	GetProjectTasks(projectID, userID uint) ([]models.Task, error)
+	QueryProjectTasks(projectID, userID uint, query TaskListQuery) ([]models.Task, error)
+	GetProjectSchedule(projectID, userID uint, start time.Time) (*ProjectSchedule, error)
+}
+
+// ProjectServiceImpl is the concrete implementation of the ProjectService interface
+type ProjectServiceImpl struct {
+	ProjectRepo repositories.ProjectRepository
+	TaskRepo    repositories.TaskRepository
+	UserRepo    repositories.UserRepository
+	Blobs       blobstore.BlobStore
+}
+
+// NewProjectService creates and returns a new ProjectService instance
+func NewProjectService(projectRepo repositories.ProjectRepository, taskRepo repositories.TaskRepository, userRepo repositories.UserRepository, blobs blobstore.BlobStore) ProjectService {
+	return &ProjectServiceImpl{
+		ProjectRepo: projectRepo,
+		TaskRepo:    taskRepo,
+		UserRepo:    userRepo,
+		Blobs:       blobs,
+	}
+}
+
+// CreateProject creates a project and makes its creator the owner
+func (s *ProjectServiceImpl) CreateProject(project *models.Project) (*models.Project, error) {
+	project.Name = strings.TrimSpace(project.Name)
+	if project.Name == "" {
+		return nil, ErrProjectNameRequired
+	}
+
+	createdProject, err := s.ProjectRepo.CreateProject(project)
+	if err != nil {
+		log.Println("Error creating project:", err)
+		return nil, fmt.Errorf("failed to create project: %v", err)
+	}
+	return createdProject, nil
+}
+
+// GetProjectByID retrieves a project the user is a member of
+func (s *ProjectServiceImpl) GetProjectByID(id, userID uint) (*models.Project, error) {
+	if _, err := requireProjectRole(s.ProjectRepo, id, userID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	project, err := s.ProjectRepo.GetProjectByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+	return project, nil
+}
+
+// GetProjectsByUser retrieves all projects the user is a member of
+func (s *ProjectServiceImpl) GetProjectsByUser(userID uint) ([]models.Project, error) {
+	return s.ProjectRepo.GetProjectsByUserID(userID)
+}
+
+// UpdateProject saves changes to a project; only owners may do this
+func (s *ProjectServiceImpl) UpdateProject(project *models.Project, userID uint) (*models.Project, error) {
+	if _, err := requireProjectRole(s.ProjectRepo, project.ID, userID, models.ProjectRoleOwner); err != nil {
+		return nil, err
+	}
+
+	project.Name = strings.TrimSpace(project.Name)
+	if project.Name == "" {
+		return nil, ErrProjectNameRequired
+	}
+	return s.ProjectRepo.UpdateProject(project)
+}
+
+// DeleteProject deletes a project, its tasks and their attachments; only owners may do this
+func (s *ProjectServiceImpl) DeleteProject(id, userID uint) error {
+	if _, err := requireProjectRole(s.ProjectRepo, id, userID, models.ProjectRoleOwner); err != nil {
+		return err
+	}
+	keys, err := s.ProjectRepo.DeleteProject(id)
+	if err != nil {
+		return err
+	}
+	deleteBlobs(s.Blobs, keys)
+	return nil
+}
+
+// GetMembers lists the members of a project the user belongs to
+func (s *ProjectServiceImpl) GetMembers(projectID, userID uint) ([]models.ProjectMember, error) {
+	if _, err := requireProjectRole(s.ProjectRepo, projectID, userID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+	return s.ProjectRepo.GetMembers(projectID)
+}
+
+// AddMember adds an existing user to a project; only owners may do this
+func (s *ProjectServiceImpl) AddMember(projectID, userID, memberID uint, role string) (*models.ProjectMember, error) {
+	if _, ok := projectRoleRank[role]; !ok {
+		return nil, ErrInvalidProjectRole
+	}
+	if _, err := requireProjectRole(s.ProjectRepo, projectID, userID, models.ProjectRoleOwner); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.UserRepo.GetUserByID(memberID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("unexpected error checking user: %v", err)
+	}
+
+	if _, err := s.ProjectRepo.GetMember(projectID, memberID); err == nil {
+		return nil, ErrMemberExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("unexpected error checking project membership: %v", err)
+	}
+
+	return s.ProjectRepo.AddMember(&models.ProjectMember{
+		ProjectID: projectID,
+		UserID:    memberID,
+		Role:      role,
+	})
+}
+
+// UpdateMemberRole changes a member's role; only owners may do this
+func (s *ProjectServiceImpl) UpdateMemberRole(projectID, userID, memberID uint, role string) (*models.ProjectMember, error) {
+	if _, ok := projectRoleRank[role]; !ok {
+		return nil, ErrInvalidProjectRole
+	}
+	if _, err := requireProjectRole(s.ProjectRepo, projectID, userID, models.ProjectRoleOwner); err != nil {
+		return nil, err
+	}
+
+	member, err := s.getMember(projectID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role == models.ProjectRoleOwner && role != models.ProjectRoleOwner {
+		if err := s.ensureAnotherOwner(projectID, memberID); err != nil {
+			return nil, err
+		}
+	}
+
+	member.Role = role
+	return s.ProjectRepo.UpdateMember(member)
+}
+
+// RemoveMember removes a member from a project. Owners may remove anyone,
+// and every member may remove themselves.
+func (s *ProjectServiceImpl) RemoveMember(projectID, userID, memberID uint) error {
+	minRole := models.ProjectRoleOwner
+	if userID == memberID {
+		minRole = models.ProjectRoleViewer
+	}
+	if _, err := requireProjectRole(s.ProjectRepo, projectID, userID, minRole); err != nil {
+		return err
+	}
+
+	member, err := s.getMember(projectID, memberID)
+	if err != nil {
+		return err
+	}
+	if member.Role == models.ProjectRoleOwner {
+		if err := s.ensureAnotherOwner(projectID, memberID); err != nil {
+			return err
+		}
+	}
+	return s.ProjectRepo.RemoveMember(projectID, memberID)
+}
+
+// GetProjectTasks lists the tasks of a project the user belongs to
+func (s *ProjectServiceImpl) GetProjectTasks(projectID, userID uint) ([]models.Task, error) {
+	if _, err := requireProjectRole(s.ProjectRepo, projectID, userID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+	return s.TaskRepo.GetTasksByProjectID(projectID)
+}
+
+// QueryProjectTasks lists the tasks of a project the user belongs to,
+// filtered and sorted by their columns and custom field values
+func (s *ProjectServiceImpl) QueryProjectTasks(projectID, userID uint, query TaskListQuery) ([]models.Task, error) {
+	if _, err := requireProjectRole(s.ProjectRepo, projectID, userID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	var fields []models.CustomField
+	if len(query.Filters) > 0 || query.Sort != "" {
+		var err error
+		if fields, err = s.ProjectRepo.GetCustomFields(projectID); err != nil {
+			return nil, fmt.Errorf("unexpected error fetching custom fields: %v", err)
+		}
+	}
+	filters, sort, err := compileTaskListQuery(query, fields)
+	if err != nil {
+		return nil, err
+	}
+	return s.TaskRepo.QueryProjectTasks(projectID, filters, sort)
+}
+
+// GetProjectSchedule computes earliest/latest start and finish, slack and the
+// critical path of a project's tasks from their estimates, due dates and
+// blockers. Completed tasks take no further time.
+func (s *ProjectServiceImpl) GetProjectSchedule(projectID, userID uint, start time.Time) (*ProjectSchedule, error) {
+	tasks, err := s.GetProjectTasks(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]models.Task, len(tasks))
+	ids := make([]uint, 0, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+		ids = append(ids, task.ID)
+	}
+
+	dependencies, err := s.TaskRepo.GetDependencies(ids)
+	if err != nil {
+		return nil, err
+	}
+	dependsOn := map[uint][]uint{}
+	for _, dep := range dependencies {
+		if _, ok := byID[dep.BlockedByID]; ok {
+			dependsOn[dep.TaskID] = append(dependsOn[dep.TaskID], dep.BlockedByID)
+		}
+	}
+
+	items := make([]schedule.Item, 0, len(tasks))
+	for _, task := range tasks {
+		item := schedule.Item{
+			ID:        task.ID,
+			Deadline:  task.DueDate,
+			DependsOn: dependsOn[task.ID],
+		}
+		if !isTaskDone(&task) {
+			item.Duration = time.Duration(task.EstimateMinutes) * time.Minute
+		}
+		items = append(items, item)
+	}
+
+	result, err := schedule.Compute(start, items)
+	if err != nil {
+		if errors.Is(err, schedule.ErrCycle) {
+			return nil, ErrScheduleCycle
+		}
+		return nil, fmt.Errorf("failed to compute schedule: %v", err)
+	}
+
+	return &ProjectSchedule{
+		ProjectID: projectID,
+		Tasks:     byID,
+		Schedule:  result,
+	}, nil
+}
+
+// getMember fetches a membership, mapping a missing row to ErrMemberNotFound (internal helper)
+func (s *ProjectServiceImpl) getMember(projectID, memberID uint) (*models.ProjectMember, error) {
+	member, err := s.ProjectRepo.GetMember(projectID, memberID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, fmt.Errorf("unexpected error fetching project member: %v", err)
+	}
+	return member, nil
+}
+
+// ensureAnotherOwner makes sure the project keeps an owner besides memberID (internal helper)
+func (s *ProjectServiceImpl) ensureAnotherOwner(projectID, memberID uint) error {
+	members, err := s.ProjectRepo.GetMembers(projectID)
+	if err != nil {
+		return err
+	}
+	for _, m := range members {
+		if m.UserID != memberID && m.Role == models.ProjectRoleOwner {
+			return nil
+		}
+	}
+	return ErrLastOwner
+}
@@ -0,0 +1,156 @@
+package services_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestCreateProject_RequiresName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	projectSvc := services.NewProjectService(mockProjects, mocks.NewMockTaskRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	mockProjects.EXPECT().CreateProject(gomock.Any()).Times(0)
+
+	_, err := projectSvc.CreateProject(&models.Project{Name: "   ", OwnerID: 1})
+	assert.ErrorIs(t, err, services.ErrProjectNameRequired)
+}
+
+func TestGetProjectTasks_NonMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	projectSvc := services.NewProjectService(mockProjects, mockTasks, mocks.NewMockUserRepository(ctrl), nil)
+
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(nil, gorm.ErrRecordNotFound)
+	mockTasks.EXPECT().GetTasksByProjectID(gomock.Any()).Times(0)
+
+	tasks, err := projectSvc.GetProjectTasks(3, 1)
+	assert.ErrorIs(t, err, services.ErrProjectNotFound)
+	assert.Nil(t, tasks)
+}
+
+func TestGetProjectTasks_Member(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	projectSvc := services.NewProjectService(mockProjects, mockTasks, mocks.NewMockUserRepository(ctrl), nil)
+
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleViewer}, nil)
+	mockTasks.EXPECT().GetTasksByProjectID(uint(3)).Return([]models.Task{{Title: "A"}, {Title: "B"}}, nil)
+
+	tasks, err := projectSvc.GetProjectTasks(3, 1)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 2)
+}
+
+func TestAddMember_OnlyOwners(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockUsers := mocks.NewMockUserRepository(ctrl)
+	projectSvc := services.NewProjectService(mockProjects, mocks.NewMockTaskRepository(ctrl), mockUsers, nil)
+
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleEditor}, nil)
+	mockProjects.EXPECT().AddMember(gomock.Any()).Times(0)
+
+	_, err := projectSvc.AddMember(3, 1, 2, models.ProjectRoleViewer)
+	assert.ErrorIs(t, err, services.ErrProjectForbidden)
+}
+
+func TestAddMember_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockUsers := mocks.NewMockUserRepository(ctrl)
+	projectSvc := services.NewProjectService(mockProjects, mocks.NewMockTaskRepository(ctrl), mockUsers, nil)
+
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleOwner}, nil)
+	mockUsers.EXPECT().GetUserByID(uint(2)).Return(&models.User{Username: "jane"}, nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(2)).Return(nil, gorm.ErrRecordNotFound)
+	mockProjects.EXPECT().AddMember(gomock.Any()).DoAndReturn(
+		func(m *models.ProjectMember) (*models.ProjectMember, error) { return m, nil },
+	)
+
+	member, err := projectSvc.AddMember(3, 1, 2, models.ProjectRoleEditor)
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), member.UserID)
+	assert.Equal(t, models.ProjectRoleEditor, member.Role)
+}
+
+func TestAddMember_InvalidRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectSvc := services.NewProjectService(mocks.NewMockProjectRepository(ctrl), mocks.NewMockTaskRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	_, err := projectSvc.AddMember(3, 1, 2, "admin")
+	assert.ErrorIs(t, err, services.ErrInvalidProjectRole)
+}
+
+func TestRemoveMember_LastOwnerCannotLeave(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	projectSvc := services.NewProjectService(mockProjects, mocks.NewMockTaskRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	owner := &models.ProjectMember{ProjectID: 3, UserID: 1, Role: models.ProjectRoleOwner}
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(owner, nil).Times(2)
+	mockProjects.EXPECT().GetMembers(uint(3)).Return([]models.ProjectMember{
+		*owner,
+		{ProjectID: 3, UserID: 2, Role: models.ProjectRoleEditor},
+	}, nil)
+	mockProjects.EXPECT().RemoveMember(gomock.Any(), gomock.Any()).Times(0)
+
+	err := projectSvc.RemoveMember(3, 1, 1)
+	assert.ErrorIs(t, err, services.ErrLastOwner)
+}
+
+func TestGetProjectSchedule_UsesEstimatesAndBlockers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	projectSvc := services.NewProjectService(mockProjects, mockTasks, mocks.NewMockUserRepository(ctrl), nil)
+
+	tasks := []models.Task{
+		{Model: gorm.Model{ID: 1}, Title: "Design", EstimateMinutes: 120, Status: models.TaskStatusTodo},
+		{Model: gorm.Model{ID: 2}, Title: "Build", EstimateMinutes: 240, Status: models.TaskStatusTodo},
+		{Model: gorm.Model{ID: 3}, Title: "Docs", EstimateMinutes: 60, Status: models.TaskStatusTodo},
+		{Model: gorm.Model{ID: 4}, Title: "Kickoff", EstimateMinutes: 30, Status: models.TaskStatusDone, Closed: true},
+	}
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleViewer}, nil)
+	mockTasks.EXPECT().GetTasksByProjectID(uint(3)).Return(tasks, nil)
+	mockTasks.EXPECT().GetDependencies([]uint{1, 2, 3, 4}).Return([]models.TaskDependency{
+		{TaskID: 1, BlockedByID: 4},
+		{TaskID: 2, BlockedByID: 1},
+		{TaskID: 3, BlockedByID: 1},
+	}, nil)
+
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	result, err := projectSvc.GetProjectSchedule(3, 1, start)
+	require.NoError(t, err)
+
+	// The finished kickoff takes no time, so design -> build is the critical path
+	assert.Equal(t, start.Add(6*time.Hour), result.Schedule.Finish)
+	assert.Equal(t, []uint{4, 1, 2}, result.Schedule.CriticalPath)
+	assert.Equal(t, "Build", result.Tasks[2].Title)
+}
@@ -0,0 +1,70 @@
+// internal/services/search_service.go
+package services
+
+import (
+	"TaskManager/internal/repositories"
+	"TaskManager/pkg/textsearch"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Bounds of a full-text search
+const (
+	MaxSearchLength    = 200
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+// SearchService interface defines the methods for full-text search
+type SearchService interface {
+	Search(search string, limit int, userID uint) ([]repositories.SearchHit, error)
+}
+
+// SearchServiceImpl is the concrete implementation of the SearchService interface
+type SearchServiceImpl struct {
+	SearchRepo repositories.SearchRepository
+}
+
+// NewSearchService creates and returns a new SearchService instance
+func NewSearchService(searchRepo repositories.SearchRepository) SearchService {
+	return &SearchServiceImpl{
+		SearchRepo: searchRepo,
+	}
+}
+
+// Search finds the tasks and comments the user can see matching a search,
+// best first. A limit of 0 means DefaultSearchLimit; larger limits are
+// capped at MaxSearchLimit.
+func (s *SearchServiceImpl) Search(search string, limit int, userID uint) ([]repositories.SearchHit, error) {
+	problems := map[string]string{}
+
+	search = strings.TrimSpace(search)
+	switch {
+	case search == "":
+		problems["q"] = "is required"
+	case utf8.RuneCountInString(search) > MaxSearchLength:
+		problems["q"] = fmt.Sprintf("must be at most %d characters", MaxSearchLength)
+	case len(textsearch.Words(search)) == 0:
+		problems["q"] = "must contain a word"
+	}
+
+	switch {
+	case limit < 0:
+		problems["limit"] = "must be a positive number"
+	case limit == 0:
+		limit = DefaultSearchLimit
+	case limit > MaxSearchLimit:
+		limit = MaxSearchLimit
+	}
+
+	if len(problems) > 0 {
+		return nil, &ValidationError{Fields: problems}
+	}
+
+	hits, err := s.SearchRepo.Search(userID, search, limit)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error searching: %v", err)
+	}
+	return hits, nil
+}
@@ -0,0 +1,96 @@
+package services_test
+
+import (
+	"TaskManager/internal/repositories"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"TaskManager/pkg/textsearch"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		wantLimit int
+	}{
+		{name: "default limit", limit: 0, wantLimit: services.DefaultSearchLimit},
+		{name: "given limit", limit: 5, wantLimit: 5},
+		{name: "capped limit", limit: 1000, wantLimit: services.MaxSearchLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockSearchRepository(ctrl)
+			searchSvc := services.NewSearchService(mockRepo)
+
+			found := []repositories.SearchHit{{
+				Kind:    repositories.SearchHitTask,
+				TaskID:  5,
+				Title:   textsearch.Text{Text: "Login crash", Highlights: []textsearch.Span{{Start: 6, End: 11}}},
+				Snippet: textsearch.Text{Text: "", Highlights: []textsearch.Span{}},
+			}}
+			mockRepo.EXPECT().Search(uint(1), "crash", tt.wantLimit).Return(found, nil)
+
+			hits, err := searchSvc.Search("  crash ", tt.limit, 1)
+			require.NoError(t, err)
+			assert.Equal(t, found, hits)
+		})
+	}
+}
+
+func TestSearch_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		search     string
+		limit      int
+		wantFields []string
+	}{
+		{name: "missing search", search: "  ", wantFields: []string{"q"}},
+		{name: "search too long", search: strings.Repeat("a", services.MaxSearchLength+1), wantFields: []string{"q"}},
+		{name: "no words", search: `"-" !`, wantFields: []string{"q"}},
+		{name: "negative limit", search: "crash", limit: -1, wantFields: []string{"limit"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// The repository isn't queried for invalid searches
+			searchSvc := services.NewSearchService(mocks.NewMockSearchRepository(ctrl))
+
+			_, err := searchSvc.Search(tt.search, tt.limit, 1)
+
+			var validationErr *services.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Len(t, validationErr.Fields, len(tt.wantFields))
+			for _, field := range tt.wantFields {
+				assert.Contains(t, validationErr.Fields, field)
+			}
+		})
+	}
+}
+
+func TestSearch_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSearchRepository(ctrl)
+	searchSvc := services.NewSearchService(mockRepo)
+
+	mockRepo.EXPECT().Search(uint(1), "crash", services.DefaultSearchLimit).Return(nil, errors.New("connection reset"))
+
+	_, err := searchSvc.Search("crash", 0, 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected error searching")
+}
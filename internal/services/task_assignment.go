@@ -0,0 +1,126 @@
+// internal/services/task_assignment.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAssigneeNoAccess = errors.New("user has no access to the task")
+	ErrAlreadyAssigned  = errors.New("user is already assigned to the task")
+	ErrNotAssigned      = errors.New("user isn't assigned to the task")
+)
+
+// checkUserCanSee makes sure another user could open the task: personal
+// tasks are visible to their owner only, project tasks to the project's
+// members (internal helper)
+func (s *TaskServiceImpl) checkUserCanSee(task *models.Task, userID uint) error {
+	if _, err := s.UserRepo.GetUserByID(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("unexpected error fetching user: %v", err)
+	}
+
+	err := s.checkTaskAccess(task, userID, models.ProjectRoleViewer)
+	if errors.Is(err, ErrTaskNotFound) {
+		return ErrAssigneeNoAccess
+	}
+	return err
+}
+
+// hasTaskUser reports whether the user is among the given assignees or watchers
+func hasTaskUser(users []models.User, userID uint) bool {
+	for _, user := range users {
+		if user.ID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// AssignTask assigns a user to a task the caller may edit. The assignee
+// must exist and be able to see the task.
+func (s *TaskServiceImpl) AssignTask(taskID, assigneeID, userID uint) (*models.Task, error) {
+	task, err := s.loadTask(taskID, userID, models.ProjectRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	if hasTaskUser(task.Assignees, assigneeID) {
+		return nil, ErrAlreadyAssigned
+	}
+	if err := s.checkUserCanSee(task, assigneeID); err != nil {
+		return nil, err
+	}
+
+	if err := s.TaskRepo.AddAssignee(taskID, assigneeID); err != nil {
+		return nil, fmt.Errorf("failed to assign task: %v", err)
+	}
+	return s.loadTask(taskID, userID, models.ProjectRoleViewer)
+}
+
+// UnassignTask removes an assignee from a task. Editors may unassign anyone,
+// and assignees may always unassign themselves.
+func (s *TaskServiceImpl) UnassignTask(taskID, assigneeID, userID uint) (*models.Task, error) {
+	minRole := models.ProjectRoleEditor
+	if assigneeID == userID {
+		minRole = models.ProjectRoleViewer
+	}
+	if _, err := s.loadTask(taskID, userID, minRole); err != nil {
+		return nil, err
+	}
+
+	if err := s.TaskRepo.RemoveAssignee(taskID, assigneeID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotAssigned
+		}
+		return nil, fmt.Errorf("failed to unassign task: %v", err)
+	}
+	return s.loadTask(taskID, userID, models.ProjectRoleViewer)
+}
+
+// WatchTask makes the user watch a task they can see; watching twice is a no-op
+func (s *TaskServiceImpl) WatchTask(taskID, userID uint) (*models.Task, error) {
+	task, err := s.loadTask(taskID, userID, models.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	if hasTaskUser(task.Watchers, userID) {
+		return task, nil
+	}
+
+	if err := s.TaskRepo.AddWatcher(taskID, userID); err != nil {
+		return nil, fmt.Errorf("failed to watch task: %v", err)
+	}
+	return s.loadTask(taskID, userID, models.ProjectRoleViewer)
+}
+
+// UnwatchTask stops the user from watching a task; unwatching twice is a no-op
+func (s *TaskServiceImpl) UnwatchTask(taskID, userID uint) (*models.Task, error) {
+	task, err := s.loadTask(taskID, userID, models.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	if !hasTaskUser(task.Watchers, userID) {
+		return task, nil
+	}
+
+	if err := s.TaskRepo.RemoveWatcher(taskID, userID); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to unwatch task: %v", err)
+	}
+	return s.loadTask(taskID, userID, models.ProjectRoleViewer)
+}
+
+// GetAssignedTasks retrieves the tasks the user is assigned to and can still see
+func (s *TaskServiceImpl) GetAssignedTasks(userID uint) ([]models.Task, error) {
+	return s.TaskRepo.GetTasksAssignedTo(userID)
+}
+
+// GetWatchedTasks retrieves the tasks the user watches and can still see
+func (s *TaskServiceImpl) GetWatchedTasks(userID uint) ([]models.Task, error) {
+	return s.TaskRepo.GetTasksWatchedBy(userID)
+}
@@ -0,0 +1,187 @@
+package services_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// projectTaskWithAssignees is task 5 of project 3, owned by user 1
+func projectTaskWithAssignees(assigneeIDs ...uint) *models.Task {
+	task := &models.Task{Model: gorm.Model{ID: 5}, Title: "Release", Status: models.TaskStatusTodo, UserID: 1, ProjectID: uintPtr(3)}
+	for _, id := range assigneeIDs {
+		task.Assignees = append(task.Assignees, models.User{Model: gorm.Model{ID: id}})
+	}
+	return task
+}
+
+func memberWithRole(role string) *models.ProjectMember {
+	return &models.ProjectMember{Role: role}
+}
+
+func TestAssignTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockUsers := mocks.NewMockUserRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mockUsers, nil)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil),
+		mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleEditor), nil),
+		mockUsers.EXPECT().GetUserByID(uint(2)).Return(&models.User{Model: gorm.Model{ID: 2}}, nil),
+		mockProjects.EXPECT().GetMember(uint(3), uint(2)).Return(memberWithRole(models.ProjectRoleViewer), nil),
+		mockRepo.EXPECT().AddAssignee(uint(5), uint(2)).Return(nil),
+		mockRepo.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(2), nil),
+		mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleEditor), nil),
+	)
+
+	task, err := taskSvc.AssignTask(5, 2, 1)
+	require.NoError(t, err)
+	require.Len(t, task.Assignees, 1)
+	assert.Equal(t, uint(2), task.Assignees[0].ID)
+}
+
+func TestAssignTask_Validation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockUsers := mocks.NewMockUserRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mockUsers, nil)
+	mockRepo.EXPECT().AddAssignee(gomock.Any(), gomock.Any()).Times(0)
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleEditor), nil).AnyTimes()
+
+	// Unknown user
+	mockRepo.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil)
+	mockUsers.EXPECT().GetUserByID(uint(9)).Return(nil, gorm.ErrRecordNotFound)
+	_, err := taskSvc.AssignTask(5, 9, 1)
+	assert.ErrorIs(t, err, services.ErrUserNotFound)
+
+	// Not a member of the task's project
+	mockRepo.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil)
+	mockUsers.EXPECT().GetUserByID(uint(2)).Return(&models.User{Model: gorm.Model{ID: 2}}, nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(2)).Return(nil, gorm.ErrRecordNotFound)
+	_, err = taskSvc.AssignTask(5, 2, 1)
+	assert.ErrorIs(t, err, services.ErrAssigneeNoAccess)
+
+	// Already assigned
+	mockRepo.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(2), nil)
+	_, err = taskSvc.AssignTask(5, 2, 1)
+	assert.ErrorIs(t, err, services.ErrAlreadyAssigned)
+}
+
+func TestAssignTask_PersonalTaskOnlyToOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockUsers := mocks.NewMockUserRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mockUsers, nil)
+
+	mockRepo.EXPECT().GetTaskByID(uint(4)).Return(&models.Task{Model: gorm.Model{ID: 4}, Title: "Groceries", UserID: 1}, nil)
+	mockUsers.EXPECT().GetUserByID(uint(2)).Return(&models.User{Model: gorm.Model{ID: 2}}, nil)
+	mockRepo.EXPECT().AddAssignee(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := taskSvc.AssignTask(4, 2, 1)
+	assert.ErrorIs(t, err, services.ErrAssigneeNoAccess)
+}
+
+func TestUnassignTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil)
+
+	// Viewers may unassign themselves
+	mockProjects.EXPECT().GetMember(uint(3), uint(2)).Return(memberWithRole(models.ProjectRoleViewer), nil).AnyTimes()
+	mockRepo.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(2), nil)
+	mockRepo.EXPECT().RemoveAssignee(uint(5), uint(2)).Return(nil)
+	mockRepo.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil)
+
+	task, err := taskSvc.UnassignTask(5, 2, 2)
+	require.NoError(t, err)
+	assert.Empty(t, task.Assignees)
+
+	// ... but not others
+	mockRepo.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(4), nil)
+	_, err = taskSvc.UnassignTask(5, 4, 2)
+	assert.ErrorIs(t, err, services.ErrProjectForbidden)
+
+	// Unassigning someone who isn't assigned
+	mockRepo.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil)
+	mockRepo.EXPECT().RemoveAssignee(uint(5), uint(2)).Return(gorm.ErrRecordNotFound)
+	_, err = taskSvc.UnassignTask(5, 2, 2)
+	assert.ErrorIs(t, err, services.ErrNotAssigned)
+}
+
+func TestWatchTask_Idempotent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(2)).Return(memberWithRole(models.ProjectRoleViewer), nil).AnyTimes()
+
+	watched := projectTaskWithAssignees()
+	watched.Watchers = []models.User{{Model: gorm.Model{ID: 2}}}
+
+	mockRepo.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil)
+	mockRepo.EXPECT().AddWatcher(uint(5), uint(2)).Return(nil)
+	mockRepo.EXPECT().GetTaskByID(uint(5)).Return(watched, nil)
+	task, err := taskSvc.WatchTask(5, 2)
+	require.NoError(t, err)
+	assert.Len(t, task.Watchers, 1)
+
+	// Watching again doesn't touch the repository
+	mockRepo.EXPECT().GetTaskByID(uint(5)).Return(watched, nil)
+	_, err = taskSvc.WatchTask(5, 2)
+	require.NoError(t, err)
+
+	mockRepo.EXPECT().GetTaskByID(uint(5)).Return(watched, nil)
+	mockRepo.EXPECT().RemoveWatcher(uint(5), uint(2)).Return(nil)
+	mockRepo.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil)
+	task, err = taskSvc.UnwatchTask(5, 2)
+	require.NoError(t, err)
+	assert.Empty(t, task.Watchers)
+}
+
+func TestUpdateTask_AssigneeGuardFollowsAssignees(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil)
+
+	// Task 8 belongs to user 1 but is assigned to user 2
+	stored := &models.Task{Model: gorm.Model{ID: 8}, Title: "Review", Status: "backlog", UserID: 1, ProjectID: uintPtr(3),
+		Assignees: []models.User{{Model: gorm.Model{ID: 2}}}}
+	mockRepo.EXPECT().GetTaskByID(uint(8)).Return(stored, nil).Times(2)
+	mockProjects.EXPECT().GetMember(uint(3), gomock.Any()).Return(memberWithRole(models.ProjectRoleEditor), nil).AnyTimes()
+	mockRepo.EXPECT().GetSubtasks([]uint{8}).Return(nil, nil).Times(2)
+	mockProjects.EXPECT().GetWorkflow(uint(3)).Return(reviewWorkflow(), nil).Times(2)
+
+	// The owner is no longer the assignee
+	_, err := taskSvc.UpdateTask(&models.Task{Model: gorm.Model{ID: 8}, Title: "Review", Status: "in_review", ProjectID: uintPtr(3)}, 1)
+	assert.ErrorIs(t, err, services.ErrTransitionGuard)
+
+	mockRepo.EXPECT().UpdateTask(gomock.Any()).DoAndReturn(
+		func(task *models.Task) (*models.Task, error) { return task, nil },
+	)
+	task, err := taskSvc.UpdateTask(&models.Task{Model: gorm.Model{ID: 8}, Title: "Review", Status: "in_review", ProjectID: uintPtr(3)}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "in_review", task.Status)
+}
@@ -0,0 +1,301 @@
+// internal/services/task_checklist.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/pkg/rank"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrChecklistItemNotFound     = errors.New("checklist item not found")
+	ErrChecklistTextRequired     = errors.New("checklist item text is required")
+	ErrChecklistTextTooLong      = errors.New("checklist item text is too long")
+	ErrChecklistFull             = errors.New("checklist has too many items")
+	ErrInvalidChecklistAdvanceTo = errors.New("checklist_advance_to must be a state of the task's workflow")
+)
+
+// MaxChecklistItems caps the items of a single checklist
+const MaxChecklistItems = 200
+
+// MaxChecklistTextLength caps the text of a checklist item, in characters
+const MaxChecklistTextLength = 500
+
+// ChecklistToggle is the outcome of checking or unchecking an item. Task
+// carries the refreshed checklist counts; Advanced reports that the last
+// item completed the checklist and moved the task to its advance state.
+// AdvanceError explains why a wanted advance didn't happen, e.g. because
+// the workflow or an open blocker doesn't allow it.
+type ChecklistToggle struct {
+	Item         *models.ChecklistItem
+	Task         *models.Task
+	Advanced     bool
+	AdvanceError error
+}
+
+// validateChecklistText trims and checks an item's text (internal helper)
+func validateChecklistText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", ErrChecklistTextRequired
+	}
+	if utf8.RuneCountInString(text) > MaxChecklistTextLength {
+		return "", fmt.Errorf("%w: at most %d characters", ErrChecklistTextTooLong, MaxChecklistTextLength)
+	}
+	return text, nil
+}
+
+// prepareChecklist checks the initial checklist of a new task and ranks its
+// items in the order given (internal helper)
+func prepareChecklist(task *models.Task) error {
+	if len(task.Checklist) > MaxChecklistItems {
+		return fmt.Errorf("%w: at most %d", ErrChecklistFull, MaxChecklistItems)
+	}
+	ranks := rank.Spread(len(task.Checklist))
+	for i := range task.Checklist {
+		item := &task.Checklist[i]
+		text, err := validateChecklistText(item.Text)
+		if err != nil {
+			return err
+		}
+		*item = models.ChecklistItem{Text: text, Rank: ranks[i]}
+	}
+	task.ChecklistTotal = len(task.Checklist)
+	task.ChecklistDone = 0
+	return nil
+}
+
+// loadChecklistItem fetches an item of the task's checklist (internal helper)
+func (s *TaskServiceImpl) loadChecklistItem(taskID, itemID uint) (*models.ChecklistItem, error) {
+	item, err := s.TaskRepo.GetChecklistItem(taskID, itemID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrChecklistItemNotFound
+		}
+		return nil, fmt.Errorf("unexpected error fetching checklist item: %v", err)
+	}
+	return item, nil
+}
+
+// GetChecklist retrieves the ordered checklist of a task the user can see
+func (s *TaskServiceImpl) GetChecklist(taskID, userID uint) ([]models.ChecklistItem, error) {
+	if _, err := s.loadTask(taskID, userID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+	return s.TaskRepo.GetChecklistItems(taskID)
+}
+
+// AddChecklistItem appends an item to the checklist of a task the user may edit
+func (s *TaskServiceImpl) AddChecklistItem(taskID uint, text string, userID uint) (*models.ChecklistItem, error) {
+	task, err := s.loadTask(taskID, userID, models.ProjectRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	if text, err = validateChecklistText(text); err != nil {
+		return nil, err
+	}
+
+	items, err := s.TaskRepo.GetChecklistItems(task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error fetching checklist: %v", err)
+	}
+	if len(items) >= MaxChecklistItems {
+		return nil, fmt.Errorf("%w: at most %d", ErrChecklistFull, MaxChecklistItems)
+	}
+
+	item := models.ChecklistItem{TaskID: task.ID, Text: text}
+	item.Rank, err = s.checklistRankAt(items, len(items))
+	if err != nil {
+		return nil, err
+	}
+	return s.TaskRepo.CreateChecklistItem(&item)
+}
+
+// RenameChecklistItem changes the text of an item
+func (s *TaskServiceImpl) RenameChecklistItem(taskID, itemID uint, text string, userID uint) (*models.ChecklistItem, error) {
+	if _, err := s.loadTask(taskID, userID, models.ProjectRoleEditor); err != nil {
+		return nil, err
+	}
+	item, err := s.loadChecklistItem(taskID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.Text, err = validateChecklistText(text); err != nil {
+		return nil, err
+	}
+	return s.TaskRepo.UpdateChecklistItem(item)
+}
+
+// ToggleChecklistItem checks or unchecks an item; a nil done flips it.
+// Checking the last open item moves the task to its ChecklistAdvanceTo
+// state, if set, following the workflow like any other status change.
+func (s *TaskServiceImpl) ToggleChecklistItem(taskID, itemID uint, done *bool, userID uint) (*ChecklistToggle, error) {
+	if _, err := s.loadTask(taskID, userID, models.ProjectRoleEditor); err != nil {
+		return nil, err
+	}
+	item, err := s.loadChecklistItem(taskID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	checked := !item.Done
+	if done != nil {
+		checked = *done
+	}
+	changed := checked != item.Done
+	if changed {
+		item.Done = checked
+		item.DoneBy, item.DoneAt = nil, nil
+		if checked {
+			now := time.Now()
+			item.DoneBy, item.DoneAt = &userID, &now
+		}
+		if item, err = s.TaskRepo.UpdateChecklistItem(item); err != nil {
+			return nil, fmt.Errorf("failed to update checklist item: %v", err)
+		}
+	}
+
+	// Reload the task for the recounted checklist
+	task, err := s.TaskRepo.GetTaskByID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error fetching task: %v", err)
+	}
+	result := &ChecklistToggle{Item: item, Task: task}
+
+	complete := task.ChecklistTotal > 0 && task.ChecklistDone == task.ChecklistTotal
+	if !changed || !checked || !complete || task.ChecklistAdvanceTo == "" || task.ChecklistAdvanceTo == task.Status {
+		return result, nil
+	}
+
+	advanced := *task
+	advanced.Status = task.ChecklistAdvanceTo
+	updated, err := s.UpdateTask(&advanced, userID)
+	switch {
+	case err == nil:
+		result.Task, result.Advanced = updated, true
+	case errors.Is(err, ErrInvalidTransition), errors.Is(err, ErrTransitionGuard),
+		errors.Is(err, ErrTaskBlocked), errors.Is(err, ErrInvalidTaskStatus), errors.Is(err, ErrWipLimitReached),
+		errors.Is(err, ErrInvalidChecklistAdvanceTo):
+		// The item stays checked; the task just doesn't move
+		log.Printf("Checklist of task %d is complete but the task can't advance: %v", taskID, err)
+		result.AdvanceError = err
+	default:
+		return nil, err
+	}
+	return result, nil
+}
+
+// MoveChecklistItem moves an item to a zero-based position among the other
+// items and returns the reordered checklist
+func (s *TaskServiceImpl) MoveChecklistItem(taskID, itemID uint, position int, userID uint) ([]models.ChecklistItem, error) {
+	if _, err := s.loadTask(taskID, userID, models.ProjectRoleEditor); err != nil {
+		return nil, err
+	}
+	item, err := s.loadChecklistItem(taskID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.TaskRepo.GetChecklistItems(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error fetching checklist: %v", err)
+	}
+	others := make([]models.ChecklistItem, 0, len(items))
+	for _, other := range items {
+		if other.ID != item.ID {
+			others = append(others, other)
+		}
+	}
+	if position < 0 {
+		position = 0
+	}
+	if position > len(others) {
+		position = len(others)
+	}
+
+	if item.Rank, err = s.checklistRankAt(others, position); err != nil {
+		return nil, err
+	}
+	if err := s.TaskRepo.UpdateChecklistRanks(map[uint]string{item.ID: item.Rank}); err != nil {
+		return nil, fmt.Errorf("failed to move checklist item: %v", err)
+	}
+	return s.TaskRepo.GetChecklistItems(taskID)
+}
+
+// checklistRankAt returns a rank for an item inserted at position among the
+// ordered items, re-ranking them first when the neighbours leave no room
+// (internal helper)
+func (s *TaskServiceImpl) checklistRankAt(items []models.ChecklistItem, position int) (string, error) {
+	prev, next, ranked := "", "", true
+	if position > 0 {
+		prev = items[position-1].Rank
+		ranked = prev != ""
+	}
+	if position < len(items) {
+		next = items[position].Rank
+		ranked = ranked && next != ""
+	}
+	if ranked {
+		if r, err := rank.Between(prev, next); err == nil {
+			return r, nil
+		}
+	}
+
+	ranks := rank.Spread(len(items) + 1)
+	updates := make(map[uint]string, len(items))
+	for i, item := range items {
+		slot := i
+		if i >= position {
+			slot++
+		}
+		updates[item.ID] = ranks[slot]
+	}
+	if len(updates) > 0 {
+		if err := s.TaskRepo.UpdateChecklistRanks(updates); err != nil {
+			return "", fmt.Errorf("failed to re-rank checklist: %v", err)
+		}
+	}
+	return ranks[position], nil
+}
+
+// DeleteChecklistItem removes an item from the checklist of a task the user may edit
+func (s *TaskServiceImpl) DeleteChecklistItem(taskID, itemID, userID uint) error {
+	if _, err := s.loadTask(taskID, userID, models.ProjectRoleEditor); err != nil {
+		return err
+	}
+	item, err := s.loadChecklistItem(taskID, itemID)
+	if err != nil {
+		return err
+	}
+	if err := s.TaskRepo.DeleteChecklistItem(item); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrChecklistItemNotFound
+		}
+		return fmt.Errorf("failed to delete checklist item: %v", err)
+	}
+	return nil
+}
+
+// copyChecklist prepares unchecked copies of a task's checklist for its next
+// occurrence (internal helper)
+func (s *TaskServiceImpl) copyChecklist(task, next *models.Task) error {
+	if task.ChecklistTotal == 0 {
+		return nil
+	}
+	items, err := s.TaskRepo.GetChecklistItems(task.ID)
+	if err != nil {
+		return fmt.Errorf("unexpected error fetching checklist: %v", err)
+	}
+	for _, item := range items {
+		next.Checklist = append(next.Checklist, models.ChecklistItem{Text: item.Text, Rank: item.Rank})
+	}
+	next.ChecklistTotal = len(next.Checklist)
+	return nil
+}
@@ -0,0 +1,241 @@
+package services_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// checklistTask is a personal task of user 1 with done of total checklist items checked
+func checklistTask(done, total int, advanceTo string) *models.Task {
+	return &models.Task{
+		Model:              gorm.Model{ID: 4},
+		Title:              "Ship release",
+		Status:             models.TaskStatusInProgress,
+		UserID:             1,
+		ChecklistDone:      done,
+		ChecklistTotal:     total,
+		ChecklistAdvanceTo: advanceTo,
+	}
+}
+
+func TestCreateTask_WithChecklist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+	mockRepo.EXPECT().CreateTask(gomock.Any()).DoAndReturn(
+		func(task *models.Task) (*models.Task, error) { return task, nil },
+	)
+
+	task, err := taskSvc.CreateTask(&models.Task{
+		Title:              "Release",
+		UserID:             1,
+		ChecklistAdvanceTo: " done ",
+		Checklist:          []models.ChecklistItem{{Text: " Tag "}, {Text: "Build", Done: true}, {Text: "Announce"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "done", task.ChecklistAdvanceTo)
+	assert.Equal(t, 3, task.ChecklistTotal)
+	assert.Equal(t, 0, task.ChecklistDone)
+	require.Len(t, task.Checklist, 3)
+	assert.Equal(t, "Tag", task.Checklist[0].Text)
+	assert.False(t, task.Checklist[1].Done)
+	assert.Less(t, task.Checklist[0].Rank, task.Checklist[1].Rank)
+	assert.Less(t, task.Checklist[1].Rank, task.Checklist[2].Rank)
+}
+
+func TestCreateTask_InvalidChecklist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+	mockRepo.EXPECT().CreateTask(gomock.Any()).Times(0)
+
+	_, err := taskSvc.CreateTask(&models.Task{Title: "Release", UserID: 1, Checklist: []models.ChecklistItem{{Text: "  "}}})
+	assert.ErrorIs(t, err, services.ErrChecklistTextRequired)
+
+	// The advance state must exist in the workflow
+	_, err = taskSvc.CreateTask(&models.Task{Title: "Release", UserID: 1, ChecklistAdvanceTo: "shipped"})
+	assert.ErrorIs(t, err, services.ErrInvalidChecklistAdvanceTo)
+}
+
+func TestToggleChecklistItem_LastItemAdvancesTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().GetTaskByID(uint(4)).Return(checklistTask(1, 2, models.TaskStatusDone), nil),
+		mockRepo.EXPECT().GetChecklistItem(uint(4), uint(8)).Return(&models.ChecklistItem{ID: 8, TaskID: 4, Text: "Tag"}, nil),
+		mockRepo.EXPECT().UpdateChecklistItem(gomock.Any()).DoAndReturn(
+			func(item *models.ChecklistItem) (*models.ChecklistItem, error) { return item, nil },
+		),
+		// Reloaded with the recounted checklist, then again by the status update
+		mockRepo.EXPECT().GetTaskByID(uint(4)).Return(checklistTask(2, 2, models.TaskStatusDone), nil).Times(2),
+	)
+	mockRepo.EXPECT().GetSubtasks([]uint{4}).Return(nil, nil)
+	mockRepo.EXPECT().GetDependencies([]uint{4}).Return(nil, nil)
+	mockRepo.EXPECT().UpdateTask(gomock.Any()).DoAndReturn(
+		func(task *models.Task) (*models.Task, error) { return task, nil },
+	)
+
+	toggle, err := taskSvc.ToggleChecklistItem(4, 8, nil, 1)
+	require.NoError(t, err)
+	assert.True(t, toggle.Item.Done)
+	require.NotNil(t, toggle.Item.DoneBy)
+	assert.Equal(t, uint(1), *toggle.Item.DoneBy)
+	assert.NotNil(t, toggle.Item.DoneAt)
+	assert.True(t, toggle.Advanced)
+	assert.NoError(t, toggle.AdvanceError)
+	assert.Equal(t, models.TaskStatusDone, toggle.Task.Status)
+	assert.True(t, toggle.Task.Closed)
+}
+
+func TestToggleChecklistItem_BlockedTaskStaysPut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().GetTaskByID(uint(4)).Return(checklistTask(0, 1, models.TaskStatusDone), nil),
+		mockRepo.EXPECT().GetChecklistItem(uint(4), uint(8)).Return(&models.ChecklistItem{ID: 8, TaskID: 4}, nil),
+		mockRepo.EXPECT().UpdateChecklistItem(gomock.Any()).DoAndReturn(
+			func(item *models.ChecklistItem) (*models.ChecklistItem, error) { return item, nil },
+		),
+		mockRepo.EXPECT().GetTaskByID(uint(4)).Return(checklistTask(1, 1, models.TaskStatusDone), nil).Times(2),
+	)
+	mockRepo.EXPECT().GetSubtasks([]uint{4}).Return(nil, nil)
+	mockRepo.EXPECT().GetDependencies([]uint{4}).Return([]models.TaskDependency{
+		{TaskID: 4, BlockedByID: 2, BlockedBy: &models.Task{Model: gorm.Model{ID: 2}, Status: models.TaskStatusTodo}},
+	}, nil)
+	mockRepo.EXPECT().UpdateTask(gomock.Any()).Times(0)
+
+	done := true
+	toggle, err := taskSvc.ToggleChecklistItem(4, 8, &done, 1)
+	require.NoError(t, err)
+	assert.True(t, toggle.Item.Done)
+	assert.False(t, toggle.Advanced)
+	assert.ErrorIs(t, toggle.AdvanceError, services.ErrTaskBlocked)
+	assert.Equal(t, models.TaskStatusInProgress, toggle.Task.Status)
+}
+
+func TestToggleChecklistItem_UncheckingNeverAdvances(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	mockRepo.EXPECT().GetTaskByID(uint(4)).Return(checklistTask(1, 1, models.TaskStatusDone), nil).AnyTimes()
+	mockRepo.EXPECT().GetChecklistItem(uint(4), uint(8)).Return(&models.ChecklistItem{ID: 8, TaskID: 4, Done: true}, nil).Times(2)
+	mockRepo.EXPECT().UpdateChecklistItem(gomock.Any()).DoAndReturn(
+		func(item *models.ChecklistItem) (*models.ChecklistItem, error) { return item, nil },
+	)
+	mockRepo.EXPECT().UpdateTask(gomock.Any()).Times(0)
+
+	// Checking an item that's already done changes nothing
+	done := true
+	toggle, err := taskSvc.ToggleChecklistItem(4, 8, &done, 1)
+	require.NoError(t, err)
+	assert.False(t, toggle.Advanced)
+
+	toggle, err = taskSvc.ToggleChecklistItem(4, 8, nil, 1)
+	require.NoError(t, err)
+	assert.False(t, toggle.Item.Done)
+	assert.Nil(t, toggle.Item.DoneAt)
+	assert.False(t, toggle.Advanced)
+}
+
+func TestMoveChecklistItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	items := []models.ChecklistItem{{ID: 1, TaskID: 4, Rank: "i"}, {ID: 2, TaskID: 4, Rank: "r"}, {ID: 3, TaskID: 4, Rank: "z"}}
+	mockRepo.EXPECT().GetTaskByID(uint(4)).Return(checklistTask(0, 3, ""), nil).AnyTimes()
+	mockRepo.EXPECT().GetChecklistItem(uint(4), uint(3)).Return(&items[2], nil)
+	mockRepo.EXPECT().GetChecklistItems(uint(4)).Return(items, nil).Times(2)
+
+	// Moving the last item to the top ranks it before the current first
+	mockRepo.EXPECT().UpdateChecklistRanks(gomock.Any()).DoAndReturn(
+		func(ranks map[uint]string) error {
+			require.Len(t, ranks, 1)
+			assert.Less(t, ranks[3], "i")
+			return nil
+		},
+	)
+	_, err := taskSvc.MoveChecklistItem(4, 3, 0, 1)
+	require.NoError(t, err)
+
+	mockRepo.EXPECT().GetChecklistItem(uint(4), uint(9)).Return(nil, gorm.ErrRecordNotFound)
+	_, err = taskSvc.MoveChecklistItem(4, 9, 0, 1)
+	assert.ErrorIs(t, err, services.ErrChecklistItemNotFound)
+}
+
+func TestAddChecklistItem_RequiresEditor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil)
+
+	mockRepo.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(2)).Return(memberWithRole(models.ProjectRoleViewer), nil)
+	mockRepo.EXPECT().CreateChecklistItem(gomock.Any()).Times(0)
+
+	_, err := taskSvc.AddChecklistItem(5, "Tag", 2)
+	assert.ErrorIs(t, err, services.ErrProjectForbidden)
+}
+
+func TestUpdateTask_NextOccurrenceGetsFreshChecklist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	chore := weeklyChore("FREQ=WEEKLY")
+	chore.ChecklistTotal, chore.ChecklistDone = 2, 2
+	mockRepo.EXPECT().GetTaskByID(uint(4)).Return(chore, nil)
+	mockRepo.EXPECT().GetSubtasks([]uint{4}).Return(nil, nil)
+	mockRepo.EXPECT().GetDependencies([]uint{4}).Return(nil, nil)
+	mockRepo.EXPECT().GetChecklistItems(uint(4)).Return([]models.ChecklistItem{
+		{ID: 1, TaskID: 4, Text: "Kitchen", Done: true, Rank: "i"},
+		{ID: 2, TaskID: 4, Text: "Balcony", Done: true, Rank: "r"},
+	}, nil)
+	mockRepo.EXPECT().UpdateTask(gomock.Any()).DoAndReturn(
+		func(task *models.Task) (*models.Task, error) { return task, nil },
+	)
+
+	var next *models.Task
+	mockRepo.EXPECT().CreateTask(gomock.Any()).DoAndReturn(
+		func(task *models.Task) (*models.Task, error) { next = task; return task, nil },
+	)
+
+	changed := weeklyChore("FREQ=WEEKLY")
+	changed.Status = models.TaskStatusDone
+	changed.ChecklistTotal, changed.ChecklistDone = 2, 2
+	_, err := taskSvc.UpdateTask(changed, 1)
+	require.NoError(t, err)
+
+	require.NotNil(t, next)
+	assert.Equal(t, 2, next.ChecklistTotal)
+	assert.Equal(t, 0, next.ChecklistDone)
+	assert.Equal(t, []models.ChecklistItem{{Text: "Kitchen", Rank: "i"}, {Text: "Balcony", Rank: "r"}}, next.Checklist)
+}
@@ -0,0 +1,334 @@
+// internal/services/task_custom_fields.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// customFieldDateLayout is the format of date field values
+const customFieldDateLayout = "2006-01-02"
+
+// customFieldInputPrefix prefixes custom field keys in validation errors
+const customFieldInputPrefix = "custom_fields."
+
+// customValueColumn returns the value column a field type is stored in
+func customValueColumn(fieldType string) string {
+	switch fieldType {
+	case models.CustomFieldNumber:
+		return "number_value"
+	case models.CustomFieldDate:
+		return "date_value"
+	case models.CustomFieldUser:
+		return "user_value"
+	default:
+		return "text_value"
+	}
+}
+
+// hasOption reports whether a select field offers the option
+func hasOption(field *models.CustomField, option string) bool {
+	for _, o := range field.Options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCustomValue converts a JSON-decoded value to the rows storing it. A
+// nil or empty value yields no rows. The message explains invalid values
+// (internal helper).
+func (s *TaskServiceImpl) parseCustomValue(field *models.CustomField, raw interface{}, projectID uint) ([]models.CustomFieldValue, string, error) {
+	if raw == nil {
+		return nil, "", nil
+	}
+	value := models.CustomFieldValue{FieldID: field.ID, Field: field}
+
+	switch field.Type {
+	case models.CustomFieldText:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, "must be a string", nil
+		}
+		if text = strings.TrimSpace(text); text == "" {
+			return nil, "", nil
+		}
+		if utf8.RuneCountInString(text) > MaxCustomFieldTextLength {
+			return nil, fmt.Sprintf("must be at most %d characters", MaxCustomFieldTextLength), nil
+		}
+		value.TextValue = text
+
+	case models.CustomFieldNumber:
+		number, ok := raw.(float64)
+		if !ok || math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, "must be a number", nil
+		}
+		value.NumberValue = &number
+
+	case models.CustomFieldDate:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, "must be a date like 2024-01-31", nil
+		}
+		date, err := time.Parse(customFieldDateLayout, text)
+		if err != nil {
+			return nil, "must be a date like 2024-01-31", nil
+		}
+		value.DateValue = &date
+
+	case models.CustomFieldSelect:
+		option, ok := raw.(string)
+		if !ok || !hasOption(field, option) {
+			return nil, "must be one of " + strings.Join(field.Options, ", "), nil
+		}
+		value.TextValue = option
+
+	case models.CustomFieldMultiSelect:
+		list, ok := raw.([]interface{})
+		if !ok {
+			return nil, "must be a list of options", nil
+		}
+		seen := map[string]bool{}
+		var values []models.CustomFieldValue
+		for _, item := range list {
+			option, ok := item.(string)
+			if !ok || !hasOption(field, option) {
+				return nil, "options must be among " + strings.Join(field.Options, ", "), nil
+			}
+			if !seen[option] {
+				seen[option] = true
+				values = append(values, models.CustomFieldValue{FieldID: field.ID, Field: field, TextValue: option})
+			}
+		}
+		return values, "", nil
+
+	case models.CustomFieldUser:
+		number, ok := raw.(float64)
+		if !ok || number < 1 || number != math.Trunc(number) || number > math.MaxUint32 {
+			return nil, "must be a user ID", nil
+		}
+		userID := uint(number)
+		if _, err := requireProjectRole(s.ProjectRepo, projectID, userID, models.ProjectRoleViewer); err != nil {
+			if errors.Is(err, ErrProjectNotFound) {
+				return nil, "must be a member of the project", nil
+			}
+			return nil, "", err
+		}
+		value.UserValue = &userID
+	}
+	return []models.CustomFieldValue{value}, "", nil
+}
+
+// applyCustomFields validates task.CustomFieldInput against the fields of
+// the task's project and sets task.CustomValues to the task's resulting
+// values. New tasks and tasks moving to another project must fill every
+// required field; other updates may only not clear one. Reports whether the
+// stored values need replacing (internal helper).
+func (s *TaskServiceImpl) applyCustomFields(task, existing *models.Task) (bool, error) {
+	input := task.CustomFieldInput
+	stays := existing != nil && sameProject(existing.ProjectID, task.ProjectID)
+	if stays && input == nil {
+		task.CustomValues = existing.CustomValues
+		return false, nil
+	}
+
+	if task.ProjectID == nil {
+		if len(input) > 0 {
+			return false, &ValidationError{Fields: map[string]string{"custom_fields": "only project tasks have custom fields"}}
+		}
+		task.CustomValues = nil
+		return existing != nil && len(existing.CustomValues) > 0, nil
+	}
+
+	fields, err := s.ProjectRepo.GetCustomFields(*task.ProjectID)
+	if err != nil {
+		return false, fmt.Errorf("unexpected error fetching custom fields: %v", err)
+	}
+	if len(fields) == 0 && len(input) == 0 {
+		task.CustomValues = nil
+		return existing != nil && len(existing.CustomValues) > 0, nil
+	}
+
+	// Start from the values the task keeps
+	values := map[uint][]models.CustomFieldValue{}
+	if stays {
+		for _, value := range existing.CustomValues {
+			values[value.FieldID] = append(values[value.FieldID], value)
+		}
+	}
+
+	byKey := make(map[string]*models.CustomField, len(fields))
+	for i := range fields {
+		byKey[fields[i].Key] = &fields[i]
+	}
+	problems := map[string]string{}
+	for key, raw := range input {
+		field := byKey[key]
+		if field == nil {
+			problems[customFieldInputPrefix+key] = "is not a field of this project"
+			continue
+		}
+		parsed, problem, err := s.parseCustomValue(field, raw, *task.ProjectID)
+		if err != nil {
+			return false, err
+		}
+		if problem != "" {
+			problems[customFieldInputPrefix+key] = problem
+			continue
+		}
+		values[field.ID] = parsed
+	}
+
+	checkAll := !stays
+	for _, field := range fields {
+		_, given := input[field.Key]
+		if field.Required && len(values[field.ID]) == 0 && (checkAll || given) {
+			problems[customFieldInputPrefix+field.Key] = "is required"
+		}
+	}
+	if len(problems) > 0 {
+		return false, &ValidationError{Fields: problems}
+	}
+
+	task.CustomValues = nil
+	for i := range fields {
+		for _, value := range values[fields[i].ID] {
+			value.Field = &fields[i]
+			task.CustomValues = append(task.CustomValues, value)
+		}
+	}
+	return true, nil
+}
+
+// TaskFilter is a filter of a task listing as given in a request, e.g. the
+// key "points", the op "gt" and the value "3"
+type TaskFilter struct {
+	Key   string
+	Op    string
+	Value string
+}
+
+// TaskListQuery filters and orders a project's tasks. Sort names a task
+// column (title, due_date, created_at, updated_at) or a custom field key,
+// prefixed with "-" for descending order.
+type TaskListQuery struct {
+	Filters []TaskFilter
+	Sort    string
+}
+
+// parseFilterValue converts a filter value from a query string to the type
+// of the field's value column (internal helper)
+func parseFilterValue(field *models.CustomField, raw string) (interface{}, string) {
+	switch field.Type {
+	case models.CustomFieldNumber:
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, "must be a number"
+		}
+		return number, ""
+	case models.CustomFieldDate:
+		date, err := time.Parse(customFieldDateLayout, raw)
+		if err != nil {
+			return nil, "must be a date like 2024-01-31"
+		}
+		return date, ""
+	case models.CustomFieldUser:
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil || id == 0 {
+			return nil, "must be a user ID"
+		}
+		return uint(id), ""
+	default:
+		return raw, ""
+	}
+}
+
+// compileTaskListQuery resolves a listing query against a project's custom
+// fields. Problems are reported per query parameter (internal helper).
+func compileTaskListQuery(query TaskListQuery, fields []models.CustomField) ([]repositories.CustomFieldFilter, repositories.TaskSort, error) {
+	byKey := make(map[string]*models.CustomField, len(fields))
+	for i := range fields {
+		byKey[fields[i].Key] = &fields[i]
+	}
+	problems := map[string]string{}
+
+	var filters []repositories.CustomFieldFilter
+	for _, filter := range query.Filters {
+		param := "cf." + filter.Key
+		if filter.Op != "" {
+			param += "." + filter.Op
+		}
+		field := byKey[filter.Key]
+		if field == nil {
+			problems[param] = "is not a field of this project"
+			continue
+		}
+
+		op := filter.Op
+		if op == "" {
+			op = repositories.FilterOpEq
+		}
+		switch op {
+		case repositories.FilterOpEq, repositories.FilterOpNe:
+		case repositories.FilterOpLt, repositories.FilterOpGt:
+			if field.Type != models.CustomFieldNumber && field.Type != models.CustomFieldDate {
+				problems[param] = "lt and gt only apply to number and date fields"
+				continue
+			}
+		case repositories.FilterOpContains:
+			if field.Type != models.CustomFieldText {
+				problems[param] = "contains only applies to text fields"
+				continue
+			}
+		default:
+			problems[param] = "unknown operator; use eq, ne, lt, gt or contains"
+			continue
+		}
+
+		value, problem := parseFilterValue(field, filter.Value)
+		if problem != "" {
+			problems[param] = problem
+			continue
+		}
+		filters = append(filters, repositories.CustomFieldFilter{
+			FieldID: field.ID,
+			Column:  customValueColumn(field.Type),
+			Op:      op,
+			Value:   value,
+		})
+	}
+
+	var sort repositories.TaskSort
+	if query.Sort != "" {
+		key := strings.TrimPrefix(query.Sort, "-")
+		sort.Desc = key != query.Sort
+		switch key {
+		case "title", "due_date", "created_at", "updated_at":
+			sort.Column = key
+		default:
+			field := byKey[key]
+			switch {
+			case field == nil:
+				problems["sort"] = "must be title, due_date, created_at, updated_at or a custom field key"
+			case field.Type == models.CustomFieldMultiSelect:
+				problems["sort"] = "multi-select fields can't be sorted by"
+			default:
+				sort.FieldID = field.ID
+				sort.Column = customValueColumn(field.Type)
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, sort, &ValidationError{Fields: problems}
+	}
+	return filters, sort, nil
+}
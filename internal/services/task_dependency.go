@@ -0,0 +1,276 @@
+// internal/services/task_dependency.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"container/heap"
+	"errors"
+	"fmt"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrDependencyCycle    = errors.New("dependency would create a cycle")
+	ErrDependencyExists   = errors.New("dependency already exists")
+	ErrDependencyNotFound = errors.New("dependency not found")
+	ErrTaskBlocked        = errors.New("task is blocked by open tasks")
+)
+
+// NextTask is an open task in work order together with the open tasks blocking it
+type NextTask struct {
+	Task      models.Task
+	Ready     bool
+	BlockedBy []uint
+}
+
+// taskPriorityRank orders priorities from most to least urgent
+var taskPriorityRank = map[string]int{
+	models.TaskPriorityUrgent: 0,
+	models.TaskPriorityHigh:   1,
+	models.TaskPriorityMedium: 2,
+	models.TaskPriorityLow:    3,
+}
+
+// workBefore reports whether a should be worked on before b when neither blocks the other:
+// higher priority first, then earlier due date, then older task.
+func workBefore(a, b *models.Task) bool {
+	if taskPriorityRank[a.Priority] != taskPriorityRank[b.Priority] {
+		return taskPriorityRank[a.Priority] < taskPriorityRank[b.Priority]
+	}
+	if a.DueDate != nil && b.DueDate != nil && !a.DueDate.Equal(*b.DueDate) {
+		return a.DueDate.Before(*b.DueDate)
+	}
+	if (a.DueDate == nil) != (b.DueDate == nil) {
+		return a.DueDate != nil
+	}
+	return a.ID < b.ID
+}
+
+// readyQueue is a heap of tasks whose in-set blockers have all been ordered
+type readyQueue []*models.Task
+
+func (q readyQueue) Len() int            { return len(q) }
+func (q readyQueue) Less(i, j int) bool  { return workBefore(q[i], q[j]) }
+func (q readyQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *readyQueue) Push(x interface{}) { *q = append(*q, x.(*models.Task)) }
+func (q *readyQueue) Pop() interface{} {
+	old := *q
+	task := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return task
+}
+
+// orderTasks sorts open tasks topologically so that every task comes after
+// its blockers, breaking ties with workBefore. Tasks caught in a cycle (which
+// AddDependency prevents, but old data may contain) are appended at the end.
+func orderTasks(tasks []models.Task, dependencies []models.TaskDependency) []NextTask {
+	byID := make(map[uint]*models.Task, len(tasks))
+	for i := range tasks {
+		byID[tasks[i].ID] = &tasks[i]
+	}
+
+	blockedBy := map[uint][]uint{}
+	dependents := map[uint][]uint{}
+	inDegree := map[uint]int{}
+	for _, dep := range dependencies {
+		if _, ok := byID[dep.TaskID]; !ok {
+			continue
+		}
+		if _, ok := byID[dep.BlockedByID]; ok {
+			dependents[dep.BlockedByID] = append(dependents[dep.BlockedByID], dep.TaskID)
+			inDegree[dep.TaskID]++
+			blockedBy[dep.TaskID] = append(blockedBy[dep.TaskID], dep.BlockedByID)
+		} else if dep.BlockedBy != nil && !isTaskDone(dep.BlockedBy) {
+			// Blocked by an open task outside of the list, e.g. someone else's
+			blockedBy[dep.TaskID] = append(blockedBy[dep.TaskID], dep.BlockedByID)
+		}
+	}
+
+	queue := &readyQueue{}
+	for i := range tasks {
+		if inDegree[tasks[i].ID] == 0 {
+			heap.Push(queue, &tasks[i])
+		}
+	}
+
+	ordered := make([]NextTask, 0, len(tasks))
+	placed := map[uint]bool{}
+	for queue.Len() > 0 {
+		task := heap.Pop(queue).(*models.Task)
+		placed[task.ID] = true
+		ordered = append(ordered, NextTask{
+			Task:      *task,
+			Ready:     len(blockedBy[task.ID]) == 0,
+			BlockedBy: blockedBy[task.ID],
+		})
+		for _, dependentID := range dependents[task.ID] {
+			inDegree[dependentID]--
+			if inDegree[dependentID] == 0 {
+				heap.Push(queue, byID[dependentID])
+			}
+		}
+	}
+
+	var cyclic []models.Task
+	for _, task := range tasks {
+		if !placed[task.ID] {
+			cyclic = append(cyclic, task)
+		}
+	}
+	sort.Slice(cyclic, func(i, j int) bool { return cyclic[i].ID < cyclic[j].ID })
+	for _, task := range cyclic {
+		ordered = append(ordered, NextTask{Task: task, BlockedBy: blockedBy[task.ID]})
+	}
+	return ordered
+}
+
+// openBlockerIDs returns the IDs of the open tasks blocking the given task (internal helper)
+func (s *TaskServiceImpl) openBlockerIDs(taskID uint) ([]uint, error) {
+	dependencies, err := s.TaskRepo.GetDependencies([]uint{taskID})
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error fetching dependencies: %v", err)
+	}
+
+	var open []uint
+	for _, dep := range dependencies {
+		if dep.BlockedBy != nil && !isTaskDone(dep.BlockedBy) {
+			open = append(open, dep.BlockedByID)
+		}
+	}
+	return open, nil
+}
+
+// ensureUnblocked fails with ErrTaskBlocked while the task has open blockers
+func (s *TaskServiceImpl) ensureUnblocked(taskID uint) error {
+	open, err := s.openBlockerIDs(taskID)
+	if err != nil {
+		return err
+	}
+	if len(open) > 0 {
+		return fmt.Errorf("%w: %v", ErrTaskBlocked, open)
+	}
+	return nil
+}
+
+// GetBlockers lists the tasks blocking the given task
+func (s *TaskServiceImpl) GetBlockers(taskID, userID uint) ([]models.Task, error) {
+	if _, err := s.loadTask(taskID, userID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	dependencies, err := s.TaskRepo.GetDependencies([]uint{taskID})
+	if err != nil {
+		return nil, err
+	}
+
+	blockers := make([]models.Task, 0, len(dependencies))
+	for _, dep := range dependencies {
+		if dep.BlockedBy != nil {
+			blockers = append(blockers, *dep.BlockedBy)
+		}
+	}
+	return blockers, nil
+}
+
+// AddDependency marks taskID as blocked by blockedByID. Both tasks must live in
+// the same project and the new edge must not close a cycle.
+func (s *TaskServiceImpl) AddDependency(taskID, blockedByID, userID uint) (*models.TaskDependency, error) {
+	if taskID == blockedByID {
+		return nil, ErrDependencyCycle
+	}
+
+	task, err := s.loadTask(taskID, userID, models.ProjectRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	blocker, err := s.loadTask(blockedByID, userID, models.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	if !sameProject(task.ProjectID, blocker.ProjectID) {
+		return nil, ErrTaskProjectMismatch
+	}
+
+	existing, err := s.TaskRepo.GetDependencies([]uint{taskID})
+	if err != nil {
+		return nil, err
+	}
+	for _, dep := range existing {
+		if dep.BlockedByID == blockedByID {
+			return nil, ErrDependencyExists
+		}
+	}
+
+	if err := s.ensureNoDependencyPath(blockedByID, taskID); err != nil {
+		return nil, err
+	}
+
+	return s.TaskRepo.AddDependency(&models.TaskDependency{
+		TaskID:      taskID,
+		BlockedByID: blockedByID,
+	})
+}
+
+// ensureNoDependencyPath follows blocker links from start and fails if target
+// is reachable, since blocking target by start would then close a loop.
+func (s *TaskServiceImpl) ensureNoDependencyPath(start, target uint) error {
+	visited := map[uint]bool{start: true}
+	frontier := []uint{start}
+
+	for len(frontier) > 0 {
+		dependencies, err := s.TaskRepo.GetDependencies(frontier)
+		if err != nil {
+			return fmt.Errorf("unexpected error fetching dependencies: %v", err)
+		}
+
+		var next []uint
+		for _, dep := range dependencies {
+			if dep.BlockedByID == target {
+				return ErrDependencyCycle
+			}
+			if !visited[dep.BlockedByID] {
+				visited[dep.BlockedByID] = true
+				next = append(next, dep.BlockedByID)
+			}
+		}
+		frontier = next
+	}
+	return nil
+}
+
+// RemoveDependency unblocks taskID from blockedByID
+func (s *TaskServiceImpl) RemoveDependency(taskID, blockedByID, userID uint) error {
+	if _, err := s.loadTask(taskID, userID, models.ProjectRoleEditor); err != nil {
+		return err
+	}
+
+	if err := s.TaskRepo.RemoveDependency(taskID, blockedByID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrDependencyNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// GetNextTasks lists the user's open tasks in an order that respects their
+// dependencies, flagging which ones can be started right away
+func (s *TaskServiceImpl) GetNextTasks(userID uint) ([]NextTask, error) {
+	tasks, err := s.TaskRepo.GetOpenTasksByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	dependencies, err := s.TaskRepo.GetDependencies(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return orderTasks(tasks, dependencies), nil
+}
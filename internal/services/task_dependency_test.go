@@ -0,0 +1,119 @@
+package services_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestAddDependency_RejectsSelf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskSvc := services.NewTaskService(mocks.NewMockTaskRepository(ctrl), mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	_, err := taskSvc.AddDependency(1, 1, 1)
+	assert.ErrorIs(t, err, services.ErrDependencyCycle)
+}
+
+func TestAddDependency_RejectsCycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	// 3 is blocked by 2, which is blocked by 1; blocking 1 by 3 closes the loop
+	mockRepo.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{Model: gorm.Model{ID: 1}, UserID: 1}, nil)
+	mockRepo.EXPECT().GetTaskByID(uint(3)).Return(&models.Task{Model: gorm.Model{ID: 3}, UserID: 1}, nil)
+	mockRepo.EXPECT().GetDependencies([]uint{1}).Return(nil, nil)
+	mockRepo.EXPECT().GetDependencies([]uint{3}).Return([]models.TaskDependency{{TaskID: 3, BlockedByID: 2}}, nil)
+	mockRepo.EXPECT().GetDependencies([]uint{2}).Return([]models.TaskDependency{{TaskID: 2, BlockedByID: 1}}, nil)
+	mockRepo.EXPECT().AddDependency(gomock.Any()).Times(0)
+
+	_, err := taskSvc.AddDependency(1, 3, 1)
+	assert.ErrorIs(t, err, services.ErrDependencyCycle)
+}
+
+func TestAddDependency_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	mockRepo.EXPECT().GetTaskByID(uint(2)).Return(&models.Task{Model: gorm.Model{ID: 2}, UserID: 1}, nil)
+	mockRepo.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{Model: gorm.Model{ID: 1}, UserID: 1}, nil)
+	mockRepo.EXPECT().GetDependencies([]uint{2}).Return(nil, nil)
+	mockRepo.EXPECT().GetDependencies([]uint{1}).Return(nil, nil)
+	mockRepo.EXPECT().AddDependency(gomock.Any()).DoAndReturn(
+		func(dep *models.TaskDependency) (*models.TaskDependency, error) { return dep, nil },
+	)
+
+	dep, err := taskSvc.AddDependency(2, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), dep.TaskID)
+	assert.Equal(t, uint(1), dep.BlockedByID)
+}
+
+func TestUpdateTask_DoneWhileBlocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	stored := &models.Task{Model: gorm.Model{ID: 2}, Title: "Deploy", Status: models.TaskStatusTodo, UserID: 1}
+	blocker := &models.Task{Model: gorm.Model{ID: 1}, Title: "Build", Status: models.TaskStatusInProgress, UserID: 1}
+	mockRepo.EXPECT().GetTaskByID(uint(2)).Return(stored, nil)
+	mockRepo.EXPECT().GetSubtasks([]uint{2}).Return(nil, nil)
+	mockRepo.EXPECT().GetDependencies([]uint{2}).Return([]models.TaskDependency{{TaskID: 2, BlockedByID: 1, BlockedBy: blocker}}, nil)
+	mockRepo.EXPECT().UpdateTask(gomock.Any()).Times(0)
+
+	changed := &models.Task{Model: gorm.Model{ID: 2}, Title: "Deploy", Status: models.TaskStatusDone, UserID: 1}
+	_, err := taskSvc.UpdateTask(changed, 1)
+	assert.ErrorIs(t, err, services.ErrTaskBlocked)
+}
+
+func TestGetNextTasks_TopologicalOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	// The urgent task is blocked by the low priority one, so it has to wait
+	tasks := []models.Task{
+		{Model: gorm.Model{ID: 1}, Title: "Low", Priority: models.TaskPriorityLow, UserID: 1},
+		{Model: gorm.Model{ID: 2}, Title: "Urgent", Priority: models.TaskPriorityUrgent, UserID: 1},
+		{Model: gorm.Model{ID: 3}, Title: "Medium", Priority: models.TaskPriorityMedium, UserID: 1},
+		{Model: gorm.Model{ID: 4}, Title: "External", Priority: models.TaskPriorityHigh, UserID: 1},
+	}
+	foreign := &models.Task{Model: gorm.Model{ID: 9}, Status: models.TaskStatusTodo, UserID: 2}
+	mockRepo.EXPECT().GetOpenTasksByUserID(uint(1)).Return(tasks, nil)
+	mockRepo.EXPECT().GetDependencies([]uint{1, 2, 3, 4}).Return([]models.TaskDependency{
+		{TaskID: 2, BlockedByID: 1, BlockedBy: &tasks[0]},
+		{TaskID: 4, BlockedByID: 9, BlockedBy: foreign},
+	}, nil)
+
+	next, err := taskSvc.GetNextTasks(1)
+	require.NoError(t, err)
+
+	var order []string
+	for _, n := range next {
+		order = append(order, n.Task.Title)
+	}
+	assert.Equal(t, []string{"External", "Medium", "Low", "Urgent"}, order)
+	assert.False(t, next[0].Ready)
+	assert.Equal(t, []uint{9}, next[0].BlockedBy)
+	assert.True(t, next[1].Ready)
+	assert.True(t, next[2].Ready)
+	assert.False(t, next[3].Ready)
+	assert.Equal(t, []uint{1}, next[3].BlockedBy)
+}
@@ -0,0 +1,208 @@
+// internal/services/task_recurrence.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/pkg/rrule"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidRecurrence       = errors.New("invalid recurrence rule")
+	ErrInvalidTimezone         = errors.New("invalid recurrence time zone")
+	ErrRecurrenceStartRequired = errors.New("a recurring task needs a due date to start from")
+)
+
+// Bounds of an occurrence preview
+const (
+	DefaultOccurrenceCount = 5
+	MaxOccurrenceCount     = 100
+)
+
+// RecurrencePreview lists upcoming occurrences of a rule in its time zone
+type RecurrencePreview struct {
+	Rule        string
+	Timezone    string
+	Occurrences []time.Time
+}
+
+// parseRecurrence parses a rule and its time zone, defaulting to UTC (internal helper)
+func parseRecurrence(rule, timezone string) (*rrule.Rule, *time.Location, error) {
+	parsed, err := rrule.Parse(rule)
+	if err != nil {
+		detail := strings.TrimPrefix(err.Error(), rrule.ErrInvalidRule.Error()+": ")
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidRecurrence, detail)
+	}
+
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %q", ErrInvalidTimezone, timezone)
+	}
+	return parsed, loc, nil
+}
+
+// normaliseRecurrence checks a task's recurrence and stores the rule in its
+// canonical form. The series starts at the due date unless it already has a
+// start (internal helper).
+func normaliseRecurrence(task *models.Task) error {
+	task.Recurrence = strings.TrimSpace(task.Recurrence)
+	if task.Recurrence == "" {
+		task.RecurrenceTimezone = ""
+		task.RecurrenceStart = nil
+		return nil
+	}
+
+	rule, loc, err := parseRecurrence(task.Recurrence, task.RecurrenceTimezone)
+	if err != nil {
+		return err
+	}
+	task.Recurrence = rule.String()
+	task.RecurrenceTimezone = loc.String()
+
+	if task.RecurrenceStart == nil {
+		if task.DueDate == nil {
+			return ErrRecurrenceStartRequired
+		}
+		start := *task.DueDate
+		task.RecurrenceStart = &start
+	}
+	if task.DueDate == nil {
+		due := *task.RecurrenceStart
+		task.DueDate = &due
+	}
+	return nil
+}
+
+// occurrencesAfter lists up to n occurrences of the task's series strictly
+// after t, in the series' time zone (internal helper)
+func occurrencesAfter(task *models.Task, t time.Time, n int) ([]time.Time, error) {
+	rule, loc, err := parseRecurrence(task.Recurrence, task.RecurrenceTimezone)
+	if err != nil {
+		return nil, err
+	}
+	return rule.Occurrences(task.RecurrenceStart.In(loc), t, n), nil
+}
+
+// handOverRecurrence prepares the next occurrence of a recurring task that is
+// being completed. The rule moves over to the new occurrence, so reopening
+// and completing the old one again doesn't repeat the series. Returns nil
+// when there's nothing to schedule (internal helper).
+func (s *TaskServiceImpl) handOverRecurrence(task, existing *models.Task) (*models.Task, error) {
+	if task.Recurrence == "" || task.RecurrenceStart == nil || !task.Closed || existing.Closed {
+		return nil, nil
+	}
+
+	// Occurrences missed while the task was overdue are skipped
+	after := time.Now()
+	if task.DueDate != nil && task.DueDate.After(after) {
+		after = *task.DueDate
+	}
+	occurrences, err := occurrencesAfter(task, after, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	var next *models.Task
+	if len(occurrences) > 0 {
+		due := occurrences[0].UTC()
+		start := *task.RecurrenceStart
+		next = &models.Task{
+			Title:              task.Title,
+			Description:        task.Description,
+			Priority:           task.Priority,
+			DueDate:            &due,
+			EstimateMinutes:    task.EstimateMinutes,
+			UserID:             task.UserID,
+			ProjectID:          task.ProjectID,
+			ParentID:           task.ParentID,
+			Recurrence:         task.Recurrence,
+			RecurrenceTimezone: task.RecurrenceTimezone,
+			RecurrenceStart:    &start,
+			Assignees:          task.Assignees,
+			Watchers:           task.Watchers,
+			Labels:             task.Labels,
+			ChecklistAdvanceTo: task.ChecklistAdvanceTo,
+		}
+		for _, value := range task.CustomValues {
+			value.ID, value.TaskID = 0, 0
+			next.CustomValues = append(next.CustomValues, value)
+		}
+		// The next occurrence starts over with an unchecked checklist
+		if err := s.copyChecklist(task, next); err != nil {
+			return nil, err
+		}
+	}
+
+	task.Recurrence = ""
+	task.RecurrenceTimezone = ""
+	task.RecurrenceStart = nil
+	return next, nil
+}
+
+// scheduleOccurrence persists an occurrence prepared by handOverRecurrence (internal helper)
+func (s *TaskServiceImpl) scheduleOccurrence(next *models.Task) error {
+	if next == nil {
+		return nil
+	}
+	if _, err := s.insertTask(next); err != nil {
+		return fmt.Errorf("task completed but its next occurrence couldn't be created: %w", err)
+	}
+	return nil
+}
+
+// clampOccurrenceCount applies the default and the cap of a preview (internal helper)
+func clampOccurrenceCount(n int) int {
+	if n <= 0 {
+		return DefaultOccurrenceCount
+	}
+	if n > MaxOccurrenceCount {
+		return MaxOccurrenceCount
+	}
+	return n
+}
+
+// PreviewRecurrence lists the first n occurrences of a rule starting at start,
+// the start included, without saving anything
+func (s *TaskServiceImpl) PreviewRecurrence(rule, timezone string, start time.Time, n int) (*RecurrencePreview, error) {
+	parsed, loc, err := parseRecurrence(rule, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	start = start.In(loc)
+	return &RecurrencePreview{
+		Rule:        parsed.String(),
+		Timezone:    loc.String(),
+		Occurrences: parsed.Occurrences(start, start.Add(-time.Nanosecond), clampOccurrenceCount(n)),
+	}, nil
+}
+
+// GetUpcomingOccurrences lists the next n occurrences of a recurring task,
+// starting with its current one. Tasks that don't recur have none.
+func (s *TaskServiceImpl) GetUpcomingOccurrences(id, userID uint, n int) (*RecurrencePreview, error) {
+	task, err := s.loadTask(id, userID, models.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &RecurrencePreview{Rule: task.Recurrence, Timezone: task.RecurrenceTimezone}
+	if task.Recurrence == "" || task.RecurrenceStart == nil {
+		return preview, nil
+	}
+
+	from := *task.RecurrenceStart
+	if task.DueDate != nil && task.DueDate.After(from) {
+		from = *task.DueDate
+	}
+	preview.Occurrences, err = occurrencesAfter(task, from.Add(-time.Nanosecond), clampOccurrenceCount(n))
+	if err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
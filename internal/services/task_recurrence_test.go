@@ -0,0 +1,216 @@
+package services_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func timePtr(t time.Time) *time.Time { return &t }
+
+// Monday 2099-01-05 09:00 in Berlin (08:00 UTC); far enough ahead to never be overdue
+var weeklyDue = time.Date(2099, 1, 5, 8, 0, 0, 0, time.UTC)
+
+// weeklyChore is a personal task of user 1 repeating every Monday at 09:00 Berlin time
+func weeklyChore(rule string) *models.Task {
+	return &models.Task{
+		Model:              gorm.Model{ID: 4},
+		Title:              "Water plants",
+		Status:             models.TaskStatusTodo,
+		Priority:           models.TaskPriorityLow,
+		EstimateMinutes:    10,
+		DueDate:            timePtr(weeklyDue),
+		UserID:             1,
+		Recurrence:         rule,
+		RecurrenceTimezone: "Europe/Berlin",
+		RecurrenceStart:    timePtr(weeklyDue),
+	}
+}
+
+func TestCreateTask_Recurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	mockRepo.EXPECT().CreateTask(gomock.Any()).DoAndReturn(
+		func(task *models.Task) (*models.Task, error) { return task, nil },
+	)
+
+	task, err := taskSvc.CreateTask(&models.Task{
+		Title:              "Standup",
+		UserID:             1,
+		DueDate:            timePtr(weeklyDue),
+		Recurrence:         " rrule:freq=weekly;byday=mo,we ",
+		RecurrenceTimezone: "Europe/Berlin",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO,WE", task.Recurrence)
+	require.NotNil(t, task.RecurrenceStart)
+	assert.Equal(t, weeklyDue, *task.RecurrenceStart)
+}
+
+func TestCreateTask_InvalidRecurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+	mockRepo.EXPECT().CreateTask(gomock.Any()).Times(0)
+
+	_, err := taskSvc.CreateTask(&models.Task{Title: "Chore", UserID: 1, DueDate: timePtr(weeklyDue), Recurrence: "FREQ=HOURLY"})
+	assert.ErrorIs(t, err, services.ErrInvalidRecurrence)
+	assert.Contains(t, err.Error(), "HOURLY")
+
+	_, err = taskSvc.CreateTask(&models.Task{Title: "Chore", UserID: 1, DueDate: timePtr(weeklyDue), Recurrence: "FREQ=DAILY", RecurrenceTimezone: "Mars/Olympus"})
+	assert.ErrorIs(t, err, services.ErrInvalidTimezone)
+
+	_, err = taskSvc.CreateTask(&models.Task{Title: "Chore", UserID: 1, Recurrence: "FREQ=DAILY"})
+	assert.ErrorIs(t, err, services.ErrRecurrenceStartRequired)
+}
+
+func TestUpdateTask_CompletingCreatesNextOccurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	mockRepo.EXPECT().GetTaskByID(uint(4)).Return(weeklyChore("FREQ=WEEKLY"), nil)
+	mockRepo.EXPECT().GetSubtasks([]uint{4}).Return(nil, nil)
+	mockRepo.EXPECT().GetDependencies([]uint{4}).Return(nil, nil)
+
+	var completed, next *models.Task
+	mockRepo.EXPECT().UpdateTask(gomock.Any()).DoAndReturn(
+		func(task *models.Task) (*models.Task, error) { completed = task; return task, nil },
+	)
+	mockRepo.EXPECT().CreateTask(gomock.Any()).DoAndReturn(
+		func(task *models.Task) (*models.Task, error) { next = task; return task, nil },
+	)
+
+	changed := weeklyChore("FREQ=WEEKLY")
+	changed.Status = models.TaskStatusDone
+	_, err := taskSvc.UpdateTask(changed, 1)
+	require.NoError(t, err)
+
+	// The rule moves on to the new occurrence
+	assert.True(t, completed.Closed)
+	assert.Empty(t, completed.Recurrence)
+	assert.Nil(t, completed.RecurrenceStart)
+
+	require.NotNil(t, next)
+	assert.Equal(t, "Water plants", next.Title)
+	assert.Equal(t, models.TaskStatusTodo, next.Status)
+	assert.False(t, next.Closed)
+	assert.Equal(t, 10, next.EstimateMinutes)
+	assert.Equal(t, "FREQ=WEEKLY", next.Recurrence)
+	assert.Equal(t, weeklyDue, *next.RecurrenceStart)
+	assert.Equal(t, weeklyDue.AddDate(0, 0, 7), *next.DueDate)
+}
+
+func TestUpdateTask_CompletingLastOccurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	// The second of two occurrences
+	stored := weeklyChore("FREQ=WEEKLY;COUNT=2")
+	stored.DueDate = timePtr(weeklyDue.AddDate(0, 0, 7))
+	mockRepo.EXPECT().GetTaskByID(uint(4)).Return(stored, nil)
+	mockRepo.EXPECT().GetSubtasks([]uint{4}).Return(nil, nil)
+	mockRepo.EXPECT().GetDependencies([]uint{4}).Return(nil, nil)
+	mockRepo.EXPECT().UpdateTask(gomock.Any()).DoAndReturn(
+		func(task *models.Task) (*models.Task, error) { return task, nil },
+	)
+	mockRepo.EXPECT().CreateTask(gomock.Any()).Times(0)
+
+	changed := weeklyChore("FREQ=WEEKLY;COUNT=2")
+	changed.DueDate = timePtr(weeklyDue.AddDate(0, 0, 7))
+	changed.Status = models.TaskStatusDone
+	task, err := taskSvc.UpdateTask(changed, 1)
+	require.NoError(t, err)
+	assert.Empty(t, task.Recurrence)
+}
+
+func TestUpdateTask_ChangedRuleRestartsSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	mockRepo.EXPECT().GetTaskByID(uint(4)).Return(weeklyChore("FREQ=WEEKLY"), nil)
+	mockRepo.EXPECT().GetSubtasks([]uint{4}).Return(nil, nil)
+	mockRepo.EXPECT().UpdateTask(gomock.Any()).DoAndReturn(
+		func(task *models.Task) (*models.Task, error) { return task, nil },
+	)
+
+	movedDue := weeklyDue.AddDate(0, 0, 14)
+	changed := weeklyChore("FREQ=DAILY")
+	changed.DueDate = timePtr(movedDue)
+	task, err := taskSvc.UpdateTask(changed, 1)
+	require.NoError(t, err)
+	assert.Equal(t, movedDue, *task.RecurrenceStart)
+}
+
+func TestGetUpcomingOccurrences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	// The series is at its second occurrence; DST starts in Berlin on 2099-03-29
+	stored := weeklyChore("FREQ=WEEKLY;INTERVAL=6")
+	stored.DueDate = timePtr(weeklyDue.AddDate(0, 0, 42))
+	mockRepo.EXPECT().GetTaskByID(uint(4)).Return(stored, nil)
+
+	preview, err := taskSvc.GetUpcomingOccurrences(4, 1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "FREQ=WEEKLY;INTERVAL=6", preview.Rule)
+	require.Len(t, preview.Occurrences, 3)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2099, 2, 16, 9, 0, 0, 0, berlin), preview.Occurrences[0])
+	assert.Equal(t, time.Date(2099, 3, 30, 9, 0, 0, 0, berlin), preview.Occurrences[1])
+	assert.Equal(t, 7, preview.Occurrences[1].UTC().Hour(), "summer time keeps 09:00 local")
+
+	// Other users' personal tasks stay hidden
+	mockRepo.EXPECT().GetTaskByID(uint(4)).Return(weeklyChore("FREQ=WEEKLY"), nil)
+	_, err = taskSvc.GetUpcomingOccurrences(4, 2, 3)
+	assert.ErrorIs(t, err, services.ErrTaskNotFound)
+}
+
+func TestPreviewRecurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskSvc := services.NewTaskService(mocks.NewMockTaskRepository(ctrl), mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	start := time.Date(2024, 1, 31, 18, 0, 0, 0, time.UTC)
+	preview, err := taskSvc.PreviewRecurrence("FREQ=MONTHLY;BYMONTHDAY=-1", "", start, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", preview.Timezone)
+	require.Len(t, preview.Occurrences, services.DefaultOccurrenceCount)
+	assert.Equal(t, start, preview.Occurrences[0])
+	assert.Equal(t, time.Date(2024, 2, 29, 18, 0, 0, 0, time.UTC), preview.Occurrences[1])
+
+	// Large previews are capped
+	preview, err = taskSvc.PreviewRecurrence("FREQ=DAILY", "", start, 1000)
+	require.NoError(t, err)
+	assert.Len(t, preview.Occurrences, services.MaxOccurrenceCount)
+
+	_, err = taskSvc.PreviewRecurrence("FREQ=DAILY;COUNT=0", "", start, 5)
+	assert.ErrorIs(t, err, services.ErrInvalidRecurrence)
+}
@@ -0,0 +1,33 @@
+// internal/services/task_search.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"TaskManager/pkg/tql"
+	"time"
+)
+
+// MaxSearchResults caps the tasks a search returns
+const MaxSearchResults = 200
+
+// runTaskSearch compiles a parsed task query for the user and runs it over
+// the tasks the user can see (internal helper)
+func runTaskSearch(taskRepo repositories.TaskRepository, query *tql.Query, userID uint) ([]models.Task, error) {
+	compiled, err := repositories.TaskSearchSchema(userID, time.Now()).Compile(query)
+	if err != nil {
+		return nil, err
+	}
+	return taskRepo.SearchTasks(userID, compiled, MaxSearchResults)
+}
+
+// SearchTasks finds the tasks the user can see matching a query of the task
+// query language, e.g. "status:open assignee:me due<7d sort:priority".
+// Problems with the query are returned as a *tql.Error with their position.
+func (s *TaskServiceImpl) SearchTasks(query string, userID uint) ([]models.Task, error) {
+	parsed, err := tql.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	return runTaskSearch(s.TaskRepo, parsed, userID)
+}
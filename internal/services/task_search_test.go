@@ -0,0 +1,160 @@
+package services_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"TaskManager/pkg/tql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// searchSQL builds the statement a compiled search adds to a task query,
+// without a database
+func searchSQL(t *testing.T, search *tql.Compiled) *gorm.Statement {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+	return search.Apply(db.Model(&models.Task{})).Find(&[]models.Task{}).Statement
+}
+
+func TestSearchTasks(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantSQL  string
+		wantVars []interface{}
+	}{
+		{
+			name:    "empty query",
+			query:   "",
+			wantSQL: `SELECT * FROM "tasks" WHERE "tasks"."deleted_at" IS NULL`,
+		},
+		{
+			name:  "status, labels and sort",
+			query: "status:open label:Bug -label:wontfix sort:priority,-due",
+			wantSQL: `SELECT * FROM "tasks" WHERE tasks.closed = $1 ` +
+				`AND tasks.id IN (SELECT task_labels.task_id FROM task_labels JOIN labels ON labels.id = task_labels.label_id WHERE LOWER(labels.name) = $2) ` +
+				`AND NOT (tasks.id IN (SELECT task_labels.task_id FROM task_labels JOIN labels ON labels.id = task_labels.label_id WHERE LOWER(labels.name) = $3)) ` +
+				`AND "tasks"."deleted_at" IS NULL ` +
+				`ORDER BY CASE tasks.priority WHEN 'urgent' THEN 0 WHEN 'high' THEN 1 WHEN 'medium' THEN 2 ELSE 3 END ASC NULLS LAST,tasks.due_date DESC NULLS LAST`,
+			wantVars: []interface{}{false, "bug", "wontfix"},
+		},
+		{
+			name:  "people",
+			query: "assignee:me owner:Alice assignee:none",
+			wantSQL: `SELECT * FROM "tasks" WHERE tasks.id IN (SELECT task_id FROM task_assignees WHERE user_id IN ($1)) ` +
+				`AND (tasks.user_id IN (SELECT id FROM users WHERE LOWER(username) = $2 AND deleted_at IS NULL)) ` +
+				`AND tasks.id NOT IN (SELECT task_id FROM task_assignees) AND "tasks"."deleted_at" IS NULL`,
+			wantVars: []interface{}{uint(1), "alice"},
+		},
+		{
+			name:     "priorities and projects",
+			query:    "priority>=high project:3 status:Review",
+			wantSQL:  `SELECT * FROM "tasks" WHERE tasks.priority IN ($1,$2) AND tasks.project_id = $3 AND LOWER(tasks.status) = $4 AND "tasks"."deleted_at" IS NULL`,
+			wantVars: []interface{}{models.TaskPriorityHigh, models.TaskPriorityUrgent, uint(3), "review"},
+		},
+		{
+			name:     "free text is escaped",
+			query:    `"50%_off"`,
+			wantSQL:  `SELECT * FROM "tasks" WHERE (LOWER(tasks.title) LIKE $1 ESCAPE '\' OR LOWER(tasks.description) LIKE $2 ESCAPE '\') AND "tasks"."deleted_at" IS NULL`,
+			wantVars: []interface{}{`%50\%\_off%`, `%50\%\_off%`},
+		},
+		{
+			name:    "due dates",
+			query:   "due:none",
+			wantSQL: `SELECT * FROM "tasks" WHERE tasks.due_date IS NULL AND "tasks"."deleted_at" IS NULL`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockTaskRepository(ctrl)
+			taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+			found := []models.Task{{Model: gorm.Model{ID: 5}}}
+			mockRepo.EXPECT().SearchTasks(uint(1), gomock.Any(), services.MaxSearchResults).
+				DoAndReturn(func(userID uint, search *tql.Compiled, limit int) ([]models.Task, error) {
+					stmt := searchSQL(t, search)
+					assert.Equal(t, tt.wantSQL, stmt.SQL.String())
+					if tt.wantVars == nil {
+						assert.Empty(t, stmt.Vars)
+					} else {
+						assert.Equal(t, tt.wantVars, stmt.Vars)
+					}
+					return found, nil
+				})
+
+			tasks, err := taskSvc.SearchTasks(tt.query, 1)
+			require.NoError(t, err)
+			assert.Equal(t, found, tasks)
+		})
+	}
+}
+
+func TestSearchTasks_DateRanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	mockRepo.EXPECT().SearchTasks(uint(1), gomock.Any(), services.MaxSearchResults).
+		DoAndReturn(func(userID uint, search *tql.Compiled, limit int) ([]models.Task, error) {
+			stmt := searchSQL(t, search)
+			assert.Equal(t, `SELECT * FROM "tasks" WHERE (tasks.due_date >= $1 AND tasks.due_date < $2) `+
+				`AND tasks.created_at < $3 AND tasks.updated_at >= $4 AND "tasks"."deleted_at" IS NULL`, stmt.SQL.String())
+			require.Len(t, stmt.Vars, 4)
+			day := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+			assert.Equal(t, day, stmt.Vars[0])
+			assert.Equal(t, day.AddDate(0, 0, 1), stmt.Vars[1])
+			// Days after the date and offsets from now
+			assert.Equal(t, day.AddDate(0, 0, 1), stmt.Vars[2])
+			assert.WithinDuration(t, time.Now().AddDate(0, 0, -7), stmt.Vars[3].(time.Time), time.Minute)
+			return nil, nil
+		})
+
+	_, err := taskSvc.SearchTasks("due:2024-01-31 created<=2024-01-31 updated>=-1w", 1)
+	require.NoError(t, err)
+}
+
+func TestSearchTasks_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantPos int
+	}{
+		{name: "syntax error", query: `status:open label:"bug`, wantPos: 19},
+		{name: "unknown field", query: "status:open colour:red", wantPos: 13},
+		{name: "invalid priority", query: "priority:highest", wantPos: 10},
+		{name: "comparison on label", query: "label>bug", wantPos: 7},
+		{name: "invalid date", query: "due<tomorrow", wantPos: 5},
+		{name: "offset needs a comparison", query: "due:7d", wantPos: 5},
+		{name: "invalid project", query: "project:abc", wantPos: 9},
+		{name: "unknown sort key", query: "sort:priority,size", wantPos: 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// The repository isn't queried for invalid queries
+			mockRepo := mocks.NewMockTaskRepository(ctrl)
+			taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+			_, err := taskSvc.SearchTasks(tt.query, 1)
+			var queryErr *tql.Error
+			require.ErrorAs(t, err, &queryErr)
+			assert.Equal(t, tt.wantPos, queryErr.Pos, queryErr.Message)
+		})
+	}
+}
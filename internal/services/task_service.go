@@ -0,0 +1,308 @@
+// internal/services/task_service.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"TaskManager/pkg/blobstore"
+	"TaskManager/pkg/rank"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTaskNotFound        = errors.New("task not found")
+	ErrTaskTitleRequired   = errors.New("task title is required")
+	ErrInvalidTaskStatus   = errors.New("invalid task status")
+	ErrInvalidTaskPriority = errors.New("invalid task priority")
+	ErrInvalidTaskEstimate = errors.New("task estimate can't be negative")
+	ErrTaskCycle           = errors.New("a task can't be moved under itself or its own subtasks")
+	ErrTaskProjectMismatch = errors.New("a subtask must belong to the same project as its parent")
+	ErrTaskInHierarchy     = errors.New("tasks with a parent or subtasks can't change project")
+)
+
+// TaskService interface defines the methods for task-related business operations
+type TaskService interface {
+	CreateTask(task *models.Task) (*models.Task, error)
+	GetTaskByID(id, userID uint) (*models.Task, error)
+	GetTasksByUser(userID uint, labels []string, matchAll bool) ([]models.Task, error)
+	UpdateTask(task *models.Task, userID uint) (*models.Task, error)
+	DeleteTask(id, userID uint) error
+	GetTaskTree(id, userID uint) (*TaskNode, error)
+	MoveTask(id uint, parentID *uint, userID uint) (*models.Task, error)
+	GetBlockers(taskID, userID uint) ([]models.Task, error)
+	AddDependency(taskID, blockedByID, userID uint) (*models.TaskDependency, error)
+	RemoveDependency(taskID, blockedByID, userID uint) error
+	GetNextTasks(userID uint) ([]NextTask, error)
+	PreviewRecurrence(rule, timezone string, start time.Time, n int) (*RecurrencePreview, error)
+	GetUpcomingOccurrences(id, userID uint, n int) (*RecurrencePreview, error)
+	AssignTask(taskID, assigneeID, userID uint) (*models.Task, error)
+	UnassignTask(taskID, assigneeID, userID uint) (*models.Task, error)
+	WatchTask(taskID, userID uint) (*models.Task, error)
+	UnwatchTask(taskID, userID uint) (*models.Task, error)
+	GetAssignedTasks(userID uint) ([]models.Task, error)
+	GetWatchedTasks(userID uint) ([]models.Task, error)
+	SearchTasks(query string, userID uint) ([]models.Task, error)
+	GetChecklist(taskID, userID uint) ([]models.ChecklistItem, error)
+	AddChecklistItem(taskID uint, text string, userID uint) (*models.ChecklistItem, error)
+	RenameChecklistItem(taskID, itemID uint, text string, userID uint) (*models.ChecklistItem, error)
+	ToggleChecklistItem(taskID, itemID uint, done *bool, userID uint) (*ChecklistToggle, error)
+	MoveChecklistItem(taskID, itemID uint, position int, userID uint) ([]models.ChecklistItem, error)
+	DeleteChecklistItem(taskID, itemID, userID uint) error
+}
+
+// TaskServiceImpl is the concrete implementation of the TaskService interface
+type TaskServiceImpl struct {
+	TaskRepo    repositories.TaskRepository
+	ProjectRepo repositories.ProjectRepository
+	UserRepo    repositories.UserRepository
+	Blobs       blobstore.BlobStore
+}
+
+// NewTaskService creates and returns a new TaskService instance. Other
+// services share it to apply the same task rules.
+func NewTaskService(taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository, userRepo repositories.UserRepository, blobs blobstore.BlobStore) *TaskServiceImpl {
+	return &TaskServiceImpl{
+		TaskRepo:    taskRepo,
+		ProjectRepo: projectRepo,
+		UserRepo:    userRepo,
+		Blobs:       blobs,
+	}
+}
+
+// validateTask normalises defaults and checks the task's fields (internal
+// helper). The status is checked against the project workflow separately.
+func validateTask(task *models.Task) error {
+	task.Title = strings.TrimSpace(task.Title)
+	if task.Title == "" {
+		return ErrTaskTitleRequired
+	}
+
+	if task.Priority == "" {
+		task.Priority = models.TaskPriorityMedium
+	}
+	switch task.Priority {
+	case models.TaskPriorityLow, models.TaskPriorityMedium, models.TaskPriorityHigh, models.TaskPriorityUrgent:
+	default:
+		return ErrInvalidTaskPriority
+	}
+
+	if task.EstimateMinutes < 0 {
+		return ErrInvalidTaskEstimate
+	}
+	task.ChecklistAdvanceTo = strings.TrimSpace(task.ChecklistAdvanceTo)
+	return normaliseRecurrence(task)
+}
+
+// checkTaskAccess verifies the user holds at least minRole on the task. Personal
+// tasks are only accessible to their owner; project tasks follow membership.
+func (s *TaskServiceImpl) checkTaskAccess(task *models.Task, userID uint, minRole string) error {
+	if task.ProjectID == nil {
+		if task.UserID != userID {
+			return ErrTaskNotFound
+		}
+		return nil
+	}
+
+	_, err := requireProjectRole(s.ProjectRepo, *task.ProjectID, userID, minRole)
+	if errors.Is(err, ErrProjectNotFound) {
+		// Tasks of foreign projects are reported as missing so IDs can't be probed
+		return ErrTaskNotFound
+	}
+	return err
+}
+
+// loadTask fetches a task and makes sure the user holds minRole on it (internal helper)
+func (s *TaskServiceImpl) loadTask(id, userID uint, minRole string) (*models.Task, error) {
+	task, err := s.TaskRepo.GetTaskByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, fmt.Errorf("unexpected error fetching task: %v", err)
+	}
+	if err := s.checkTaskAccess(task, userID, minRole); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// CreateTask validates and persists a new task for its owner. Tasks created
+// inside a project require an editor role there; subtasks inherit the
+// project of their parent.
+func (s *TaskServiceImpl) CreateTask(task *models.Task) (*models.Task, error) {
+	if err := validateTask(task); err != nil {
+		return nil, err
+	}
+	if err := prepareChecklist(task); err != nil {
+		return nil, err
+	}
+	if task.ParentID != nil {
+		parent, err := s.loadTask(*task.ParentID, task.UserID, models.ProjectRoleEditor)
+		if err != nil {
+			return nil, err
+		}
+		task.ProjectID = parent.ProjectID
+	} else if task.ProjectID != nil {
+		if _, err := requireProjectRole(s.ProjectRepo, *task.ProjectID, task.UserID, models.ProjectRoleEditor); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := s.applyCustomFields(task, nil); err != nil {
+		return nil, err
+	}
+
+	return s.insertTask(task)
+}
+
+// insertTask places a validated task in its workflow and on the board, then
+// persists it (internal helper)
+func (s *TaskServiceImpl) insertTask(task *models.Task) (*models.Task, error) {
+	if err := s.applyWorkflow(task, nil, task.UserID); err != nil {
+		return nil, err
+	}
+
+	// A new task has no subtasks yet, so its progress follows its own status
+	task.Progress = computeProgress(task, nil)
+
+	// New project tasks are placed at the bottom of their board column
+	if task.ProjectID != nil {
+		last, err := s.TaskRepo.GetLastTaskRank(*task.ProjectID)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected error ranking task: %v", err)
+		}
+		if task.Rank, err = rank.After(last); err != nil {
+			return nil, fmt.Errorf("unexpected error ranking task: %v", err)
+		}
+	}
+
+	createdTask, err := s.TaskRepo.CreateTask(task)
+	if errors.Is(err, ErrWipLimitReached) {
+		return nil, err
+	}
+	if err != nil {
+		log.Println("Error creating task:", err)
+		return nil, fmt.Errorf("failed to create task: %v", err)
+	}
+	if err := s.rollUpProgress(createdTask.ParentID); err != nil {
+		log.Println("Error rolling up task progress:", err)
+	}
+	return createdTask, nil
+}
+
+// GetTaskByID retrieves a task by its ID if the user may see it
+func (s *TaskServiceImpl) GetTaskByID(id, userID uint) (*models.Task, error) {
+	return s.loadTask(id, userID, models.ProjectRoleViewer)
+}
+
+// GetTasksByUser retrieves all tasks owned by the given user. With labels,
+// only tasks tagged with any (or, with matchAll, every) of them are returned.
+func (s *TaskServiceImpl) GetTasksByUser(userID uint, labels []string, matchAll bool) ([]models.Task, error) {
+	filter := repositories.LabelFilter{MatchAll: matchAll}
+	for _, label := range labels {
+		if label = strings.TrimSpace(label); label != "" {
+			filter.Names = append(filter.Names, label)
+		}
+	}
+	return s.TaskRepo.GetTasksByUserID(userID, filter)
+}
+
+// UpdateTask validates and saves changes to a task the user may edit
+func (s *TaskServiceImpl) UpdateTask(task *models.Task, userID uint) (*models.Task, error) {
+	existing, err := s.loadTask(task.ID, userID, models.ProjectRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ownership and hierarchy can't be changed through an update; see MoveTask.
+	// Assignees, watchers and labels have endpoints of their own.
+	task.UserID = existing.UserID
+	task.ParentID = existing.ParentID
+	task.Assignees = existing.Assignees
+	task.Watchers = existing.Watchers
+	task.Labels = existing.Labels
+
+	// A changed rule starts a new series from the task's due date
+	if task.Recurrence != existing.Recurrence || task.RecurrenceTimezone != existing.RecurrenceTimezone {
+		task.RecurrenceStart = nil
+	}
+
+	if err := validateTask(task); err != nil {
+		return nil, err
+	}
+
+	subtasks, err := s.TaskRepo.GetSubtasks([]uint{task.ID})
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error fetching subtasks: %v", err)
+	}
+
+	// Moving a task into another project requires editing rights there as well
+	if task.ProjectID != nil && (existing.ProjectID == nil || *existing.ProjectID != *task.ProjectID) {
+		if task.ParentID != nil || len(subtasks) > 0 {
+			return nil, ErrTaskInHierarchy
+		}
+		if _, err := requireProjectRole(s.ProjectRepo, *task.ProjectID, userID, models.ProjectRoleEditor); err != nil {
+			return nil, err
+		}
+	}
+
+	// Custom values are validated against the fields of the task's (new) project
+	replaceValues, err := s.applyCustomFields(task, existing)
+	if err != nil {
+		return nil, err
+	}
+
+	// Status changes follow the workflow; completing also requires the blockers to be done
+	if err := s.applyWorkflow(task, existing, userID); err != nil {
+		return nil, err
+	}
+
+	task.Progress = computeProgress(task, subtasks)
+	next, err := s.handOverRecurrence(task, existing)
+	if err != nil {
+		return nil, err
+	}
+	updatedTask, err := s.TaskRepo.UpdateTask(task)
+	if err != nil {
+		return nil, err
+	}
+	if replaceValues {
+		if err := s.TaskRepo.ReplaceCustomFieldValues(updatedTask.ID, updatedTask.CustomValues); err != nil {
+			return nil, fmt.Errorf("failed to save custom field values: %v", err)
+		}
+	}
+	if err := s.rollUpProgress(updatedTask.ParentID); err != nil {
+		log.Println("Error rolling up task progress:", err)
+	}
+	if err := s.scheduleOccurrence(next); err != nil {
+		return nil, err
+	}
+	return updatedTask, nil
+}
+
+// DeleteTask deletes a task the user may edit together with all of its subtasks and their attachments
+func (s *TaskServiceImpl) DeleteTask(id, userID uint) error {
+	task, err := s.loadTask(id, userID, models.ProjectRoleEditor)
+	if err != nil {
+		return err
+	}
+
+	tree, err := s.buildTree(task)
+	if err != nil {
+		return err
+	}
+	keys, err := s.TaskRepo.DeleteTasks(tree.IDs())
+	if err != nil {
+		return err
+	}
+	deleteBlobs(s.Blobs, keys)
+	if err := s.rollUpProgress(task.ParentID); err != nil {
+		log.Println("Error rolling up task progress:", err)
+	}
+	return nil
+}
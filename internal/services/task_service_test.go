@@ -0,0 +1,202 @@
+package services_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestCreateTask_Defaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil)
+
+	mockRepo.EXPECT().CreateTask(gomock.Any()).DoAndReturn(
+		func(task *models.Task) (*models.Task, error) { return task, nil },
+	)
+
+	task, err := taskSvc.CreateTask(&models.Task{Title: "  Write docs ", UserID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Write docs", task.Title)
+	assert.Equal(t, models.TaskStatusTodo, task.Status)
+	assert.Equal(t, models.TaskPriorityMedium, task.Priority)
+}
+
+func TestCreateTask_InvalidInput(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil)
+
+	// The repository must not be reached when validation fails
+	mockRepo.EXPECT().CreateTask(gomock.Any()).Times(0)
+
+	_, err := taskSvc.CreateTask(&models.Task{Title: " ", UserID: 1})
+	assert.ErrorIs(t, err, services.ErrTaskTitleRequired)
+
+	_, err = taskSvc.CreateTask(&models.Task{Title: "Task", Status: "archived", UserID: 1})
+	assert.ErrorIs(t, err, services.ErrInvalidTaskStatus)
+
+	_, err = taskSvc.CreateTask(&models.Task{Title: "Task", Priority: "whenever", UserID: 1})
+	assert.ErrorIs(t, err, services.ErrInvalidTaskPriority)
+}
+
+func TestGetTaskByID_OtherUsersTaskIsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil)
+
+	stored := &models.Task{Model: gorm.Model{ID: 7}, Title: "Secret", UserID: 2}
+	mockRepo.EXPECT().GetTaskByID(uint(7)).Return(stored, nil)
+
+	task, err := taskSvc.GetTaskByID(7, 1)
+	assert.ErrorIs(t, err, services.ErrTaskNotFound)
+	assert.Nil(t, task)
+}
+
+func TestGetTaskByID_Missing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil)
+
+	mockRepo.EXPECT().GetTaskByID(uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := taskSvc.GetTaskByID(9, 1)
+	assert.ErrorIs(t, err, services.ErrTaskNotFound)
+}
+
+func TestUpdateTask_KeepsOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil)
+
+	stored := &models.Task{Model: gorm.Model{ID: 3}, Title: "Old", Status: models.TaskStatusTodo, UserID: 1}
+	mockRepo.EXPECT().GetTaskByID(uint(3)).Return(stored, nil)
+	mockRepo.EXPECT().GetDependencies([]uint{3}).Return(nil, nil)
+	mockRepo.EXPECT().GetSubtasks([]uint{3}).Return(nil, nil)
+	mockRepo.EXPECT().UpdateTask(gomock.Any()).DoAndReturn(
+		func(task *models.Task) (*models.Task, error) { return task, nil },
+	)
+
+	changed := &models.Task{Model: gorm.Model{ID: 3}, Title: "New", Status: models.TaskStatusDone, UserID: 99}
+	task, err := taskSvc.UpdateTask(changed, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "New", task.Title)
+	assert.Equal(t, uint(1), task.UserID)
+}
+
+func TestDeleteTask_OtherUsersTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil)
+
+	mockRepo.EXPECT().GetTaskByID(uint(4)).Return(&models.Task{Model: gorm.Model{ID: 4}, UserID: 2}, nil)
+	mockRepo.EXPECT().DeleteTasks(gomock.Any()).Times(0)
+
+	err := taskSvc.DeleteTask(4, 1)
+	assert.ErrorIs(t, err, services.ErrTaskNotFound)
+}
+
+func TestCreateTask_InProjectRequiresEditor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil)
+
+	projectID := uint(5)
+	mockProjects.EXPECT().GetMember(projectID, uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleViewer}, nil)
+	mockRepo.EXPECT().CreateTask(gomock.Any()).Times(0)
+
+	_, err := taskSvc.CreateTask(&models.Task{Title: "Task", UserID: 1, ProjectID: &projectID})
+	assert.ErrorIs(t, err, services.ErrProjectForbidden)
+}
+
+func TestCreateTask_InProjectGoesToBottomOfBoard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil)
+
+	projectID := uint(5)
+	mockProjects.EXPECT().GetMember(projectID, uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleEditor}, nil)
+	mockProjects.EXPECT().GetCustomFields(projectID).Return(nil, nil)
+	mockProjects.EXPECT().GetWorkflow(projectID).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.EXPECT().GetLastTaskRank(projectID).Return("m", nil)
+	mockRepo.EXPECT().CreateTask(gomock.Any()).DoAndReturn(
+		func(task *models.Task) (*models.Task, error) { return task, nil },
+	)
+
+	task, err := taskSvc.CreateTask(&models.Task{Title: "Task", UserID: 1, ProjectID: &projectID})
+	require.NoError(t, err)
+	assert.Greater(t, task.Rank, "m")
+}
+
+func TestCreateTask_FullColumn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil)
+
+	projectID := uint(5)
+	mockProjects.EXPECT().GetMember(projectID, uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleEditor}, nil)
+	mockProjects.EXPECT().GetCustomFields(projectID).Return(nil, nil)
+	mockProjects.EXPECT().GetWorkflow(projectID).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.EXPECT().GetLastTaskRank(projectID).Return("m", nil)
+	mockRepo.EXPECT().CreateTask(gomock.Any()).Return(nil, fmt.Errorf("%w: %q allows %d tasks", services.ErrWipLimitReached, "Doing", 2))
+
+	_, err := taskSvc.CreateTask(&models.Task{Title: "Task", Status: models.TaskStatusInProgress, UserID: 1, ProjectID: &projectID})
+	assert.ErrorIs(t, err, services.ErrWipLimitReached)
+}
+
+func TestGetTaskByID_ProjectMemberCanRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil)
+
+	projectID := uint(5)
+	stored := &models.Task{Model: gorm.Model{ID: 8}, Title: "Shared", UserID: 2, ProjectID: &projectID}
+	mockRepo.EXPECT().GetTaskByID(uint(8)).Return(stored, nil).Times(2)
+	mockProjects.EXPECT().GetMember(projectID, uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleViewer}, nil)
+	mockProjects.EXPECT().GetMember(projectID, uint(3)).Return(nil, gorm.ErrRecordNotFound)
+
+	task, err := taskSvc.GetTaskByID(8, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Shared", task.Title)
+
+	// Non-members can't tell the task exists
+	_, err = taskSvc.GetTaskByID(8, 3)
+	assert.ErrorIs(t, err, services.ErrTaskNotFound)
+}
@@ -0,0 +1,187 @@
+// internal/services/task_tree.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"errors"
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// TaskNode is a task together with its nested subtasks
+type TaskNode struct {
+	Task     models.Task
+	Subtasks []*TaskNode
+}
+
+// IDs returns the IDs of the node and all of its descendants
+func (n *TaskNode) IDs() []uint {
+	ids := []uint{n.Task.ID}
+	for _, child := range n.Subtasks {
+		ids = append(ids, child.IDs()...)
+	}
+	return ids
+}
+
+// isTaskDone reports whether a task counts as completed, i.e. it is in a
+// final state of its workflow
+func isTaskDone(task *models.Task) bool {
+	return task.Closed
+}
+
+// computeProgress returns a task's completion percentage: the average of its
+// subtasks' progress, or 0/100 from its own status when it has none.
+func computeProgress(task *models.Task, subtasks []models.Task) int {
+	if len(subtasks) == 0 {
+		if isTaskDone(task) {
+			return 100
+		}
+		return 0
+	}
+
+	total := 0
+	for _, subtask := range subtasks {
+		total += subtask.Progress
+	}
+	return total / len(subtasks)
+}
+
+// rollUpProgress recomputes the progress of the task with the given ID and
+// propagates changes to its ancestors, stopping as soon as nothing changes.
+func (s *TaskServiceImpl) rollUpProgress(id *uint) error {
+	visited := map[uint]bool{}
+	for id != nil && !visited[*id] {
+		visited[*id] = true
+
+		task, err := s.TaskRepo.GetTaskByID(*id)
+		if err != nil {
+			return err
+		}
+		subtasks, err := s.TaskRepo.GetSubtasks([]uint{task.ID})
+		if err != nil {
+			return err
+		}
+
+		progress := computeProgress(task, subtasks)
+		if progress == task.Progress {
+			return nil
+		}
+		if err := s.TaskRepo.UpdateTaskProgress(task.ID, progress); err != nil {
+			return err
+		}
+		id = task.ParentID
+	}
+	return nil
+}
+
+// buildTree loads all descendants of a task level by level (internal helper)
+func (s *TaskServiceImpl) buildTree(root *models.Task) (*TaskNode, error) {
+	rootNode := &TaskNode{Task: *root}
+	nodes := map[uint]*TaskNode{root.ID: rootNode}
+	level := []uint{root.ID}
+
+	for len(level) > 0 {
+		subtasks, err := s.TaskRepo.GetSubtasks(level)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected error fetching subtasks: %v", err)
+		}
+
+		var next []uint
+		for _, subtask := range subtasks {
+			// Guard against corrupted data looping back into the tree
+			if _, seen := nodes[subtask.ID]; seen || subtask.ParentID == nil {
+				continue
+			}
+			parent, ok := nodes[*subtask.ParentID]
+			if !ok {
+				continue
+			}
+			node := &TaskNode{Task: subtask}
+			parent.Subtasks = append(parent.Subtasks, node)
+			nodes[subtask.ID] = node
+			next = append(next, subtask.ID)
+		}
+		level = next
+	}
+	return rootNode, nil
+}
+
+// GetTaskTree retrieves a task with its whole subtree of subtasks
+func (s *TaskServiceImpl) GetTaskTree(id, userID uint) (*TaskNode, error) {
+	task, err := s.loadTask(id, userID, models.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildTree(task)
+}
+
+// MoveTask re-parents a task (and its subtree) under parentID, or makes it a
+// root task when parentID is nil. Moves that would create a cycle are rejected.
+func (s *TaskServiceImpl) MoveTask(id uint, parentID *uint, userID uint) (*models.Task, error) {
+	task, err := s.loadTask(id, userID, models.ProjectRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID != nil {
+		parent, err := s.loadTask(*parentID, userID, models.ProjectRoleEditor)
+		if err != nil {
+			return nil, err
+		}
+		if !sameProject(task.ProjectID, parent.ProjectID) {
+			return nil, ErrTaskProjectMismatch
+		}
+		if err := s.ensureNotDescendant(task.ID, parent); err != nil {
+			return nil, err
+		}
+	}
+
+	oldParentID := task.ParentID
+	task.ParentID = parentID
+	movedTask, err := s.TaskRepo.UpdateTask(task)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, affected := range []*uint{oldParentID, parentID} {
+		if err := s.rollUpProgress(affected); err != nil {
+			log.Println("Error rolling up task progress:", err)
+		}
+	}
+	return movedTask, nil
+}
+
+// ensureNotDescendant walks up from candidate to the root and fails if it
+// passes through taskID, i.e. if candidate is the task or one of its subtasks.
+func (s *TaskServiceImpl) ensureNotDescendant(taskID uint, candidate *models.Task) error {
+	visited := map[uint]bool{}
+	current := candidate
+	for {
+		if current.ID == taskID {
+			return ErrTaskCycle
+		}
+		if current.ParentID == nil || visited[current.ID] {
+			return nil
+		}
+		visited[current.ID] = true
+
+		next, err := s.TaskRepo.GetTaskByID(*current.ParentID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return fmt.Errorf("unexpected error fetching parent task: %v", err)
+		}
+		current = next
+	}
+}
+
+// sameProject reports whether two optional project IDs refer to the same project
+func sameProject(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,143 @@
+package services_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"TaskManager/pkg/blobstore"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func uintPtr(v uint) *uint { return &v }
+
+func TestMoveTask_RejectsCycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	// 1 -> 2 -> 3; moving 1 under 3 would close a loop
+	root := &models.Task{Model: gorm.Model{ID: 1}, UserID: 1}
+	child := &models.Task{Model: gorm.Model{ID: 2}, UserID: 1, ParentID: uintPtr(1)}
+	grandchild := &models.Task{Model: gorm.Model{ID: 3}, UserID: 1, ParentID: uintPtr(2)}
+
+	mockRepo.EXPECT().GetTaskByID(uint(1)).Return(root, nil).AnyTimes()
+	mockRepo.EXPECT().GetTaskByID(uint(2)).Return(child, nil).AnyTimes()
+	mockRepo.EXPECT().GetTaskByID(uint(3)).Return(grandchild, nil).AnyTimes()
+	mockRepo.EXPECT().UpdateTask(gomock.Any()).Times(0)
+
+	_, err := taskSvc.MoveTask(1, uintPtr(3), 1)
+	assert.ErrorIs(t, err, services.ErrTaskCycle)
+
+	_, err = taskSvc.MoveTask(1, uintPtr(1), 1)
+	assert.ErrorIs(t, err, services.ErrTaskCycle)
+}
+
+func TestMoveTask_RejectsOtherProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil)
+
+	mockRepo.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{Model: gorm.Model{ID: 1}, UserID: 1}, nil)
+	mockRepo.EXPECT().GetTaskByID(uint(2)).Return(&models.Task{Model: gorm.Model{ID: 2}, UserID: 1, ProjectID: uintPtr(9)}, nil)
+	mockProjects.EXPECT().GetMember(uint(9), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleEditor}, nil)
+
+	_, err := taskSvc.MoveTask(1, uintPtr(2), 1)
+	assert.ErrorIs(t, err, services.ErrTaskProjectMismatch)
+}
+
+func TestUpdateTask_RollsUpProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	// 1 -> 2 -> {3, 4}; completing 3 makes 2 half done, and 1 follows 2
+	root := &models.Task{Model: gorm.Model{ID: 1}, Title: "Release", UserID: 1}
+	parent := &models.Task{Model: gorm.Model{ID: 2}, Title: "Backend", UserID: 1, ParentID: uintPtr(1)}
+	leaf := &models.Task{Model: gorm.Model{ID: 3}, Title: "API", Status: models.TaskStatusTodo, UserID: 1, ParentID: uintPtr(2)}
+	sibling := models.Task{Model: gorm.Model{ID: 4}, Title: "DB", UserID: 1, ParentID: uintPtr(2)}
+
+	mockRepo.EXPECT().GetTaskByID(uint(3)).Return(leaf, nil)
+	mockRepo.EXPECT().GetDependencies([]uint{3}).Return(nil, nil)
+	mockRepo.EXPECT().GetSubtasks([]uint{3}).Return(nil, nil)
+	mockRepo.EXPECT().UpdateTask(gomock.Any()).DoAndReturn(
+		func(task *models.Task) (*models.Task, error) { return task, nil },
+	)
+
+	mockRepo.EXPECT().GetTaskByID(uint(2)).Return(parent, nil)
+	mockRepo.EXPECT().GetSubtasks([]uint{2}).Return([]models.Task{
+		{Model: gorm.Model{ID: 3}, ParentID: uintPtr(2), Progress: 100},
+		sibling,
+	}, nil)
+	mockRepo.EXPECT().UpdateTaskProgress(uint(2), 50).Return(nil)
+
+	mockRepo.EXPECT().GetTaskByID(uint(1)).Return(root, nil)
+	mockRepo.EXPECT().GetSubtasks([]uint{1}).Return([]models.Task{
+		{Model: gorm.Model{ID: 2}, ParentID: uintPtr(1), Progress: 50},
+	}, nil)
+	mockRepo.EXPECT().UpdateTaskProgress(uint(1), 50).Return(nil)
+
+	changed := &models.Task{Model: gorm.Model{ID: 3}, Title: "API", Status: models.TaskStatusDone, UserID: 1, ParentID: uintPtr(2)}
+	task, err := taskSvc.UpdateTask(changed, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 100, task.Progress)
+}
+
+func TestGetTaskTree_NestsSubtasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), nil)
+
+	mockRepo.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{Model: gorm.Model{ID: 1}, UserID: 1}, nil)
+	mockRepo.EXPECT().GetSubtasks([]uint{1}).Return([]models.Task{
+		{Model: gorm.Model{ID: 2}, ParentID: uintPtr(1)},
+		{Model: gorm.Model{ID: 3}, ParentID: uintPtr(1)},
+	}, nil)
+	mockRepo.EXPECT().GetSubtasks([]uint{2, 3}).Return([]models.Task{
+		{Model: gorm.Model{ID: 4}, ParentID: uintPtr(3)},
+	}, nil)
+	mockRepo.EXPECT().GetSubtasks([]uint{4}).Return(nil, nil)
+
+	tree, err := taskSvc.GetTaskTree(1, 1)
+	require.NoError(t, err)
+	require.Len(t, tree.Subtasks, 2)
+	assert.Empty(t, tree.Subtasks[0].Subtasks)
+	require.Len(t, tree.Subtasks[1].Subtasks, 1)
+	assert.Equal(t, uint(4), tree.Subtasks[1].Subtasks[0].Task.ID)
+	assert.Equal(t, []uint{1, 2, 3, 4}, tree.IDs())
+}
+
+func TestDeleteTask_DeletesSubtree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	blobs := blobstore.NewMemoryStore()
+	taskSvc := services.NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mocks.NewMockUserRepository(ctrl), blobs)
+
+	_, err := blobs.Put("subtask-attachment", strings.NewReader("log"))
+	require.NoError(t, err)
+
+	mockRepo.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{Model: gorm.Model{ID: 1}, UserID: 1}, nil)
+	mockRepo.EXPECT().GetSubtasks([]uint{1}).Return([]models.Task{{Model: gorm.Model{ID: 2}, ParentID: uintPtr(1)}}, nil)
+	mockRepo.EXPECT().GetSubtasks([]uint{2}).Return(nil, nil)
+	mockRepo.EXPECT().DeleteTasks([]uint{1, 2}).Return([]string{"subtask-attachment"}, nil)
+
+	require.NoError(t, taskSvc.DeleteTask(1, 1))
+	// The blobs of the deleted attachments go too
+	assert.Equal(t, 0, blobs.Len())
+}
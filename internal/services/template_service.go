@@ -0,0 +1,408 @@
+// internal/services/template_service.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"TaskManager/pkg/rank"
+	"TaskManager/pkg/reldate"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTemplateNotFound     = errors.New("task template not found")
+	ErrTemplateNameRequired = errors.New("template name is required")
+	ErrInvalidTemplate      = errors.New("invalid task template")
+)
+
+// Bounds of a template's task tree
+const (
+	MaxTemplateTasks = 200
+	MaxTemplateDepth = 5
+)
+
+// TemplateTarget says where a template's tasks are created and which date
+// their due offsets count from. Without a project the tasks are personal.
+type TemplateTarget struct {
+	ProjectID *uint
+	Anchor    time.Time
+}
+
+// TemplateService interface defines the methods for task-template-related business operations
+type TemplateService interface {
+	CreateTemplate(template *models.TaskTemplate, userID uint) (*models.TaskTemplate, error)
+	GetTemplateByID(id, userID uint) (*models.TaskTemplate, error)
+	GetPersonalTemplates(userID uint) ([]models.TaskTemplate, error)
+	GetProjectTemplates(projectID, userID uint) ([]models.TaskTemplate, error)
+	UpdateTemplate(template *models.TaskTemplate, userID uint) (*models.TaskTemplate, error)
+	DeleteTemplate(id, userID uint) error
+	InstantiateTemplate(id uint, target TemplateTarget, userID uint) ([]*TaskNode, error)
+}
+
+// TemplateServiceImpl is the concrete implementation of the TemplateService interface
+type TemplateServiceImpl struct {
+	TemplateRepo repositories.TemplateRepository
+	TaskRepo     repositories.TaskRepository
+	ProjectRepo  repositories.ProjectRepository
+	LabelRepo    repositories.LabelRepository
+
+	// tasks gives access to the rules (workflow, checklist, custom fields) new tasks follow
+	tasks *TaskServiceImpl
+}
+
+// NewTemplateService creates and returns a new TemplateService instance
+func NewTemplateService(templateRepo repositories.TemplateRepository, taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository, labelRepo repositories.LabelRepository, tasks *TaskServiceImpl) TemplateService {
+	return &TemplateServiceImpl{
+		TemplateRepo: templateRepo,
+		TaskRepo:     taskRepo,
+		ProjectRepo:  projectRepo,
+		LabelRepo:    labelRepo,
+		tasks:        tasks,
+	}
+}
+
+// validateTemplate normalises a template and checks its task tree (internal helper)
+func validateTemplate(template *models.TaskTemplate) error {
+	template.Name = strings.TrimSpace(template.Name)
+	if template.Name == "" {
+		return ErrTemplateNameRequired
+	}
+	template.Description = strings.TrimSpace(template.Description)
+	if len(template.Tasks) == 0 {
+		return fmt.Errorf("%w: a template needs at least one task", ErrInvalidTemplate)
+	}
+
+	count := 0
+	var walk func(tasks []models.TemplateTask, path string, depth int) error
+	walk = func(tasks []models.TemplateTask, path string, depth int) error {
+		if depth > MaxTemplateDepth {
+			return fmt.Errorf("%w: subtasks are limited to %d levels", ErrInvalidTemplate, MaxTemplateDepth)
+		}
+		for i := range tasks {
+			if count++; count > MaxTemplateTasks {
+				return fmt.Errorf("%w: at most %d tasks", ErrInvalidTemplate, MaxTemplateTasks)
+			}
+			taskPath := fmt.Sprintf("%s[%d]", path, i)
+			if err := validateTemplateTask(&tasks[i]); err != nil {
+				return fmt.Errorf("%w: %s: %v", ErrInvalidTemplate, taskPath, err)
+			}
+			if err := walk(tasks[i].Subtasks, taskPath+".subtasks", depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(template.Tasks, "tasks", 1)
+}
+
+// validateTemplateTask normalises a single task of a template; the checks
+// that depend on where the task is created run on instantiation (internal helper)
+func validateTemplateTask(task *models.TemplateTask) error {
+	task.Title = strings.TrimSpace(task.Title)
+	if task.Title == "" {
+		return ErrTaskTitleRequired
+	}
+	task.Description = strings.TrimSpace(task.Description)
+
+	switch task.Priority {
+	case "", models.TaskPriorityLow, models.TaskPriorityMedium, models.TaskPriorityHigh, models.TaskPriorityUrgent:
+	default:
+		return ErrInvalidTaskPriority
+	}
+	if task.EstimateMinutes < 0 {
+		return ErrInvalidTaskEstimate
+	}
+
+	if task.DueOffset = strings.TrimSpace(task.DueOffset); task.DueOffset != "" {
+		offset, err := reldate.Parse(task.DueOffset)
+		if err != nil {
+			return err
+		}
+		task.DueOffset = offset.String()
+	}
+
+	if len(task.Checklist) > MaxChecklistItems {
+		return fmt.Errorf("%w: at most %d", ErrChecklistFull, MaxChecklistItems)
+	}
+	for i, text := range task.Checklist {
+		text, err := validateChecklistText(text)
+		if err != nil {
+			return err
+		}
+		task.Checklist[i] = text
+	}
+
+	seen := map[string]bool{}
+	labels := make([]string, 0, len(task.Labels))
+	for _, name := range task.Labels {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[strings.ToLower(name)] {
+			continue
+		}
+		seen[strings.ToLower(name)] = true
+		labels = append(labels, name)
+	}
+	task.Labels = labels
+	return nil
+}
+
+// checkTemplateAccess verifies the user holds at least minRole on a template.
+// Personal templates are only accessible to their owner.
+func (s *TemplateServiceImpl) checkTemplateAccess(template *models.TaskTemplate, userID uint, minRole string) error {
+	if template.ProjectID == nil {
+		if template.UserID != userID {
+			return ErrTemplateNotFound
+		}
+		return nil
+	}
+
+	_, err := requireProjectRole(s.ProjectRepo, *template.ProjectID, userID, minRole)
+	if errors.Is(err, ErrProjectNotFound) {
+		return ErrTemplateNotFound
+	}
+	return err
+}
+
+// loadTemplate fetches a template and makes sure the user holds minRole on it (internal helper)
+func (s *TemplateServiceImpl) loadTemplate(id, userID uint, minRole string) (*models.TaskTemplate, error) {
+	template, err := s.TemplateRepo.GetTemplateByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("unexpected error fetching task template: %v", err)
+	}
+	if err := s.checkTemplateAccess(template, userID, minRole); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// CreateTemplate creates a project template when the template has a
+// project, and a personal template of the user otherwise. Project templates
+// require an editor role.
+func (s *TemplateServiceImpl) CreateTemplate(template *models.TaskTemplate, userID uint) (*models.TaskTemplate, error) {
+	if template.ProjectID != nil {
+		if _, err := requireProjectRole(s.ProjectRepo, *template.ProjectID, userID, models.ProjectRoleEditor); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	template.ID = 0
+	template.UserID = userID
+	return s.TemplateRepo.CreateTemplate(template)
+}
+
+// GetTemplateByID retrieves a template the user may see
+func (s *TemplateServiceImpl) GetTemplateByID(id, userID uint) (*models.TaskTemplate, error) {
+	return s.loadTemplate(id, userID, models.ProjectRoleViewer)
+}
+
+// GetPersonalTemplates retrieves the user's personal templates
+func (s *TemplateServiceImpl) GetPersonalTemplates(userID uint) ([]models.TaskTemplate, error) {
+	return s.TemplateRepo.GetTemplatesByUserID(userID)
+}
+
+// GetProjectTemplates retrieves the templates of a project the user is a member of
+func (s *TemplateServiceImpl) GetProjectTemplates(projectID, userID uint) ([]models.TaskTemplate, error) {
+	if _, err := requireProjectRole(s.ProjectRepo, projectID, userID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+	return s.TemplateRepo.GetTemplatesByProjectID(projectID)
+}
+
+// UpdateTemplate replaces a template's name, description and task tree; its
+// owner and project stay as they are
+func (s *TemplateServiceImpl) UpdateTemplate(template *models.TaskTemplate, userID uint) (*models.TaskTemplate, error) {
+	existing, err := s.loadTemplate(template.ID, userID, models.ProjectRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	template.UserID = existing.UserID
+	template.ProjectID = existing.ProjectID
+	template.CreatedAt = existing.CreatedAt
+	if err := validateTemplate(template); err != nil {
+		return nil, err
+	}
+	return s.TemplateRepo.UpdateTemplate(template)
+}
+
+// DeleteTemplate deletes a template the user may edit; tasks created from it stay
+func (s *TemplateServiceImpl) DeleteTemplate(id, userID uint) error {
+	if _, err := s.loadTemplate(id, userID, models.ProjectRoleEditor); err != nil {
+		return err
+	}
+	if err := s.TemplateRepo.DeleteTemplate(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTemplateNotFound
+		}
+		return fmt.Errorf("failed to delete task template: %v", err)
+	}
+	return nil
+}
+
+// templateInstance collects the tasks created from a template (internal helper)
+type templateInstance struct {
+	s        *TemplateServiceImpl
+	target   TemplateTarget
+	userID   uint
+	lastRank string
+	labels   map[string]models.Label
+	tasks    []repositories.TreeTask
+}
+
+// InstantiateTemplate creates the template's tasks in the target, resolving
+// due offsets from the target's anchor date and labels by name in the
+// target's scope (missing labels are created). Everything is created in one
+// transaction; the root tasks are returned with their subtasks.
+func (s *TemplateServiceImpl) InstantiateTemplate(id uint, target TemplateTarget, userID uint) ([]*TaskNode, error) {
+	template, err := s.loadTemplate(id, userID, models.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	instance := &templateInstance{s: s, target: target, userID: userID, labels: map[string]models.Label{}}
+	if target.ProjectID != nil {
+		if _, err := requireProjectRole(s.ProjectRepo, *target.ProjectID, userID, models.ProjectRoleEditor); err != nil {
+			return nil, err
+		}
+		if instance.lastRank, err = s.TaskRepo.GetLastTaskRank(*target.ProjectID); err != nil {
+			return nil, fmt.Errorf("unexpected error ranking task: %v", err)
+		}
+	}
+
+	roots := make([]*TaskNode, 0, len(template.Tasks))
+	for i := range template.Tasks {
+		root, err := instance.build(&template.Tasks[i], -1, fmt.Sprintf("tasks[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, root)
+	}
+
+	if err := s.TaskRepo.CreateTaskTree(instance.tasks); err != nil {
+		return nil, fmt.Errorf("failed to create tasks from template: %v", err)
+	}
+	return roots, nil
+}
+
+// build prepares a template task and its subtasks for creation. Parent is
+// the index of the parent task, path locates the task in error messages.
+func (i *templateInstance) build(tmpl *models.TemplateTask, parent int, path string) (*TaskNode, error) {
+	task := models.Task{
+		Title:            tmpl.Title,
+		Description:      tmpl.Description,
+		Priority:         tmpl.Priority,
+		EstimateMinutes:  tmpl.EstimateMinutes,
+		UserID:           i.userID,
+		ProjectID:        i.target.ProjectID,
+		CustomFieldInput: tmpl.CustomFields,
+	}
+	if tmpl.DueOffset != "" {
+		offset, err := reldate.Parse(tmpl.DueOffset)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidTemplate, path, err)
+		}
+		due := offset.From(i.target.Anchor).UTC()
+		task.DueDate = &due
+	}
+	for _, text := range tmpl.Checklist {
+		task.Checklist = append(task.Checklist, models.ChecklistItem{Text: text})
+	}
+
+	if err := i.prepare(&task); err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			// Point at the template task the values belong to
+			fields := make(map[string]string, len(validationErr.Fields))
+			for key, problem := range validationErr.Fields {
+				fields[path+"."+key] = problem
+			}
+			return nil, &ValidationError{Fields: fields}
+		}
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	for _, name := range tmpl.Labels {
+		label, err := i.label(name)
+		if err != nil {
+			return nil, err
+		}
+		task.Labels = append(task.Labels, label)
+	}
+
+	node := &TaskNode{Task: task}
+	i.tasks = append(i.tasks, repositories.TreeTask{Task: &node.Task, Parent: parent})
+	index := len(i.tasks) - 1
+
+	subtasks := make([]models.Task, 0, len(tmpl.Subtasks))
+	for j := range tmpl.Subtasks {
+		child, err := i.build(&tmpl.Subtasks[j], index, fmt.Sprintf("%s.subtasks[%d]", path, j))
+		if err != nil {
+			return nil, err
+		}
+		node.Subtasks = append(node.Subtasks, child)
+		subtasks = append(subtasks, child.Task)
+	}
+	node.Task.Progress = computeProgress(&node.Task, subtasks)
+	return node, nil
+}
+
+// prepare applies the rules of a new task: validation, the initial state of
+// the workflow, custom fields and the board rank (internal helper)
+func (i *templateInstance) prepare(task *models.Task) error {
+	if err := validateTask(task); err != nil {
+		return err
+	}
+	if err := prepareChecklist(task); err != nil {
+		return err
+	}
+	if err := i.s.tasks.applyWorkflow(task, nil, i.userID); err != nil {
+		return err
+	}
+	if _, err := i.s.tasks.applyCustomFields(task, nil); err != nil {
+		return err
+	}
+
+	if task.ProjectID != nil {
+		r, err := rank.After(i.lastRank)
+		if err != nil {
+			return fmt.Errorf("unexpected error ranking task: %v", err)
+		}
+		task.Rank, i.lastRank = r, r
+	}
+	return nil
+}
+
+// label resolves a label name in the target's scope; labels that don't
+// exist yet are returned without an ID and created with the tasks
+func (i *templateInstance) label(name string) (models.Label, error) {
+	key := strings.ToLower(name)
+	if label, ok := i.labels[key]; ok {
+		return label, nil
+	}
+
+	var userID *uint
+	if i.target.ProjectID == nil {
+		userID = &i.userID
+	}
+	label, err := i.s.LabelRepo.GetLabelByName(userID, i.target.ProjectID, name)
+	switch {
+	case err == nil:
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		label = &models.Label{Name: name, Color: DefaultLabelColor, UserID: userID, ProjectID: i.target.ProjectID}
+	default:
+		return models.Label{}, fmt.Errorf("unexpected error fetching label: %v", err)
+	}
+	i.labels[key] = *label
+	return *label, nil
+}
@@ -0,0 +1,279 @@
+package services_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// releaseTemplate is a personal template of user 1: a release with a QA
+// subtask (which has a nested step) and an announcement
+func releaseTemplate() *models.TaskTemplate {
+	return &models.TaskTemplate{
+		ID:     9,
+		Name:   "Release",
+		UserID: 1,
+		Tasks: []models.TemplateTask{
+			{
+				Title:     "Release",
+				DueOffset: "+1w",
+				Labels:    []string{"release"},
+				Checklist: []string{"Tag", "Build"},
+				Subtasks: []models.TemplateTask{
+					{
+						Title:     "QA",
+						DueOffset: "+3d",
+						Labels:    []string{"qa", "Release"},
+						Subtasks:  []models.TemplateTask{{Title: "Smoke test", DueOffset: "+2d"}},
+					},
+					{Title: "Announce", Labels: []string{"QA"}},
+				},
+			},
+		},
+	}
+}
+
+func TestCreateTemplate_Validation(t *testing.T) {
+	deep := models.TemplateTask{Title: "Level"}
+	for i := 0; i < services.MaxTemplateDepth; i++ {
+		deep = models.TemplateTask{Title: "Level", Subtasks: []models.TemplateTask{deep}}
+	}
+
+	tests := []struct {
+		name     string
+		template models.TaskTemplate
+		wantErr  error
+	}{
+		{
+			name:     "name required",
+			template: models.TaskTemplate{Name: " ", Tasks: []models.TemplateTask{{Title: "Task"}}},
+			wantErr:  services.ErrTemplateNameRequired,
+		},
+		{
+			name:     "no tasks",
+			template: models.TaskTemplate{Name: "Empty"},
+			wantErr:  services.ErrInvalidTemplate,
+		},
+		{
+			name:     "subtask without title",
+			template: models.TaskTemplate{Name: "Onboarding", Tasks: []models.TemplateTask{{Title: "Accounts", Subtasks: []models.TemplateTask{{Title: " "}}}}},
+			wantErr:  services.ErrInvalidTemplate,
+		},
+		{
+			name:     "invalid due offset",
+			template: models.TaskTemplate{Name: "Onboarding", Tasks: []models.TemplateTask{{Title: "Laptop", DueOffset: "in three days"}}},
+			wantErr:  services.ErrInvalidTemplate,
+		},
+		{
+			name:     "invalid priority",
+			template: models.TaskTemplate{Name: "Onboarding", Tasks: []models.TemplateTask{{Title: "Laptop", Priority: "asap"}}},
+			wantErr:  services.ErrInvalidTemplate,
+		},
+		{
+			name:     "too deep",
+			template: models.TaskTemplate{Name: "Deep", Tasks: []models.TemplateTask{deep}},
+			wantErr:  services.ErrInvalidTemplate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTemplates := mocks.NewMockTemplateRepository(ctrl)
+			mockTasks := mocks.NewMockTaskRepository(ctrl)
+			mockProjects := mocks.NewMockProjectRepository(ctrl)
+			templateSvc := services.NewTemplateService(mockTemplates, mockTasks, mockProjects, mocks.NewMockLabelRepository(ctrl), services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+			mockTemplates.EXPECT().CreateTemplate(gomock.Any()).Times(0)
+
+			_, err := templateSvc.CreateTemplate(&tt.template, 1)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCreateTemplate_Normalises(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTemplates := mocks.NewMockTemplateRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	templateSvc := services.NewTemplateService(mockTemplates, mockTasks, mockProjects, mocks.NewMockLabelRepository(ctrl), services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+	mockTemplates.EXPECT().CreateTemplate(gomock.Any()).DoAndReturn(
+		func(template *models.TaskTemplate) (*models.TaskTemplate, error) { return template, nil },
+	)
+
+	template, err := templateSvc.CreateTemplate(&models.TaskTemplate{
+		ID:     4,
+		Name:   " Onboarding ",
+		UserID: 2,
+		Tasks: []models.TemplateTask{{
+			Title:     " Laptop ",
+			DueOffset: "3d",
+			Checklist: []string{" Order "},
+			Labels:    []string{"IT", " it ", ""},
+		}},
+	}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint(0), template.ID)
+	assert.Equal(t, uint(1), template.UserID)
+	assert.Equal(t, "Onboarding", template.Name)
+	assert.Equal(t, "Laptop", template.Tasks[0].Title)
+	assert.Equal(t, "+3d", template.Tasks[0].DueOffset)
+	assert.Equal(t, []string{"Order"}, template.Tasks[0].Checklist)
+	assert.Equal(t, []string{"IT"}, template.Tasks[0].Labels)
+}
+
+func TestTemplateAccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTemplates := mocks.NewMockTemplateRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	templateSvc := services.NewTemplateService(mockTemplates, mockTasks, mockProjects, mocks.NewMockLabelRepository(ctrl), services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	// Personal templates are private to their owner
+	mockTemplates.EXPECT().GetTemplateByID(uint(9)).Return(releaseTemplate(), nil)
+	_, err := templateSvc.GetTemplateByID(9, 2)
+	assert.ErrorIs(t, err, services.ErrTemplateNotFound)
+
+	// Project templates can be read by viewers but only changed by editors
+	shared := releaseTemplate()
+	shared.ProjectID = uintPtr(3)
+	mockTemplates.EXPECT().GetTemplateByID(uint(9)).Return(shared, nil).Times(2)
+	mockProjects.EXPECT().GetMember(uint(3), uint(2)).Return(memberWithRole(models.ProjectRoleViewer), nil).Times(2)
+	_, err = templateSvc.GetTemplateByID(9, 2)
+	assert.NoError(t, err)
+
+	mockTemplates.EXPECT().DeleteTemplate(gomock.Any()).Times(0)
+	err = templateSvc.DeleteTemplate(9, 2)
+	assert.ErrorIs(t, err, services.ErrProjectForbidden)
+}
+
+func TestInstantiateTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTemplates := mocks.NewMockTemplateRepository(ctrl)
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockLabels := mocks.NewMockLabelRepository(ctrl)
+	templateSvc := services.NewTemplateService(mockTemplates, mockRepo, mockProjects, mockLabels, services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockTemplates.EXPECT().GetTemplateByID(uint(9)).Return(releaseTemplate(), nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleEditor), nil)
+	mockRepo.EXPECT().GetLastTaskRank(uint(3)).Return("m", nil)
+	mockProjects.EXPECT().GetWorkflow(uint(3)).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	mockProjects.EXPECT().GetCustomFields(uint(3)).Return(nil, nil).AnyTimes()
+
+	// Each label name is looked up once; missing ones are created with the tasks
+	existing := &models.Label{ID: 21, Name: "release", Color: "#ff0000", ProjectID: uintPtr(3)}
+	mockLabels.EXPECT().GetLabelByName(nil, uintPtr(3), "release").Return(existing, nil)
+	mockLabels.EXPECT().GetLabelByName(nil, uintPtr(3), "qa").Return(nil, gorm.ErrRecordNotFound)
+
+	var created []repositories.TreeTask
+	mockRepo.EXPECT().CreateTaskTree(gomock.Any()).DoAndReturn(func(tasks []repositories.TreeTask) error {
+		created = tasks
+		for i := range tasks {
+			tasks[i].Task.ID = uint(100 + i)
+		}
+		return nil
+	})
+
+	anchor := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	roots, err := templateSvc.InstantiateTemplate(9, services.TemplateTarget{ProjectID: uintPtr(3), Anchor: anchor}, 1)
+	require.NoError(t, err)
+
+	// Parents come before their subtasks
+	require.Len(t, created, 4)
+	assert.Equal(t, []int{-1, 0, 1, 0}, []int{created[0].Parent, created[1].Parent, created[2].Parent, created[3].Parent})
+	assert.Equal(t, []string{"Release", "QA", "Smoke test", "Announce"},
+		[]string{created[0].Task.Title, created[1].Task.Title, created[2].Task.Title, created[3].Task.Title})
+	for i, node := range created {
+		assert.Equal(t, uint(1), node.Task.UserID)
+		assert.Equal(t, uintPtr(3), node.Task.ProjectID)
+		assert.Equal(t, models.TaskStatusTodo, node.Task.Status)
+		assert.Greater(t, node.Task.Rank, "m")
+		if i > 0 {
+			assert.Greater(t, node.Task.Rank, created[i-1].Task.Rank)
+		}
+	}
+
+	// Due offsets count from the anchor; tasks without one have no due date
+	assert.Equal(t, time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC), *created[0].Task.DueDate)
+	assert.Equal(t, time.Date(2025, 3, 6, 9, 0, 0, 0, time.UTC), *created[1].Task.DueDate)
+	assert.Equal(t, time.Date(2025, 3, 5, 9, 0, 0, 0, time.UTC), *created[2].Task.DueDate)
+	assert.Nil(t, created[3].Task.DueDate)
+
+	assert.Equal(t, 2, created[0].Task.ChecklistTotal)
+	require.Len(t, created[0].Task.Labels, 1)
+	assert.Equal(t, uint(21), created[0].Task.Labels[0].ID)
+	require.Len(t, created[1].Task.Labels, 2)
+	assert.Equal(t, uint(0), created[1].Task.Labels[0].ID)
+	assert.Equal(t, uintPtr(3), created[1].Task.Labels[0].ProjectID)
+	assert.Equal(t, services.DefaultLabelColor, created[1].Task.Labels[0].Color)
+
+	// The returned tree carries the created tasks
+	require.Len(t, roots, 1)
+	assert.Equal(t, uint(100), roots[0].Task.ID)
+	require.Len(t, roots[0].Subtasks, 2)
+	assert.Equal(t, uint(102), roots[0].Subtasks[0].Subtasks[0].Task.ID)
+	assert.Equal(t, uint(103), roots[0].Subtasks[1].Task.ID)
+}
+
+func TestInstantiateTemplate_InvalidCustomFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTemplates := mocks.NewMockTemplateRepository(ctrl)
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockLabels := mocks.NewMockLabelRepository(ctrl)
+	templateSvc := services.NewTemplateService(mockTemplates, mockRepo, mockProjects, mockLabels, services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	template := releaseTemplate()
+	template.Tasks[0].Subtasks[0].CustomFields = map[string]interface{}{"points": "many"}
+	mockTemplates.EXPECT().GetTemplateByID(uint(9)).Return(template, nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleEditor), nil)
+	mockRepo.EXPECT().GetLastTaskRank(uint(3)).Return("", nil)
+	mockProjects.EXPECT().GetWorkflow(uint(3)).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	mockProjects.EXPECT().GetCustomFields(uint(3)).Return([]models.CustomField{
+		{ID: 1, ProjectID: 3, Key: "points", Name: "Points", Type: models.CustomFieldNumber},
+	}, nil).AnyTimes()
+	mockLabels.EXPECT().GetLabelByName(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	mockRepo.EXPECT().CreateTaskTree(gomock.Any()).Times(0)
+
+	_, err := templateSvc.InstantiateTemplate(9, services.TemplateTarget{ProjectID: uintPtr(3), Anchor: time.Now()}, 1)
+	var validationErr *services.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, map[string]string{"tasks[0].subtasks[0].custom_fields.points": "must be a number"}, validationErr.Fields)
+}
+
+func TestInstantiateTemplate_RequiresEditor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTemplates := mocks.NewMockTemplateRepository(ctrl)
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	templateSvc := services.NewTemplateService(mockTemplates, mockRepo, mockProjects, mocks.NewMockLabelRepository(ctrl), services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockTemplates.EXPECT().GetTemplateByID(uint(9)).Return(releaseTemplate(), nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleViewer), nil)
+	mockRepo.EXPECT().CreateTaskTree(gomock.Any()).Times(0)
+
+	_, err := templateSvc.InstantiateTemplate(9, services.TemplateTarget{ProjectID: uintPtr(3), Anchor: time.Now()}, 1)
+	assert.ErrorIs(t, err, services.ErrProjectForbidden)
+}
@@ -0,0 +1,275 @@
+// internal/services/token_revocation.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"TaskManager/pkg/utils"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TokenRevocationStore decides whether access tokens were revoked: one by
+// one through their jti, by terminating their session, or all of a user's
+// tokens at once by bumping the user's token version. The database is the
+// source of truth; answers are cached in memory. Revocations made through
+// the store take effect at once, those made by other instances within
+// CacheTTL. The store also records when sessions were last seen, at most
+// once per SeenInterval.
+type TokenRevocationStore struct {
+	TokenRepo    repositories.TokenRepository
+	CacheTTL     time.Duration
+	SeenInterval time.Duration
+
+	mu sync.Mutex
+	// revoked holds revoked token IDs until their tokens expire, checked
+	// the token IDs found unrevoked until the answer is stale
+	revoked   map[string]time.Time
+	checked   map[string]time.Time
+	versions  map[uint]cachedTokenVersion
+	sessions  map[string]cachedSession
+	seen      map[string]time.Time
+	nextSweep time.Time
+}
+
+// cachedTokenVersion is a user's token version, read until stale; deleted
+// users have none
+type cachedTokenVersion struct {
+	version int
+	deleted bool
+	stale   time.Time
+}
+
+// cachedSession tells whether the session of a token family was
+// terminated, read until stale
+type cachedSession struct {
+	revoked bool
+	stale   time.Time
+}
+
+// NewTokenRevocationStore creates and returns a new TokenRevocationStore
+// caching answers for cacheTTL and recording sessions as seen at most once
+// per seenInterval
+func NewTokenRevocationStore(tokenRepo repositories.TokenRepository, cacheTTL, seenInterval time.Duration) *TokenRevocationStore {
+	return &TokenRevocationStore{
+		TokenRepo:    tokenRepo,
+		CacheTTL:     cacheTTL,
+		SeenInterval: seenInterval,
+		revoked:      map[string]time.Time{},
+		checked:      map[string]time.Time{},
+		versions:     map[uint]cachedTokenVersion{},
+		sessions:     map[string]cachedSession{},
+		seen:         map[string]time.Time{},
+	}
+}
+
+// sweep drops stale cache entries, at most once per CacheTTL; the caller
+// holds the lock (internal helper)
+func (s *TokenRevocationStore) sweep(now time.Time) {
+	if now.Before(s.nextSweep) {
+		return
+	}
+	s.nextSweep = now.Add(s.CacheTTL)
+	for id, until := range s.revoked {
+		if !now.Before(until) {
+			delete(s.revoked, id)
+		}
+	}
+	for id, until := range s.checked {
+		if !now.Before(until) {
+			delete(s.checked, id)
+		}
+	}
+	for userID, cached := range s.versions {
+		if !now.Before(cached.stale) {
+			delete(s.versions, userID)
+		}
+	}
+	for familyID, cached := range s.sessions {
+		if !now.Before(cached.stale) {
+			delete(s.sessions, familyID)
+		}
+	}
+	for familyID, seenAt := range s.seen {
+		if now.Sub(seenAt) >= s.SeenInterval {
+			delete(s.seen, familyID)
+		}
+	}
+}
+
+// tokenVersion returns a user's current token version, or
+// gorm.ErrRecordNotFound for deleted users (internal helper)
+func (s *TokenRevocationStore) tokenVersion(userID uint) (int, error) {
+	now := time.Now()
+	s.mu.Lock()
+	cached, ok := s.versions[userID]
+	s.mu.Unlock()
+	if !ok || !now.Before(cached.stale) {
+		version, err := s.TokenRepo.GetTokenVersion(userID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, err
+		}
+		cached = cachedTokenVersion{version: version, deleted: err != nil, stale: now.Add(s.CacheTTL)}
+		s.mu.Lock()
+		s.sweep(now)
+		s.versions[userID] = cached
+		s.mu.Unlock()
+	}
+
+	if cached.deleted {
+		return 0, gorm.ErrRecordNotFound
+	}
+	return cached.version, nil
+}
+
+// sessionRevoked reports whether the session of a token family was
+// terminated (internal helper)
+func (s *TokenRevocationStore) sessionRevoked(familyID string) (bool, error) {
+	now := time.Now()
+	s.mu.Lock()
+	cached, ok := s.sessions[familyID]
+	s.mu.Unlock()
+	if ok && now.Before(cached.stale) {
+		return cached.revoked, nil
+	}
+
+	revoked, err := s.TokenRepo.IsSessionRevoked(familyID)
+	if err != nil {
+		return false, err
+	}
+	s.mu.Lock()
+	s.sweep(now)
+	s.sessions[familyID] = cachedSession{revoked: revoked, stale: now.Add(s.CacheTTL)}
+	s.mu.Unlock()
+	return revoked, nil
+}
+
+// IsRevoked reports whether an access token was revoked, by its ID, by
+// terminating its session or by a newer token version of its user. Tokens
+// of deleted users count as revoked.
+func (s *TokenRevocationStore) IsRevoked(claims *utils.TokenClaims) (bool, error) {
+	version, err := s.tokenVersion(claims.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return true, nil
+		}
+		return false, fmt.Errorf("unexpected error fetching token version: %v", err)
+	}
+	if claims.Version < version {
+		return true, nil
+	}
+	if claims.SessionID != "" {
+		revoked, err := s.sessionRevoked(claims.SessionID)
+		if err != nil {
+			return false, fmt.Errorf("unexpected error checking session: %v", err)
+		}
+		if revoked {
+			return true, nil
+		}
+	}
+	if claims.ID == "" {
+		return false, nil
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	_, revoked := s.revoked[claims.ID]
+	until, checked := s.checked[claims.ID]
+	s.mu.Unlock()
+	if revoked {
+		return true, nil
+	}
+	if checked && now.Before(until) {
+		return false, nil
+	}
+
+	revoked, err = s.TokenRepo.IsAccessTokenRevoked(claims.ID)
+	if err != nil {
+		return false, fmt.Errorf("unexpected error checking token revocation: %v", err)
+	}
+	s.mu.Lock()
+	s.sweep(now)
+	if revoked {
+		s.revoked[claims.ID] = claims.ExpiresAt
+	} else {
+		s.checked[claims.ID] = now.Add(s.CacheTTL)
+	}
+	s.mu.Unlock()
+	return revoked, nil
+}
+
+// Revoke revokes a single access token until it expires
+func (s *TokenRevocationStore) Revoke(claims *utils.TokenClaims) error {
+	if claims.ID == "" {
+		return nil
+	}
+	err := s.TokenRepo.RevokeAccessToken(&models.RevokedToken{
+		ID:        claims.ID,
+		UserID:    claims.UserID,
+		ExpiresAt: claims.ExpiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("unexpected error revoking token: %v", err)
+	}
+
+	s.mu.Lock()
+	s.revoked[claims.ID] = claims.ExpiresAt
+	delete(s.checked, claims.ID)
+	s.mu.Unlock()
+	return nil
+}
+
+// RevokeSession terminates the session of a token family, revoking its
+// refresh tokens and the access tokens issued with them
+func (s *TokenRevocationStore) RevokeSession(familyID string) error {
+	if err := s.TokenRepo.RevokeTokenFamily(familyID); err != nil {
+		return fmt.Errorf("unexpected error revoking refresh tokens: %v", err)
+	}
+
+	s.mu.Lock()
+	s.sessions[familyID] = cachedSession{revoked: true, stale: time.Now().Add(s.CacheTTL)}
+	s.mu.Unlock()
+	return nil
+}
+
+// SessionSeen records that the session of a token family was used from an
+// IP address. Within SeenInterval of the last record it does nothing, so
+// a busy session doesn't write on every request.
+func (s *TokenRevocationStore) SessionSeen(familyID, ip string) error {
+	now := time.Now()
+	s.mu.Lock()
+	last, ok := s.seen[familyID]
+	if ok && now.Sub(last) < s.SeenInterval {
+		s.mu.Unlock()
+		return nil
+	}
+	s.sweep(now)
+	s.seen[familyID] = now
+	s.mu.Unlock()
+
+	if err := s.TokenRepo.TouchSession(familyID, ip, now); err != nil {
+		return fmt.Errorf("unexpected error updating session: %v", err)
+	}
+	return nil
+}
+
+// RevokeAll bumps a user's token version, revoking every access token
+// issued to them so far, and returns the new version
+func (s *TokenRevocationStore) RevokeAll(userID uint) (int, error) {
+	version, err := s.TokenRepo.IncrementTokenVersion(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrUserNotFound
+		}
+		return 0, fmt.Errorf("unexpected error bumping token version: %v", err)
+	}
+
+	s.mu.Lock()
+	s.versions[userID] = cachedTokenVersion{version: version, stale: time.Now().Add(s.CacheTTL)}
+	s.mu.Unlock()
+	return version, nil
+}
@@ -0,0 +1,136 @@
+package services_test
+
+import (
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"TaskManager/pkg/utils"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// accessClaims are the claims of token "jti" of user 1 at token version 1
+func accessClaims() *utils.TokenClaims {
+	return &utils.TokenClaims{
+		AccessClaims: utils.AccessClaims{UserID: 1, SessionID: "family", Version: 1},
+		ID:           "jti",
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}
+}
+
+func TestIsRevoked(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     int
+		versionErr  error
+		terminated  bool
+		revoked     bool
+		wantSession bool
+		wantChecked bool
+		want        bool
+	}{
+		{name: "valid token", version: 1, wantSession: true, wantChecked: true},
+		{name: "revoked token", version: 1, revoked: true, wantSession: true, wantChecked: true, want: true},
+		{name: "terminated session", version: 1, terminated: true, wantSession: true, want: true},
+		{name: "older token version", version: 2, want: true},
+		{name: "deleted user", versionErr: gorm.ErrRecordNotFound, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTokens := mocks.NewMockTokenRepository(ctrl)
+			store := services.NewTokenRevocationStore(mockTokens, time.Minute, time.Minute)
+
+			// Answers are cached: the database is asked once for two checks
+			mockTokens.EXPECT().GetTokenVersion(uint(1)).Return(tt.version, tt.versionErr).Times(1)
+			if tt.wantSession {
+				mockTokens.EXPECT().IsSessionRevoked("family").Return(tt.terminated, nil).Times(1)
+			}
+			if tt.wantChecked {
+				mockTokens.EXPECT().IsAccessTokenRevoked("jti").Return(tt.revoked, nil).Times(1)
+			}
+
+			for i := 0; i < 2; i++ {
+				revoked, err := store.IsRevoked(accessClaims())
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, revoked)
+			}
+		})
+	}
+}
+
+func TestIsRevoked_StaleCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Without caching, a revocation made elsewhere shows on the next check
+	mockTokens := mocks.NewMockTokenRepository(ctrl)
+	store := services.NewTokenRevocationStore(mockTokens, 0, 0)
+
+	mockTokens.EXPECT().GetTokenVersion(uint(1)).Return(1, nil).Times(2)
+	mockTokens.EXPECT().IsSessionRevoked("family").Return(false, nil).Times(2)
+	gomock.InOrder(
+		mockTokens.EXPECT().IsAccessTokenRevoked("jti").Return(false, nil),
+		mockTokens.EXPECT().IsAccessTokenRevoked("jti").Return(true, nil),
+	)
+
+	revoked, err := store.IsRevoked(accessClaims())
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	revoked, err = store.IsRevoked(accessClaims())
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestIsRevoked_DatabaseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTokens := mocks.NewMockTokenRepository(ctrl)
+	store := services.NewTokenRevocationStore(mockTokens, time.Minute, time.Minute)
+
+	mockTokens.EXPECT().GetTokenVersion(uint(1)).Return(1, nil)
+	mockTokens.EXPECT().IsSessionRevoked("family").Return(false, nil)
+	mockTokens.EXPECT().IsAccessTokenRevoked("jti").Return(false, errors.New("connection reset"))
+
+	_, err := store.IsRevoked(accessClaims())
+	assert.Error(t, err)
+}
+
+func TestRevokeAll_UnknownUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTokens := mocks.NewMockTokenRepository(ctrl)
+	store := services.NewTokenRevocationStore(mockTokens, time.Minute, time.Minute)
+
+	mockTokens.EXPECT().IncrementTokenVersion(uint(9)).Return(0, gorm.ErrRecordNotFound)
+
+	_, err := store.RevokeAll(9)
+	assert.ErrorIs(t, err, services.ErrUserNotFound)
+}
+
+func TestSessionSeen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTokens := mocks.NewMockTokenRepository(ctrl)
+	store := services.NewTokenRevocationStore(mockTokens, time.Minute, time.Minute)
+
+	// Within the interval a session is recorded as seen once
+	mockTokens.EXPECT().TouchSession("family", "203.0.113.7", gomock.Any()).Return(nil).Times(1)
+	mockTokens.EXPECT().TouchSession("other", "203.0.113.7", gomock.Any()).Return(nil).Times(1)
+
+	require.NoError(t, store.SessionSeen("family", "203.0.113.7"))
+	require.NoError(t, store.SessionSeen("family", "203.0.113.7"))
+	require.NoError(t, store.SessionSeen("other", "203.0.113.7"))
+}
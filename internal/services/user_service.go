@@ -4,6 +4,7 @@ package services
 import (
 	"TaskManager/internal/models"
 	"TaskManager/internal/repositories"
+	"TaskManager/pkg/query"
 	"TaskManager/pkg/utils"
 	"errors"
 	"fmt"
@@ -18,11 +19,25 @@ type UserService interface {
 	GetUserByID(id uint) (*models.User, error)
 	GetUserByEmail(email string) (*models.User, error)       // Get user by email
 	GetUserByUsername(username string) (*models.User, error) // Get user by username
-	GetAllUsers() ([]models.User, error)
+	GetAllUsers(q *query.Query) ([]models.User, string, error)
 	UpdateUser(user *models.User) (*models.User, error)
 	DeleteUser(id uint) error
 }
 
+// UserListSpec describes how the user list can be filtered, sorted and paged
+var UserListSpec = query.Spec{
+	Fields: map[string]query.Field{
+		"id":         {Column: "id", Struct: "ID", Type: query.Int, Sortable: true, Ops: []query.Op{query.Eq, query.Ne, query.Lt, query.Gt, query.In}},
+		"username":   {Column: "username", Struct: "Username", Type: query.String, Sortable: true, Ops: []query.Op{query.Eq, query.Ne, query.Lt, query.Gt, query.In, query.Contains}},
+		"email":      {Column: "email", Struct: "Email", Type: query.String, Sortable: true, Ops: []query.Op{query.Eq, query.Ne, query.In, query.Contains}},
+		"created_at": {Column: "created_at", Struct: "CreatedAt", Type: query.Time, Sortable: true, Ops: []query.Op{query.Lt, query.Gt}},
+	},
+	Key:          "id",
+	DefaultSort:  []query.Sort{{Field: "id"}},
+	DefaultLimit: 50,
+	MaxLimit:     100,
+}
+
 // UserServiceImpl is the concrete implementation of the UserService interface
 type UserServiceImpl struct {
 	UserRepo repositories.UserRepository
@@ -80,9 +95,9 @@ func (s *UserServiceImpl) GetUserByUsername(username string) (*models.User, erro
 	return s.UserRepo.GetUserByUsername(username)
 }
 
-// GetAllUsers retrieves all users by calling the repository's GetAllUsers method
-func (s *UserServiceImpl) GetAllUsers() ([]models.User, error) {
-	return s.UserRepo.GetAllUsers()
+// GetAllUsers retrieves a page of users by calling the repository's GetAllUsers method
+func (s *UserServiceImpl) GetAllUsers(q *query.Query) ([]models.User, string, error) {
+	return s.UserRepo.GetAllUsers(q)
 }
 
 // UpdateUser updates an existing user's information by calling the repository's UpdateUser method
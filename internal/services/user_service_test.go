@@ -4,7 +4,9 @@ import (
 	"TaskManager/internal/models"
 	"TaskManager/internal/services"
 	"TaskManager/mocks"
+	"TaskManager/pkg/query"
 	"errors"
+	"net/url"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -131,3 +133,34 @@ func TestCreateUser_HashPasswordError(t *testing.T) {
 	assert.Nil(t, createdUser)
 	assert.Equal(t, mockHashError.Error(), err.Error()) // Check if the error is the expected hash error
 }
+
+func TestGetAllUsers_Page(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	userSvc := services.NewUserService(mockRepo)
+
+	// The user list spec accepts its documented filters and sorts
+	q, err := query.Parse(url.Values{"username.contains": {"ann"}, "sort": {"-created_at"}, "limit": {"500"}}, services.UserListSpec)
+	require.NoError(t, err)
+	assert.Equal(t, services.UserListSpec.MaxLimit, q.Limit)
+
+	users := []models.User{{Username: "anna"}, {Username: "joanne"}}
+	mockRepo.EXPECT().GetAllUsers(q).Return(users, "next-page", nil)
+
+	page, next, err := userSvc.GetAllUsers(q)
+
+	require.NoError(t, err)
+	assert.Equal(t, users, page)
+	assert.Equal(t, "next-page", next)
+}
+
+func TestGetAllUsers_InvalidQuery(t *testing.T) {
+	// Passwords can't be filtered or sorted on
+	_, err := query.Parse(url.Values{"password": {"secret"}, "sort": {"password"}}, services.UserListSpec)
+
+	var queryErr *query.Error
+	require.ErrorAs(t, err, &queryErr)
+	assert.Contains(t, queryErr.Params, "sort")
+}
@@ -0,0 +1,280 @@
+// internal/services/view_service.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"TaskManager/pkg/tql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrViewNotFound  = errors.New("saved view not found")
+	ErrViewForbidden = errors.New("only the owner of a view can change it")
+)
+
+// Bounds of a saved view
+const (
+	MaxViewNameLength = 100
+	MaxViewColumns    = 30
+)
+
+// viewColumns are the task columns a view can show; custom fields are
+// shown as cf.<key>
+var viewColumns = map[string]bool{
+	"title":            true,
+	"status":           true,
+	"priority":         true,
+	"due_date":         true,
+	"assignees":        true,
+	"labels":           true,
+	"project":          true,
+	"estimate_minutes": true,
+	"progress":         true,
+	"checklist":        true,
+	"created_at":       true,
+	"updated_at":       true,
+}
+
+// defaultViewColumns are shown by views saved without columns
+var defaultViewColumns = []string{"title", "status", "priority", "due_date", "assignees"}
+
+// ViewService interface defines the methods for saved-view-related business operations
+type ViewService interface {
+	CreateView(view *models.SavedView, userID uint) (*models.SavedView, error)
+	GetViewByID(id, userID uint) (*models.SavedView, error)
+	GetViews(userID uint) ([]models.SavedView, error)
+	UpdateView(view *models.SavedView, userID uint) (*models.SavedView, error)
+	DeleteView(id, userID uint) error
+	GetViewTasks(id, userID uint) ([]models.Task, error)
+}
+
+// ViewServiceImpl is the concrete implementation of the ViewService interface
+type ViewServiceImpl struct {
+	ViewRepo    repositories.ViewRepository
+	TaskRepo    repositories.TaskRepository
+	ProjectRepo repositories.ProjectRepository
+}
+
+// NewViewService creates and returns a new ViewService instance
+func NewViewService(viewRepo repositories.ViewRepository, taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository) ViewService {
+	return &ViewServiceImpl{
+		ViewRepo:    viewRepo,
+		TaskRepo:    taskRepo,
+		ProjectRepo: projectRepo,
+	}
+}
+
+// validateView normalises a view and checks its name, query, sort and
+// columns, reporting problems per field (internal helper)
+func validateView(view *models.SavedView) error {
+	problems := map[string]string{}
+
+	view.Name = strings.TrimSpace(view.Name)
+	switch {
+	case view.Name == "":
+		problems["name"] = "is required"
+	case utf8.RuneCountInString(view.Name) > MaxViewNameLength:
+		problems["name"] = fmt.Sprintf("must be at most %d characters", MaxViewNameLength)
+	}
+
+	// The query and sort must compile; who runs them doesn't matter here
+	schema := repositories.TaskSearchSchema(0, time.Now())
+	view.Query = strings.TrimSpace(view.Query)
+	query, err := tql.Parse(view.Query)
+	if err == nil {
+		_, err = schema.Compile(query)
+	}
+	if err != nil {
+		problems["query"] = err.Error()
+	}
+
+	view.Sort = strings.TrimSpace(view.Sort)
+	keys, err := tql.ParseSort(view.Sort)
+	if err == nil {
+		_, err = schema.Compile(&tql.Query{Sort: keys})
+	}
+	switch {
+	case err != nil:
+		problems["sort"] = err.Error()
+	case len(keys) > 0 && query != nil && len(query.Sort) > 0:
+		problems["sort"] = "is already given in the query"
+	}
+
+	if len(view.Columns) == 0 {
+		view.Columns = append([]string(nil), defaultViewColumns...)
+	}
+	if len(view.Columns) > MaxViewColumns {
+		problems["columns"] = fmt.Sprintf("must list at most %d columns", MaxViewColumns)
+	}
+	seen := map[string]bool{}
+	columns := make([]string, 0, len(view.Columns))
+	for i, column := range view.Columns {
+		column = strings.ToLower(strings.TrimSpace(column))
+		key := strings.TrimPrefix(column, "cf.")
+		if !viewColumns[column] && (key == column || !customFieldKeyPattern.MatchString(key)) {
+			problems[fmt.Sprintf("columns[%d]", i)] = "is not a task column or cf.<custom field key>"
+			continue
+		}
+		if !seen[column] {
+			seen[column] = true
+			columns = append(columns, column)
+		}
+	}
+	view.Columns = columns
+
+	if len(problems) > 0 {
+		return &ValidationError{Fields: problems}
+	}
+	return nil
+}
+
+// loadView fetches a view the user may see: their own views and the views
+// shared with a project they belong to (internal helper)
+func (s *ViewServiceImpl) loadView(id, userID uint) (*models.SavedView, error) {
+	view, err := s.ViewRepo.GetViewByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrViewNotFound
+		}
+		return nil, fmt.Errorf("unexpected error fetching saved view: %v", err)
+	}
+	if view.UserID == userID {
+		return view, nil
+	}
+	if view.ProjectID == nil {
+		return nil, ErrViewNotFound
+	}
+	if _, err := requireProjectRole(s.ProjectRepo, *view.ProjectID, userID, models.ProjectRoleViewer); err != nil {
+		if errors.Is(err, ErrProjectNotFound) {
+			return nil, ErrViewNotFound
+		}
+		return nil, err
+	}
+	return view, nil
+}
+
+// loadOwnView fetches a view the user owns; other members of the project
+// a view is shared with can see it but not change it (internal helper)
+func (s *ViewServiceImpl) loadOwnView(id, userID uint) (*models.SavedView, error) {
+	view, err := s.loadView(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if view.UserID != userID {
+		return nil, ErrViewForbidden
+	}
+	return view, nil
+}
+
+// CreateView saves a view of the user. Sharing it with a project requires
+// an editor role there.
+func (s *ViewServiceImpl) CreateView(view *models.SavedView, userID uint) (*models.SavedView, error) {
+	if view.ProjectID != nil {
+		if _, err := requireProjectRole(s.ProjectRepo, *view.ProjectID, userID, models.ProjectRoleEditor); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateView(view); err != nil {
+		return nil, err
+	}
+
+	view.ID = 0
+	view.UserID = userID
+	newView, err := s.ViewRepo.CreateView(view)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error creating saved view: %v", err)
+	}
+	return newView, nil
+}
+
+// GetViewByID retrieves a view visible to the user
+func (s *ViewServiceImpl) GetViewByID(id, userID uint) (*models.SavedView, error) {
+	return s.loadView(id, userID)
+}
+
+// GetViews retrieves the user's views together with the views shared with
+// their projects. IsDefault only marks the user's own default.
+func (s *ViewServiceImpl) GetViews(userID uint) ([]models.SavedView, error) {
+	views, err := s.ViewRepo.GetViewsForUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error fetching saved views: %v", err)
+	}
+	for i := range views {
+		if views[i].UserID != userID {
+			views[i].IsDefault = false
+		}
+	}
+	return views, nil
+}
+
+// UpdateView saves changes to a view the user owns. Moving it to another
+// project requires an editor role there.
+func (s *ViewServiceImpl) UpdateView(view *models.SavedView, userID uint) (*models.SavedView, error) {
+	existing, err := s.loadOwnView(view.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if view.ProjectID != nil && !sameProject(existing.ProjectID, view.ProjectID) {
+		if _, err := requireProjectRole(s.ProjectRepo, *view.ProjectID, userID, models.ProjectRoleEditor); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateView(view); err != nil {
+		return nil, err
+	}
+
+	view.UserID = existing.UserID
+	view.CreatedAt = existing.CreatedAt
+	updatedView, err := s.ViewRepo.UpdateView(view)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error updating saved view: %v", err)
+	}
+	return updatedView, nil
+}
+
+// DeleteView deletes a view the user owns
+func (s *ViewServiceImpl) DeleteView(id, userID uint) error {
+	if _, err := s.loadOwnView(id, userID); err != nil {
+		return err
+	}
+	if err := s.ViewRepo.DeleteView(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrViewNotFound
+		}
+		return fmt.Errorf("unexpected error deleting saved view: %v", err)
+	}
+	return nil
+}
+
+// GetViewTasks runs a view for the user. Access is checked anew on every
+// run, and the query runs with the user's own permissions ("me" is the
+// user, and only tasks they can see match); a shared view is further
+// limited to the tasks of its project.
+func (s *ViewServiceImpl) GetViewTasks(id, userID uint) ([]models.Task, error) {
+	view, err := s.loadView(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := tql.Parse(view.Query)
+	if err != nil {
+		return nil, err
+	}
+	if view.Sort != "" {
+		if query.Sort, err = tql.ParseSort(view.Sort); err != nil {
+			return nil, err
+		}
+	}
+	if view.ProjectID != nil {
+		query.Terms = append(query.Terms, tql.Term{Field: "project", Op: tql.OpEq, Value: strconv.FormatUint(uint64(*view.ProjectID), 10)})
+	}
+	return runTaskSearch(s.TaskRepo, query, userID)
+}
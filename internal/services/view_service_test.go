@@ -0,0 +1,252 @@
+package services_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"TaskManager/pkg/tql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// sharedView is view 7 of user 1, shared with project 3
+func sharedView() *models.SavedView {
+	return &models.SavedView{
+		ID:        7,
+		Name:      "My open bugs",
+		UserID:    1,
+		ProjectID: uintPtr(3),
+		Query:     "status:open assignee:me label:bug",
+		Sort:      "priority,-due",
+		Columns:   []string{"title", "status"},
+	}
+}
+
+func TestCreateView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockViews := mocks.NewMockViewRepository(ctrl)
+	viewSvc := services.NewViewService(mockViews, mocks.NewMockTaskRepository(ctrl), mocks.NewMockProjectRepository(ctrl))
+
+	mockViews.EXPECT().CreateView(gomock.Any()).DoAndReturn(func(view *models.SavedView) (*models.SavedView, error) {
+		view.ID = 7
+		return view, nil
+	})
+
+	view, err := viewSvc.CreateView(&models.SavedView{
+		Name:      "  Due soon ",
+		Query:     " due<7d -status:closed ",
+		Sort:      "due",
+		Columns:   []string{"Title", "due_date", "cf.points", "title"},
+		IsDefault: true,
+	}, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), view.UserID)
+	assert.Equal(t, "Due soon", view.Name)
+	assert.Equal(t, "due<7d -status:closed", view.Query)
+	assert.Equal(t, []string{"title", "due_date", "cf.points"}, view.Columns)
+	assert.True(t, view.IsDefault)
+}
+
+func TestCreateView_DefaultColumns(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockViews := mocks.NewMockViewRepository(ctrl)
+	viewSvc := services.NewViewService(mockViews, mocks.NewMockTaskRepository(ctrl), mocks.NewMockProjectRepository(ctrl))
+
+	mockViews.EXPECT().CreateView(gomock.Any()).DoAndReturn(func(view *models.SavedView) (*models.SavedView, error) {
+		return view, nil
+	})
+
+	view, err := viewSvc.CreateView(&models.SavedView{Name: "Everything"}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"title", "status", "priority", "due_date", "assignees"}, view.Columns)
+}
+
+func TestCreateView_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		view       models.SavedView
+		wantFields []string
+	}{
+		{name: "missing name", view: models.SavedView{Name: " "}, wantFields: []string{"name"}},
+		{name: "query syntax", view: models.SavedView{Name: "v", Query: `label:"bug`}, wantFields: []string{"query"}},
+		{name: "unknown field", view: models.SavedView{Name: "v", Query: "colour:red"}, wantFields: []string{"query"}},
+		{name: "unknown sort key", view: models.SavedView{Name: "v", Sort: "size"}, wantFields: []string{"sort"}},
+		{name: "sort given twice", view: models.SavedView{Name: "v", Query: "sort:due", Sort: "priority"}, wantFields: []string{"sort"}},
+		{name: "unknown columns", view: models.SavedView{Name: "v", Columns: []string{"title", "colour", "cf.", "cf.Bad-Key"}}, wantFields: []string{"columns[1]", "columns[2]", "columns[3]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Nothing is saved
+			viewSvc := services.NewViewService(mocks.NewMockViewRepository(ctrl), mocks.NewMockTaskRepository(ctrl), mocks.NewMockProjectRepository(ctrl))
+
+			view := tt.view
+			_, err := viewSvc.CreateView(&view, 1)
+
+			var validationErr *services.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Len(t, validationErr.Fields, len(tt.wantFields))
+			for _, field := range tt.wantFields {
+				assert.Contains(t, validationErr.Fields, field)
+			}
+		})
+	}
+}
+
+func TestCreateView_SharingRequiresEditor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	viewSvc := services.NewViewService(mocks.NewMockViewRepository(ctrl), mocks.NewMockTaskRepository(ctrl), mockProjects)
+
+	mockProjects.EXPECT().GetMember(uint(3), uint(2)).Return(memberWithRole(models.ProjectRoleViewer), nil)
+
+	view := sharedView()
+	_, err := viewSvc.CreateView(view, 2)
+	assert.ErrorIs(t, err, services.ErrProjectForbidden)
+}
+
+func TestGetViews_OnlyOwnDefault(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockViews := mocks.NewMockViewRepository(ctrl)
+	viewSvc := services.NewViewService(mockViews, mocks.NewMockTaskRepository(ctrl), mocks.NewMockProjectRepository(ctrl))
+
+	mine := models.SavedView{ID: 1, UserID: 2, IsDefault: true}
+	theirs := *sharedView()
+	theirs.IsDefault = true
+	mockViews.EXPECT().GetViewsForUser(uint(2)).Return([]models.SavedView{mine, theirs}, nil)
+
+	views, err := viewSvc.GetViews(2)
+	require.NoError(t, err)
+	require.Len(t, views, 2)
+	assert.True(t, views[0].IsDefault)
+	assert.False(t, views[1].IsDefault)
+}
+
+func TestUpdateView_OnlyOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockViews := mocks.NewMockViewRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	viewSvc := services.NewViewService(mockViews, mocks.NewMockTaskRepository(ctrl), mockProjects)
+
+	// A member of the project sees the shared view but can't change it
+	mockViews.EXPECT().GetViewByID(uint(7)).Return(sharedView(), nil)
+	mockProjects.EXPECT().GetMember(uint(3), uint(2)).Return(memberWithRole(models.ProjectRoleEditor), nil)
+
+	view := sharedView()
+	view.Name = "Renamed"
+	_, err := viewSvc.UpdateView(view, 2)
+	assert.ErrorIs(t, err, services.ErrViewForbidden)
+}
+
+func TestUpdateView_Unshare(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockViews := mocks.NewMockViewRepository(ctrl)
+	viewSvc := services.NewViewService(mockViews, mocks.NewMockTaskRepository(ctrl), mocks.NewMockProjectRepository(ctrl))
+
+	mockViews.EXPECT().GetViewByID(uint(7)).Return(sharedView(), nil)
+	mockViews.EXPECT().UpdateView(gomock.Any()).DoAndReturn(func(view *models.SavedView) (*models.SavedView, error) {
+		return view, nil
+	})
+
+	view := sharedView()
+	view.ProjectID = nil
+	updated, err := viewSvc.UpdateView(view, 1)
+	require.NoError(t, err)
+	assert.Nil(t, updated.ProjectID)
+}
+
+func TestDeleteView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockViews := mocks.NewMockViewRepository(ctrl)
+	viewSvc := services.NewViewService(mockViews, mocks.NewMockTaskRepository(ctrl), mocks.NewMockProjectRepository(ctrl))
+
+	gomock.InOrder(
+		mockViews.EXPECT().GetViewByID(uint(7)).Return(sharedView(), nil),
+		mockViews.EXPECT().DeleteView(uint(7)).Return(nil),
+	)
+
+	require.NoError(t, viewSvc.DeleteView(7, 1))
+}
+
+func TestGetViewTasks_SharedView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockViews := mocks.NewMockViewRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	viewSvc := services.NewViewService(mockViews, mockTasks, mockProjects)
+
+	// Run by member 2: "me" is the member, and only tasks of project 3 match
+	found := []models.Task{{Model: gorm.Model{ID: 5}}}
+	gomock.InOrder(
+		mockViews.EXPECT().GetViewByID(uint(7)).Return(sharedView(), nil),
+		mockProjects.EXPECT().GetMember(uint(3), uint(2)).Return(memberWithRole(models.ProjectRoleViewer), nil),
+		mockTasks.EXPECT().SearchTasks(uint(2), gomock.Any(), services.MaxSearchResults).
+			DoAndReturn(func(userID uint, search *tql.Compiled, limit int) ([]models.Task, error) {
+				stmt := searchSQL(t, search)
+				assert.Contains(t, stmt.SQL.String(), "tasks.project_id = $4")
+				assert.Contains(t, stmt.SQL.String(), "ORDER BY CASE tasks.priority")
+				assert.Contains(t, stmt.SQL.String(), "tasks.due_date DESC NULLS LAST")
+				assert.Equal(t, []interface{}{false, uint(2), "bug", uint(3)}, stmt.Vars)
+				return found, nil
+			}),
+	)
+
+	tasks, err := viewSvc.GetViewTasks(7, 2)
+	require.NoError(t, err)
+	assert.Equal(t, found, tasks)
+}
+
+func TestGetViewTasks_NoAccess(t *testing.T) {
+	tests := []struct {
+		name   string
+		view   *models.SavedView
+		member error
+	}{
+		{name: "removed from the project", view: sharedView(), member: gorm.ErrRecordNotFound},
+		{name: "someone else's personal view", view: &models.SavedView{ID: 7, UserID: 1, Name: "Mine"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// The search never runs
+			mockViews := mocks.NewMockViewRepository(ctrl)
+			mockProjects := mocks.NewMockProjectRepository(ctrl)
+			viewSvc := services.NewViewService(mockViews, mocks.NewMockTaskRepository(ctrl), mockProjects)
+
+			mockViews.EXPECT().GetViewByID(uint(7)).Return(tt.view, nil)
+			if tt.member != nil {
+				mockProjects.EXPECT().GetMember(uint(3), uint(2)).Return(nil, tt.member)
+			}
+
+			_, err := viewSvc.GetViewTasks(7, 2)
+			assert.ErrorIs(t, err, services.ErrViewNotFound)
+		})
+	}
+}
@@ -0,0 +1,402 @@
+// internal/services/workflow_service.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidWorkflow    = errors.New("invalid workflow")
+	ErrWorkflowStateInUse = errors.New("workflow states are still in use")
+	ErrInvalidTransition  = errors.New("transition not allowed")
+	ErrTransitionGuard    = errors.New("transition guard failed")
+)
+
+// guardDescriptions explains each guard in transition errors
+var guardDescriptions = map[string]string{
+	models.WorkflowGuardAssigneeOnly:       "the task's assignee",
+	models.WorkflowGuardOwnerOnly:          "a project owner",
+	models.WorkflowGuardResolutionRequired: "a resolution",
+}
+
+// TransitionError explains why a task can't move between two states. Guard
+// is empty when no transition connects the states at all.
+type TransitionError struct {
+	From    string
+	To      string
+	Guard   string
+	Allowed []string
+}
+
+func (e *TransitionError) Error() string {
+	if e.Guard != "" {
+		return fmt.Sprintf("moving from %q to %q requires %s", e.From, e.To, guardDescriptions[e.Guard])
+	}
+	return fmt.Sprintf("tasks can't move from %q to %q", e.From, e.To)
+}
+
+// Unwrap lets callers match the error with ErrInvalidTransition or ErrTransitionGuard
+func (e *TransitionError) Unwrap() error {
+	if e.Guard != "" {
+		return ErrTransitionGuard
+	}
+	return ErrInvalidTransition
+}
+
+// defaultWorkflow is the workflow of personal tasks and of projects that
+// haven't defined their own: todo, in progress and done, freely connected
+func defaultWorkflow(projectID uint) *models.Workflow {
+	workflow := &models.Workflow{
+		ProjectID:    projectID,
+		InitialState: models.TaskStatusTodo,
+		States: []models.WorkflowState{
+			{Name: models.TaskStatusTodo, Position: 0},
+			{Name: models.TaskStatusInProgress, Position: 1},
+			{Name: models.TaskStatusDone, Position: 2, Final: true},
+		},
+	}
+	for _, from := range workflow.States {
+		for _, to := range workflow.States {
+			if from.Name != to.Name {
+				workflow.Transitions = append(workflow.Transitions, models.WorkflowTransition{From: from.Name, To: to.Name})
+			}
+		}
+	}
+	return workflow
+}
+
+// loadWorkflow returns the workflow governing the tasks of a project; a nil
+// project means personal tasks, which follow the default workflow
+func loadWorkflow(repo repositories.ProjectRepository, projectID *uint) (*models.Workflow, error) {
+	if projectID == nil {
+		return defaultWorkflow(0), nil
+	}
+	workflow, err := repo.GetWorkflow(*projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return defaultWorkflow(*projectID), nil
+		}
+		return nil, fmt.Errorf("unexpected error fetching workflow: %v", err)
+	}
+	return workflow, nil
+}
+
+// workflowState finds a state of the workflow by name
+func workflowState(workflow *models.Workflow, name string) *models.WorkflowState {
+	for i := range workflow.States {
+		if workflow.States[i].Name == name {
+			return &workflow.States[i]
+		}
+	}
+	return nil
+}
+
+// findTransition returns the transition from one state to another, preferring
+// an explicit source over the wildcard
+func findTransition(workflow *models.Workflow, from, to string) *models.WorkflowTransition {
+	var wildcard *models.WorkflowTransition
+	for i := range workflow.Transitions {
+		transition := &workflow.Transitions[i]
+		if transition.To != to {
+			continue
+		}
+		if transition.From == from {
+			return transition
+		}
+		if transition.From == models.WorkflowAnyState && wildcard == nil {
+			wildcard = transition
+		}
+	}
+	return wildcard
+}
+
+// allowedTargets lists the states reachable from a state in one transition
+func allowedTargets(workflow *models.Workflow, from string) []string {
+	seen := map[string]bool{}
+	targets := []string{}
+	for _, transition := range workflow.Transitions {
+		if (transition.From == from || transition.From == models.WorkflowAnyState) && transition.To != from && !seen[transition.To] {
+			seen[transition.To] = true
+			targets = append(targets, transition.To)
+		}
+	}
+	return targets
+}
+
+// isTaskAssignee reports whether the user is one of the task's assignees.
+// Unassigned tasks count as assigned to the user they belong to.
+func isTaskAssignee(task *models.Task, userID uint) bool {
+	if len(task.Assignees) == 0 {
+		return task.UserID == userID
+	}
+	return hasTaskUser(task.Assignees, userID)
+}
+
+// checkGuards returns the first guard of the transition the move fails (internal helper)
+func (s *TaskServiceImpl) checkGuards(transition *models.WorkflowTransition, task *models.Task, userID uint) (string, error) {
+	for _, guard := range transition.GuardList() {
+		switch guard {
+		case models.WorkflowGuardAssigneeOnly:
+			if !isTaskAssignee(task, userID) {
+				return guard, nil
+			}
+		case models.WorkflowGuardOwnerOnly:
+			if task.ProjectID == nil {
+				continue
+			}
+			_, err := requireProjectRole(s.ProjectRepo, *task.ProjectID, userID, models.ProjectRoleOwner)
+			if errors.Is(err, ErrProjectForbidden) {
+				return guard, nil
+			}
+			if err != nil {
+				return "", err
+			}
+		case models.WorkflowGuardResolutionRequired:
+			if strings.TrimSpace(task.Resolution) == "" {
+				return guard, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// applyWorkflow checks the task's status against its project's workflow and
+// sets the derived fields. For an existing task a status change must follow
+// an allowed transition whose guards pass, and a task can only reach a final
+// state once its blockers are done. New tasks without a status start in the
+// workflow's initial state.
+func (s *TaskServiceImpl) applyWorkflow(task, existing *models.Task, userID uint) error {
+	workflow, err := loadWorkflow(s.ProjectRepo, task.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	if task.Status == "" {
+		task.Status = workflow.InitialState
+	}
+	state := workflowState(workflow, task.Status)
+	if state == nil {
+		return ErrInvalidTaskStatus
+	}
+	if task.ChecklistAdvanceTo != "" && workflowState(workflow, task.ChecklistAdvanceTo) == nil {
+		return ErrInvalidChecklistAdvanceTo
+	}
+
+	// Transitions only apply within a workflow; a task moving to another
+	// project just needs a status that exists there
+	sameWorkflow := existing != nil && sameProject(existing.ProjectID, task.ProjectID)
+	if sameWorkflow && existing.Status != task.Status {
+		transition := findTransition(workflow, existing.Status, task.Status)
+		if transition == nil {
+			return &TransitionError{From: existing.Status, To: task.Status, Allowed: allowedTargets(workflow, existing.Status)}
+		}
+		guard, err := s.checkGuards(transition, task, userID)
+		if err != nil {
+			return err
+		}
+		if guard != "" {
+			return &TransitionError{From: existing.Status, To: task.Status, Guard: guard}
+		}
+	}
+
+	task.Closed = state.Final
+	if !task.Closed {
+		// Reopened tasks lose their resolution
+		task.Resolution = ""
+	}
+
+	if existing != nil && task.Closed && !existing.Closed && existing.Status != task.Status {
+		if err := s.ensureUnblocked(task.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateWorkflow normalises and checks a workflow definition (internal helper).
+// States are positioned in the order they are given.
+func validateWorkflow(workflow *models.Workflow) error {
+	if len(workflow.States) == 0 {
+		return fmt.Errorf("%w: at least one state is required", ErrInvalidWorkflow)
+	}
+
+	names := map[string]bool{}
+	hasFinal := false
+	for i := range workflow.States {
+		state := &workflow.States[i]
+		state.Name = strings.TrimSpace(state.Name)
+		if state.Name == "" || state.Name == models.WorkflowAnyState {
+			return fmt.Errorf("%w: invalid state name %q", ErrInvalidWorkflow, state.Name)
+		}
+		if names[state.Name] {
+			return fmt.Errorf("%w: duplicate state %q", ErrInvalidWorkflow, state.Name)
+		}
+		names[state.Name] = true
+		hasFinal = hasFinal || state.Final
+		state.Position = i
+	}
+	if !hasFinal {
+		return fmt.Errorf("%w: at least one state must be final", ErrInvalidWorkflow)
+	}
+
+	workflow.InitialState = strings.TrimSpace(workflow.InitialState)
+	if workflow.InitialState == "" {
+		workflow.InitialState = workflow.States[0].Name
+	}
+	if !names[workflow.InitialState] {
+		return fmt.Errorf("%w: initial state %q is not a state of the workflow", ErrInvalidWorkflow, workflow.InitialState)
+	}
+
+	for i := range workflow.Transitions {
+		transition := &workflow.Transitions[i]
+		transition.From = strings.TrimSpace(transition.From)
+		transition.To = strings.TrimSpace(transition.To)
+		if transition.From != models.WorkflowAnyState && !names[transition.From] {
+			return fmt.Errorf("%w: transition from unknown state %q", ErrInvalidWorkflow, transition.From)
+		}
+		if !names[transition.To] {
+			return fmt.Errorf("%w: transition to unknown state %q", ErrInvalidWorkflow, transition.To)
+		}
+		guards := transition.GuardList()
+		for j := range guards {
+			guards[j] = strings.TrimSpace(guards[j])
+			if _, ok := guardDescriptions[guards[j]]; !ok {
+				return fmt.Errorf("%w: unknown guard %q", ErrInvalidWorkflow, guards[j])
+			}
+		}
+		transition.Guards = strings.Join(guards, ",")
+	}
+	return nil
+}
+
+// WorkflowService interface defines the methods for managing project workflows
+type WorkflowService interface {
+	GetWorkflow(projectID, userID uint) (*models.Workflow, error)
+	UpdateWorkflow(workflow *models.Workflow, userID uint) (*models.Workflow, error)
+	ResetWorkflow(projectID, userID uint) error
+}
+
+// WorkflowServiceImpl is the concrete implementation of the WorkflowService interface
+type WorkflowServiceImpl struct {
+	ProjectRepo repositories.ProjectRepository
+	TaskRepo    repositories.TaskRepository
+	BoardRepo   repositories.BoardRepository
+
+	// tasks rolls up the progress of tasks a workflow change closed or reopened
+	tasks *TaskServiceImpl
+}
+
+// NewWorkflowService creates and returns a new WorkflowService instance
+func NewWorkflowService(projectRepo repositories.ProjectRepository, taskRepo repositories.TaskRepository, boardRepo repositories.BoardRepository, tasks *TaskServiceImpl) WorkflowService {
+	return &WorkflowServiceImpl{
+		ProjectRepo: projectRepo,
+		TaskRepo:    taskRepo,
+		BoardRepo:   boardRepo,
+		tasks:       tasks,
+	}
+}
+
+// GetWorkflow returns the workflow a project's tasks follow, which is the
+// default one (with ID 0) until the project defines its own
+func (s *WorkflowServiceImpl) GetWorkflow(projectID, userID uint) (*models.Workflow, error) {
+	if _, err := requireProjectRole(s.ProjectRepo, projectID, userID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+	return loadWorkflow(s.ProjectRepo, &projectID)
+}
+
+// UpdateWorkflow replaces a project's workflow; owners only. States that
+// tasks or board columns still use can't be removed.
+func (s *WorkflowServiceImpl) UpdateWorkflow(workflow *models.Workflow, userID uint) (*models.Workflow, error) {
+	if _, err := requireProjectRole(s.ProjectRepo, workflow.ProjectID, userID, models.ProjectRoleOwner); err != nil {
+		return nil, err
+	}
+	if err := validateWorkflow(workflow); err != nil {
+		return nil, err
+	}
+	if err := s.ensureStatesKept(workflow); err != nil {
+		return nil, err
+	}
+	saved, changed, err := s.ProjectRepo.SaveWorkflow(workflow)
+	if err != nil {
+		return nil, err
+	}
+	s.rollUpChanged(changed)
+	return saved, nil
+}
+
+// ResetWorkflow drops a project's custom workflow so the default one applies again
+func (s *WorkflowServiceImpl) ResetWorkflow(projectID, userID uint) error {
+	if _, err := requireProjectRole(s.ProjectRepo, projectID, userID, models.ProjectRoleOwner); err != nil {
+		return err
+	}
+	workflow := defaultWorkflow(projectID)
+	if err := s.ensureStatesKept(workflow); err != nil {
+		return err
+	}
+	var finalStates []string
+	for _, state := range workflow.States {
+		if state.Final {
+			finalStates = append(finalStates, state.Name)
+		}
+	}
+	changed, err := s.ProjectRepo.DeleteWorkflow(projectID, finalStates)
+	if err != nil {
+		return err
+	}
+	s.rollUpChanged(changed)
+	return nil
+}
+
+// rollUpChanged recomputes the progress of tasks a workflow change closed or
+// reopened, and of their ancestors (internal helper)
+func (s *WorkflowServiceImpl) rollUpChanged(ids []uint) {
+	for i := range ids {
+		if err := s.tasks.rollUpProgress(&ids[i]); err != nil {
+			log.Println("Error rolling up task progress:", err)
+		}
+	}
+}
+
+// ensureStatesKept fails with ErrWorkflowStateInUse when the project's tasks or
+// board columns use a status the workflow doesn't have (internal helper)
+func (s *WorkflowServiceImpl) ensureStatesKept(workflow *models.Workflow) error {
+	used, err := s.TaskRepo.GetTaskStatusesByProjectID(workflow.ProjectID)
+	if err != nil {
+		return fmt.Errorf("unexpected error fetching task statuses: %v", err)
+	}
+	boards, err := s.BoardRepo.GetBoardsByProjectID(workflow.ProjectID)
+	if err != nil {
+		return fmt.Errorf("unexpected error fetching boards: %v", err)
+	}
+	for _, board := range boards {
+		for _, column := range board.Columns {
+			used = append(used, column.Status)
+		}
+	}
+
+	missing := map[string]bool{}
+	for _, status := range used {
+		if workflowState(workflow, status) == nil {
+			missing[status] = true
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	states := make([]string, 0, len(missing))
+	for status := range missing {
+		states = append(states, status)
+	}
+	sort.Strings(states)
+	return fmt.Errorf("%w: %s", ErrWorkflowStateInUse, strings.Join(states, ", "))
+}
@@ -0,0 +1,306 @@
+package services_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// reviewWorkflow is a custom workflow of project 3:
+// backlog -> in_review (assignee only) -> closed (resolution required), and back to backlog from anywhere
+func reviewWorkflow() *models.Workflow {
+	return &models.Workflow{
+		ID:           1,
+		ProjectID:    3,
+		InitialState: "backlog",
+		States: []models.WorkflowState{
+			{Name: "backlog"},
+			{Name: "in_review"},
+			{Name: "closed", Final: true},
+		},
+		Transitions: []models.WorkflowTransition{
+			{From: "backlog", To: "in_review", Guards: models.WorkflowGuardAssigneeOnly},
+			{From: "in_review", To: "closed", Guards: models.WorkflowGuardResolutionRequired},
+			{From: models.WorkflowAnyState, To: "backlog"},
+		},
+	}
+}
+
+// updateInReviewWorkflow sets up the mocks for updating task 8 of project 3,
+// owned by user 1 and currently in the given state
+func updateInReviewWorkflow(ctrl *gomock.Controller, status string, userID uint) (services.TaskService, *mocks.MockTaskRepository) {
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+
+	stored := &models.Task{Model: gorm.Model{ID: 8}, Title: "Review", Status: status, UserID: 1, ProjectID: uintPtr(3)}
+	mockRepo.EXPECT().GetTaskByID(uint(8)).Return(stored, nil)
+	mockProjects.EXPECT().GetMember(uint(3), userID).Return(&models.ProjectMember{Role: models.ProjectRoleEditor}, nil)
+	mockRepo.EXPECT().GetSubtasks([]uint{8}).Return(nil, nil)
+	mockProjects.EXPECT().GetWorkflow(uint(3)).Return(reviewWorkflow(), nil)
+
+	return services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil), mockRepo
+}
+
+func TestUpdateTask_RejectsUndefinedTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskSvc, mockRepo := updateInReviewWorkflow(ctrl, "backlog", 1)
+	mockRepo.EXPECT().UpdateTask(gomock.Any()).Times(0)
+
+	changed := &models.Task{Model: gorm.Model{ID: 8}, Title: "Review", Status: "closed", ProjectID: uintPtr(3)}
+	_, err := taskSvc.UpdateTask(changed, 1)
+	assert.ErrorIs(t, err, services.ErrInvalidTransition)
+
+	var transitionErr *services.TransitionError
+	require.True(t, errors.As(err, &transitionErr))
+	assert.Equal(t, "backlog", transitionErr.From)
+	assert.Equal(t, "closed", transitionErr.To)
+	assert.Equal(t, []string{"in_review"}, transitionErr.Allowed)
+}
+
+func TestUpdateTask_AssigneeGuard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// User 2 may edit the task but isn't its assignee
+	taskSvc, mockRepo := updateInReviewWorkflow(ctrl, "backlog", 2)
+	mockRepo.EXPECT().UpdateTask(gomock.Any()).Times(0)
+
+	changed := &models.Task{Model: gorm.Model{ID: 8}, Title: "Review", Status: "in_review", ProjectID: uintPtr(3)}
+	_, err := taskSvc.UpdateTask(changed, 2)
+	assert.ErrorIs(t, err, services.ErrTransitionGuard)
+
+	var transitionErr *services.TransitionError
+	require.True(t, errors.As(err, &transitionErr))
+	assert.Equal(t, models.WorkflowGuardAssigneeOnly, transitionErr.Guard)
+}
+
+func TestUpdateTask_ResolutionRequiredToClose(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskSvc, mockRepo := updateInReviewWorkflow(ctrl, "in_review", 1)
+	mockRepo.EXPECT().UpdateTask(gomock.Any()).Times(0)
+
+	changed := &models.Task{Model: gorm.Model{ID: 8}, Title: "Review", Status: "closed", ProjectID: uintPtr(3)}
+	_, err := taskSvc.UpdateTask(changed, 1)
+	assert.ErrorIs(t, err, services.ErrTransitionGuard)
+}
+
+func TestUpdateTask_ClosesWithResolution(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskSvc, mockRepo := updateInReviewWorkflow(ctrl, "in_review", 1)
+	mockRepo.EXPECT().GetDependencies([]uint{8}).Return(nil, nil)
+	mockRepo.EXPECT().UpdateTask(gomock.Any()).DoAndReturn(
+		func(task *models.Task) (*models.Task, error) { return task, nil },
+	)
+
+	changed := &models.Task{Model: gorm.Model{ID: 8}, Title: "Review", Status: "closed", Resolution: "fixed", ProjectID: uintPtr(3)}
+	task, err := taskSvc.UpdateTask(changed, 1)
+	require.NoError(t, err)
+	assert.True(t, task.Closed)
+	assert.Equal(t, 100, task.Progress)
+}
+
+func TestCreateTask_StartsInInitialState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	taskSvc := services.NewTaskService(mockRepo, mockProjects, mocks.NewMockUserRepository(ctrl), nil)
+
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleEditor}, nil)
+	mockProjects.EXPECT().GetCustomFields(uint(3)).Return(nil, nil).Times(2)
+	mockProjects.EXPECT().GetWorkflow(uint(3)).Return(reviewWorkflow(), nil)
+	mockRepo.EXPECT().GetLastTaskRank(uint(3)).Return("", nil)
+	mockRepo.EXPECT().CreateTask(gomock.Any()).DoAndReturn(
+		func(task *models.Task) (*models.Task, error) { return task, nil },
+	)
+
+	task, err := taskSvc.CreateTask(&models.Task{Title: "New", UserID: 1, ProjectID: uintPtr(3)})
+	require.NoError(t, err)
+	assert.Equal(t, "backlog", task.Status)
+	assert.False(t, task.Closed)
+
+	// Statuses of the default workflow don't exist here
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleEditor}, nil)
+	mockProjects.EXPECT().GetWorkflow(uint(3)).Return(reviewWorkflow(), nil)
+	_, err = taskSvc.CreateTask(&models.Task{Title: "New", Status: models.TaskStatusTodo, UserID: 1, ProjectID: uintPtr(3)})
+	assert.ErrorIs(t, err, services.ErrInvalidTaskStatus)
+}
+
+func TestUpdateWorkflow_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		workflow models.Workflow
+	}{
+		{
+			name:     "no states",
+			workflow: models.Workflow{ProjectID: 3},
+		},
+		{
+			name: "no final state",
+			workflow: models.Workflow{ProjectID: 3, States: []models.WorkflowState{
+				{Name: "open"},
+			}},
+		},
+		{
+			name: "duplicate state",
+			workflow: models.Workflow{ProjectID: 3, States: []models.WorkflowState{
+				{Name: "open"}, {Name: "open", Final: true},
+			}},
+		},
+		{
+			name: "unknown initial state",
+			workflow: models.Workflow{ProjectID: 3, InitialState: "new", States: []models.WorkflowState{
+				{Name: "open"}, {Name: "closed", Final: true},
+			}},
+		},
+		{
+			name: "transition to unknown state",
+			workflow: models.Workflow{ProjectID: 3,
+				States:      []models.WorkflowState{{Name: "open"}, {Name: "closed", Final: true}},
+				Transitions: []models.WorkflowTransition{{From: "open", To: "done"}},
+			},
+		},
+		{
+			name: "unknown guard",
+			workflow: models.Workflow{ProjectID: 3,
+				States:      []models.WorkflowState{{Name: "open"}, {Name: "closed", Final: true}},
+				Transitions: []models.WorkflowTransition{{From: "open", To: "closed", Guards: "manager_only"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockProjects := mocks.NewMockProjectRepository(ctrl)
+			mockTasks := mocks.NewMockTaskRepository(ctrl)
+			workflowSvc := services.NewWorkflowService(mockProjects, mockTasks, mocks.NewMockBoardRepository(ctrl), services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+			mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleOwner}, nil)
+			mockProjects.EXPECT().SaveWorkflow(gomock.Any()).Times(0)
+
+			_, err := workflowSvc.UpdateWorkflow(&tt.workflow, 1)
+			assert.ErrorIs(t, err, services.ErrInvalidWorkflow)
+		})
+	}
+}
+
+func TestUpdateWorkflow_KeepsStatesInUse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockBoards := mocks.NewMockBoardRepository(ctrl)
+	workflowSvc := services.NewWorkflowService(mockProjects, mockTasks, mockBoards, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleOwner}, nil)
+	mockTasks.EXPECT().GetTaskStatusesByProjectID(uint(3)).Return([]string{"backlog", models.TaskStatusInProgress}, nil)
+	mockBoards.EXPECT().GetBoardsByProjectID(uint(3)).Return([]models.Board{
+		{Columns: []models.BoardColumn{{Status: models.TaskStatusTodo}}},
+	}, nil)
+	mockProjects.EXPECT().SaveWorkflow(gomock.Any()).Times(0)
+
+	_, err := workflowSvc.UpdateWorkflow(reviewWorkflow(), 1)
+	assert.ErrorIs(t, err, services.ErrWorkflowStateInUse)
+	assert.Contains(t, err.Error(), "in_progress, todo")
+}
+
+func TestUpdateWorkflow_OwnersOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	workflowSvc := services.NewWorkflowService(mockProjects, mockTasks, mocks.NewMockBoardRepository(ctrl), services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleEditor}, nil)
+	mockProjects.EXPECT().SaveWorkflow(gomock.Any()).Times(0)
+
+	_, err := workflowSvc.UpdateWorkflow(reviewWorkflow(), 1)
+	assert.ErrorIs(t, err, services.ErrProjectForbidden)
+}
+
+func TestGetWorkflow_DefaultsWhenNotCustomised(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	workflowSvc := services.NewWorkflowService(mockProjects, mockTasks, mocks.NewMockBoardRepository(ctrl), services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleViewer}, nil)
+	mockProjects.EXPECT().GetWorkflow(uint(3)).Return(nil, gorm.ErrRecordNotFound)
+
+	workflow, err := workflowSvc.GetWorkflow(3, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint(0), workflow.ID)
+	assert.Equal(t, models.TaskStatusTodo, workflow.InitialState)
+	require.Len(t, workflow.States, 3)
+	assert.True(t, workflow.States[2].Final)
+}
+
+func TestUpdateWorkflow_RollsUpTasksClosedByFinalState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockBoards := mocks.NewMockBoardRepository(ctrl)
+	workflowSvc := services.NewWorkflowService(mockProjects, mockTasks, mockBoards, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	// in_review becomes final while task 8 sits in it
+	workflow := reviewWorkflow()
+	workflow.States[1].Final = true
+
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleOwner}, nil)
+	mockTasks.EXPECT().GetTaskStatusesByProjectID(uint(3)).Return([]string{"in_review"}, nil)
+	mockBoards.EXPECT().GetBoardsByProjectID(uint(3)).Return(nil, nil)
+	mockProjects.EXPECT().SaveWorkflow(workflow).Return(workflow, []uint{8}, nil)
+	mockTasks.EXPECT().GetTaskByID(uint(8)).Return(&models.Task{Model: gorm.Model{ID: 8}, Status: "in_review", Closed: true, ProjectID: uintPtr(3), ParentID: uintPtr(5)}, nil)
+	mockTasks.EXPECT().GetSubtasks([]uint{8}).Return(nil, nil)
+	mockTasks.EXPECT().UpdateTaskProgress(uint(8), 100).Return(nil)
+	mockTasks.EXPECT().GetTaskByID(uint(5)).Return(&models.Task{Model: gorm.Model{ID: 5}, Status: "backlog", ProjectID: uintPtr(3)}, nil)
+	mockTasks.EXPECT().GetSubtasks([]uint{5}).Return([]models.Task{
+		{Model: gorm.Model{ID: 8}, ParentID: uintPtr(5), Closed: true, Progress: 100},
+		{Model: gorm.Model{ID: 9}, ParentID: uintPtr(5)},
+	}, nil)
+	mockTasks.EXPECT().UpdateTaskProgress(uint(5), 50).Return(nil)
+
+	saved, err := workflowSvc.UpdateWorkflow(workflow, 1)
+	require.NoError(t, err)
+	assert.True(t, saved.States[1].Final)
+}
+
+func TestResetWorkflow_UsesDefaultFinalStates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockBoards := mocks.NewMockBoardRepository(ctrl)
+	workflowSvc := services.NewWorkflowService(mockProjects, mockTasks, mockBoards, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(&models.ProjectMember{Role: models.ProjectRoleOwner}, nil)
+	mockTasks.EXPECT().GetTaskStatusesByProjectID(uint(3)).Return([]string{models.TaskStatusDone}, nil)
+	mockBoards.EXPECT().GetBoardsByProjectID(uint(3)).Return(nil, nil)
+	mockProjects.EXPECT().DeleteWorkflow(uint(3), []string{models.TaskStatusDone}).Return(nil, nil)
+
+	require.NoError(t, workflowSvc.ResetWorkflow(3, 1))
+}
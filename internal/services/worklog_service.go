@@ -0,0 +1,482 @@
+// internal/services/worklog_service.go
+package services
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/repositories"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrWorklogNotFound  = errors.New("worklog not found")
+	ErrInvalidWorklog   = errors.New("invalid worklog")
+	ErrWorklogForbidden = errors.New("only the author can change this worklog")
+	ErrWorklogRunning   = errors.New("stop the timer before editing it")
+	ErrTimerRunning     = errors.New("a timer is already running")
+	ErrNoRunningTimer   = errors.New("no timer is running")
+	ErrInvalidTimesheet = errors.New("invalid timesheet query")
+)
+
+// MaxWorklogDuration caps a single manual worklog
+const MaxWorklogDuration = 24 * time.Hour
+
+// MaxTimesheetDays caps the date range of a timesheet
+const MaxTimesheetDays = 366
+
+// Timesheet groupings
+const (
+	TimesheetByDay     = "day"
+	TimesheetByWeek    = "week"
+	TimesheetByTask    = "task"
+	TimesheetByProject = "project"
+)
+
+// timesheetDateLayout is the format of timesheet dates
+const timesheetDateLayout = "2006-01-02"
+
+// TaskTimeSummary is the time logged on a task compared with its estimate
+type TaskTimeSummary struct {
+	Worklogs        []models.Worklog
+	LoggedSeconds   int64
+	EstimateMinutes int
+}
+
+// TimesheetQuery selects the user's worklogs for a timesheet. From and To
+// are inclusive dates (YYYY-MM-DD) in Timezone; To defaults to today and
+// From to six days before To.
+type TimesheetQuery struct {
+	From     string
+	To       string
+	Group    string
+	Timezone string
+}
+
+// TimesheetRow is the time logged in one day, week, task or project. Task
+// rows also carry the task's estimate and the time everyone logged on it.
+type TimesheetRow struct {
+	Key                string
+	Label              string
+	Seconds            int64
+	TaskID             *uint
+	ProjectID          *uint
+	EstimateMinutes    int
+	TotalLoggedSeconds int64
+}
+
+// Timesheet aggregates a user's finished worklogs. Each worklog counts
+// towards the day it started on.
+type Timesheet struct {
+	From         time.Time
+	To           time.Time
+	Group        string
+	Timezone     string
+	TotalSeconds int64
+	Rows         []TimesheetRow
+}
+
+// WorklogService interface defines the methods for time tracking
+type WorklogService interface {
+	StartTimer(taskID, userID uint, note string) (*models.Worklog, error)
+	StopTimer(userID uint) (*models.Worklog, error)
+	GetRunningTimer(userID uint) (*models.Worklog, error)
+	LogWork(worklog *models.Worklog, userID uint) (*models.Worklog, error)
+	UpdateWorklog(worklog *models.Worklog, userID uint) (*models.Worklog, error)
+	DeleteWorklog(id, userID uint) error
+	GetTaskWorklogs(taskID, userID uint) (*TaskTimeSummary, error)
+	GetTimesheet(userID uint, query TimesheetQuery) (*Timesheet, error)
+}
+
+// WorklogServiceImpl is the concrete implementation of the WorklogService interface
+type WorklogServiceImpl struct {
+	WorklogRepo repositories.WorklogRepository
+	TaskRepo    repositories.TaskRepository
+	ProjectRepo repositories.ProjectRepository
+	tasks       *TaskServiceImpl
+}
+
+// NewWorklogService creates and returns a new WorklogService instance
+func NewWorklogService(worklogRepo repositories.WorklogRepository, taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository, tasks *TaskServiceImpl) WorklogService {
+	return &WorklogServiceImpl{
+		WorklogRepo: worklogRepo,
+		TaskRepo:    taskRepo,
+		ProjectRepo: projectRepo,
+		tasks:       tasks,
+	}
+}
+
+// runningTimer fetches the user's running timer, nil when there is none (internal helper)
+func (s *WorklogServiceImpl) runningTimer(userID uint) (*models.Worklog, error) {
+	running, err := s.WorklogRepo.GetRunningWorklog(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error fetching timer: %v", err)
+	}
+	return running, nil
+}
+
+// validateWorklog checks the span of a manual worklog (internal helper)
+func validateWorklog(worklog *models.Worklog, now time.Time) error {
+	duration := time.Duration(worklog.Seconds) * time.Second
+	if worklog.Seconds <= 0 || duration > MaxWorklogDuration {
+		return fmt.Errorf("%w: duration must be between 1 second and %v", ErrInvalidWorklog, MaxWorklogDuration)
+	}
+	if worklog.StartedAt.IsZero() {
+		worklog.StartedAt = now.Add(-duration)
+	}
+	// Allow a minute of clock skew between client and server
+	ended := worklog.StartedAt.Add(duration)
+	if ended.After(now.Add(time.Minute)) {
+		return fmt.Errorf("%w: work can't end in the future", ErrInvalidWorklog)
+	}
+	worklog.EndedAt = &ended
+	worklog.Note = strings.TrimSpace(worklog.Note)
+	return nil
+}
+
+// StartTimer starts the user's timer on a task they may edit. Only one
+// timer may run at a time.
+func (s *WorklogServiceImpl) StartTimer(taskID, userID uint, note string) (*models.Worklog, error) {
+	task, err := s.tasks.loadTask(taskID, userID, models.ProjectRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	running, err := s.runningTimer(userID)
+	if err != nil {
+		return nil, err
+	}
+	if running != nil {
+		return nil, ErrTimerRunning
+	}
+
+	worklog := models.Worklog{
+		TaskID:    task.ID,
+		UserID:    userID,
+		StartedAt: time.Now(),
+		Note:      strings.TrimSpace(note),
+	}
+	return s.WorklogRepo.CreateWorklog(&worklog)
+}
+
+// StopTimer stops the user's running timer, turning it into a worklog
+func (s *WorklogServiceImpl) StopTimer(userID uint) (*models.Worklog, error) {
+	running, err := s.runningTimer(userID)
+	if err != nil {
+		return nil, err
+	}
+	if running == nil {
+		return nil, ErrNoRunningTimer
+	}
+
+	ended := time.Now()
+	running.EndedAt = &ended
+	running.Seconds = int64(ended.Sub(running.StartedAt) / time.Second)
+	if running.Seconds < 0 {
+		running.Seconds = 0
+	}
+	return s.WorklogRepo.UpdateWorklog(running)
+}
+
+// GetRunningTimer retrieves the user's running timer
+func (s *WorklogServiceImpl) GetRunningTimer(userID uint) (*models.Worklog, error) {
+	running, err := s.runningTimer(userID)
+	if err != nil {
+		return nil, err
+	}
+	if running == nil {
+		return nil, ErrNoRunningTimer
+	}
+	return running, nil
+}
+
+// LogWork records time spent on a task the user may edit. Without a start
+// time the work is assumed to have just ended.
+func (s *WorklogServiceImpl) LogWork(worklog *models.Worklog, userID uint) (*models.Worklog, error) {
+	task, err := s.tasks.loadTask(worklog.TaskID, userID, models.ProjectRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateWorklog(worklog, time.Now()); err != nil {
+		return nil, err
+	}
+
+	worklog.ID = 0
+	worklog.TaskID = task.ID
+	worklog.UserID = userID
+	return s.WorklogRepo.CreateWorklog(worklog)
+}
+
+// loadOwnWorklog fetches a worklog of the user (internal helper)
+func (s *WorklogServiceImpl) loadOwnWorklog(id, userID uint) (*models.Worklog, error) {
+	worklog, err := s.WorklogRepo.GetWorklogByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWorklogNotFound
+		}
+		return nil, fmt.Errorf("unexpected error fetching worklog: %v", err)
+	}
+	if worklog.UserID != userID {
+		return nil, ErrWorklogForbidden
+	}
+	return worklog, nil
+}
+
+// UpdateWorklog changes the start, duration or note of the user's own
+// finished worklog
+func (s *WorklogServiceImpl) UpdateWorklog(worklog *models.Worklog, userID uint) (*models.Worklog, error) {
+	existing, err := s.loadOwnWorklog(worklog.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.EndedAt == nil {
+		return nil, ErrWorklogRunning
+	}
+	if err := validateWorklog(worklog, time.Now()); err != nil {
+		return nil, err
+	}
+
+	existing.StartedAt = worklog.StartedAt
+	existing.EndedAt = worklog.EndedAt
+	existing.Seconds = worklog.Seconds
+	existing.Note = worklog.Note
+	return s.WorklogRepo.UpdateWorklog(existing)
+}
+
+// DeleteWorklog deletes the user's own worklog; deleting the running timer discards it
+func (s *WorklogServiceImpl) DeleteWorklog(id, userID uint) error {
+	if _, err := s.loadOwnWorklog(id, userID); err != nil {
+		return err
+	}
+	if err := s.WorklogRepo.DeleteWorklog(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrWorklogNotFound
+		}
+		return fmt.Errorf("failed to delete worklog: %v", err)
+	}
+	return nil
+}
+
+// GetTaskWorklogs retrieves everyone's worklogs on a task the user can see,
+// with the finished time compared with the task's estimate
+func (s *WorklogServiceImpl) GetTaskWorklogs(taskID, userID uint) (*TaskTimeSummary, error) {
+	task, err := s.tasks.loadTask(taskID, userID, models.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	worklogs, err := s.WorklogRepo.GetWorklogsByTaskID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch worklogs: %v", err)
+	}
+
+	summary := TaskTimeSummary{Worklogs: worklogs, EstimateMinutes: task.EstimateMinutes}
+	for _, worklog := range worklogs {
+		if worklog.EndedAt != nil {
+			summary.LoggedSeconds += worklog.Seconds
+		}
+	}
+	return &summary, nil
+}
+
+// parseTimesheetQuery resolves a query to its time zone and the half-open
+// interval [start, end) it covers (internal helper)
+func parseTimesheetQuery(query TimesheetQuery, now time.Time) (*time.Location, time.Time, time.Time, error) {
+	loc, err := time.LoadLocation(query.Timezone)
+	if err != nil {
+		return nil, time.Time{}, time.Time{}, fmt.Errorf("%w: unknown time zone %q", ErrInvalidTimesheet, query.Timezone)
+	}
+
+	y, m, d := now.In(loc).Date()
+	to := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	if query.To != "" {
+		if to, err = time.ParseInLocation(timesheetDateLayout, query.To, loc); err != nil {
+			return nil, time.Time{}, time.Time{}, fmt.Errorf("%w: to must be a date like 2024-01-31", ErrInvalidTimesheet)
+		}
+	}
+	from := to.AddDate(0, 0, -6)
+	if query.From != "" {
+		if from, err = time.ParseInLocation(timesheetDateLayout, query.From, loc); err != nil {
+			return nil, time.Time{}, time.Time{}, fmt.Errorf("%w: from must be a date like 2024-01-01", ErrInvalidTimesheet)
+		}
+	}
+
+	if to.Before(from) {
+		return nil, time.Time{}, time.Time{}, fmt.Errorf("%w: from is after to", ErrInvalidTimesheet)
+	}
+	if !from.AddDate(0, 0, MaxTimesheetDays).After(to) {
+		return nil, time.Time{}, time.Time{}, fmt.Errorf("%w: at most %d days", ErrInvalidTimesheet, MaxTimesheetDays)
+	}
+	return loc, from, to.AddDate(0, 0, 1), nil
+}
+
+// GetTimesheet aggregates the user's finished worklogs by day, week, task
+// or project. Day and week timesheets list every period of the range, also
+// those without logged time.
+func (s *WorklogServiceImpl) GetTimesheet(userID uint, query TimesheetQuery) (*Timesheet, error) {
+	switch query.Group {
+	case "":
+		query.Group = TimesheetByDay
+	case TimesheetByDay, TimesheetByWeek, TimesheetByTask, TimesheetByProject:
+	default:
+		return nil, fmt.Errorf("%w: group must be day, week, task or project", ErrInvalidTimesheet)
+	}
+	loc, start, end, err := parseTimesheetQuery(query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	worklogs, err := s.WorklogRepo.GetWorklogsByUserBetween(userID, start.UTC(), end.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch worklogs: %v", err)
+	}
+
+	timesheet := Timesheet{From: start, To: end.AddDate(0, 0, -1), Group: query.Group, Timezone: loc.String()}
+	for _, worklog := range worklogs {
+		timesheet.TotalSeconds += worklog.Seconds
+	}
+
+	switch query.Group {
+	case TimesheetByDay, TimesheetByWeek:
+		timesheet.Rows = periodRows(worklogs, query.Group, start, end, loc)
+	case TimesheetByTask:
+		timesheet.Rows, err = s.taskRows(worklogs)
+	case TimesheetByProject:
+		timesheet.Rows, err = s.projectRows(worklogs)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &timesheet, nil
+}
+
+// weekStart returns the Monday starting the ISO week of a local date
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	y, m, d := t.AddDate(0, 0, -offset).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// periodKey returns the day ("2024-01-31") or ISO week ("2024-W05") of a local time
+func periodKey(t time.Time, group string) string {
+	if group == TimesheetByWeek {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	}
+	return t.Format(timesheetDateLayout)
+}
+
+// periodRows builds one row per day or week of [start, end)
+func periodRows(worklogs []models.Worklog, group string, start, end time.Time, loc *time.Location) []TimesheetRow {
+	seconds := map[string]int64{}
+	for _, worklog := range worklogs {
+		seconds[periodKey(worklog.StartedAt.In(loc), group)] += worklog.Seconds
+	}
+
+	var rows []TimesheetRow
+	day := start
+	step := 1
+	if group == TimesheetByWeek {
+		day = weekStart(start)
+		step = 7
+	}
+	for ; day.Before(end); day = day.AddDate(0, 0, step) {
+		key := periodKey(day, group)
+		rows = append(rows, TimesheetRow{Key: key, Label: day.Format(timesheetDateLayout), Seconds: seconds[key]})
+	}
+	return rows
+}
+
+// sortRows orders rows by logged time, most first
+func sortRows(rows []TimesheetRow) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Seconds != rows[j].Seconds {
+			return rows[i].Seconds > rows[j].Seconds
+		}
+		return rows[i].Key < rows[j].Key
+	})
+}
+
+// taskRows builds one row per task, with the task's estimate and the time
+// everyone logged on it
+func (s *WorklogServiceImpl) taskRows(worklogs []models.Worklog) ([]TimesheetRow, error) {
+	byTask := map[uint]*TimesheetRow{}
+	var taskIDs []uint
+	for _, worklog := range worklogs {
+		row, ok := byTask[worklog.TaskID]
+		if !ok {
+			taskID := worklog.TaskID
+			row = &TimesheetRow{Key: strconv.FormatUint(uint64(taskID), 10), Label: "(deleted task)", TaskID: &taskID}
+			if worklog.Task != nil {
+				row.Label = worklog.Task.Title
+				row.ProjectID = worklog.Task.ProjectID
+				row.EstimateMinutes = worklog.Task.EstimateMinutes
+			}
+			byTask[taskID] = row
+			taskIDs = append(taskIDs, taskID)
+		}
+		row.Seconds += worklog.Seconds
+	}
+
+	logged, err := s.WorklogRepo.GetLoggedSecondsByTask(taskIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum worklogs: %v", err)
+	}
+
+	rows := make([]TimesheetRow, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		row := byTask[taskID]
+		row.TotalLoggedSeconds = logged[taskID]
+		rows = append(rows, *row)
+	}
+	sortRows(rows)
+	return rows, nil
+}
+
+// projectRows builds one row per project, plus one for personal tasks
+func (s *WorklogServiceImpl) projectRows(worklogs []models.Worklog) ([]TimesheetRow, error) {
+	byProject := map[string]*TimesheetRow{}
+	var keys []string
+	for _, worklog := range worklogs {
+		key := "personal"
+		var projectID *uint
+		if worklog.Task != nil && worklog.Task.ProjectID != nil {
+			projectID = worklog.Task.ProjectID
+			key = strconv.FormatUint(uint64(*projectID), 10)
+		}
+
+		row, ok := byProject[key]
+		if !ok {
+			row = &TimesheetRow{Key: key, Label: "Personal tasks", ProjectID: projectID}
+			if projectID != nil {
+				project, err := s.ProjectRepo.GetProjectByID(*projectID)
+				switch {
+				case err == nil:
+					row.Label = project.Name
+				case errors.Is(err, gorm.ErrRecordNotFound):
+					row.Label = "(deleted project)"
+				default:
+					return nil, fmt.Errorf("unexpected error fetching project: %v", err)
+				}
+			}
+			byProject[key] = row
+			keys = append(keys, key)
+		}
+		row.Seconds += worklog.Seconds
+	}
+
+	rows := make([]TimesheetRow, 0, len(keys))
+	for _, key := range keys {
+		rows = append(rows, *byProject[key])
+	}
+	sortRows(rows)
+	return rows, nil
+}
@@ -0,0 +1,239 @@
+package services_test
+
+import (
+	"TaskManager/internal/models"
+	"TaskManager/internal/services"
+	"TaskManager/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func finishedWorklog(taskID uint, startedAt time.Time, seconds int64) models.Worklog {
+	ended := startedAt.Add(time.Duration(seconds) * time.Second)
+	return models.Worklog{TaskID: taskID, UserID: 1, StartedAt: startedAt, EndedAt: &ended, Seconds: seconds}
+}
+
+func TestStartTimer_OnlyOneRunning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorklogs := mocks.NewMockWorklogRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	worklogSvc := services.NewWorklogService(mockWorklogs, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockTasks.EXPECT().GetTaskByID(uint(5)).Return(projectTaskWithAssignees(), nil).Times(2)
+	mockProjects.EXPECT().GetMember(uint(3), uint(1)).Return(memberWithRole(models.ProjectRoleEditor), nil).Times(2)
+
+	mockWorklogs.EXPECT().GetRunningWorklog(uint(1)).Return(nil, gorm.ErrRecordNotFound)
+	mockWorklogs.EXPECT().CreateWorklog(gomock.Any()).DoAndReturn(
+		func(worklog *models.Worklog) (*models.Worklog, error) {
+			worklog.ID = 7
+			return worklog, nil
+		},
+	)
+	timer, err := worklogSvc.StartTimer(5, 1, "  release notes ")
+	require.NoError(t, err)
+	assert.Nil(t, timer.EndedAt)
+	assert.Equal(t, "release notes", timer.Note)
+
+	mockWorklogs.EXPECT().GetRunningWorklog(uint(1)).Return(timer, nil)
+	_, err = worklogSvc.StartTimer(5, 1, "")
+	assert.ErrorIs(t, err, services.ErrTimerRunning)
+}
+
+func TestStopTimer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorklogs := mocks.NewMockWorklogRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	worklogSvc := services.NewWorklogService(mockWorklogs, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockWorklogs.EXPECT().GetRunningWorklog(uint(1)).Return(nil, gorm.ErrRecordNotFound)
+	_, err := worklogSvc.StopTimer(1)
+	assert.ErrorIs(t, err, services.ErrNoRunningTimer)
+
+	running := &models.Worklog{ID: 7, TaskID: 5, UserID: 1, StartedAt: time.Now().Add(-90 * time.Minute)}
+	mockWorklogs.EXPECT().GetRunningWorklog(uint(1)).Return(running, nil)
+	mockWorklogs.EXPECT().UpdateWorklog(running).Return(running, nil)
+
+	stopped, err := worklogSvc.StopTimer(1)
+	require.NoError(t, err)
+	require.NotNil(t, stopped.EndedAt)
+	assert.InDelta(t, 90*60, stopped.Seconds, 2)
+}
+
+func TestLogWork_Validation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorklogs := mocks.NewMockWorklogRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	worklogSvc := services.NewWorklogService(mockWorklogs, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+	mockTasks.EXPECT().GetTaskByID(uint(4)).Return(&models.Task{Model: gorm.Model{ID: 4}, UserID: 1}, nil).AnyTimes()
+
+	tests := []struct {
+		name    string
+		worklog models.Worklog
+	}{
+		{"no duration", models.Worklog{TaskID: 4}},
+		{"longer than a day", models.Worklog{TaskID: 4, Seconds: 25 * 3600}},
+		{"ends in the future", models.Worklog{TaskID: 4, Seconds: 3600, StartedAt: time.Now().Add(-30 * time.Minute)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := worklogSvc.LogWork(&tt.worklog, 1)
+			assert.ErrorIs(t, err, services.ErrInvalidWorklog)
+		})
+	}
+
+	// Without a start time the work ends now
+	mockWorklogs.EXPECT().CreateWorklog(gomock.Any()).DoAndReturn(
+		func(worklog *models.Worklog) (*models.Worklog, error) { return worklog, nil },
+	)
+	created, err := worklogSvc.LogWork(&models.Worklog{TaskID: 4, Seconds: 1800, UserID: 9}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), created.UserID)
+	assert.WithinDuration(t, time.Now().Add(-30*time.Minute), created.StartedAt, 2*time.Second)
+	assert.Equal(t, created.StartedAt.Add(30*time.Minute), *created.EndedAt)
+}
+
+func TestUpdateWorklog_OwnFinishedOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorklogs := mocks.NewMockWorklogRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	worklogSvc := services.NewWorklogService(mockWorklogs, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockWorklogs.EXPECT().GetWorklogByID(uint(7)).Return(&models.Worklog{ID: 7, UserID: 2, StartedAt: time.Now()}, nil).Times(2)
+	_, err := worklogSvc.UpdateWorklog(&models.Worklog{ID: 7, Seconds: 60}, 1)
+	assert.ErrorIs(t, err, services.ErrWorklogForbidden)
+
+	// Running timers are stopped, not edited
+	_, err = worklogSvc.UpdateWorklog(&models.Worklog{ID: 7, Seconds: 60}, 2)
+	assert.ErrorIs(t, err, services.ErrWorklogRunning)
+
+	mockWorklogs.EXPECT().GetWorklogByID(uint(8)).Return(nil, gorm.ErrRecordNotFound)
+	assert.ErrorIs(t, worklogSvc.DeleteWorklog(8, 1), services.ErrWorklogNotFound)
+}
+
+func TestGetTimesheet_ByDayInTimezone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorklogs := mocks.NewMockWorklogRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	worklogSvc := services.NewWorklogService(mockWorklogs, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, loc)
+	end := time.Date(2024, 3, 4, 0, 0, 0, 0, loc)
+
+	mockWorklogs.EXPECT().GetWorklogsByUserBetween(uint(1), start.UTC(), end.UTC()).Return([]models.Worklog{
+		// 23:30 in New York on the 1st is already the 2nd in UTC
+		finishedWorklog(5, time.Date(2024, 3, 2, 4, 30, 0, 0, time.UTC), 3600),
+		finishedWorklog(6, time.Date(2024, 3, 3, 15, 0, 0, 0, time.UTC), 1800),
+	}, nil)
+
+	timesheet, err := worklogSvc.GetTimesheet(1, services.TimesheetQuery{From: "2024-03-01", To: "2024-03-03", Timezone: "America/New_York"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5400), timesheet.TotalSeconds)
+	require.Len(t, timesheet.Rows, 3)
+	assert.Equal(t, services.TimesheetRow{Key: "2024-03-01", Label: "2024-03-01", Seconds: 3600}, timesheet.Rows[0])
+	assert.Equal(t, int64(0), timesheet.Rows[1].Seconds)
+	assert.Equal(t, int64(1800), timesheet.Rows[2].Seconds)
+}
+
+func TestGetTimesheet_ByWeek(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorklogs := mocks.NewMockWorklogRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	worklogSvc := services.NewWorklogService(mockWorklogs, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	mockWorklogs.EXPECT().GetWorklogsByUserBetween(uint(1), gomock.Any(), gomock.Any()).Return([]models.Worklog{
+		finishedWorklog(5, time.Date(2024, 12, 31, 9, 0, 0, 0, time.UTC), 600),
+		finishedWorklog(5, time.Date(2025, 1, 7, 9, 0, 0, 0, time.UTC), 1200),
+	}, nil)
+
+	timesheet, err := worklogSvc.GetTimesheet(1, services.TimesheetQuery{From: "2024-12-28", To: "2025-01-08", Group: services.TimesheetByWeek})
+	require.NoError(t, err)
+	assert.Equal(t, []services.TimesheetRow{
+		{Key: "2024-W52", Label: "2024-12-23"},
+		{Key: "2025-W01", Label: "2024-12-30", Seconds: 600},
+		{Key: "2025-W02", Label: "2025-01-06", Seconds: 1200},
+	}, timesheet.Rows)
+}
+
+func TestGetTimesheet_ByTaskComparesEstimate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorklogs := mocks.NewMockWorklogRepository(ctrl)
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	worklogSvc := services.NewWorklogService(mockWorklogs, mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	release := projectTaskWithAssignees()
+	release.EstimateMinutes = 120
+	first := finishedWorklog(5, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC), 1800)
+	first.Task = release
+	second := finishedWorklog(5, time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC), 3600)
+	second.Task = release
+	orphan := finishedWorklog(6, time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC), 600)
+
+	mockWorklogs.EXPECT().GetWorklogsByUserBetween(uint(1), gomock.Any(), gomock.Any()).Return([]models.Worklog{first, second, orphan}, nil)
+	mockWorklogs.EXPECT().GetLoggedSecondsByTask([]uint{5, 6}).Return(map[uint]int64{5: 9000, 6: 600}, nil)
+
+	timesheet, err := worklogSvc.GetTimesheet(1, services.TimesheetQuery{From: "2024-03-01", To: "2024-03-07", Group: services.TimesheetByTask})
+	require.NoError(t, err)
+	require.Len(t, timesheet.Rows, 2)
+
+	row := timesheet.Rows[0]
+	assert.Equal(t, "Release", row.Label)
+	assert.Equal(t, int64(5400), row.Seconds)
+	assert.Equal(t, 120, row.EstimateMinutes)
+	assert.Equal(t, int64(9000), row.TotalLoggedSeconds)
+	assert.Equal(t, uint(3), *row.ProjectID)
+	assert.Equal(t, "(deleted task)", timesheet.Rows[1].Label)
+}
+
+func TestGetTimesheet_InvalidQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTasks := mocks.NewMockTaskRepository(ctrl)
+	mockProjects := mocks.NewMockProjectRepository(ctrl)
+	worklogSvc := services.NewWorklogService(mocks.NewMockWorklogRepository(ctrl), mockTasks, mockProjects, services.NewTaskService(mockTasks, mockProjects, mocks.NewMockUserRepository(ctrl), nil))
+
+	tests := []struct {
+		name  string
+		query services.TimesheetQuery
+	}{
+		{"bad date", services.TimesheetQuery{From: "March"}},
+		{"reversed range", services.TimesheetQuery{From: "2024-03-05", To: "2024-03-01"}},
+		{"range too long", services.TimesheetQuery{From: "2023-01-01", To: "2024-03-01"}},
+		{"unknown zone", services.TimesheetQuery{Timezone: "Mars/Olympus"}},
+		{"unknown group", services.TimesheetQuery{Group: "month"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := worklogSvc.GetTimesheet(1, tt.query)
+			assert.ErrorIs(t, err, services.ErrInvalidTimesheet)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repositories/board_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "TaskManager/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBoardRepository is a mock of BoardRepository interface.
+type MockBoardRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardRepositoryMockRecorder
+}
+
+// MockBoardRepositoryMockRecorder is the mock recorder for MockBoardRepository.
+type MockBoardRepositoryMockRecorder struct {
+	mock *MockBoardRepository
+}
+
+// NewMockBoardRepository creates a new mock instance.
+func NewMockBoardRepository(ctrl *gomock.Controller) *MockBoardRepository {
+	mock := &MockBoardRepository{ctrl: ctrl}
+	mock.recorder = &MockBoardRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoardRepository) EXPECT() *MockBoardRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateBoard mocks base method.
+func (m *MockBoardRepository) CreateBoard(board *models.Board) (*models.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoard", board)
+	ret0, _ := ret[0].(*models.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoard indicates an expected call of CreateBoard.
+func (mr *MockBoardRepositoryMockRecorder) CreateBoard(board interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoard", reflect.TypeOf((*MockBoardRepository)(nil).CreateBoard), board)
+}
+
+// DeleteBoard mocks base method.
+func (m *MockBoardRepository) DeleteBoard(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoard", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoard indicates an expected call of DeleteBoard.
+func (mr *MockBoardRepositoryMockRecorder) DeleteBoard(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoard", reflect.TypeOf((*MockBoardRepository)(nil).DeleteBoard), id)
+}
+
+// GetBoardByID mocks base method.
+func (m *MockBoardRepository) GetBoardByID(id uint) (*models.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardByID", id)
+	ret0, _ := ret[0].(*models.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardByID indicates an expected call of GetBoardByID.
+func (mr *MockBoardRepositoryMockRecorder) GetBoardByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardByID", reflect.TypeOf((*MockBoardRepository)(nil).GetBoardByID), id)
+}
+
+// GetBoardsByProjectID mocks base method.
+func (m *MockBoardRepository) GetBoardsByProjectID(projectID uint) ([]models.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardsByProjectID", projectID)
+	ret0, _ := ret[0].([]models.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardsByProjectID indicates an expected call of GetBoardsByProjectID.
+func (mr *MockBoardRepositoryMockRecorder) GetBoardsByProjectID(projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsByProjectID", reflect.TypeOf((*MockBoardRepository)(nil).GetBoardsByProjectID), projectID)
+}
+
+// UpdateBoard mocks base method.
+func (m *MockBoardRepository) UpdateBoard(board *models.Board) (*models.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBoard", board)
+	ret0, _ := ret[0].(*models.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBoard indicates an expected call of UpdateBoard.
+func (mr *MockBoardRepositoryMockRecorder) UpdateBoard(board interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBoard", reflect.TypeOf((*MockBoardRepository)(nil).UpdateBoard), board)
+}
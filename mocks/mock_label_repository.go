@@ -0,0 +1,181 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repositories/label_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "TaskManager/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLabelRepository is a mock of LabelRepository interface.
+type MockLabelRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLabelRepositoryMockRecorder
+}
+
+// MockLabelRepositoryMockRecorder is the mock recorder for MockLabelRepository.
+type MockLabelRepositoryMockRecorder struct {
+	mock *MockLabelRepository
+}
+
+// NewMockLabelRepository creates a new mock instance.
+func NewMockLabelRepository(ctrl *gomock.Controller) *MockLabelRepository {
+	mock := &MockLabelRepository{ctrl: ctrl}
+	mock.recorder = &MockLabelRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLabelRepository) EXPECT() *MockLabelRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddTaskLabel mocks base method.
+func (m *MockLabelRepository) AddTaskLabel(taskID, labelID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTaskLabel", taskID, labelID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTaskLabel indicates an expected call of AddTaskLabel.
+func (mr *MockLabelRepositoryMockRecorder) AddTaskLabel(taskID, labelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTaskLabel", reflect.TypeOf((*MockLabelRepository)(nil).AddTaskLabel), taskID, labelID)
+}
+
+// CreateLabel mocks base method.
+func (m *MockLabelRepository) CreateLabel(label *models.Label) (*models.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLabel", label)
+	ret0, _ := ret[0].(*models.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLabel indicates an expected call of CreateLabel.
+func (mr *MockLabelRepositoryMockRecorder) CreateLabel(label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLabel", reflect.TypeOf((*MockLabelRepository)(nil).CreateLabel), label)
+}
+
+// DeleteLabel mocks base method.
+func (m *MockLabelRepository) DeleteLabel(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLabel", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLabel indicates an expected call of DeleteLabel.
+func (mr *MockLabelRepositoryMockRecorder) DeleteLabel(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLabel", reflect.TypeOf((*MockLabelRepository)(nil).DeleteLabel), id)
+}
+
+// GetLabelByID mocks base method.
+func (m *MockLabelRepository) GetLabelByID(id uint) (*models.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabelByID", id)
+	ret0, _ := ret[0].(*models.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabelByID indicates an expected call of GetLabelByID.
+func (mr *MockLabelRepositoryMockRecorder) GetLabelByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabelByID", reflect.TypeOf((*MockLabelRepository)(nil).GetLabelByID), id)
+}
+
+// GetLabelByName mocks base method.
+func (m *MockLabelRepository) GetLabelByName(userID, projectID *uint, name string) (*models.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabelByName", userID, projectID, name)
+	ret0, _ := ret[0].(*models.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabelByName indicates an expected call of GetLabelByName.
+func (mr *MockLabelRepositoryMockRecorder) GetLabelByName(userID, projectID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabelByName", reflect.TypeOf((*MockLabelRepository)(nil).GetLabelByName), userID, projectID, name)
+}
+
+// GetLabelsByProjectID mocks base method.
+func (m *MockLabelRepository) GetLabelsByProjectID(projectID uint) ([]models.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabelsByProjectID", projectID)
+	ret0, _ := ret[0].([]models.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabelsByProjectID indicates an expected call of GetLabelsByProjectID.
+func (mr *MockLabelRepositoryMockRecorder) GetLabelsByProjectID(projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabelsByProjectID", reflect.TypeOf((*MockLabelRepository)(nil).GetLabelsByProjectID), projectID)
+}
+
+// GetLabelsByUserID mocks base method.
+func (m *MockLabelRepository) GetLabelsByUserID(userID uint) ([]models.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabelsByUserID", userID)
+	ret0, _ := ret[0].([]models.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabelsByUserID indicates an expected call of GetLabelsByUserID.
+func (mr *MockLabelRepositoryMockRecorder) GetLabelsByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabelsByUserID", reflect.TypeOf((*MockLabelRepository)(nil).GetLabelsByUserID), userID)
+}
+
+// MergeLabels mocks base method.
+func (m *MockLabelRepository) MergeLabels(sourceID, targetID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeLabels", sourceID, targetID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeLabels indicates an expected call of MergeLabels.
+func (mr *MockLabelRepositoryMockRecorder) MergeLabels(sourceID, targetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeLabels", reflect.TypeOf((*MockLabelRepository)(nil).MergeLabels), sourceID, targetID)
+}
+
+// RemoveTaskLabel mocks base method.
+func (m *MockLabelRepository) RemoveTaskLabel(taskID, labelID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTaskLabel", taskID, labelID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTaskLabel indicates an expected call of RemoveTaskLabel.
+func (mr *MockLabelRepositoryMockRecorder) RemoveTaskLabel(taskID, labelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTaskLabel", reflect.TypeOf((*MockLabelRepository)(nil).RemoveTaskLabel), taskID, labelID)
+}
+
+// UpdateLabel mocks base method.
+func (m *MockLabelRepository) UpdateLabel(label *models.Label) (*models.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLabel", label)
+	ret0, _ := ret[0].(*models.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLabel indicates an expected call of UpdateLabel.
+func (mr *MockLabelRepositoryMockRecorder) UpdateLabel(label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLabel", reflect.TypeOf((*MockLabelRepository)(nil).UpdateLabel), label)
+}
@@ -0,0 +1,304 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repositories/project_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "TaskManager/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockProjectRepository is a mock of ProjectRepository interface.
+type MockProjectRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectRepositoryMockRecorder
+}
+
+// MockProjectRepositoryMockRecorder is the mock recorder for MockProjectRepository.
+type MockProjectRepositoryMockRecorder struct {
+	mock *MockProjectRepository
+}
+
+// NewMockProjectRepository creates a new mock instance.
+func NewMockProjectRepository(ctrl *gomock.Controller) *MockProjectRepository {
+	mock := &MockProjectRepository{ctrl: ctrl}
+	mock.recorder = &MockProjectRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProjectRepository) EXPECT() *MockProjectRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockProjectRepository) AddMember(member *models.ProjectMember) (*models.ProjectMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", member)
+	ret0, _ := ret[0].(*models.ProjectMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockProjectRepositoryMockRecorder) AddMember(member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockProjectRepository)(nil).AddMember), member)
+}
+
+// CreateCustomField mocks base method.
+func (m *MockProjectRepository) CreateCustomField(field *models.CustomField) (*models.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomField", field)
+	ret0, _ := ret[0].(*models.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomField indicates an expected call of CreateCustomField.
+func (mr *MockProjectRepositoryMockRecorder) CreateCustomField(field interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomField", reflect.TypeOf((*MockProjectRepository)(nil).CreateCustomField), field)
+}
+
+// CreateProject mocks base method.
+func (m *MockProjectRepository) CreateProject(project *models.Project) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProject", project)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProject indicates an expected call of CreateProject.
+func (mr *MockProjectRepositoryMockRecorder) CreateProject(project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockProjectRepository)(nil).CreateProject), project)
+}
+
+// DeleteCustomField mocks base method.
+func (m *MockProjectRepository) DeleteCustomField(field *models.CustomField) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomField", field)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomField indicates an expected call of DeleteCustomField.
+func (mr *MockProjectRepositoryMockRecorder) DeleteCustomField(field interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomField", reflect.TypeOf((*MockProjectRepository)(nil).DeleteCustomField), field)
+}
+
+// DeleteProject mocks base method.
+func (m *MockProjectRepository) DeleteProject(id uint) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProject", id)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProject indicates an expected call of DeleteProject.
+func (mr *MockProjectRepositoryMockRecorder) DeleteProject(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockProjectRepository)(nil).DeleteProject), id)
+}
+
+// DeleteWorkflow mocks base method.
+func (m *MockProjectRepository) DeleteWorkflow(projectID uint, finalStates []string) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkflow", projectID, finalStates)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWorkflow indicates an expected call of DeleteWorkflow.
+func (mr *MockProjectRepositoryMockRecorder) DeleteWorkflow(projectID, finalStates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkflow", reflect.TypeOf((*MockProjectRepository)(nil).DeleteWorkflow), projectID, finalStates)
+}
+
+// GetCustomField mocks base method.
+func (m *MockProjectRepository) GetCustomField(projectID, id uint) (*models.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomField", projectID, id)
+	ret0, _ := ret[0].(*models.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomField indicates an expected call of GetCustomField.
+func (mr *MockProjectRepositoryMockRecorder) GetCustomField(projectID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomField", reflect.TypeOf((*MockProjectRepository)(nil).GetCustomField), projectID, id)
+}
+
+// GetCustomFields mocks base method.
+func (m *MockProjectRepository) GetCustomFields(projectID uint) ([]models.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomFields", projectID)
+	ret0, _ := ret[0].([]models.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomFields indicates an expected call of GetCustomFields.
+func (mr *MockProjectRepositoryMockRecorder) GetCustomFields(projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomFields", reflect.TypeOf((*MockProjectRepository)(nil).GetCustomFields), projectID)
+}
+
+// GetMember mocks base method.
+func (m *MockProjectRepository) GetMember(projectID, userID uint) (*models.ProjectMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMember", projectID, userID)
+	ret0, _ := ret[0].(*models.ProjectMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMember indicates an expected call of GetMember.
+func (mr *MockProjectRepositoryMockRecorder) GetMember(projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMember", reflect.TypeOf((*MockProjectRepository)(nil).GetMember), projectID, userID)
+}
+
+// GetMembers mocks base method.
+func (m *MockProjectRepository) GetMembers(projectID uint) ([]models.ProjectMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", projectID)
+	ret0, _ := ret[0].([]models.ProjectMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockProjectRepositoryMockRecorder) GetMembers(projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockProjectRepository)(nil).GetMembers), projectID)
+}
+
+// GetProjectByID mocks base method.
+func (m *MockProjectRepository) GetProjectByID(id uint) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectByID", id)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectByID indicates an expected call of GetProjectByID.
+func (mr *MockProjectRepositoryMockRecorder) GetProjectByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectByID", reflect.TypeOf((*MockProjectRepository)(nil).GetProjectByID), id)
+}
+
+// GetProjectsByUserID mocks base method.
+func (m *MockProjectRepository) GetProjectsByUserID(userID uint) ([]models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectsByUserID", userID)
+	ret0, _ := ret[0].([]models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectsByUserID indicates an expected call of GetProjectsByUserID.
+func (mr *MockProjectRepositoryMockRecorder) GetProjectsByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectsByUserID", reflect.TypeOf((*MockProjectRepository)(nil).GetProjectsByUserID), userID)
+}
+
+// GetWorkflow mocks base method.
+func (m *MockProjectRepository) GetWorkflow(projectID uint) (*models.Workflow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkflow", projectID)
+	ret0, _ := ret[0].(*models.Workflow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkflow indicates an expected call of GetWorkflow.
+func (mr *MockProjectRepositoryMockRecorder) GetWorkflow(projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkflow", reflect.TypeOf((*MockProjectRepository)(nil).GetWorkflow), projectID)
+}
+
+// RemoveMember mocks base method.
+func (m *MockProjectRepository) RemoveMember(projectID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", projectID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockProjectRepositoryMockRecorder) RemoveMember(projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockProjectRepository)(nil).RemoveMember), projectID, userID)
+}
+
+// SaveWorkflow mocks base method.
+func (m *MockProjectRepository) SaveWorkflow(workflow *models.Workflow) (*models.Workflow, []uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWorkflow", workflow)
+	ret0, _ := ret[0].(*models.Workflow)
+	ret1, _ := ret[1].([]uint)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SaveWorkflow indicates an expected call of SaveWorkflow.
+func (mr *MockProjectRepositoryMockRecorder) SaveWorkflow(workflow interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWorkflow", reflect.TypeOf((*MockProjectRepository)(nil).SaveWorkflow), workflow)
+}
+
+// UpdateCustomField mocks base method.
+func (m *MockProjectRepository) UpdateCustomField(field *models.CustomField, removedOptions []string) (*models.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomField", field, removedOptions)
+	ret0, _ := ret[0].(*models.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCustomField indicates an expected call of UpdateCustomField.
+func (mr *MockProjectRepositoryMockRecorder) UpdateCustomField(field, removedOptions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomField", reflect.TypeOf((*MockProjectRepository)(nil).UpdateCustomField), field, removedOptions)
+}
+
+// UpdateMember mocks base method.
+func (m *MockProjectRepository) UpdateMember(member *models.ProjectMember) (*models.ProjectMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMember", member)
+	ret0, _ := ret[0].(*models.ProjectMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMember indicates an expected call of UpdateMember.
+func (mr *MockProjectRepositoryMockRecorder) UpdateMember(member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMember", reflect.TypeOf((*MockProjectRepository)(nil).UpdateMember), member)
+}
+
+// UpdateProject mocks base method.
+func (m *MockProjectRepository) UpdateProject(project *models.Project) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", project)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockProjectRepositoryMockRecorder) UpdateProject(project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockProjectRepository)(nil).UpdateProject), project)
+}
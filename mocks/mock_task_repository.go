@@ -0,0 +1,491 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repositories/task_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "TaskManager/internal/models"
+	repositories "TaskManager/internal/repositories"
+	tql "TaskManager/pkg/tql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTaskRepository is a mock of TaskRepository interface.
+type MockTaskRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskRepositoryMockRecorder
+}
+
+// MockTaskRepositoryMockRecorder is the mock recorder for MockTaskRepository.
+type MockTaskRepositoryMockRecorder struct {
+	mock *MockTaskRepository
+}
+
+// NewMockTaskRepository creates a new mock instance.
+func NewMockTaskRepository(ctrl *gomock.Controller) *MockTaskRepository {
+	mock := &MockTaskRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskRepository) EXPECT() *MockTaskRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddAssignee mocks base method.
+func (m *MockTaskRepository) AddAssignee(taskID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAssignee", taskID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAssignee indicates an expected call of AddAssignee.
+func (mr *MockTaskRepositoryMockRecorder) AddAssignee(taskID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAssignee", reflect.TypeOf((*MockTaskRepository)(nil).AddAssignee), taskID, userID)
+}
+
+// AddDependency mocks base method.
+func (m *MockTaskRepository) AddDependency(dependency *models.TaskDependency) (*models.TaskDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDependency", dependency)
+	ret0, _ := ret[0].(*models.TaskDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDependency indicates an expected call of AddDependency.
+func (mr *MockTaskRepositoryMockRecorder) AddDependency(dependency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDependency", reflect.TypeOf((*MockTaskRepository)(nil).AddDependency), dependency)
+}
+
+// AddWatcher mocks base method.
+func (m *MockTaskRepository) AddWatcher(taskID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWatcher", taskID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddWatcher indicates an expected call of AddWatcher.
+func (mr *MockTaskRepositoryMockRecorder) AddWatcher(taskID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWatcher", reflect.TypeOf((*MockTaskRepository)(nil).AddWatcher), taskID, userID)
+}
+
+// CreateChecklistItem mocks base method.
+func (m *MockTaskRepository) CreateChecklistItem(item *models.ChecklistItem) (*models.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChecklistItem", item)
+	ret0, _ := ret[0].(*models.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChecklistItem indicates an expected call of CreateChecklistItem.
+func (mr *MockTaskRepositoryMockRecorder) CreateChecklistItem(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChecklistItem", reflect.TypeOf((*MockTaskRepository)(nil).CreateChecklistItem), item)
+}
+
+// CreateTask mocks base method.
+func (m *MockTaskRepository) CreateTask(task *models.Task) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTask", task)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTask indicates an expected call of CreateTask.
+func (mr *MockTaskRepositoryMockRecorder) CreateTask(task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskRepository)(nil).CreateTask), task)
+}
+
+// CreateTaskTree mocks base method.
+func (m *MockTaskRepository) CreateTaskTree(tasks []repositories.TreeTask) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskTree", tasks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTaskTree indicates an expected call of CreateTaskTree.
+func (mr *MockTaskRepositoryMockRecorder) CreateTaskTree(tasks interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskTree", reflect.TypeOf((*MockTaskRepository)(nil).CreateTaskTree), tasks)
+}
+
+// DeleteChecklistItem mocks base method.
+func (m *MockTaskRepository) DeleteChecklistItem(item *models.ChecklistItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChecklistItem", item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChecklistItem indicates an expected call of DeleteChecklistItem.
+func (mr *MockTaskRepositoryMockRecorder) DeleteChecklistItem(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChecklistItem", reflect.TypeOf((*MockTaskRepository)(nil).DeleteChecklistItem), item)
+}
+
+// DeleteTasks mocks base method.
+func (m *MockTaskRepository) DeleteTasks(ids []uint) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTasks", ids)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTasks indicates an expected call of DeleteTasks.
+func (mr *MockTaskRepositoryMockRecorder) DeleteTasks(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTasks", reflect.TypeOf((*MockTaskRepository)(nil).DeleteTasks), ids)
+}
+
+// GetChecklistItem mocks base method.
+func (m *MockTaskRepository) GetChecklistItem(taskID, id uint) (*models.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChecklistItem", taskID, id)
+	ret0, _ := ret[0].(*models.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChecklistItem indicates an expected call of GetChecklistItem.
+func (mr *MockTaskRepositoryMockRecorder) GetChecklistItem(taskID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChecklistItem", reflect.TypeOf((*MockTaskRepository)(nil).GetChecklistItem), taskID, id)
+}
+
+// GetChecklistItems mocks base method.
+func (m *MockTaskRepository) GetChecklistItems(taskID uint) ([]models.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChecklistItems", taskID)
+	ret0, _ := ret[0].([]models.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChecklistItems indicates an expected call of GetChecklistItems.
+func (mr *MockTaskRepositoryMockRecorder) GetChecklistItems(taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChecklistItems", reflect.TypeOf((*MockTaskRepository)(nil).GetChecklistItems), taskID)
+}
+
+// GetDependencies mocks base method.
+func (m *MockTaskRepository) GetDependencies(taskIDs []uint) ([]models.TaskDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDependencies", taskIDs)
+	ret0, _ := ret[0].([]models.TaskDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDependencies indicates an expected call of GetDependencies.
+func (mr *MockTaskRepositoryMockRecorder) GetDependencies(taskIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDependencies", reflect.TypeOf((*MockTaskRepository)(nil).GetDependencies), taskIDs)
+}
+
+// GetLastTaskRank mocks base method.
+func (m *MockTaskRepository) GetLastTaskRank(projectID uint) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastTaskRank", projectID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastTaskRank indicates an expected call of GetLastTaskRank.
+func (mr *MockTaskRepositoryMockRecorder) GetLastTaskRank(projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastTaskRank", reflect.TypeOf((*MockTaskRepository)(nil).GetLastTaskRank), projectID)
+}
+
+// GetOpenTasksByUserID mocks base method.
+func (m *MockTaskRepository) GetOpenTasksByUserID(userID uint) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenTasksByUserID", userID)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenTasksByUserID indicates an expected call of GetOpenTasksByUserID.
+func (mr *MockTaskRepositoryMockRecorder) GetOpenTasksByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenTasksByUserID", reflect.TypeOf((*MockTaskRepository)(nil).GetOpenTasksByUserID), userID)
+}
+
+// GetSubtasks mocks base method.
+func (m *MockTaskRepository) GetSubtasks(parentIDs []uint) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtasks", parentIDs)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtasks indicates an expected call of GetSubtasks.
+func (mr *MockTaskRepositoryMockRecorder) GetSubtasks(parentIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtasks", reflect.TypeOf((*MockTaskRepository)(nil).GetSubtasks), parentIDs)
+}
+
+// GetTaskByID mocks base method.
+func (m *MockTaskRepository) GetTaskByID(id uint) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskByID", id)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskByID indicates an expected call of GetTaskByID.
+func (mr *MockTaskRepositoryMockRecorder) GetTaskByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByID", reflect.TypeOf((*MockTaskRepository)(nil).GetTaskByID), id)
+}
+
+// GetTaskStatusesByProjectID mocks base method.
+func (m *MockTaskRepository) GetTaskStatusesByProjectID(projectID uint) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskStatusesByProjectID", projectID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskStatusesByProjectID indicates an expected call of GetTaskStatusesByProjectID.
+func (mr *MockTaskRepositoryMockRecorder) GetTaskStatusesByProjectID(projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskStatusesByProjectID", reflect.TypeOf((*MockTaskRepository)(nil).GetTaskStatusesByProjectID), projectID)
+}
+
+// GetTasksAssignedTo mocks base method.
+func (m *MockTaskRepository) GetTasksAssignedTo(userID uint) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksAssignedTo", userID)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksAssignedTo indicates an expected call of GetTasksAssignedTo.
+func (mr *MockTaskRepositoryMockRecorder) GetTasksAssignedTo(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksAssignedTo", reflect.TypeOf((*MockTaskRepository)(nil).GetTasksAssignedTo), userID)
+}
+
+// GetTasksByProjectID mocks base method.
+func (m *MockTaskRepository) GetTasksByProjectID(projectID uint) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksByProjectID", projectID)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksByProjectID indicates an expected call of GetTasksByProjectID.
+func (mr *MockTaskRepositoryMockRecorder) GetTasksByProjectID(projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByProjectID", reflect.TypeOf((*MockTaskRepository)(nil).GetTasksByProjectID), projectID)
+}
+
+// GetTasksByUserID mocks base method.
+func (m *MockTaskRepository) GetTasksByUserID(userID uint, labels repositories.LabelFilter) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksByUserID", userID, labels)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksByUserID indicates an expected call of GetTasksByUserID.
+func (mr *MockTaskRepositoryMockRecorder) GetTasksByUserID(userID, labels interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByUserID", reflect.TypeOf((*MockTaskRepository)(nil).GetTasksByUserID), userID, labels)
+}
+
+// GetTasksWatchedBy mocks base method.
+func (m *MockTaskRepository) GetTasksWatchedBy(userID uint) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksWatchedBy", userID)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksWatchedBy indicates an expected call of GetTasksWatchedBy.
+func (mr *MockTaskRepositoryMockRecorder) GetTasksWatchedBy(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksWatchedBy", reflect.TypeOf((*MockTaskRepository)(nil).GetTasksWatchedBy), userID)
+}
+
+// MoveTaskCard mocks base method.
+func (m *MockTaskRepository) MoveTaskCard(task *models.Task, from string, position int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTaskCard", task, from, position)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveTaskCard indicates an expected call of MoveTaskCard.
+func (mr *MockTaskRepositoryMockRecorder) MoveTaskCard(task, from, position interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTaskCard", reflect.TypeOf((*MockTaskRepository)(nil).MoveTaskCard), task, from, position)
+}
+
+// QueryProjectTasks mocks base method.
+func (m *MockTaskRepository) QueryProjectTasks(projectID uint, filters []repositories.CustomFieldFilter, sort repositories.TaskSort) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryProjectTasks", projectID, filters, sort)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryProjectTasks indicates an expected call of QueryProjectTasks.
+func (mr *MockTaskRepositoryMockRecorder) QueryProjectTasks(projectID, filters, sort interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryProjectTasks", reflect.TypeOf((*MockTaskRepository)(nil).QueryProjectTasks), projectID, filters, sort)
+}
+
+// RemoveAssignee mocks base method.
+func (m *MockTaskRepository) RemoveAssignee(taskID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAssignee", taskID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAssignee indicates an expected call of RemoveAssignee.
+func (mr *MockTaskRepositoryMockRecorder) RemoveAssignee(taskID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAssignee", reflect.TypeOf((*MockTaskRepository)(nil).RemoveAssignee), taskID, userID)
+}
+
+// RemoveDependency mocks base method.
+func (m *MockTaskRepository) RemoveDependency(taskID, blockedByID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDependency", taskID, blockedByID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDependency indicates an expected call of RemoveDependency.
+func (mr *MockTaskRepositoryMockRecorder) RemoveDependency(taskID, blockedByID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDependency", reflect.TypeOf((*MockTaskRepository)(nil).RemoveDependency), taskID, blockedByID)
+}
+
+// RemoveWatcher mocks base method.
+func (m *MockTaskRepository) RemoveWatcher(taskID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveWatcher", taskID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveWatcher indicates an expected call of RemoveWatcher.
+func (mr *MockTaskRepositoryMockRecorder) RemoveWatcher(taskID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveWatcher", reflect.TypeOf((*MockTaskRepository)(nil).RemoveWatcher), taskID, userID)
+}
+
+// ReplaceCustomFieldValues mocks base method.
+func (m *MockTaskRepository) ReplaceCustomFieldValues(taskID uint, values []models.CustomFieldValue) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceCustomFieldValues", taskID, values)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceCustomFieldValues indicates an expected call of ReplaceCustomFieldValues.
+func (mr *MockTaskRepositoryMockRecorder) ReplaceCustomFieldValues(taskID, values interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceCustomFieldValues", reflect.TypeOf((*MockTaskRepository)(nil).ReplaceCustomFieldValues), taskID, values)
+}
+
+// SearchTasks mocks base method.
+func (m *MockTaskRepository) SearchTasks(userID uint, search *tql.Compiled, limit int) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTasks", userID, search, limit)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTasks indicates an expected call of SearchTasks.
+func (mr *MockTaskRepositoryMockRecorder) SearchTasks(userID, search, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTasks", reflect.TypeOf((*MockTaskRepository)(nil).SearchTasks), userID, search, limit)
+}
+
+// UpdateChecklistItem mocks base method.
+func (m *MockTaskRepository) UpdateChecklistItem(item *models.ChecklistItem) (*models.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChecklistItem", item)
+	ret0, _ := ret[0].(*models.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateChecklistItem indicates an expected call of UpdateChecklistItem.
+func (mr *MockTaskRepositoryMockRecorder) UpdateChecklistItem(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChecklistItem", reflect.TypeOf((*MockTaskRepository)(nil).UpdateChecklistItem), item)
+}
+
+// UpdateChecklistRanks mocks base method.
+func (m *MockTaskRepository) UpdateChecklistRanks(ranks map[uint]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChecklistRanks", ranks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateChecklistRanks indicates an expected call of UpdateChecklistRanks.
+func (mr *MockTaskRepositoryMockRecorder) UpdateChecklistRanks(ranks interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChecklistRanks", reflect.TypeOf((*MockTaskRepository)(nil).UpdateChecklistRanks), ranks)
+}
+
+// UpdateTask mocks base method.
+func (m *MockTaskRepository) UpdateTask(task *models.Task) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", task)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockTaskRepositoryMockRecorder) UpdateTask(task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskRepository)(nil).UpdateTask), task)
+}
+
+// UpdateTaskProgress mocks base method.
+func (m *MockTaskRepository) UpdateTaskProgress(id uint, progress int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskProgress", id, progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTaskProgress indicates an expected call of UpdateTaskProgress.
+func (mr *MockTaskRepositoryMockRecorder) UpdateTaskProgress(id, progress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskProgress", reflect.TypeOf((*MockTaskRepository)(nil).UpdateTaskProgress), id, progress)
+}
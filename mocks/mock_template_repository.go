@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repositories/template_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "TaskManager/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTemplateRepository is a mock of TemplateRepository interface.
+type MockTemplateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTemplateRepositoryMockRecorder
+}
+
+// MockTemplateRepositoryMockRecorder is the mock recorder for MockTemplateRepository.
+type MockTemplateRepositoryMockRecorder struct {
+	mock *MockTemplateRepository
+}
+
+// NewMockTemplateRepository creates a new mock instance.
+func NewMockTemplateRepository(ctrl *gomock.Controller) *MockTemplateRepository {
+	mock := &MockTemplateRepository{ctrl: ctrl}
+	mock.recorder = &MockTemplateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTemplateRepository) EXPECT() *MockTemplateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTemplate mocks base method.
+func (m *MockTemplateRepository) CreateTemplate(template *models.TaskTemplate) (*models.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTemplate", template)
+	ret0, _ := ret[0].(*models.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTemplate indicates an expected call of CreateTemplate.
+func (mr *MockTemplateRepositoryMockRecorder) CreateTemplate(template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplate", reflect.TypeOf((*MockTemplateRepository)(nil).CreateTemplate), template)
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockTemplateRepository) DeleteTemplate(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockTemplateRepositoryMockRecorder) DeleteTemplate(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockTemplateRepository)(nil).DeleteTemplate), id)
+}
+
+// GetTemplateByID mocks base method.
+func (m *MockTemplateRepository) GetTemplateByID(id uint) (*models.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateByID", id)
+	ret0, _ := ret[0].(*models.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateByID indicates an expected call of GetTemplateByID.
+func (mr *MockTemplateRepositoryMockRecorder) GetTemplateByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateByID", reflect.TypeOf((*MockTemplateRepository)(nil).GetTemplateByID), id)
+}
+
+// GetTemplatesByProjectID mocks base method.
+func (m *MockTemplateRepository) GetTemplatesByProjectID(projectID uint) ([]models.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplatesByProjectID", projectID)
+	ret0, _ := ret[0].([]models.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplatesByProjectID indicates an expected call of GetTemplatesByProjectID.
+func (mr *MockTemplateRepositoryMockRecorder) GetTemplatesByProjectID(projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplatesByProjectID", reflect.TypeOf((*MockTemplateRepository)(nil).GetTemplatesByProjectID), projectID)
+}
+
+// GetTemplatesByUserID mocks base method.
+func (m *MockTemplateRepository) GetTemplatesByUserID(userID uint) ([]models.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplatesByUserID", userID)
+	ret0, _ := ret[0].([]models.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplatesByUserID indicates an expected call of GetTemplatesByUserID.
+func (mr *MockTemplateRepositoryMockRecorder) GetTemplatesByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplatesByUserID", reflect.TypeOf((*MockTemplateRepository)(nil).GetTemplatesByUserID), userID)
+}
+
+// UpdateTemplate mocks base method.
+func (m *MockTemplateRepository) UpdateTemplate(template *models.TaskTemplate) (*models.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplate", template)
+	ret0, _ := ret[0].(*models.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTemplate indicates an expected call of UpdateTemplate.
+func (mr *MockTemplateRepositoryMockRecorder) UpdateTemplate(template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplate", reflect.TypeOf((*MockTemplateRepository)(nil).UpdateTemplate), template)
+}
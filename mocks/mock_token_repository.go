@@ -0,0 +1,330 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repositories/token_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "TaskManager/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTokenRepository is a mock of TokenRepository interface.
+type MockTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenRepositoryMockRecorder
+}
+
+// MockTokenRepositoryMockRecorder is the mock recorder for MockTokenRepository.
+type MockTokenRepositoryMockRecorder struct {
+	mock *MockTokenRepository
+}
+
+// NewMockTokenRepository creates a new mock instance.
+func NewMockTokenRepository(ctrl *gomock.Controller) *MockTokenRepository {
+	mock := &MockTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenRepository) EXPECT() *MockTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateEmailVerificationToken mocks base method.
+func (m *MockTokenRepository) CreateEmailVerificationToken(token *models.EmailVerificationToken) (*models.EmailVerificationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmailVerificationToken", token)
+	ret0, _ := ret[0].(*models.EmailVerificationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEmailVerificationToken indicates an expected call of CreateEmailVerificationToken.
+func (mr *MockTokenRepositoryMockRecorder) CreateEmailVerificationToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailVerificationToken", reflect.TypeOf((*MockTokenRepository)(nil).CreateEmailVerificationToken), token)
+}
+
+// CreatePasswordResetToken mocks base method.
+func (m *MockTokenRepository) CreatePasswordResetToken(token *models.PasswordResetToken) (*models.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordResetToken", token)
+	ret0, _ := ret[0].(*models.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePasswordResetToken indicates an expected call of CreatePasswordResetToken.
+func (mr *MockTokenRepositoryMockRecorder) CreatePasswordResetToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetToken", reflect.TypeOf((*MockTokenRepository)(nil).CreatePasswordResetToken), token)
+}
+
+// CreateSession mocks base method.
+func (m *MockTokenRepository) CreateSession(session *models.Session, token *models.RefreshToken) (*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", session, token)
+	ret0, _ := ret[0].(*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockTokenRepositoryMockRecorder) CreateSession(session, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockTokenRepository)(nil).CreateSession), session, token)
+}
+
+// GetActiveSessions mocks base method.
+func (m *MockTokenRepository) GetActiveSessions(userID uint) ([]models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveSessions", userID)
+	ret0, _ := ret[0].([]models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveSessions indicates an expected call of GetActiveSessions.
+func (mr *MockTokenRepositoryMockRecorder) GetActiveSessions(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveSessions", reflect.TypeOf((*MockTokenRepository)(nil).GetActiveSessions), userID)
+}
+
+// GetEmailVerificationTokenByHash mocks base method.
+func (m *MockTokenRepository) GetEmailVerificationTokenByHash(hash string) (*models.EmailVerificationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailVerificationTokenByHash", hash)
+	ret0, _ := ret[0].(*models.EmailVerificationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailVerificationTokenByHash indicates an expected call of GetEmailVerificationTokenByHash.
+func (mr *MockTokenRepositoryMockRecorder) GetEmailVerificationTokenByHash(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailVerificationTokenByHash", reflect.TypeOf((*MockTokenRepository)(nil).GetEmailVerificationTokenByHash), hash)
+}
+
+// GetLatestEmailVerificationToken mocks base method.
+func (m *MockTokenRepository) GetLatestEmailVerificationToken(userID uint) (*models.EmailVerificationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestEmailVerificationToken", userID)
+	ret0, _ := ret[0].(*models.EmailVerificationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestEmailVerificationToken indicates an expected call of GetLatestEmailVerificationToken.
+func (mr *MockTokenRepositoryMockRecorder) GetLatestEmailVerificationToken(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestEmailVerificationToken", reflect.TypeOf((*MockTokenRepository)(nil).GetLatestEmailVerificationToken), userID)
+}
+
+// GetPasswordResetTokenByHash mocks base method.
+func (m *MockTokenRepository) GetPasswordResetTokenByHash(hash string) (*models.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordResetTokenByHash", hash)
+	ret0, _ := ret[0].(*models.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordResetTokenByHash indicates an expected call of GetPasswordResetTokenByHash.
+func (mr *MockTokenRepositoryMockRecorder) GetPasswordResetTokenByHash(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordResetTokenByHash", reflect.TypeOf((*MockTokenRepository)(nil).GetPasswordResetTokenByHash), hash)
+}
+
+// GetRefreshTokenByHash mocks base method.
+func (m *MockTokenRepository) GetRefreshTokenByHash(hash string) (*models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshTokenByHash", hash)
+	ret0, _ := ret[0].(*models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefreshTokenByHash indicates an expected call of GetRefreshTokenByHash.
+func (mr *MockTokenRepositoryMockRecorder) GetRefreshTokenByHash(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshTokenByHash", reflect.TypeOf((*MockTokenRepository)(nil).GetRefreshTokenByHash), hash)
+}
+
+// GetSession mocks base method.
+func (m *MockTokenRepository) GetSession(id uint) (*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", id)
+	ret0, _ := ret[0].(*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockTokenRepositoryMockRecorder) GetSession(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockTokenRepository)(nil).GetSession), id)
+}
+
+// GetTokenVersion mocks base method.
+func (m *MockTokenRepository) GetTokenVersion(userID uint) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokenVersion", userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokenVersion indicates an expected call of GetTokenVersion.
+func (mr *MockTokenRepositoryMockRecorder) GetTokenVersion(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenVersion", reflect.TypeOf((*MockTokenRepository)(nil).GetTokenVersion), userID)
+}
+
+// IncrementTokenVersion mocks base method.
+func (m *MockTokenRepository) IncrementTokenVersion(userID uint) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementTokenVersion", userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementTokenVersion indicates an expected call of IncrementTokenVersion.
+func (mr *MockTokenRepositoryMockRecorder) IncrementTokenVersion(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementTokenVersion", reflect.TypeOf((*MockTokenRepository)(nil).IncrementTokenVersion), userID)
+}
+
+// IsAccessTokenRevoked mocks base method.
+func (m *MockTokenRepository) IsAccessTokenRevoked(id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAccessTokenRevoked", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAccessTokenRevoked indicates an expected call of IsAccessTokenRevoked.
+func (mr *MockTokenRepositoryMockRecorder) IsAccessTokenRevoked(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAccessTokenRevoked", reflect.TypeOf((*MockTokenRepository)(nil).IsAccessTokenRevoked), id)
+}
+
+// IsSessionRevoked mocks base method.
+func (m *MockTokenRepository) IsSessionRevoked(familyID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSessionRevoked", familyID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSessionRevoked indicates an expected call of IsSessionRevoked.
+func (mr *MockTokenRepositoryMockRecorder) IsSessionRevoked(familyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSessionRevoked", reflect.TypeOf((*MockTokenRepository)(nil).IsSessionRevoked), familyID)
+}
+
+// ResetPassword mocks base method.
+func (m *MockTokenRepository) ResetPassword(tokenID, userID uint, hashedPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", tokenID, userID, hashedPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockTokenRepositoryMockRecorder) ResetPassword(tokenID, userID, hashedPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockTokenRepository)(nil).ResetPassword), tokenID, userID, hashedPassword)
+}
+
+// RevokeAccessToken mocks base method.
+func (m *MockTokenRepository) RevokeAccessToken(token *models.RevokedToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAccessToken", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAccessToken indicates an expected call of RevokeAccessToken.
+func (mr *MockTokenRepositoryMockRecorder) RevokeAccessToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAccessToken", reflect.TypeOf((*MockTokenRepository)(nil).RevokeAccessToken), token)
+}
+
+// RevokeTokenFamily mocks base method.
+func (m *MockTokenRepository) RevokeTokenFamily(familyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeTokenFamily", familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeTokenFamily indicates an expected call of RevokeTokenFamily.
+func (mr *MockTokenRepositoryMockRecorder) RevokeTokenFamily(familyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTokenFamily", reflect.TypeOf((*MockTokenRepository)(nil).RevokeTokenFamily), familyID)
+}
+
+// RevokeUserTokenFamilies mocks base method.
+func (m *MockTokenRepository) RevokeUserTokenFamilies(userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserTokenFamilies", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserTokenFamilies indicates an expected call of RevokeUserTokenFamilies.
+func (mr *MockTokenRepositoryMockRecorder) RevokeUserTokenFamilies(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserTokenFamilies", reflect.TypeOf((*MockTokenRepository)(nil).RevokeUserTokenFamilies), userID)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockTokenRepository) RotateRefreshToken(usedID uint, next *models.RefreshToken) (*models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", usedID, next)
+	ret0, _ := ret[0].(*models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockTokenRepositoryMockRecorder) RotateRefreshToken(usedID, next interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockTokenRepository)(nil).RotateRefreshToken), usedID, next)
+}
+
+// TouchSession mocks base method.
+func (m *MockTokenRepository) TouchSession(familyID, ip string, seenAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchSession", familyID, ip, seenAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchSession indicates an expected call of TouchSession.
+func (mr *MockTokenRepositoryMockRecorder) TouchSession(familyID, ip, seenAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchSession", reflect.TypeOf((*MockTokenRepository)(nil).TouchSession), familyID, ip, seenAt)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockTokenRepository) VerifyEmail(tokenID, userID uint, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", tokenID, userID, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockTokenRepositoryMockRecorder) VerifyEmail(tokenID, userID, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockTokenRepository)(nil).VerifyEmail), tokenID, userID, email)
+}
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	models "TaskManager/internal/models"
+	query "TaskManager/pkg/query"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -64,18 +65,19 @@ func (mr *MockUserRepositoryMockRecorder) DeleteUser(id interface{}) *gomock.Cal
 }
 
 // GetAllUsers mocks base method.
-func (m *MockUserRepository) GetAllUsers() ([]models.User, error) {
+func (m *MockUserRepository) GetAllUsers(q *query.Query) ([]models.User, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllUsers")
+	ret := m.ctrl.Call(m, "GetAllUsers", q)
 	ret0, _ := ret[0].([]models.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAllUsers indicates an expected call of GetAllUsers.
-func (mr *MockUserRepositoryMockRecorder) GetAllUsers() *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) GetAllUsers(q interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockUserRepository)(nil).GetAllUsers))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockUserRepository)(nil).GetAllUsers), q)
 }
 
 // GetUserByEmail mocks base method.
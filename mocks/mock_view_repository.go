@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repositories/view_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "TaskManager/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockViewRepository is a mock of ViewRepository interface.
+type MockViewRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockViewRepositoryMockRecorder
+}
+
+// MockViewRepositoryMockRecorder is the mock recorder for MockViewRepository.
+type MockViewRepositoryMockRecorder struct {
+	mock *MockViewRepository
+}
+
+// NewMockViewRepository creates a new mock instance.
+func NewMockViewRepository(ctrl *gomock.Controller) *MockViewRepository {
+	mock := &MockViewRepository{ctrl: ctrl}
+	mock.recorder = &MockViewRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockViewRepository) EXPECT() *MockViewRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateView mocks base method.
+func (m *MockViewRepository) CreateView(view *models.SavedView) (*models.SavedView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateView", view)
+	ret0, _ := ret[0].(*models.SavedView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateView indicates an expected call of CreateView.
+func (mr *MockViewRepositoryMockRecorder) CreateView(view interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateView", reflect.TypeOf((*MockViewRepository)(nil).CreateView), view)
+}
+
+// DeleteView mocks base method.
+func (m *MockViewRepository) DeleteView(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteView", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteView indicates an expected call of DeleteView.
+func (mr *MockViewRepositoryMockRecorder) DeleteView(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteView", reflect.TypeOf((*MockViewRepository)(nil).DeleteView), id)
+}
+
+// GetViewByID mocks base method.
+func (m *MockViewRepository) GetViewByID(id uint) (*models.SavedView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetViewByID", id)
+	ret0, _ := ret[0].(*models.SavedView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetViewByID indicates an expected call of GetViewByID.
+func (mr *MockViewRepositoryMockRecorder) GetViewByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetViewByID", reflect.TypeOf((*MockViewRepository)(nil).GetViewByID), id)
+}
+
+// GetViewsForUser mocks base method.
+func (m *MockViewRepository) GetViewsForUser(userID uint) ([]models.SavedView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetViewsForUser", userID)
+	ret0, _ := ret[0].([]models.SavedView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetViewsForUser indicates an expected call of GetViewsForUser.
+func (mr *MockViewRepositoryMockRecorder) GetViewsForUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetViewsForUser", reflect.TypeOf((*MockViewRepository)(nil).GetViewsForUser), userID)
+}
+
+// UpdateView mocks base method.
+func (m *MockViewRepository) UpdateView(view *models.SavedView) (*models.SavedView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateView", view)
+	ret0, _ := ret[0].(*models.SavedView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateView indicates an expected call of UpdateView.
+func (mr *MockViewRepositoryMockRecorder) UpdateView(view interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateView", reflect.TypeOf((*MockViewRepository)(nil).UpdateView), view)
+}
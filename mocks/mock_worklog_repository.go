@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repositories/worklog_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "TaskManager/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWorklogRepository is a mock of WorklogRepository interface.
+type MockWorklogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorklogRepositoryMockRecorder
+}
+
+// MockWorklogRepositoryMockRecorder is the mock recorder for MockWorklogRepository.
+type MockWorklogRepositoryMockRecorder struct {
+	mock *MockWorklogRepository
+}
+
+// NewMockWorklogRepository creates a new mock instance.
+func NewMockWorklogRepository(ctrl *gomock.Controller) *MockWorklogRepository {
+	mock := &MockWorklogRepository{ctrl: ctrl}
+	mock.recorder = &MockWorklogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorklogRepository) EXPECT() *MockWorklogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateWorklog mocks base method.
+func (m *MockWorklogRepository) CreateWorklog(worklog *models.Worklog) (*models.Worklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorklog", worklog)
+	ret0, _ := ret[0].(*models.Worklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorklog indicates an expected call of CreateWorklog.
+func (mr *MockWorklogRepositoryMockRecorder) CreateWorklog(worklog interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorklog", reflect.TypeOf((*MockWorklogRepository)(nil).CreateWorklog), worklog)
+}
+
+// DeleteWorklog mocks base method.
+func (m *MockWorklogRepository) DeleteWorklog(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorklog", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWorklog indicates an expected call of DeleteWorklog.
+func (mr *MockWorklogRepositoryMockRecorder) DeleteWorklog(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorklog", reflect.TypeOf((*MockWorklogRepository)(nil).DeleteWorklog), id)
+}
+
+// GetLoggedSecondsByTask mocks base method.
+func (m *MockWorklogRepository) GetLoggedSecondsByTask(taskIDs []uint) (map[uint]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoggedSecondsByTask", taskIDs)
+	ret0, _ := ret[0].(map[uint]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoggedSecondsByTask indicates an expected call of GetLoggedSecondsByTask.
+func (mr *MockWorklogRepositoryMockRecorder) GetLoggedSecondsByTask(taskIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoggedSecondsByTask", reflect.TypeOf((*MockWorklogRepository)(nil).GetLoggedSecondsByTask), taskIDs)
+}
+
+// GetRunningWorklog mocks base method.
+func (m *MockWorklogRepository) GetRunningWorklog(userID uint) (*models.Worklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunningWorklog", userID)
+	ret0, _ := ret[0].(*models.Worklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunningWorklog indicates an expected call of GetRunningWorklog.
+func (mr *MockWorklogRepositoryMockRecorder) GetRunningWorklog(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningWorklog", reflect.TypeOf((*MockWorklogRepository)(nil).GetRunningWorklog), userID)
+}
+
+// GetWorklogByID mocks base method.
+func (m *MockWorklogRepository) GetWorklogByID(id uint) (*models.Worklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorklogByID", id)
+	ret0, _ := ret[0].(*models.Worklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorklogByID indicates an expected call of GetWorklogByID.
+func (mr *MockWorklogRepositoryMockRecorder) GetWorklogByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorklogByID", reflect.TypeOf((*MockWorklogRepository)(nil).GetWorklogByID), id)
+}
+
+// GetWorklogsByTaskID mocks base method.
+func (m *MockWorklogRepository) GetWorklogsByTaskID(taskID uint) ([]models.Worklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorklogsByTaskID", taskID)
+	ret0, _ := ret[0].([]models.Worklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorklogsByTaskID indicates an expected call of GetWorklogsByTaskID.
+func (mr *MockWorklogRepositoryMockRecorder) GetWorklogsByTaskID(taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorklogsByTaskID", reflect.TypeOf((*MockWorklogRepository)(nil).GetWorklogsByTaskID), taskID)
+}
+
+// GetWorklogsByUserBetween mocks base method.
+func (m *MockWorklogRepository) GetWorklogsByUserBetween(userID uint, from, to time.Time) ([]models.Worklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorklogsByUserBetween", userID, from, to)
+	ret0, _ := ret[0].([]models.Worklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorklogsByUserBetween indicates an expected call of GetWorklogsByUserBetween.
+func (mr *MockWorklogRepositoryMockRecorder) GetWorklogsByUserBetween(userID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorklogsByUserBetween", reflect.TypeOf((*MockWorklogRepository)(nil).GetWorklogsByUserBetween), userID, from, to)
+}
+
+// UpdateWorklog mocks base method.
+func (m *MockWorklogRepository) UpdateWorklog(worklog *models.Worklog) (*models.Worklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorklog", worklog)
+	ret0, _ := ret[0].(*models.Worklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWorklog indicates an expected call of UpdateWorklog.
+func (mr *MockWorklogRepositoryMockRecorder) UpdateWorklog(worklog interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorklog", reflect.TypeOf((*MockWorklogRepository)(nil).UpdateWorklog), worklog)
+}
@@ -0,0 +1,35 @@
+// Package blobstore stores opaque binary blobs, such as task attachments,
+// under caller-chosen keys. Keys may only use letters, digits, "-" and "_"
+// so they can't escape a store's root when mapped to file names.
+package blobstore
+
+import (
+	"errors"
+	"io"
+	"regexp"
+)
+
+var (
+	// ErrNotFound is returned for keys without a stored blob
+	ErrNotFound = errors.New("blobstore: blob not found")
+	// ErrInvalidKey is returned for keys outside the allowed alphabet
+	ErrInvalidKey = errors.New("blobstore: invalid key")
+)
+
+var keyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{2,128}$`)
+
+// BlobStore stores blobs by key. Put replaces any blob stored under the key
+// and only makes the blob visible once it was written completely.
+type BlobStore interface {
+	Put(key string, r io.Reader) (int64, error)
+	Open(key string) (io.ReadSeekCloser, error)
+	Delete(key string) error
+}
+
+// ValidateKey checks that key is usable as a blob key
+func ValidateKey(key string) error {
+	if !keyPattern.MatchString(key) {
+		return ErrInvalidKey
+	}
+	return nil
+}
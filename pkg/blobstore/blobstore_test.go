@@ -0,0 +1,85 @@
+package blobstore
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingReader returns some data and then an error
+type failingReader struct{ sent bool }
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.sent {
+		return 0, errors.New("connection reset")
+	}
+	r.sent = true
+	return copy(p, "partial"), nil
+}
+
+// testStore runs the behaviour every BlobStore implementation shares
+func testStore(t *testing.T, store BlobStore) {
+	n, err := store.Put("ab12", strings.NewReader("hello, world"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), n)
+
+	blob, err := store.Open("ab12")
+	require.NoError(t, err)
+	_, err = blob.Seek(7, io.SeekStart)
+	require.NoError(t, err)
+	rest, err := io.ReadAll(blob)
+	require.NoError(t, err)
+	assert.Equal(t, "world", string(rest))
+	require.NoError(t, blob.Close())
+
+	// Put replaces the blob
+	_, err = store.Put("ab12", strings.NewReader("bye"))
+	require.NoError(t, err)
+	blob, err = store.Open("ab12")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(blob)
+	blob.Close()
+	assert.Equal(t, "bye", string(data))
+
+	// A failed write leaves nothing behind
+	_, err = store.Put("cd34", &failingReader{})
+	assert.Error(t, err)
+	_, err = store.Open("cd34")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.Delete("ab12"))
+	_, err = store.Open("ab12")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, store.Delete("ab12"), ErrNotFound)
+
+	for _, key := range []string{"", "a", "../etc/passwd", "ab/cd", "ab.cd"} {
+		_, err := store.Put(key, strings.NewReader("x"))
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+	}
+}
+
+// Test function for MemoryStore
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+	testStore(t, store)
+	assert.Equal(t, 0, store.Len())
+}
+
+// Test function for LocalStore
+func TestLocalStore(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "blobs")
+	store, err := NewLocalStore(root)
+	require.NoError(t, err)
+	testStore(t, store)
+
+	// No temporary files are left over from the failed write
+	entries, err := os.ReadDir(filepath.Join(root, "cd"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,71 @@
+package blobstore
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// MemoryStore keeps blobs in memory. It is meant for tests and is safe for
+// concurrent use.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	blobs map[string][]byte
+}
+
+// NewMemoryStore returns an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{blobs: map[string][]byte{}}
+}
+
+// Put reads the whole blob and stores it; nothing is stored if reading fails
+func (s *MemoryStore) Put(key string, r io.Reader) (int64, error) {
+	if err := ValidateKey(key); err != nil {
+		return 0, err
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[key] = data
+	return int64(len(data)), nil
+}
+
+// Open returns a reader over the stored blob
+func (s *MemoryStore) Open(key string) (io.ReadSeekCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return nopCloser{bytes.NewReader(data)}, nil
+}
+
+// Delete removes the blob
+func (s *MemoryStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.blobs[key]; !ok {
+		return ErrNotFound
+	}
+	delete(s.blobs, key)
+	return nil
+}
+
+// Len returns the number of stored blobs
+func (s *MemoryStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.blobs)
+}
+
+// nopCloser adds a no-op Close to a bytes.Reader
+type nopCloser struct {
+	*bytes.Reader
+}
+
+func (nopCloser) Close() error { return nil }
@@ -0,0 +1,65 @@
+package mailer
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileMailer appends every message to a file instead of sending it, for
+// development and tests. It is safe for concurrent use.
+type FileMailer struct {
+	mu   sync.Mutex
+	path string
+	from string
+}
+
+// NewFileMailer returns a mailer appending messages to the file at path,
+// which is created along with its directory when missing
+func NewFileMailer(path, from string) *FileMailer {
+	return &FileMailer{path: path, from: from}
+}
+
+// Send appends the message to the file
+func (m *FileMailer) Send(msg Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(m.path), 0o750); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(m.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(format(m.from, msg, time.Now()), "\r\n"...)); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// LogMailer writes every message to a logger instead of sending it
+type LogMailer struct {
+	logger *log.Logger
+}
+
+// NewLogMailer returns a mailer logging messages to w
+func NewLogMailer(w io.Writer) *LogMailer {
+	return &LogMailer{logger: log.New(w, "mail: ", log.LstdFlags)}
+}
+
+// Send logs the recipient, subject and body of the message
+func (m *LogMailer) Send(msg Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	m.logger.Printf("to %s: %s\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
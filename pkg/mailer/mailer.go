@@ -0,0 +1,64 @@
+// Package mailer delivers plain-text mail, such as password reset links.
+// Messages are checked before they are sent so that neither addresses nor
+// subjects can inject headers.
+package mailer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidAddress is returned for recipients that aren't a single mail address
+	ErrInvalidAddress = errors.New("mailer: invalid address")
+	// ErrInvalidSubject is returned for subjects spanning several lines
+	ErrInvalidSubject = errors.New("mailer: invalid subject")
+)
+
+// Message is a plain-text mail to a single recipient
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends messages
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// Validate checks that a message can be sent safely
+func (msg Message) Validate() error {
+	address, err := mail.ParseAddress(msg.To)
+	if err != nil || address.Address != msg.To {
+		return ErrInvalidAddress
+	}
+	if strings.ContainsAny(msg.Subject, "\r\n") {
+		return ErrInvalidSubject
+	}
+	return nil
+}
+
+// format renders a message with its headers, lines ending in CRLF
+func format(from string, msg Message, date time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	body := strings.ReplaceAll(msg.Body, "\r\n", "\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	if !strings.HasSuffix(body, "\n") {
+		b.WriteString("\r\n")
+	}
+	return b.Bytes()
+}
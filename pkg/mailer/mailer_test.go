@@ -0,0 +1,145 @@
+package mailer
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test function for Message.Validate against header injection
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  Message
+		want error
+	}{
+		{name: "valid", msg: Message{To: "john@example.com", Subject: "Reset your password"}},
+		{name: "display name", msg: Message{To: "John <john@example.com>"}, want: ErrInvalidAddress},
+		{name: "several recipients", msg: Message{To: "john@example.com, jane@example.com"}, want: ErrInvalidAddress},
+		{name: "injected header", msg: Message{To: "john@example.com\r\nBcc: jane@example.com"}, want: ErrInvalidAddress},
+		{name: "multi-line subject", msg: Message{To: "john@example.com", Subject: "Hi\r\nBcc: jane@example.com"}, want: ErrInvalidSubject},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.msg.Validate())
+		})
+	}
+}
+
+// Test function for format
+func TestFormat(t *testing.T) {
+	date := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	data := format("noreply@example.com", Message{To: "john@example.com", Subject: "Größe", Body: "line 1\nline 2"}, date)
+
+	assert.Equal(t, "From: noreply@example.com\r\n"+
+		"To: john@example.com\r\n"+
+		"Subject: =?utf-8?q?Gr=C3=B6=C3=9Fe?=\r\n"+
+		"Date: Fri, 01 Mar 2024 12:00:00 +0000\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/plain; charset=utf-8\r\n"+
+		"Content-Transfer-Encoding: 8bit\r\n"+
+		"\r\n"+
+		"line 1\r\nline 2\r\n", string(data))
+}
+
+// Test function for FileMailer
+func TestFileMailer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail", "mail.log")
+	mailer := NewFileMailer(path, "noreply@example.com")
+
+	require.NoError(t, mailer.Send(Message{To: "john@example.com", Subject: "First", Body: "one"}))
+	require.NoError(t, mailer.Send(Message{To: "jane@example.com", Subject: "Second", Body: "two"}))
+	assert.ErrorIs(t, mailer.Send(Message{To: "not an address"}), ErrInvalidAddress)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(data), "From: noreply@example.com\r\n"))
+	assert.Contains(t, string(data), "To: john@example.com\r\nSubject: First\r\n")
+	assert.Contains(t, string(data), "To: jane@example.com\r\nSubject: Second\r\n")
+}
+
+// Test function for LogMailer
+func TestLogMailer(t *testing.T) {
+	var out bytes.Buffer
+	mailer := NewLogMailer(&out)
+
+	require.NoError(t, mailer.Send(Message{To: "john@example.com", Subject: "Hello", Body: "Body text"}))
+	assert.Contains(t, out.String(), "to john@example.com: Hello\nBody text")
+}
+
+// serveSMTP answers a single SMTP session on the listener, recording the
+// commands and message data it receives
+func serveSMTP(t *testing.T, listener net.Listener, received chan<- []string) {
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer conn.Close()
+
+	var lines []string
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+	reply("220 localhost ESMTP")
+	inData := false
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			break
+		}
+		line = strings.TrimRight(line, "\r\n")
+		lines = append(lines, line)
+		switch {
+		case inData && line == ".":
+			inData = false
+			reply("250 OK")
+		case inData:
+		case strings.HasPrefix(line, "EHLO"):
+			reply("250-localhost")
+			reply("250 8BITMIME")
+		case line == "DATA":
+			inData = true
+			reply("354 Go ahead")
+		case line == "QUIT":
+			reply("221 Bye")
+			received <- lines
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+	received <- lines
+}
+
+// Test function for SMTPMailer against a local server
+func TestSMTPMailer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	received := make(chan []string, 1)
+	go serveSMTP(t, listener, received)
+
+	port := listener.Addr().(*net.TCPAddr).Port
+	mailer := NewSMTPMailer("127.0.0.1", port, "", "", "TaskManager <noreply@example.com>")
+	require.NoError(t, mailer.Send(Message{To: "john@example.com", Subject: "Hello", Body: "Hi\n.\nBye"}))
+
+	lines := <-received
+	assert.Contains(t, lines, "MAIL FROM:<noreply@example.com> BODY=8BITMIME")
+	assert.Contains(t, lines, "RCPT TO:<john@example.com>")
+	assert.Contains(t, lines, "From: TaskManager <noreply@example.com>")
+	assert.Contains(t, lines, "Subject: Hello")
+	// Lines of the body starting with a dot are escaped
+	assert.Contains(t, lines, "..")
+
+	assert.ErrorIs(t, mailer.Send(Message{To: "john@example.com", Subject: "a\nb"}), ErrInvalidSubject)
+}
@@ -0,0 +1,33 @@
+package mailer
+
+import "sync"
+
+// MemoryMailer keeps the messages it is given. It is meant for tests and is
+// safe for concurrent use.
+type MemoryMailer struct {
+	mu   sync.Mutex
+	sent []Message
+}
+
+// NewMemoryMailer returns a mailer that hasn't sent anything
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+// Send keeps the message
+func (m *MemoryMailer) Send(msg Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// Sent returns the messages sent so far, oldest first
+func (m *MemoryMailer) Sent() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.sent...)
+}
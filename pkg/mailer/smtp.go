@@ -0,0 +1,46 @@
+package mailer
+
+import (
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTPMailer sends messages through an SMTP server, upgrading the
+// connection with STARTTLS when the server offers it. It logs in only when
+// given a username.
+type SMTPMailer struct {
+	addr   string
+	from   string
+	sender string
+	auth   smtp.Auth
+}
+
+// NewSMTPMailer returns a mailer sending as from, such as
+// "TaskManager <noreply@example.com>", through the server at host and port
+func NewSMTPMailer(host string, port int, username, password, from string) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	sender := from
+	if address, err := mail.ParseAddress(from); err == nil {
+		sender = address.Address
+	}
+	return &SMTPMailer{
+		addr:   net.JoinHostPort(host, strconv.Itoa(port)),
+		from:   from,
+		sender: sender,
+		auth:   auth,
+	}
+}
+
+// Send delivers the message to the server
+func (m *SMTPMailer) Send(msg Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	return smtp.SendMail(m.addr, m.auth, m.sender, []string{msg.To}, format(m.from, msg, time.Now()))
+}
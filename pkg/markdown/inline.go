@@ -0,0 +1,250 @@
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"strings"
+)
+
+// maxInlineDepth bounds nested emphasis and links; deeper markers are
+// shown as text
+const maxInlineDepth = 16
+
+// escapable lists the characters a backslash escapes
+const escapable = "\\`*_{}[]()#+-.!~>|@"
+
+// emphasis maps delimiters to the tags they produce, longest first
+var emphasis = []struct {
+	delim string
+	tag   string
+}{
+	{"**", "strong"},
+	{"__", "strong"},
+	{"~~", "del"},
+	{"*", "em"},
+}
+
+// renderInline renders the inline content of a block
+func renderInline(s string) string {
+	var b strings.Builder
+	inline(&b, s, 0)
+	return b.String()
+}
+
+// inline renders s into b, escaping everything that isn't recognised markup
+func inline(b *strings.Builder, s string, depth int) {
+	text := 0 // start of the pending plain text
+	flush := func(end int) {
+		b.WriteString(html.EscapeString(s[text:end]))
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && strings.IndexByte(escapable, s[i+1]) >= 0:
+			flush(i)
+			b.WriteString(html.EscapeString(s[i+1 : i+2]))
+			i += 2
+			text = i
+			continue
+
+		case c == '`':
+			if end, code, ok := codeSpan(s, i); ok {
+				flush(i)
+				b.WriteString("<code>" + html.EscapeString(code) + "</code>")
+				i, text = end, end
+				continue
+			}
+			// Skip the whole backtick run so it isn't retried one by one
+			for i < len(s) && s[i] == '`' {
+				i++
+			}
+			continue
+
+		case c == '[' && depth < maxInlineDepth:
+			if end, label, target, ok := link(s, i); ok {
+				flush(i)
+				if href, safe := safeURL(target); safe {
+					b.WriteString(`<a href="` + html.EscapeString(href) + `" rel="nofollow noopener noreferrer">`)
+					inline(b, label, depth+1)
+					b.WriteString("</a>")
+				} else {
+					inline(b, label, depth+1)
+				}
+				i, text = end, end
+				continue
+			}
+
+		case (c == '*' || c == '_' || c == '~') && depth < maxInlineDepth:
+			if end, tag, inner, ok := emphasised(s, i); ok {
+				flush(i)
+				b.WriteString("<" + tag + ">")
+				inline(b, inner, depth+1)
+				b.WriteString("</" + tag + ">")
+				i, text = end, end
+				continue
+			}
+		}
+		i++
+	}
+	flush(len(s))
+}
+
+// codeSpan matches a code span opening at i, closed by a backtick run of the
+// same length. It returns the index after the span and the code inside.
+func codeSpan(s string, i int) (int, string, bool) {
+	n := 0
+	for i+n < len(s) && s[i+n] == '`' {
+		n++
+	}
+	fence := s[i : i+n]
+	for j := i + n; j < len(s); {
+		k := strings.Index(s[j:], fence)
+		if k < 0 {
+			return 0, "", false
+		}
+		k += j
+		end := k + n
+		if end < len(s) && s[end] == '`' {
+			// A longer run doesn't close the span; skip past it
+			for end < len(s) && s[end] == '`' {
+				end++
+			}
+			j = end
+			continue
+		}
+		code := s[i+n : k]
+		if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' {
+			code = code[1 : len(code)-1]
+		}
+		return end, code, true
+	}
+	return 0, "", false
+}
+
+// link matches [label](target) opening at i. Brackets and parentheses may
+// nest in the label and target respectively.
+func link(s string, i int) (int, string, string, bool) {
+	depth := 0
+	j := i
+	for ; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			depth--
+		}
+		if depth == 0 {
+			break
+		}
+	}
+	if j >= len(s) || j+1 >= len(s) || s[j+1] != '(' {
+		return 0, "", "", false
+	}
+	label := s[i+1 : j]
+
+	depth = 0
+	k := j + 1
+	for ; k < len(s); k++ {
+		switch s[k] {
+		case '\\':
+			k++
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		if depth == 0 {
+			break
+		}
+	}
+	if k >= len(s) {
+		return 0, "", "", false
+	}
+	target := strings.TrimSpace(s[j+2 : k])
+	// Drop an optional title: [label](url "title")
+	if sp := strings.IndexAny(target, " \t"); sp >= 0 {
+		target = target[:sp]
+	}
+	target = strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+	return k + 1, label, target, label != ""
+}
+
+// emphasised matches an emphasis delimiter opening at i and its closing
+// delimiter. It returns the index after the closer, the tag and the inner text.
+func emphasised(s string, i int) (int, string, string, bool) {
+	for _, e := range emphasis {
+		if !strings.HasPrefix(s[i:], e.delim) {
+			continue
+		}
+		start := i + len(e.delim)
+		// The opener must be followed by text, not whitespace: "2 * 3" isn't emphasis
+		if start >= len(s) || s[start] == ' ' || s[start] == '\t' {
+			return 0, "", "", false
+		}
+		// Underscores inside words (snake_case) aren't delimiters
+		if e.delim == "__" && i > 0 && isWordByte(s[i-1]) {
+			return 0, "", "", false
+		}
+
+		for j := start + 1; j <= len(s)-len(e.delim); j++ {
+			if s[j-1] == '\\' {
+				continue
+			}
+			if !strings.HasPrefix(s[j:], e.delim) || s[j-1] == ' ' || s[j-1] == '\t' {
+				continue
+			}
+			// A single "*" mustn't close on half of a "**"
+			if e.delim == "*" && j+1 < len(s) && s[j+1] == '*' {
+				j++
+				continue
+			}
+			// Close at the end of a longer run so "**a *b***" nests
+			for len(e.delim) == 2 && j+2 < len(s) && s[j+2] == e.delim[0] {
+				j++
+			}
+			end := j + len(e.delim)
+			if e.delim == "__" && end < len(s) && isWordByte(s[end]) {
+				continue
+			}
+			return end, e.tag, s[start:j], true
+		}
+		return 0, "", "", false
+	}
+	return 0, "", "", false
+}
+
+// isWordByte reports whether c is an ASCII letter, digit or underscore
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// safeURL reports whether a link target may be used as an href: absolute
+// http, https and mailto URLs, and relative references
+func safeURL(target string) (string, bool) {
+	if target == "" {
+		return "", false
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return "", false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		return u.String(), u.Host != ""
+	case "mailto":
+		return u.String(), u.Opaque != ""
+	case "":
+		// Relative references can't smuggle a scheme past url.Parse, but a
+		// colon before the first slash would make browsers read one
+		if colon := strings.IndexByte(target, ':'); colon >= 0 {
+			if slash := strings.IndexByte(target, '/'); slash < 0 || colon < slash {
+				return "", false
+			}
+		}
+		return u.String(), true
+	}
+	return "", false
+}
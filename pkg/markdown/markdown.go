@@ -0,0 +1,178 @@
+// Package markdown renders the Markdown subset used in comments to HTML
+// that is safe to embed in a page. Safety comes from construction rather
+// than filtering: all input text is escaped and the only markup in the
+// output is the fixed set of tags the renderer emits itself.
+//
+// Supported blocks are paragraphs (single newlines become <br>), ATX
+// headings, fenced code blocks, block quotes, flat bullet and numbered
+// lists and horizontal rules. Inline, it supports code spans, **strong**,
+// *emphasis*, ~~strikethrough~~, [links](https://example.com) with
+// http, https, mailto or relative targets, and backslash escapes. Raw HTML
+// in the input is shown as text.
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxQuoteDepth bounds how deeply block quotes nest before further ">"
+// markers are shown as text
+const maxQuoteDepth = 8
+
+var (
+	headingPattern  = regexp.MustCompile(`^(#{1,6})(?:[ \t]+(.*?))?[ \t]*#*[ \t]*$`)
+	rulePattern     = regexp.MustCompile(`^(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	bulletPattern   = regexp.MustCompile(`^[-*+][ \t]+(.*)$`)
+	orderedPattern  = regexp.MustCompile(`^(\d{1,9})[.)][ \t]+(.*)$`)
+	fencePattern    = regexp.MustCompile("^(`{3,}|~{3,})[ \t]*([^`]*)$")
+	languagePattern = regexp.MustCompile(`^[A-Za-z0-9_+-]+$`)
+)
+
+// Render converts Markdown source to safe HTML
+func Render(src string) string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\r", "\n")
+
+	var b strings.Builder
+	renderBlocks(&b, strings.Split(src, "\n"), 0)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// renderBlocks renders a sequence of lines as block elements
+func renderBlocks(b *strings.Builder, lines []string, depth int) {
+	for i := 0; i < len(lines); {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimLeft(line, " ")
+		if len(line)-len(trimmed) >= 4 {
+			// Indented code isn't supported; treat the line as text
+			trimmed = strings.TrimLeft(trimmed, " \t")
+		}
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case fencePattern.MatchString(trimmed):
+			i = renderFence(b, lines, i, trimmed)
+
+		case headingPattern.MatchString(trimmed):
+			m := headingPattern.FindStringSubmatch(trimmed)
+			level := strconv.Itoa(len(m[1]))
+			b.WriteString("<h" + level + ">" + renderInline(m[2]) + "</h" + level + ">\n")
+			i++
+
+		case rulePattern.MatchString(trimmed):
+			b.WriteString("<hr>\n")
+			i++
+
+		case strings.HasPrefix(trimmed, ">") && depth < maxQuoteDepth:
+			var quoted []string
+			for ; i < len(lines); i++ {
+				l := strings.TrimLeft(lines[i], " ")
+				if !strings.HasPrefix(l, ">") {
+					break
+				}
+				l = strings.TrimPrefix(l, ">")
+				quoted = append(quoted, strings.TrimPrefix(l, " "))
+			}
+			b.WriteString("<blockquote>\n")
+			renderBlocks(b, quoted, depth+1)
+			b.WriteString("</blockquote>\n")
+
+		case bulletPattern.MatchString(trimmed):
+			i = renderList(b, lines, i, bulletPattern, "ul", 1)
+
+		case orderedPattern.MatchString(trimmed):
+			i = renderList(b, lines, i, orderedPattern, "ol", 2)
+
+		default:
+			i = renderParagraph(b, lines, i, depth)
+		}
+	}
+}
+
+// renderFence renders a fenced code block starting at line i and returns
+// the index of the line after it. An unclosed fence runs to the end.
+func renderFence(b *strings.Builder, lines []string, i int, opening string) int {
+	m := fencePattern.FindStringSubmatch(opening)
+	fence, info := m[1], strings.TrimSpace(m[2])
+	if fields := strings.Fields(info); len(fields) > 0 {
+		info = fields[0]
+	}
+
+	var code []string
+	for i++; i < len(lines); i++ {
+		l := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(l, fence) && strings.Trim(l, fence[:1]) == "" {
+			i++
+			break
+		}
+		code = append(code, lines[i])
+	}
+
+	b.WriteString("<pre><code")
+	if languagePattern.MatchString(info) {
+		b.WriteString(` class="language-` + info + `"`)
+	}
+	b.WriteString(">")
+	for _, l := range code {
+		b.WriteString(html.EscapeString(l) + "\n")
+	}
+	b.WriteString("</code></pre>\n")
+	return i
+}
+
+// renderList renders consecutive items matching pattern as one list and
+// returns the index of the line after it. group is the submatch holding
+// the item text.
+func renderList(b *strings.Builder, lines []string, i int, pattern *regexp.Regexp, tag string, group int) int {
+	b.WriteString("<" + tag)
+	if tag == "ol" {
+		m := pattern.FindStringSubmatch(strings.TrimSpace(lines[i]))
+		if start, err := strconv.Atoi(m[1]); err == nil && start != 1 {
+			b.WriteString(` start="` + strconv.Itoa(start) + `"`)
+		}
+	}
+	b.WriteString(">\n")
+
+	for ; i < len(lines); i++ {
+		m := pattern.FindStringSubmatch(strings.TrimSpace(lines[i]))
+		if m == nil {
+			break
+		}
+		b.WriteString("<li>" + renderInline(m[group]) + "</li>\n")
+	}
+	b.WriteString("</" + tag + ">\n")
+	return i
+}
+
+// renderParagraph renders lines up to the next blank line or block start as
+// one paragraph and returns the index of the line after it
+func renderParagraph(b *strings.Builder, lines []string, i, depth int) int {
+	var text []string
+	for ; i < len(lines); i++ {
+		l := strings.TrimSpace(lines[i])
+		if l == "" {
+			break
+		}
+		if len(text) > 0 && startsBlock(l, depth) {
+			break
+		}
+		text = append(text, renderInline(l))
+	}
+	b.WriteString("<p>" + strings.Join(text, "<br>\n") + "</p>\n")
+	return i
+}
+
+// startsBlock reports whether a line interrupts a paragraph
+func startsBlock(line string, depth int) bool {
+	return fencePattern.MatchString(line) ||
+		headingPattern.MatchString(line) ||
+		rulePattern.MatchString(line) ||
+		(strings.HasPrefix(line, ">") && depth < maxQuoteDepth) ||
+		bulletPattern.MatchString(line) ||
+		orderedPattern.MatchString(line)
+}
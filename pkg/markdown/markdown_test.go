@@ -0,0 +1,116 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test function for Render on each supported construct
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{name: "paragraph with line break", src: "first\nsecond", want: "<p>first<br>\nsecond</p>"},
+		{name: "paragraphs", src: "one\n\ntwo", want: "<p>one</p>\n<p>two</p>"},
+		{name: "heading", src: "## Plan ##", want: "<h2>Plan</h2>"},
+		{name: "hashtag is not a heading", src: "#hashtag", want: "<p>#hashtag</p>"},
+		{name: "emphasis", src: "**bold** and *it* and ~~gone~~", want: "<p><strong>bold</strong> and <em>it</em> and <del>gone</del></p>"},
+		{name: "nested emphasis", src: "**very *much***", want: "<p><strong>very <em>much</em></strong></p>"},
+		{name: "arithmetic is not emphasis", src: "2 * 3 * 4", want: "<p>2 * 3 * 4</p>"},
+		{name: "snake_case", src: "use my__var__name", want: "<p>use my__var__name</p>"},
+		{name: "code span", src: "run `a <b> *c*`", want: "<p>run <code>a &lt;b&gt; *c*</code></p>"},
+		{name: "double backtick span", src: "``a ` b``", want: "<p><code>a ` b</code></p>"},
+		{name: "escapes", src: `\*not em\*`, want: "<p>*not em*</p>"},
+		{name: "link", src: "[docs](https://example.com/a?b=1&c=2)", want: `<p><a href="https://example.com/a?b=1&amp;c=2" rel="nofollow noopener noreferrer">docs</a></p>`},
+		{name: "link with title", src: `[x](/tasks/4 "Task")`, want: `<p><a href="/tasks/4" rel="nofollow noopener noreferrer">x</a></p>`},
+		{name: "mailto link", src: "[mail](mailto:a@example.com)", want: `<p><a href="mailto:a@example.com" rel="nofollow noopener noreferrer">mail</a></p>`},
+		{name: "bullet list", src: "- one\n- **two**", want: "<ul>\n<li>one</li>\n<li><strong>two</strong></li>\n</ul>"},
+		{name: "ordered list", src: "3. c\n4. d", want: "<ol start=\"3\">\n<li>c</li>\n<li>d</li>\n</ol>"},
+		{name: "list interrupts paragraph", src: "steps:\n1. a", want: "<p>steps:</p>\n<ol>\n<li>a</li>\n</ol>"},
+		{name: "quote", src: "> said\n> this", want: "<blockquote>\n<p>said<br>\nthis</p>\n</blockquote>"},
+		{name: "rule", src: "a\n\n---\n\nb", want: "<p>a</p>\n<hr>\n<p>b</p>"},
+		{name: "fenced code", src: "```go\nif a < b {}\n```", want: "<pre><code class=\"language-go\">if a &lt; b {}\n</code></pre>"},
+		{name: "unclosed fence", src: "~~~\nx", want: "<pre><code>x\n</code></pre>"},
+		{name: "windows newlines", src: "a\r\nb", want: "<p>a<br>\nb</p>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Render(tt.src))
+		})
+	}
+}
+
+// Test function for Render on hostile input
+func TestRender_Sanitises(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{name: "raw html", src: `<script>alert(1)</script>`, want: "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>"},
+		{name: "attribute breakout", src: `[x](https://a.com/"onmouseover="alert(1))`, want: `<p><a href="https://a.com/%22onmouseover=%22alert%281%29" rel="nofollow noopener noreferrer">x</a></p>`},
+		{name: "javascript url", src: "[x](javascript:alert(1))", want: "<p>x</p>"},
+		{name: "mixed case scheme", src: "[x](JaVaScRiPt:alert(1))", want: "<p>x</p>"},
+		{name: "data url", src: "[x](data:text/html;base64,PHNjcmlwdD4=)", want: "<p>x</p>"},
+		{name: "entity encoded scheme", src: "[x](javascript&#58;alert(1))", want: `<p><a href="javascript&amp;#58;alert(1)" rel="nofollow noopener noreferrer">x</a></p>`},
+		{name: "control character", src: "[x](java\x01script:alert(1))", want: "<p>x</p>"},
+		{name: "scheme-relative host", src: "[x](//evil.com)", want: `<p><a href="//evil.com" rel="nofollow noopener noreferrer">x</a></p>`},
+		{name: "code language", src: "```\"><script>\nx\n```", want: "<pre><code>x\n</code></pre>"},
+		{name: "html in link label", src: "[<b>x</b>](/a)", want: `<p><a href="/a" rel="nofollow noopener noreferrer">&lt;b&gt;x&lt;/b&gt;</a></p>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Render(tt.src))
+		})
+	}
+}
+
+// Test function for Render on deeply nested input
+func TestRender_DeepNesting(t *testing.T) {
+	quotes := Render(strings.Repeat(">", 100) + " deep")
+	assert.Equal(t, maxQuoteDepth, strings.Count(quotes, "<blockquote>"))
+
+	links := Render(strings.Repeat("[", 100) + "x" + strings.Repeat("](/a)", 100))
+	assert.LessOrEqual(t, strings.Count(links, "<a "), maxInlineDepth)
+	assert.NotContains(t, links, "<script")
+}
+
+// Test function for Mentions
+func TestMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{name: "plain", src: "@alice can you look, cc @bob.", want: []string{"alice", "bob"}},
+		{name: "start of line and punctuation", src: "(@carol) @dave, thanks", want: []string{"carol", "dave"}},
+		{name: "dotted name", src: "ping @jane.doe-2!", want: []string{"jane.doe-2"}},
+		{name: "duplicates ignore case", src: "@Bob and @bob", want: []string{"Bob"}},
+		{name: "email address", src: "mail bob@example.com", want: nil},
+		{name: "escaped", src: `\@bob`, want: nil},
+		{name: "code span", src: "run `@decorator` for @erin", want: []string{"erin"}},
+		{name: "code block", src: "```\n@Override\n```\n@frank", want: []string{"frank"}},
+		{name: "lone at", src: "meet @ 5", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Mentions(tt.src))
+		})
+	}
+}
+
+// Test function for the Mentions cap
+func TestMentions_Capped(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < MaxMentions+10; i++ {
+		b.WriteString("@user" + strings.Repeat("x", i) + " ")
+	}
+	assert.Len(t, Mentions(b.String()), MaxMentions)
+}
@@ -0,0 +1,78 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MaxMentions caps how many distinct usernames Mentions returns
+const MaxMentions = 50
+
+// mentionPattern matches @username preceded by start of text or a character
+// that can't be part of an e-mail address or another mention
+var mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_.@\\])@([A-Za-z0-9_][A-Za-z0-9_.-]*)`)
+
+// Mentions returns the distinct usernames mentioned as @username, in order
+// of first appearance. Mentions inside code spans and code blocks are
+// ignored, as are trailing dots and dashes ("thanks @bob.").
+func Mentions(src string) []string {
+	seen := map[string]bool{}
+	var names []string
+
+	for _, line := range visibleText(src) {
+		for _, m := range mentionPattern.FindAllStringSubmatch(line, -1) {
+			name := strings.TrimRight(m[1], ".-")
+			key := strings.ToLower(name)
+			if name == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			names = append(names, name)
+			if len(names) == MaxMentions {
+				return names
+			}
+		}
+	}
+	return names
+}
+
+// visibleText returns the lines of src with fenced code blocks dropped and
+// code spans blanked out
+func visibleText(src string) []string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+
+	var lines []string
+	fence := ""
+	for _, line := range strings.Split(src, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+				fence = ""
+			}
+			continue
+		}
+		if m := fencePattern.FindStringSubmatch(trimmed); m != nil {
+			fence = m[1]
+			continue
+		}
+		lines = append(lines, stripCodeSpans(line))
+	}
+	return lines
+}
+
+// stripCodeSpans replaces the code spans of a line with a space
+func stripCodeSpans(line string) string {
+	var b strings.Builder
+	for i := 0; i < len(line); {
+		if line[i] == '`' {
+			if end, _, ok := codeSpan(line, i); ok {
+				b.WriteByte(' ')
+				i = end
+				continue
+			}
+		}
+		b.WriteByte(line[i])
+		i++
+	}
+	return b.String()
+}
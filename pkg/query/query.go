@@ -0,0 +1,450 @@
+// Package query parses the filtering, sorting and pagination parameters of
+// list endpoints and applies them to GORM queries.
+//
+// Filters are given as field=value or field.op=value with the operators eq,
+// ne, lt, gt, in (comma-separated values) and contains, e.g.
+// ?username.contains=ann&created_at.gt=2024-01-01. sort takes a
+// comma-separated list of fields, each prefixed with "-" for descending
+// order. Pages are addressed with opaque cursors (keyset pagination), so a
+// page stays stable while rows are added and deep pages cost as little as
+// the first one.
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Names of the reserved parameters
+const (
+	ParamLimit  = "limit"
+	ParamCursor = "cursor"
+	ParamSort   = "sort"
+)
+
+// MaxInValues caps the values of an "in" filter
+const MaxInValues = 100
+
+// ErrInvalidQuery is matched by every *Error
+var ErrInvalidQuery = errors.New("query: invalid list parameters")
+
+// Type is the type of a field's values
+type Type int
+
+// Field types
+const (
+	String Type = iota
+	Int
+	Time
+	Bool
+)
+
+// Op is a filter operator
+type Op string
+
+// Filter operators
+const (
+	Eq       Op = "eq"
+	Ne       Op = "ne"
+	Lt       Op = "lt"
+	Gt       Op = "gt"
+	In       Op = "in"
+	Contains Op = "contains"
+)
+
+// Field describes a field of a list. Column is its SQL column and Struct
+// the Go field of the listed model holding its value, which cursors are
+// built from. Sort fields must not be NULL.
+type Field struct {
+	Column   string
+	Struct   string
+	Type     Type
+	Sortable bool
+	Ops      []Op
+}
+
+// allows reports whether the field may be filtered with op
+func (f Field) allows(op Op) bool {
+	for _, allowed := range f.Ops {
+		if allowed == op {
+			return true
+		}
+	}
+	return false
+}
+
+// Spec describes the fields of a list and its paging defaults. Key names a
+// unique field that breaks ties between rows sorting equally.
+type Spec struct {
+	Fields       map[string]Field
+	Key          string
+	DefaultSort  []Sort
+	DefaultLimit int
+	MaxLimit     int
+}
+
+// Sort orders a list by a field
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Filter narrows a list to rows whose field compares to one of Values
+type Filter struct {
+	Field  string
+	Op     Op
+	Values []interface{}
+}
+
+// Query is a parsed list request
+type Query struct {
+	Filters []Filter
+	Sort    []Sort
+	Limit   int
+
+	spec  Spec
+	after []interface{}
+}
+
+// Error reports invalid list parameters, keyed by parameter name
+type Error struct {
+	Params map[string]string
+}
+
+func (e *Error) Error() string {
+	keys := make([]string, 0, len(e.Params))
+	for key := range e.Params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+" "+e.Params[key])
+	}
+	return ErrInvalidQuery.Error() + ": " + strings.Join(parts, "; ")
+}
+
+// Unwrap lets callers match the error with ErrInvalidQuery
+func (e *Error) Unwrap() error {
+	return ErrInvalidQuery
+}
+
+// cursor is the content of an encoded cursor: the sort it belongs to and
+// the sort values of the last row of the previous page
+type cursor struct {
+	Sort   string            `json:"s"`
+	Values []json.RawMessage `json:"v"`
+}
+
+// Parse reads the list parameters of a request. Parameters that aren't
+// fields of the spec are left to the caller; limits above the spec's
+// maximum are lowered to it.
+func Parse(values url.Values, spec Spec) (*Query, error) {
+	q := &Query{Limit: spec.DefaultLimit, spec: spec}
+	problems := map[string]string{}
+
+	if raw := values.Get(ParamLimit); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			problems[ParamLimit] = "must be a positive number"
+		} else {
+			q.Limit = limit
+		}
+	}
+	if q.Limit > spec.MaxLimit {
+		q.Limit = spec.MaxLimit
+	}
+
+	if problem := q.parseSort(values.Get(ParamSort)); problem != "" {
+		problems[ParamSort] = problem
+	}
+
+	// Filters in a stable order, so equal requests build equal SQL
+	params := make([]string, 0, len(values))
+	for param := range values {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+	for _, param := range params {
+		name, op := param, Eq
+		if i := strings.IndexByte(param, '.'); i >= 0 {
+			name, op = param[:i], Op(param[i+1:])
+		}
+		field, ok := spec.Fields[name]
+		if !ok {
+			continue
+		}
+		if !field.allows(op) {
+			problems[param] = fmt.Sprintf("can't be filtered with %q", op)
+			continue
+		}
+		for _, raw := range values[param] {
+			filter, problem := parseFilter(name, field, op, raw)
+			if problem != "" {
+				problems[param] = problem
+				break
+			}
+			q.Filters = append(q.Filters, filter)
+		}
+	}
+
+	// The cursor only makes sense for the sort it was issued for
+	if raw := values.Get(ParamCursor); raw != "" && problems[ParamSort] == "" {
+		if err := q.decodeCursor(raw); err != nil {
+			problems[ParamCursor] = "is invalid or belongs to another sort order"
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, &Error{Params: problems}
+	}
+	return q, nil
+}
+
+// parseSort reads the sort parameter, ending the order with the key field
+// (internal helper)
+func (q *Query) parseSort(raw string) string {
+	q.Sort = nil
+	if raw == "" {
+		q.Sort = append(q.Sort, q.spec.DefaultSort...)
+	}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		s := Sort{Field: strings.TrimPrefix(part, "-")}
+		s.Desc = s.Field != part
+		field, ok := q.spec.Fields[s.Field]
+		if !ok || !field.Sortable {
+			return fmt.Sprintf("can't sort by %q", s.Field)
+		}
+		if seen[s.Field] {
+			return fmt.Sprintf("names %q twice", s.Field)
+		}
+		seen[s.Field] = true
+		q.Sort = append(q.Sort, s)
+	}
+
+	for _, s := range q.Sort {
+		if s.Field == q.spec.Key {
+			return ""
+		}
+	}
+	q.Sort = append(q.Sort, Sort{Field: q.spec.Key})
+	return ""
+}
+
+// parseFilter converts a filter parameter's value to the field's type (internal helper)
+func parseFilter(name string, field Field, op Op, raw string) (Filter, string) {
+	filter := Filter{Field: name, Op: op}
+	parts := []string{raw}
+	if op == In {
+		parts = strings.Split(raw, ",")
+		if len(parts) > MaxInValues {
+			return filter, fmt.Sprintf("takes at most %d values", MaxInValues)
+		}
+	}
+	for _, part := range parts {
+		value, err := parseValue(field.Type, strings.TrimSpace(part))
+		if err != nil {
+			return filter, err.Error()
+		}
+		filter.Values = append(filter.Values, value)
+	}
+	return filter, ""
+}
+
+// parseValue converts a parameter value to a field type (internal helper)
+func parseValue(t Type, raw string) (interface{}, error) {
+	switch t {
+	case Int:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, errors.New("must be a whole number")
+		}
+		return n, nil
+	case Time:
+		if date, err := time.Parse("2006-01-02", raw); err == nil {
+			return date, nil
+		}
+		ts, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, errors.New("must be a date like 2024-01-31 or an RFC 3339 time")
+		}
+		return ts, nil
+	case Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errors.New("must be true or false")
+		}
+		return b, nil
+	default:
+		return raw, nil
+	}
+}
+
+// signature identifies the sort order a cursor was issued for (internal helper)
+func (q *Query) signature() string {
+	parts := make([]string, 0, len(q.Sort))
+	for _, s := range q.Sort {
+		if s.Desc {
+			parts = append(parts, "-"+s.Field)
+		} else {
+			parts = append(parts, s.Field)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// decodeCursor reads the position a cursor points after (internal helper)
+func (q *Query) decodeCursor(raw string) error {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return err
+	}
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+	if c.Sort != q.signature() || len(c.Values) != len(q.Sort) {
+		return errors.New("cursor of another sort order")
+	}
+
+	q.after = make([]interface{}, len(q.Sort))
+	for i, s := range q.Sort {
+		var value interface{}
+		switch q.spec.Fields[s.Field].Type {
+		case Int:
+			var n int64
+			err = json.Unmarshal(c.Values[i], &n)
+			value = n
+		case Time:
+			var ts time.Time
+			err = json.Unmarshal(c.Values[i], &ts)
+			value = ts
+		case Bool:
+			var b bool
+			err = json.Unmarshal(c.Values[i], &b)
+			value = b
+		default:
+			var str string
+			err = json.Unmarshal(c.Values[i], &str)
+			value = str
+		}
+		if err != nil {
+			return err
+		}
+		q.after[i] = value
+	}
+	return nil
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// Apply adds the filters, the position of the cursor, the order and the
+// limit to db. One row more than the limit is fetched so Page can tell
+// whether another page follows.
+func (q *Query) Apply(db *gorm.DB) *gorm.DB {
+	for _, filter := range q.Filters {
+		column := q.spec.Fields[filter.Field].Column
+		switch filter.Op {
+		case Ne:
+			db = db.Where(column+" <> ?", filter.Values[0])
+		case Lt:
+			db = db.Where(column+" < ?", filter.Values[0])
+		case Gt:
+			db = db.Where(column+" > ?", filter.Values[0])
+		case In:
+			db = db.Where(column+" IN ?", filter.Values)
+		case Contains:
+			pattern := "%" + escapeLike(strings.ToLower(fmt.Sprint(filter.Values[0]))) + "%"
+			db = db.Where("LOWER("+column+`) LIKE ? ESCAPE '\'`, pattern)
+		default:
+			db = db.Where(column+" = ?", filter.Values[0])
+		}
+	}
+
+	// Rows after the cursor: (a > x) OR (a = x AND b > y) OR ..., with < for descending fields
+	if q.after != nil {
+		var alternatives []string
+		var args []interface{}
+		for i, s := range q.Sort {
+			var conds []string
+			for j := 0; j < i; j++ {
+				conds = append(conds, q.spec.Fields[q.Sort[j].Field].Column+" = ?")
+				args = append(args, q.after[j])
+			}
+			op := " > ?"
+			if s.Desc {
+				op = " < ?"
+			}
+			conds = append(conds, q.spec.Fields[s.Field].Column+op)
+			args = append(args, q.after[i])
+			alternatives = append(alternatives, "("+strings.Join(conds, " AND ")+")")
+		}
+		db = db.Where("("+strings.Join(alternatives, " OR ")+")", args...)
+	}
+
+	for _, s := range q.Sort {
+		direction := " ASC"
+		if s.Desc {
+			direction = " DESC"
+		}
+		db = db.Order(q.spec.Fields[s.Field].Column + direction)
+	}
+	return db.Limit(q.Limit + 1)
+}
+
+// Page looks at the rows fetched with Apply, a slice of the listed model,
+// and returns how many of them belong to the page together with the cursor
+// of the next page; the cursor is empty on the last page
+func (q *Query) Page(rows interface{}) (int, string, error) {
+	v := reflect.ValueOf(rows)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice {
+		return 0, "", fmt.Errorf("query: rows must be a slice, not %s", v.Kind())
+	}
+	if v.Len() <= q.Limit {
+		return v.Len(), "", nil
+	}
+
+	last := v.Index(q.Limit - 1)
+	for last.Kind() == reflect.Ptr {
+		last = last.Elem()
+	}
+	c := cursor{Sort: q.signature()}
+	for _, s := range q.Sort {
+		field := last.FieldByName(q.spec.Fields[s.Field].Struct)
+		if !field.IsValid() {
+			return 0, "", fmt.Errorf("query: %s has no field %s", last.Type(), q.spec.Fields[s.Field].Struct)
+		}
+		value, err := json.Marshal(field.Interface())
+		if err != nil {
+			return 0, "", err
+		}
+		c.Values = append(c.Values, value)
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return 0, "", err
+	}
+	return q.Limit, base64.RawURLEncoding.EncodeToString(data), nil
+}
@@ -0,0 +1,174 @@
+package query
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type item struct {
+	ID        uint
+	Name      string
+	CreatedAt time.Time
+}
+
+var testSpec = Spec{
+	Fields: map[string]Field{
+		"id":         {Column: "id", Struct: "ID", Type: Int, Sortable: true, Ops: []Op{Eq, Lt, Gt, In}},
+		"name":       {Column: "name", Struct: "Name", Type: String, Sortable: true, Ops: []Op{Eq, Ne, Contains}},
+		"created_at": {Column: "created_at", Struct: "CreatedAt", Type: Time, Sortable: true, Ops: []Op{Lt, Gt}},
+		"archived":   {Column: "archived", Type: Bool, Ops: []Op{Eq}},
+	},
+	Key:          "id",
+	DefaultSort:  []Sort{{Field: "id"}},
+	DefaultLimit: 20,
+	MaxLimit:     50,
+}
+
+// Test function for Parse on valid and invalid list parameters
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      string
+		wantLimit   int
+		wantSort    []Sort
+		wantFilters []Filter
+		wantErr     []string
+	}{
+		{name: "defaults", params: "", wantLimit: 20, wantSort: []Sort{{Field: "id"}}},
+		{name: "limit", params: "limit=5", wantLimit: 5, wantSort: []Sort{{Field: "id"}}},
+		{name: "limit capped", params: "limit=1000", wantLimit: 50, wantSort: []Sort{{Field: "id"}}},
+		{name: "sort gets key tiebreaker", params: "sort=-created_at,name", wantLimit: 20,
+			wantSort: []Sort{{Field: "created_at", Desc: true}, {Field: "name"}, {Field: "id"}}},
+		{name: "sort by key", params: "sort=-id", wantLimit: 20, wantSort: []Sort{{Field: "id", Desc: true}}},
+		{name: "filters", params: "name.contains=an&id.in=1,2,3&archived=true", wantLimit: 20, wantSort: []Sort{{Field: "id"}},
+			wantFilters: []Filter{
+				{Field: "archived", Op: Eq, Values: []interface{}{true}},
+				{Field: "id", Op: In, Values: []interface{}{int64(1), int64(2), int64(3)}},
+				{Field: "name", Op: Contains, Values: []interface{}{"an"}},
+			}},
+		{name: "date filter", params: "created_at.gt=2024-01-31", wantLimit: 20, wantSort: []Sort{{Field: "id"}},
+			wantFilters: []Filter{{Field: "created_at", Op: Gt, Values: []interface{}{time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)}}}},
+		{name: "unknown params ignored", params: "page=2&foo.bar=1", wantLimit: 20, wantSort: []Sort{{Field: "id"}}},
+		{name: "bad limit", params: "limit=0", wantErr: []string{"limit"}},
+		{name: "unknown sort field", params: "sort=archived", wantErr: []string{"sort"}},
+		{name: "repeated sort field", params: "sort=name,-name", wantErr: []string{"sort"}},
+		{name: "op not allowed", params: "created_at=2024-01-01&name.lt=b", wantErr: []string{"created_at", "name.lt"}},
+		{name: "bad values", params: "id=abc&created_at.lt=yesterday&archived=maybe", wantErr: []string{"id", "created_at.lt", "archived"}},
+		{name: "bad cursor", params: "cursor=not-a-cursor", wantErr: []string{"cursor"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.params)
+			require.NoError(t, err)
+
+			q, err := Parse(values, testSpec)
+			if tt.wantErr != nil {
+				var queryErr *Error
+				require.ErrorAs(t, err, &queryErr)
+				assert.ErrorIs(t, err, ErrInvalidQuery)
+				assert.Len(t, queryErr.Params, len(tt.wantErr))
+				for _, param := range tt.wantErr {
+					assert.Contains(t, queryErr.Params, param)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLimit, q.Limit)
+			assert.Equal(t, tt.wantSort, q.Sort)
+			assert.Equal(t, tt.wantFilters, q.Filters)
+		})
+	}
+}
+
+// Test function for Page and the cursor it returns
+func TestPageCursor(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 30, 0, 123456000, time.UTC)
+	rows := []item{
+		{ID: 9, Name: "b", CreatedAt: created},
+		{ID: 4, Name: "a", CreatedAt: created},
+		{ID: 7, Name: "c", CreatedAt: created.Add(-time.Hour)},
+	}
+
+	q, err := Parse(url.Values{"sort": {"-created_at"}, "limit": {"2"}}, testSpec)
+	require.NoError(t, err)
+
+	n, next, err := q.Page(rows)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	require.NotEmpty(t, next)
+
+	// The cursor points after the last row of the page
+	following, err := Parse(url.Values{"sort": {"-created_at"}, "limit": {"2"}, "cursor": {next}}, testSpec)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{created, int64(4)}, following.after)
+
+	// A cursor only fits the sort it was issued for
+	_, err = Parse(url.Values{"sort": {"name"}, "cursor": {next}}, testSpec)
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+
+	// A last page has no cursor
+	n, last, err := following.Page(rows[2:])
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Empty(t, last)
+}
+
+// Test function for the SQL built by Apply
+func TestApply(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		params url.Values
+		after  []interface{}
+		want   string
+	}{
+		{
+			name:   "defaults",
+			params: url.Values{},
+			want:   `SELECT * FROM "items" ORDER BY id ASC LIMIT 21`,
+		},
+		{
+			name:   "filters",
+			params: url.Values{"name.ne": {"x"}, "id.in": {"1,2"}, "name.contains": {"50%_off"}, "limit": {"5"}},
+			want: `SELECT * FROM "items" WHERE id IN (1,2) AND LOWER(name) LIKE '%50\%\_off%' ESCAPE '\' AND name <> 'x' ` +
+				`ORDER BY id ASC LIMIT 6`,
+		},
+		{
+			name:   "after cursor",
+			params: url.Values{"sort": {"-name"}},
+			after:  []interface{}{"m", int64(12)},
+			want:   `SELECT * FROM "items" WHERE ((name < 'm') OR (name = 'm' AND id > 12)) ORDER BY name DESC,id ASC LIMIT 21`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.params, testSpec)
+			require.NoError(t, err)
+			q.after = tt.after
+
+			sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				return q.Apply(tx.Model(&item{})).Find(&[]item{})
+			})
+			assert.Equal(t, tt.want, sql)
+		})
+	}
+}
+
+// Test function for Page on rows that aren't a slice
+func TestPageInvalidRows(t *testing.T) {
+	q, err := Parse(url.Values{}, testSpec)
+	require.NoError(t, err)
+
+	_, _, err = q.Page(item{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,172 @@
+// Package rank generates lexicographic ranks for ordering items in a list.
+// A new rank can always be found between two existing ones, so moving an
+// item only rewrites that item's rank.
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+// digits is the rank alphabet in ascending byte order
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const base = len(digits)
+
+var (
+	// ErrInvalidRank is returned for ranks with characters outside the alphabet
+	// or a trailing zero (which would leave no room below the rank)
+	ErrInvalidRank = errors.New("rank: invalid rank")
+	// ErrOutOfOrder is returned when the lower bound isn't below the upper bound
+	ErrOutOfOrder = errors.New("rank: bounds out of order")
+)
+
+// Validate checks that r is a usable rank
+func Validate(r string) error {
+	if r == "" || r[len(r)-1] == digits[0] {
+		return ErrInvalidRank
+	}
+	for i := 0; i < len(r); i++ {
+		if strings.IndexByte(digits, r[i]) < 0 {
+			return ErrInvalidRank
+		}
+	}
+	return nil
+}
+
+// Between returns a rank sorting strictly after prev and before next. An
+// empty prev means "from the start" and an empty next "to the end".
+func Between(prev, next string) (string, error) {
+	if prev != "" {
+		if err := Validate(prev); err != nil {
+			return "", err
+		}
+	}
+	if next != "" {
+		if err := Validate(next); err != nil {
+			return "", err
+		}
+		if prev >= next {
+			return "", ErrOutOfOrder
+		}
+	}
+	return midpoint(prev, next), nil
+}
+
+// After returns a rank sorting after prev
+func After(prev string) (string, error) {
+	return Between(prev, "")
+}
+
+// Spread returns n ascending ranks spaced evenly over the whole range, for
+// re-ranking a list from scratch
+func Spread(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	// Pick a width with room for n ranks plus both ends
+	width, capacity := 1, uint64(base)
+	for capacity <= uint64(n) {
+		width++
+		capacity *= uint64(base)
+	}
+
+	ranks := make([]string, n)
+	buf := make([]byte, width)
+	for i := range ranks {
+		value := uint64(i+1) * capacity / uint64(n+1)
+		for j := width - 1; j >= 0; j-- {
+			buf[j] = digits[value%uint64(base)]
+			value /= uint64(base)
+		}
+		// Dropping trailing zeros keeps the order and the ranks valid
+		ranks[i] = strings.TrimRight(string(buf), digits[:1])
+	}
+	return ranks
+}
+
+// Insert returns a rank for an item inserted at position into a list with
+// the given ranks in ascending order. When the neighbours leave no room, or
+// one of them has no rank yet, the whole list is ranked anew and the new
+// ranks of the existing items are returned as well, in list order.
+func Insert(ranks []string, position int) (string, []string) {
+	if position < 0 {
+		position = 0
+	}
+	if position > len(ranks) {
+		position = len(ranks)
+	}
+
+	// An empty rank on an actual neighbour would read as an open bound
+	prev, next, ranked := "", "", true
+	if position > 0 {
+		prev = ranks[position-1]
+		ranked = prev != ""
+	}
+	if position < len(ranks) {
+		next = ranks[position]
+		ranked = ranked && next != ""
+	}
+	if ranked {
+		if r, err := Between(prev, next); err == nil {
+			return r, nil
+		}
+	}
+
+	spread := Spread(len(ranks) + 1)
+	respread := make([]string, 0, len(ranks))
+	respread = append(respread, spread[:position]...)
+	respread = append(respread, spread[position+1:]...)
+	return spread[position], respread
+}
+
+// midpoint finds a rank between a and b; b == "" stands for the end of the
+// range. Both are assumed valid with a < b.
+func midpoint(a, b string) string {
+	if b != "" {
+		// Keep the common prefix, treating a as padded with zeros
+		n := 0
+		for n < len(b) && digitAt(a, n) == digitIndex(b[n]) {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + midpoint(rest, b[n:])
+		}
+	}
+
+	lo := digitAt(a, 0)
+	hi := base
+	if b != "" {
+		hi = digitIndex(b[0])
+	}
+	if hi-lo > 1 {
+		return string(digits[(lo+hi)/2])
+	}
+
+	// The first digits are adjacent; b's first digit alone still sorts below b
+	if len(b) > 1 {
+		return b[:1]
+	}
+	rest := ""
+	if len(a) > 1 {
+		rest = a[1:]
+	}
+	return string(digits[lo]) + midpoint(rest, "")
+}
+
+// digitAt returns the value of s's i-th digit, or zero past its end
+func digitAt(s string, i int) int {
+	if i >= len(s) {
+		return 0
+	}
+	return digitIndex(s[i])
+}
+
+func digitIndex(c byte) int {
+	return strings.IndexByte(digits, c)
+}
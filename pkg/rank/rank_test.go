@@ -0,0 +1,140 @@
+package rank
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test function for Between on representative bounds
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name       string
+		prev, next string
+	}{
+		{name: "empty list", prev: "", next: ""},
+		{name: "append", prev: "i", next: ""},
+		{name: "prepend", prev: "", next: "i"},
+		{name: "wide gap", prev: "a", next: "z"},
+		{name: "adjacent digits", prev: "a", next: "b"},
+		{name: "prefix of next", prev: "a", next: "a5"},
+		{name: "next has trailing digits", prev: "a", next: "b1"},
+		{name: "before smallest", prev: "", next: "01"},
+		{name: "after largest", prev: "zz", next: ""},
+		{name: "long shared prefix", prev: "abcx", next: "abcy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Between(tt.prev, tt.next)
+			require.NoError(t, err)
+			require.NoError(t, Validate(got))
+			assert.Greater(t, got, tt.prev)
+			if tt.next != "" {
+				assert.Less(t, got, tt.next)
+			}
+		})
+	}
+}
+
+// Test function for Between on invalid bounds
+func TestBetween_Errors(t *testing.T) {
+	_, err := Between("b", "a")
+	assert.ErrorIs(t, err, ErrOutOfOrder)
+
+	_, err = Between("a", "a")
+	assert.ErrorIs(t, err, ErrOutOfOrder)
+
+	_, err = Between("a0", "")
+	assert.ErrorIs(t, err, ErrInvalidRank)
+
+	_, err = Between("", "A")
+	assert.ErrorIs(t, err, ErrInvalidRank)
+}
+
+// Repeatedly inserting at random positions must keep the list strictly ordered
+func TestBetween_RandomInserts(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var ranks []string
+
+	for i := 0; i < 2000; i++ {
+		pos := rng.Intn(len(ranks) + 1)
+		prev, next := "", ""
+		if pos > 0 {
+			prev = ranks[pos-1]
+		}
+		if pos < len(ranks) {
+			next = ranks[pos]
+		}
+
+		r, err := Between(prev, next)
+		require.NoError(t, err)
+		ranks = append(ranks[:pos], append([]string{r}, ranks[pos:]...)...)
+	}
+
+	assert.True(t, sort.StringsAreSorted(ranks))
+	for i := 1; i < len(ranks); i++ {
+		require.NotEqual(t, ranks[i-1], ranks[i])
+	}
+}
+
+// Test function for Spread
+func TestSpread(t *testing.T) {
+	assert.Nil(t, Spread(0))
+
+	for _, n := range []int{1, 5, 35, 36, 1000} {
+		ranks := Spread(n)
+		require.Len(t, ranks, n)
+		for i, r := range ranks {
+			require.NoError(t, Validate(r))
+			if i > 0 {
+				require.Less(t, ranks[i-1], r)
+			}
+		}
+	}
+}
+
+// Test function for Insert
+func TestInsert(t *testing.T) {
+	tests := []struct {
+		name     string
+		ranks    []string
+		position int
+		respread bool
+	}{
+		{name: "empty list", ranks: nil, position: 0},
+		{name: "between neighbours", ranks: []string{"a", "c"}, position: 1},
+		{name: "at the top", ranks: []string{"a", "c"}, position: 0},
+		{name: "past the end", ranks: []string{"a", "c"}, position: 9},
+		{name: "unranked neighbour", ranks: []string{"a", ""}, position: 1, respread: true},
+		{name: "out of order neighbours", ranks: []string{"c", "a"}, position: 1, respread: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, respread := Insert(tt.ranks, tt.position)
+			require.NoError(t, Validate(r))
+
+			ranks := tt.ranks
+			if tt.respread {
+				require.Len(t, respread, len(tt.ranks))
+				ranks = respread
+			} else {
+				require.Nil(t, respread)
+			}
+
+			// The new rank sorts exactly at the clamped position
+			position := tt.position
+			if position > len(ranks) {
+				position = len(ranks)
+			}
+			list := append(append(append([]string{}, ranks[:position]...), r), ranks[position:]...)
+			for i := 1; i < len(list); i++ {
+				assert.Less(t, list[i-1], list[i])
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Package reldate parses date offsets relative to an anchor, e.g. "+3d"
+// (three days after), "-1w" (a week before) or "+1w2d4h". Days and weeks
+// are calendar days, so an offset keeps the anchor's time of day across
+// daylight saving changes.
+package reldate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxAmount caps the number before each unit
+const MaxAmount = 9999
+
+// ErrInvalidOffset is returned for offsets that don't follow the syntax
+var ErrInvalidOffset = errors.New("reldate: invalid offset")
+
+// units lists the accepted units in the order they must appear
+const units = "wdh"
+
+// Offset is a signed distance from an anchor in weeks, days and hours
+type Offset struct {
+	Weeks int
+	Days  int
+	Hours int
+}
+
+// Parse reads an offset: an optional sign followed by one or more amounts,
+// each with one of the units w (weeks), d (days) or h (hours) in that order
+func Parse(s string) (Offset, error) {
+	var o Offset
+	s = strings.TrimSpace(s)
+	sign := 1
+	switch {
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	case strings.HasPrefix(s, "-"):
+		sign, s = -1, s[1:]
+	}
+	if s == "" {
+		return o, fmt.Errorf("%w: expected an amount like 3d", ErrInvalidOffset)
+	}
+
+	next := 0 // index in units of the earliest unit still allowed
+	for pos := 0; pos < len(s); {
+		start := pos
+		amount := 0
+		for pos < len(s) && s[pos] >= '0' && s[pos] <= '9' {
+			amount = amount*10 + int(s[pos]-'0')
+			if amount > MaxAmount {
+				return o, fmt.Errorf("%w: amounts are limited to %d", ErrInvalidOffset, MaxAmount)
+			}
+			pos++
+		}
+		if pos == start {
+			return o, fmt.Errorf("%w: expected a number at position %d", ErrInvalidOffset, start+1)
+		}
+		if pos == len(s) {
+			return o, fmt.Errorf("%w: missing unit (w, d or h) after %s", ErrInvalidOffset, s[start:pos])
+		}
+
+		unit := strings.IndexByte(units, s[pos])
+		if unit < 0 {
+			return o, fmt.Errorf("%w: unknown unit %q; use w, d or h", ErrInvalidOffset, s[pos])
+		}
+		if unit < next {
+			return o, fmt.Errorf("%w: units must appear once, in the order w, d, h", ErrInvalidOffset)
+		}
+		next = unit + 1
+		pos++
+
+		switch units[unit] {
+		case 'w':
+			o.Weeks = sign * amount
+		case 'd':
+			o.Days = sign * amount
+		case 'h':
+			o.Hours = sign * amount
+		}
+	}
+	return o, nil
+}
+
+// From returns the time the offset points at from anchor
+func (o Offset) From(anchor time.Time) time.Time {
+	return anchor.AddDate(0, 0, 7*o.Weeks+o.Days).Add(time.Duration(o.Hours) * time.Hour)
+}
+
+// IsZero reports whether the offset points at the anchor itself
+func (o Offset) IsZero() bool {
+	return o.Weeks == 0 && o.Days == 0 && o.Hours == 0
+}
+
+// String formats the offset in its canonical form, e.g. "+1w2d" or "-3d"
+func (o Offset) String() string {
+	if o.IsZero() {
+		return "+0d"
+	}
+	sign := "+"
+	if o.Weeks < 0 || o.Days < 0 || o.Hours < 0 {
+		sign = "-"
+	}
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for _, part := range []struct {
+		amount int
+		unit   byte
+	}{{o.Weeks, 'w'}, {o.Days, 'd'}, {o.Hours, 'h'}} {
+		if part.amount == 0 {
+			continue
+		}
+		if part.amount < 0 {
+			part.amount = -part.amount
+		}
+		fmt.Fprintf(&b, "%d%c", part.amount, part.unit)
+	}
+	return b.String()
+}
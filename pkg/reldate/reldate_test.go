@@ -0,0 +1,108 @@
+package reldate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test function for Parse on valid and invalid offsets
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Offset
+		wantErr bool
+	}{
+		{name: "days", input: "+3d", want: Offset{Days: 3}},
+		{name: "no sign", input: "3d", want: Offset{Days: 3}},
+		{name: "negative", input: "-1w", want: Offset{Weeks: -1}},
+		{name: "combined", input: "+1w2d4h", want: Offset{Weeks: 1, Days: 2, Hours: 4}},
+		{name: "negative combined", input: "-2d12h", want: Offset{Days: -2, Hours: -12}},
+		{name: "zero", input: "0d", want: Offset{}},
+		{name: "surrounding spaces", input: " +5h ", want: Offset{Hours: 5}},
+		{name: "empty", input: "", wantErr: true},
+		{name: "sign only", input: "+", wantErr: true},
+		{name: "missing unit", input: "+3", wantErr: true},
+		{name: "unknown unit", input: "+3m", wantErr: true},
+		{name: "missing number", input: "+d", wantErr: true},
+		{name: "units out of order", input: "+2d1w", wantErr: true},
+		{name: "repeated unit", input: "+1d1d", wantErr: true},
+		{name: "too large", input: "+10000d", wantErr: true},
+		{name: "inner space", input: "+1w 2d", wantErr: true},
+		{name: "double sign", input: "+-3d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidOffset)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// Test function for From across month ends and daylight saving changes
+func TestFrom(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		offset string
+		anchor time.Time
+		want   time.Time
+	}{
+		{
+			name:   "days cross a month end",
+			offset: "+3d",
+			anchor: time.Date(2025, 1, 30, 9, 0, 0, 0, time.UTC),
+			want:   time.Date(2025, 2, 2, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "weeks back",
+			offset: "-2w",
+			anchor: time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+			want:   time.Date(2025, 2, 24, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "days keep the time of day over DST",
+			offset: "+1d",
+			anchor: time.Date(2025, 3, 29, 9, 0, 0, 0, berlin),
+			want:   time.Date(2025, 3, 30, 9, 0, 0, 0, berlin),
+		},
+		{
+			name:   "hours are elapsed time",
+			offset: "+1d4h",
+			anchor: time.Date(2025, 1, 1, 22, 0, 0, 0, time.UTC),
+			want:   time.Date(2025, 1, 3, 2, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, err := Parse(tt.offset)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(offset.From(tt.anchor)), "got %v", offset.From(tt.anchor))
+		})
+	}
+}
+
+// Test function for String round trips
+func TestString(t *testing.T) {
+	for _, input := range []string{"+3d", "-1w", "+1w2d4h", "-2d12h", "+0d", "+5h"} {
+		offset, err := Parse(input)
+		require.NoError(t, err)
+		assert.Equal(t, input, offset.String())
+
+		again, err := Parse(offset.String())
+		require.NoError(t, err)
+		assert.Equal(t, offset, again)
+	}
+}
@@ -0,0 +1,268 @@
+package rrule
+
+import (
+	"sort"
+	"time"
+)
+
+// maxEmptyPeriods stops the expansion of rules that can never match again,
+// such as FREQ=YEARLY;BYMONTHDAY=30 started in February
+const maxEmptyPeriods = 1000
+
+// maxYear bounds the expansion of unlimited rules
+const maxYear = 9999
+
+// Iterator walks the occurrences of a rule in chronological order
+type Iterator struct {
+	rule  *Rule
+	start time.Time
+	loc   *time.Location
+
+	until    time.Time
+	hasUntil bool
+
+	period  int // index of the next period to expand
+	pending []time.Time
+	emitted int
+	done    bool
+}
+
+// Iterator returns an iterator over the occurrences of a series starting at
+// start. As in RFC 5545 the start is always the first occurrence, and the
+// rule is expanded on the wall clock of start's location.
+func (r *Rule) Iterator(start time.Time) *Iterator {
+	loc := start.Location()
+	it := &Iterator{
+		rule:    r,
+		start:   start,
+		loc:     loc,
+		pending: []time.Time{start},
+	}
+
+	switch r.untilKind {
+	case untilUTC:
+		it.until, it.hasUntil = r.until, true
+	case untilFloating:
+		u := r.until
+		it.until = time.Date(u.Year(), u.Month(), u.Day(), u.Hour(), u.Minute(), u.Second(), 0, loc)
+		it.hasUntil = true
+	case untilDate:
+		// The whole day counts: anything before the next midnight is included
+		u := r.until
+		it.until = time.Date(u.Year(), u.Month(), u.Day()+1, 0, 0, 0, 0, loc).Add(-time.Nanosecond)
+		it.hasUntil = true
+	}
+	return it
+}
+
+// Next returns the next occurrence, and false once the series has ended
+func (it *Iterator) Next() (time.Time, bool) {
+	empty := 0
+	for !it.done {
+		if len(it.pending) > 0 {
+			occurrence := it.pending[0]
+			it.pending = it.pending[1:]
+
+			if (it.hasUntil && occurrence.After(it.until)) || (it.rule.Count > 0 && it.emitted >= it.rule.Count) {
+				it.done = true
+				break
+			}
+			it.emitted++
+			return occurrence, true
+		}
+
+		if empty >= maxEmptyPeriods {
+			it.done = true
+			break
+		}
+		days, ok := it.expand(it.period)
+		if !ok {
+			it.done = true
+			break
+		}
+		it.period++
+
+		it.pending = it.occurrencesOn(days)
+		if len(it.pending) == 0 {
+			empty++
+		} else {
+			empty = 0
+		}
+	}
+	return time.Time{}, false
+}
+
+// occurrencesOn turns candidate days into sorted occurrences at the start's
+// time of day, dropping the ones not after the start
+func (it *Iterator) occurrencesOn(days []time.Time) []time.Time {
+	var occurrences []time.Time
+	seen := map[time.Time]bool{}
+	for _, day := range days {
+		occurrence := time.Date(day.Year(), day.Month(), day.Day(),
+			it.start.Hour(), it.start.Minute(), it.start.Second(), it.start.Nanosecond(), it.loc)
+		if !occurrence.After(it.start) || seen[occurrence] {
+			continue
+		}
+		seen[occurrence] = true
+		occurrences = append(occurrences, occurrence)
+	}
+	sort.Slice(occurrences, func(i, j int) bool { return occurrences[i].Before(occurrences[j]) })
+	return occurrences
+}
+
+// expand returns the candidate days of the given period, as dates at
+// midnight UTC; false means the expansion has run past maxYear
+func (it *Iterator) expand(period int) ([]time.Time, bool) {
+	r := it.rule
+	year, month, day := it.start.Date()
+	step := period * r.Interval
+
+	switch r.Freq {
+	case Daily:
+		d := date(year, month, day+step)
+		if d.Year() > maxYear {
+			return nil, false
+		}
+		if r.matchesWeekday(d) && r.matchesMonthDay(d) {
+			return []time.Time{d}, true
+		}
+		return nil, true
+
+	case Weekly:
+		monday := date(year, month, day-(int(it.start.Weekday())+6)%7+7*step)
+		if monday.Year() > maxYear {
+			return nil, false
+		}
+		var days []time.Time
+		for i := 0; i < 7; i++ {
+			d := monday.AddDate(0, 0, i)
+			if len(r.ByDay) == 0 && d.Weekday() != it.start.Weekday() {
+				continue
+			}
+			if r.matchesWeekday(d) {
+				days = append(days, d)
+			}
+		}
+		return days, true
+
+	case Monthly:
+		first := date(year, month+time.Month(step), 1)
+		if first.Year() > maxYear {
+			return nil, false
+		}
+		return r.monthDays(first, day), true
+
+	case Yearly:
+		y := year + step
+		if y > maxYear {
+			return nil, false
+		}
+		switch {
+		case len(r.ByMonthDay) > 0:
+			var days []time.Time
+			for m := time.January; m <= time.December; m++ {
+				days = append(days, r.monthDays(date(y, m, 1), day)...)
+			}
+			return days, true
+		case len(r.ByDay) > 0:
+			return r.weekdaysIn(date(y, time.January, 1), date(y+1, time.January, 1)), true
+		default:
+			// Skipped in years without that day, e.g. February 29
+			d := date(y, month, day)
+			if d.Month() != month {
+				return nil, true
+			}
+			return []time.Time{d}, true
+		}
+	}
+	return nil, false
+}
+
+// monthDays returns the candidate days of the month starting at first;
+// startDay is used when the rule has neither BYMONTHDAY nor BYDAY
+func (r *Rule) monthDays(first time.Time, startDay int) []time.Time {
+	next := first.AddDate(0, 1, 0)
+	length := next.AddDate(0, 0, -1).Day()
+
+	switch {
+	case len(r.ByMonthDay) > 0:
+		var days []time.Time
+		for _, md := range r.ByMonthDay {
+			if md < 0 {
+				md = length + md + 1
+			}
+			if md < 1 || md > length {
+				continue
+			}
+			d := first.AddDate(0, 0, md-1)
+			if r.matchesWeekday(d) {
+				days = append(days, d)
+			}
+		}
+		return days
+	case len(r.ByDay) > 0:
+		return r.weekdaysIn(first, next)
+	default:
+		// Months without that day are skipped, as RFC 5545 requires
+		if startDay > length {
+			return nil
+		}
+		return []time.Time{first.AddDate(0, 0, startDay-1)}
+	}
+}
+
+// weekdaysIn returns the BYDAY days in [from, to), resolving ordinals
+// relative to that range
+func (r *Rule) weekdaysIn(from, to time.Time) []time.Time {
+	var days []time.Time
+	for _, wd := range r.ByDay {
+		var matches []time.Time
+		offset := (int(wd.Weekday) - int(from.Weekday()) + 7) % 7
+		for d := from.AddDate(0, 0, offset); d.Before(to); d = d.AddDate(0, 0, 7) {
+			matches = append(matches, d)
+		}
+
+		switch {
+		case wd.N == 0:
+			days = append(days, matches...)
+		case wd.N > 0 && wd.N <= len(matches):
+			days = append(days, matches[wd.N-1])
+		case wd.N < 0 && -wd.N <= len(matches):
+			days = append(days, matches[len(matches)+wd.N])
+		}
+	}
+	return days
+}
+
+// matchesWeekday reports whether d is one of the BYDAY weekdays, ignoring
+// ordinals; it only filters when ordinals aren't in play
+func (r *Rule) matchesWeekday(d time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, wd := range r.ByDay {
+		if wd.Weekday == d.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMonthDay reports whether d is one of the BYMONTHDAY days
+func (r *Rule) matchesMonthDay(d time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	length := date(d.Year(), d.Month()+1, 0).Day()
+	for _, md := range r.ByMonthDay {
+		if md == d.Day() || length+md+1 == d.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+// date returns midnight UTC of the given day, normalising overflows
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,303 @@
+// Package rrule parses and expands a subset of RFC 5545 recurrence rules:
+// FREQ (DAILY, WEEKLY, MONTHLY, YEARLY), INTERVAL, BYDAY, BYMONTHDAY, COUNT
+// and UNTIL. Weeks start on Monday.
+//
+// Occurrences are computed on the wall clock of the start time's location,
+// so a rule starting at 09:00 in Europe/Berlin stays at 09:00 local time
+// across daylight saving changes.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRule is returned for rules that can't be parsed or aren't supported
+var ErrInvalidRule = errors.New("rrule: invalid rule")
+
+// Frequency is how often a rule repeats
+type Frequency int
+
+// Supported frequencies
+const (
+	Daily Frequency = iota
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = map[Frequency]string{
+	Daily:   "DAILY",
+	Weekly:  "WEEKLY",
+	Monthly: "MONTHLY",
+	Yearly:  "YEARLY",
+}
+
+func (f Frequency) String() string {
+	return frequencyNames[f]
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// WeekdayNum is a BYDAY entry: a weekday with an optional ordinal, such as
+// 2TU (the second Tuesday) or -1FR (the last Friday). N is zero for every
+// such weekday.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+func (w WeekdayNum) String() string {
+	if w.N == 0 {
+		return weekdayNames[w.Weekday]
+	}
+	return strconv.Itoa(w.N) + weekdayNames[w.Weekday]
+}
+
+// untilKind tells how an UNTIL value is compared with occurrences
+type untilKind int
+
+const (
+	untilNone     untilKind = iota
+	untilUTC                // an instant, e.g. 20240131T170000Z
+	untilFloating           // a wall-clock time in the start's location, e.g. 20240131T170000
+	untilDate               // a whole day in the start's location, e.g. 20240131
+)
+
+// Rule is a parsed recurrence rule
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	// Count limits the number of occurrences, the start included; zero means unlimited
+	Count int
+
+	// until holds the UNTIL value; for floating and date forms only its
+	// wall-clock fields matter
+	until     time.Time
+	untilKind untilKind
+}
+
+// Parse parses a rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=10".
+// An "RRULE:" prefix is accepted.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimSpace(s)
+	if len(s) >= 6 && strings.EqualFold(s[:6], "RRULE:") {
+		s = s[6:]
+	}
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	rule := &Rule{Interval: 1}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if !ok || key == "" || value == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("%w: %s given twice", ErrInvalidRule, key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			err = rule.parseFreq(value)
+		case "INTERVAL":
+			rule.Interval, err = parsePositive(value)
+		case "COUNT":
+			rule.Count, err = parsePositive(value)
+		case "UNTIL":
+			err = rule.parseUntil(value)
+		case "BYDAY":
+			err = rule.parseByDay(value)
+		case "BYMONTHDAY":
+			err = rule.parseByMonthDay(value)
+		default:
+			err = fmt.Errorf("%w: %s is not supported", ErrInvalidRule, key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !seen["FREQ"] {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if err := rule.check(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *Rule) parseFreq(value string) error {
+	for freq, name := range frequencyNames {
+		if name == value {
+			r.Freq = freq
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: unsupported FREQ %s", ErrInvalidRule, value)
+}
+
+func parsePositive(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%w: %q is not a positive number", ErrInvalidRule, value)
+	}
+	return n, nil
+}
+
+func (r *Rule) parseUntil(value string) error {
+	layouts := []struct {
+		layout string
+		kind   untilKind
+	}{
+		{"20060102T150405Z", untilUTC},
+		{"20060102T150405", untilFloating},
+		{"20060102", untilDate},
+	}
+	for _, l := range layouts {
+		if t, err := time.Parse(l.layout, value); err == nil {
+			r.until, r.untilKind = t, l.kind
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: malformed UNTIL %s", ErrInvalidRule, value)
+}
+
+func (r *Rule) parseByDay(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return fmt.Errorf("%w: malformed BYDAY %q", ErrInvalidRule, item)
+		}
+		name := item[len(item)-2:]
+		weekday := -1
+		for i, n := range weekdayNames {
+			if n == name {
+				weekday = i
+			}
+		}
+		if weekday < 0 {
+			return fmt.Errorf("%w: unknown weekday %q", ErrInvalidRule, name)
+		}
+
+		entry := WeekdayNum{Weekday: time.Weekday(weekday)}
+		if ordinal := item[:len(item)-2]; ordinal != "" {
+			n, err := strconv.Atoi(ordinal)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return fmt.Errorf("%w: malformed BYDAY %q", ErrInvalidRule, item)
+			}
+			entry.N = n
+		}
+		r.ByDay = append(r.ByDay, entry)
+	}
+	return nil
+}
+
+func (r *Rule) parseByMonthDay(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil || n == 0 || n < -31 || n > 31 {
+			return fmt.Errorf("%w: malformed BYMONTHDAY %q", ErrInvalidRule, item)
+		}
+		r.ByMonthDay = append(r.ByMonthDay, n)
+	}
+	return nil
+}
+
+// check validates combinations of parts that parse fine on their own
+func (r *Rule) check() error {
+	if r.Count > 0 && r.untilKind != untilNone {
+		return fmt.Errorf("%w: COUNT and UNTIL can't be combined", ErrInvalidRule)
+	}
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 {
+		return fmt.Errorf("%w: BYMONTHDAY can't be used with FREQ=WEEKLY", ErrInvalidRule)
+	}
+	for _, day := range r.ByDay {
+		if day.N == 0 {
+			continue
+		}
+		if r.Freq != Monthly && r.Freq != Yearly {
+			return fmt.Errorf("%w: BYDAY ordinals need FREQ=MONTHLY or YEARLY", ErrInvalidRule)
+		}
+		if len(r.ByMonthDay) > 0 {
+			return fmt.Errorf("%w: BYDAY ordinals can't be combined with BYMONTHDAY", ErrInvalidRule)
+		}
+		if r.Freq == Monthly && (day.N > 5 || day.N < -5) {
+			return fmt.Errorf("%w: a month has at most 5 of each weekday", ErrInvalidRule)
+		}
+	}
+	return nil
+}
+
+// String formats the rule in its canonical form, without the RRULE: prefix
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = day.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, day := range r.ByMonthDay {
+			days[i] = strconv.Itoa(day)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	switch r.untilKind {
+	case untilUTC:
+		parts = append(parts, "UNTIL="+r.until.Format("20060102T150405Z"))
+	case untilFloating:
+		parts = append(parts, "UNTIL="+r.until.Format("20060102T150405"))
+	case untilDate:
+		parts = append(parts, "UNTIL="+r.until.Format("20060102"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence of a series starting at start that is
+// strictly after t, and false when the series ends before that
+func (r *Rule) Next(start, t time.Time) (time.Time, bool) {
+	it := r.Iterator(start)
+	for {
+		occurrence, ok := it.Next()
+		if !ok || occurrence.After(t) {
+			return occurrence, ok
+		}
+	}
+}
+
+// Occurrences returns up to n occurrences of a series starting at start
+// that are strictly after t
+func (r *Rule) Occurrences(start, t time.Time, n int) []time.Time {
+	var occurrences []time.Time
+	it := r.Iterator(start)
+	for len(occurrences) < n {
+		occurrence, ok := it.Next()
+		if !ok {
+			break
+		}
+		if occurrence.After(t) {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+	return occurrences
+}
@@ -0,0 +1,325 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata" // the tests shouldn't depend on the host's zoneinfo
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+// formatAll renders occurrences in their own location for readable assertions
+func formatAll(occurrences []time.Time) []string {
+	formatted := make([]string, len(occurrences))
+	for i, o := range occurrences {
+		formatted[i] = o.Format("2006-01-02 15:04 Mon")
+	}
+	return formatted
+}
+
+// take returns the first n occurrences, the start included
+func take(t *testing.T, rule string, start time.Time, n int) []string {
+	t.Helper()
+	r, err := Parse(rule)
+	require.NoError(t, err)
+	return formatAll(r.Occurrences(start, start.Add(-time.Nanosecond), n))
+}
+
+// Test function for Parse on valid rules and their canonical form
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		rule      string
+		canonical string
+	}{
+		{name: "daily", rule: "FREQ=DAILY", canonical: "FREQ=DAILY"},
+		{name: "prefix and lower case", rule: "RRULE:freq=weekly;byday=mo,fr", canonical: "FREQ=WEEKLY;BYDAY=MO,FR"},
+		{name: "interval one is implied", rule: "FREQ=DAILY;INTERVAL=1", canonical: "FREQ=DAILY"},
+		{name: "interval", rule: "INTERVAL=3;FREQ=MONTHLY", canonical: "FREQ=MONTHLY;INTERVAL=3"},
+		{name: "ordinal weekdays", rule: "FREQ=MONTHLY;BYDAY=2TU,-1FR", canonical: "FREQ=MONTHLY;BYDAY=2TU,-1FR"},
+		{name: "positive ordinal sign", rule: "FREQ=YEARLY;BYDAY=+20MO", canonical: "FREQ=YEARLY;BYDAY=20MO"},
+		{name: "month days", rule: "FREQ=MONTHLY;BYMONTHDAY=1,15,-1", canonical: "FREQ=MONTHLY;BYMONTHDAY=1,15,-1"},
+		{name: "count", rule: "FREQ=DAILY;COUNT=10", canonical: "FREQ=DAILY;COUNT=10"},
+		{name: "until utc", rule: "FREQ=DAILY;UNTIL=20240131T170000Z", canonical: "FREQ=DAILY;UNTIL=20240131T170000Z"},
+		{name: "until floating", rule: "FREQ=DAILY;UNTIL=20240131T170000", canonical: "FREQ=DAILY;UNTIL=20240131T170000"},
+		{name: "until date", rule: "FREQ=DAILY;UNTIL=20240131", canonical: "FREQ=DAILY;UNTIL=20240131"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Parse(tt.rule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.canonical, r.String())
+
+			// The canonical form parses back to the same rule
+			again, err := Parse(r.String())
+			require.NoError(t, err)
+			assert.Equal(t, r, again)
+		})
+	}
+}
+
+// Test function for Parse on invalid or unsupported rules
+func TestParse_Errors(t *testing.T) {
+	rules := []string{
+		"",
+		"RRULE:",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;INTERVAL=x",
+		"FREQ=DAILY;COUNT=-1",
+		"FREQ=DAILY;COUNT=3;UNTIL=20240131",
+		"FREQ=DAILY;UNTIL=2024-01-31",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=MONTHLY;BYDAY=0MO",
+		"FREQ=MONTHLY;BYDAY=6MO",
+		"FREQ=YEARLY;BYDAY=54MO",
+		"FREQ=MONTHLY;BYDAY=1MO;BYMONTHDAY=1",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=MONTHLY;BYMONTH=2",
+		"FREQ=DAILY;WKST=SU",
+		"FREQ=DAILY;;COUNT=2",
+		"FREQ",
+	}
+
+	for _, rule := range rules {
+		t.Run(rule, func(t *testing.T) {
+			_, err := Parse(rule)
+			assert.ErrorIs(t, err, ErrInvalidRule)
+		})
+	}
+}
+
+// Test function for expanding rules of every frequency
+func TestOccurrences(t *testing.T) {
+	// Monday 2024-01-01 09:00
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		n     int
+		want  []string
+	}{
+		{
+			name: "daily with interval",
+			rule: "FREQ=DAILY;INTERVAL=2", start: start, n: 3,
+			want: []string{"2024-01-01 09:00 Mon", "2024-01-03 09:00 Wed", "2024-01-05 09:00 Fri"},
+		},
+		{
+			name: "daily on weekdays only",
+			rule: "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR", start: time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC), n: 3,
+			want: []string{"2024-01-05 09:00 Fri", "2024-01-08 09:00 Mon", "2024-01-09 09:00 Tue"},
+		},
+		{
+			name: "weekly on the start's weekday",
+			rule: "FREQ=WEEKLY", start: start, n: 3,
+			want: []string{"2024-01-01 09:00 Mon", "2024-01-08 09:00 Mon", "2024-01-15 09:00 Mon"},
+		},
+		{
+			name: "weekly on several days",
+			rule: "FREQ=WEEKLY;BYDAY=FR,MO", start: start, n: 4,
+			want: []string{"2024-01-01 09:00 Mon", "2024-01-05 09:00 Fri", "2024-01-08 09:00 Mon", "2024-01-12 09:00 Fri"},
+		},
+		{
+			name: "every other week from mid-week",
+			rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", start: time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC), n: 4,
+			// The start week keeps its Thursday; its Monday is before the start
+			want: []string{"2024-01-03 09:00 Wed", "2024-01-04 09:00 Thu", "2024-01-15 09:00 Mon", "2024-01-18 09:00 Thu"},
+		},
+		{
+			name: "monthly skips months without the day",
+			rule: "FREQ=MONTHLY", start: time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC), n: 4,
+			want: []string{"2024-01-31 09:00 Wed", "2024-03-31 09:00 Sun", "2024-05-31 09:00 Fri", "2024-07-31 09:00 Wed"},
+		},
+		{
+			name: "monthly on the last day",
+			rule: "FREQ=MONTHLY;BYMONTHDAY=-1", start: start, n: 4,
+			want: []string{"2024-01-01 09:00 Mon", "2024-01-31 09:00 Wed", "2024-02-29 09:00 Thu", "2024-03-31 09:00 Sun"},
+		},
+		{
+			name: "monthly on several days",
+			rule: "FREQ=MONTHLY;BYMONTHDAY=15,1", start: start, n: 4,
+			want: []string{"2024-01-01 09:00 Mon", "2024-01-15 09:00 Mon", "2024-02-01 09:00 Thu", "2024-02-15 09:00 Thu"},
+		},
+		{
+			name: "monthly on the second Tuesday",
+			rule: "FREQ=MONTHLY;BYDAY=2TU", start: start, n: 4,
+			want: []string{"2024-01-01 09:00 Mon", "2024-01-09 09:00 Tue", "2024-02-13 09:00 Tue", "2024-03-12 09:00 Tue"},
+		},
+		{
+			name: "monthly on the last Friday",
+			rule: "FREQ=MONTHLY;BYDAY=-1FR", start: start, n: 3,
+			want: []string{"2024-01-01 09:00 Mon", "2024-01-26 09:00 Fri", "2024-02-23 09:00 Fri"},
+		},
+		{
+			name: "fifth Monday only in months that have one",
+			rule: "FREQ=MONTHLY;BYDAY=5MO", start: start, n: 3,
+			want: []string{"2024-01-01 09:00 Mon", "2024-01-29 09:00 Mon", "2024-04-29 09:00 Mon"},
+		},
+		{
+			name: "Friday the 13th",
+			rule: "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13", start: start, n: 3,
+			want: []string{"2024-01-01 09:00 Mon", "2024-09-13 09:00 Fri", "2024-12-13 09:00 Fri"},
+		},
+		{
+			name: "quarterly",
+			rule: "FREQ=MONTHLY;INTERVAL=3", start: time.Date(2024, 11, 30, 9, 0, 0, 0, time.UTC), n: 3,
+			want: []string{"2024-11-30 09:00 Sat", "2025-05-30 09:00 Fri", "2025-08-30 09:00 Sat"},
+		},
+		{
+			name: "yearly on leap day",
+			rule: "FREQ=YEARLY", start: time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC), n: 3,
+			want: []string{"2024-02-29 09:00 Thu", "2028-02-29 09:00 Tue", "2032-02-29 09:00 Sun"},
+		},
+		{
+			name: "yearly on month days expands every month",
+			rule: "FREQ=YEARLY;INTERVAL=2;BYMONTHDAY=31", start: time.Date(2024, 11, 1, 9, 0, 0, 0, time.UTC), n: 3,
+			want: []string{"2024-11-01 09:00 Fri", "2024-12-31 09:00 Tue", "2026-01-31 09:00 Sat"},
+		},
+		{
+			name: "yearly on the twentieth Monday",
+			rule: "FREQ=YEARLY;BYDAY=20MO", start: start, n: 3,
+			want: []string{"2024-01-01 09:00 Mon", "2024-05-13 09:00 Mon", "2025-05-19 09:00 Mon"},
+		},
+		{
+			name: "yearly on the last Sunday",
+			rule: "FREQ=YEARLY;BYDAY=-1SU", start: start, n: 2,
+			want: []string{"2024-01-01 09:00 Mon", "2024-12-29 09:00 Sun"},
+		},
+		{
+			name: "count includes the start",
+			rule: "FREQ=DAILY;COUNT=2", start: start, n: 10,
+			want: []string{"2024-01-01 09:00 Mon", "2024-01-02 09:00 Tue"},
+		},
+		{
+			name: "until is inclusive",
+			rule: "FREQ=WEEKLY;UNTIL=20240115T090000Z", start: start, n: 10,
+			want: []string{"2024-01-01 09:00 Mon", "2024-01-08 09:00 Mon", "2024-01-15 09:00 Mon"},
+		},
+		{
+			name: "until date includes the whole day",
+			rule: "FREQ=DAILY;UNTIL=20240103", start: start, n: 10,
+			want: []string{"2024-01-01 09:00 Mon", "2024-01-02 09:00 Tue", "2024-01-03 09:00 Wed"},
+		},
+		{
+			name: "start after until",
+			rule: "FREQ=DAILY;UNTIL=20231231", start: start, n: 10,
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, take(t, tt.rule, tt.start, tt.n))
+		})
+	}
+}
+
+// A rule that never matches again ends instead of looping forever
+func TestOccurrences_NeverMatching(t *testing.T) {
+	start := time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, []string{"2024-02-01 09:00 Thu"}, take(t, "FREQ=MONTHLY;INTERVAL=12;BYMONTHDAY=30", start, 5))
+}
+
+// Test function for keeping the local time of day across DST changes
+func TestOccurrences_DaylightSaving(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+
+	// DST starts on 2024-03-10 in New York
+	start := time.Date(2024, 3, 8, 9, 0, 0, 0, newYork)
+	r, err := Parse("FREQ=DAILY")
+	require.NoError(t, err)
+
+	occurrences := r.Occurrences(start, start.Add(-time.Nanosecond), 4)
+	require.Len(t, occurrences, 4)
+	for _, o := range occurrences {
+		assert.Equal(t, 9, o.Hour(), "local time of day is kept")
+		assert.Equal(t, newYork, o.Location())
+	}
+	assert.Equal(t, 14, occurrences[1].UTC().Hour(), "EST is UTC-5")
+	assert.Equal(t, 13, occurrences[3].UTC().Hour(), "EDT is UTC-4")
+	assert.Equal(t, 23*time.Hour, occurrences[2].Sub(occurrences[1]), "the DST day is shorter")
+
+	// Weekly across the autumn change in Europe
+	berlin := mustLoad(t, "Europe/Berlin")
+	start = time.Date(2024, 10, 21, 18, 30, 0, 0, berlin)
+	assert.Equal(t,
+		[]string{"2024-10-21 18:30 Mon", "2024-10-28 18:30 Mon"},
+		take(t, "FREQ=WEEKLY", start, 2),
+	)
+}
+
+// Weekdays and month days are those of the start's zone, not of UTC
+func TestOccurrences_LocalCalendar(t *testing.T) {
+	tokyo := mustLoad(t, "Asia/Tokyo")
+
+	// 08:00 on Monday in Tokyo is still Sunday in UTC
+	start := time.Date(2024, 1, 1, 8, 0, 0, 0, tokyo)
+	assert.Equal(t,
+		[]string{"2024-01-01 08:00 Mon", "2024-01-08 08:00 Mon"},
+		take(t, "FREQ=WEEKLY;BYDAY=MO", start, 2),
+	)
+	assert.Equal(t,
+		[]string{"2024-01-01 08:00 Mon", "2024-01-31 08:00 Wed"},
+		take(t, "FREQ=MONTHLY;BYMONTHDAY=-1", start, 2),
+	)
+}
+
+// Test function for how UNTIL forms are compared in a non-UTC zone
+func TestOccurrences_UntilForms(t *testing.T) {
+	sydney := mustLoad(t, "Australia/Sydney")
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, sydney)
+
+	// 09:00 on Jan 3 in Sydney is Jan 2 22:00 UTC, so an instant of Jan 2 23:00Z includes it
+	assert.Len(t, take(t, "FREQ=DAILY;UNTIL=20240102T230000Z", start, 10), 3)
+	// A floating UNTIL is read on Sydney's clock
+	assert.Len(t, take(t, "FREQ=DAILY;UNTIL=20240102T230000", start, 10), 2)
+	assert.Len(t, take(t, "FREQ=DAILY;UNTIL=20240103T090000", start, 10), 3)
+	// So is a date
+	assert.Len(t, take(t, "FREQ=DAILY;UNTIL=20240102", start, 10), 2)
+}
+
+// Test function for Next
+func TestNext(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	r, err := Parse("FREQ=WEEKLY;COUNT=3")
+	require.NoError(t, err)
+
+	next, ok := r.Next(start, start)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC), next)
+
+	// Between occurrences
+	next, ok = r.Next(start, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), next)
+
+	// After the last one
+	_, ok = r.Next(start, time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+}
+
+// Occurrences after a point in the series still honour COUNT from the start
+func TestOccurrences_AfterCountsFromStart(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	r, err := Parse("FREQ=DAILY;COUNT=5")
+	require.NoError(t, err)
+
+	occurrences := r.Occurrences(start, time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC), 10)
+	assert.Equal(t, []string{"2024-01-04 09:00 Thu", "2024-01-05 09:00 Fri"}, formatAll(occurrences))
+}
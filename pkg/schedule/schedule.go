@@ -0,0 +1,218 @@
+// Package schedule computes critical-path schedules for sets of dependent work items.
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var (
+	// ErrCycle is returned when the dependencies between items form a loop
+	ErrCycle = errors.New("schedule: dependency cycle")
+	// ErrUnknownDependency is returned when an item depends on an ID that isn't scheduled
+	ErrUnknownDependency = errors.New("schedule: unknown dependency")
+	// ErrDuplicateItem is returned when two items share an ID
+	ErrDuplicateItem = errors.New("schedule: duplicate item")
+)
+
+// Item is a unit of work to schedule
+type Item struct {
+	ID        uint
+	Duration  time.Duration
+	Deadline  *time.Time
+	DependsOn []uint
+}
+
+// Entry is the computed schedule of a single item
+type Entry struct {
+	ID             uint
+	Duration       time.Duration
+	DependsOn      []uint
+	EarliestStart  time.Time
+	EarliestFinish time.Time
+	LatestStart    time.Time
+	LatestFinish   time.Time
+	// Slack is how long the item can slip without delaying the project or
+	// missing a deadline; it is negative when a deadline can't be met.
+	Slack    time.Duration
+	Critical bool
+	// Late is set when the item can't finish before its own deadline
+	Late bool
+}
+
+// Schedule is the result of a critical-path computation
+type Schedule struct {
+	Start        time.Time
+	Finish       time.Time
+	Entries      []Entry
+	CriticalPath []uint
+}
+
+// Compute runs the critical path method over items starting at start. Entries
+// are returned in topological order (ties broken by ID), and the critical
+// path lists one chain of critical items from the first to the last.
+func Compute(start time.Time, items []Item) (*Schedule, error) {
+	index := make(map[uint]int, len(items))
+	for i, item := range items {
+		if _, dup := index[item.ID]; dup {
+			return nil, fmt.Errorf("%w: %d", ErrDuplicateItem, item.ID)
+		}
+		index[item.ID] = i
+	}
+
+	successors := make(map[uint][]uint, len(items))
+	inDegree := make(map[uint]int, len(items))
+	for _, item := range items {
+		for _, dep := range item.DependsOn {
+			if _, ok := index[dep]; !ok {
+				return nil, fmt.Errorf("%w: %d depends on %d", ErrUnknownDependency, item.ID, dep)
+			}
+			successors[dep] = append(successors[dep], item.ID)
+			inDegree[item.ID]++
+		}
+	}
+
+	order, err := topologicalOrder(items, successors, inDegree)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[uint]*Entry, len(items))
+
+	// Forward pass: an item starts as soon as all of its dependencies finish
+	finish := start
+	for _, id := range order {
+		item := items[index[id]]
+		earliest := start
+		for _, dep := range item.DependsOn {
+			if entries[dep].EarliestFinish.After(earliest) {
+				earliest = entries[dep].EarliestFinish
+			}
+		}
+		entry := &Entry{
+			ID:             id,
+			Duration:       item.Duration,
+			DependsOn:      item.DependsOn,
+			EarliestStart:  earliest,
+			EarliestFinish: earliest.Add(item.Duration),
+		}
+		if entry.EarliestFinish.After(finish) {
+			finish = entry.EarliestFinish
+		}
+		entries[id] = entry
+	}
+
+	// Backward pass: an item must finish before its successors have to start,
+	// before the project finishes and before its own deadline
+	for i := len(order) - 1; i >= 0; i-- {
+		id := order[i]
+		item := items[index[id]]
+		entry := entries[id]
+
+		latest := finish
+		for _, succ := range successors[id] {
+			if entries[succ].LatestStart.Before(latest) {
+				latest = entries[succ].LatestStart
+			}
+		}
+		if item.Deadline != nil && item.Deadline.Before(latest) {
+			latest = *item.Deadline
+		}
+
+		entry.LatestFinish = latest
+		entry.LatestStart = latest.Add(-item.Duration)
+		entry.Slack = entry.LatestStart.Sub(entry.EarliestStart)
+		entry.Late = item.Deadline != nil && entry.EarliestFinish.After(*item.Deadline)
+	}
+
+	// Critical items are those with the least slack (zero unless a deadline is tight)
+	result := &Schedule{Start: start, Finish: finish, Entries: make([]Entry, 0, len(order))}
+	if len(order) > 0 {
+		minSlack := entries[order[0]].Slack
+		for _, id := range order {
+			if entries[id].Slack < minSlack {
+				minSlack = entries[id].Slack
+			}
+		}
+		for _, id := range order {
+			entries[id].Critical = entries[id].Slack == minSlack
+		}
+		result.CriticalPath = criticalPath(order, entries, successors)
+	}
+
+	for _, id := range order {
+		result.Entries = append(result.Entries, *entries[id])
+	}
+	return result, nil
+}
+
+// topologicalOrder orders items so that each comes after its dependencies,
+// picking the lowest ID among the available items at each step
+func topologicalOrder(items []Item, successors map[uint][]uint, inDegree map[uint]int) ([]uint, error) {
+	remaining := make(map[uint]int, len(inDegree))
+	for id, degree := range inDegree {
+		remaining[id] = degree
+	}
+
+	var ready []uint
+	for _, item := range items {
+		if remaining[item.ID] == 0 {
+			ready = append(ready, item.ID)
+		}
+	}
+
+	order := make([]uint, 0, len(items))
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool { return ready[i] < ready[j] })
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, id)
+
+		for _, succ := range successors[id] {
+			remaining[succ]--
+			if remaining[succ] == 0 {
+				ready = append(ready, succ)
+			}
+		}
+	}
+
+	if len(order) != len(items) {
+		return nil, ErrCycle
+	}
+	return order, nil
+}
+
+// criticalPath follows critical items that start exactly when their
+// predecessor finishes, beginning with the earliest critical item
+func criticalPath(order []uint, entries map[uint]*Entry, successors map[uint][]uint) []uint {
+	var current *Entry
+	for _, id := range order {
+		entry := entries[id]
+		if !entry.Critical {
+			continue
+		}
+		if current == nil || entry.EarliestStart.Before(current.EarliestStart) {
+			current = entry
+		}
+	}
+
+	var path []uint
+	for current != nil {
+		path = append(path, current.ID)
+
+		var next *Entry
+		for _, succ := range successors[current.ID] {
+			candidate := entries[succ]
+			if !candidate.Critical || !candidate.EarliestStart.Equal(current.EarliestFinish) {
+				continue
+			}
+			if next == nil || candidate.ID < next.ID {
+				next = candidate
+			}
+		}
+		current = next
+	}
+	return path
+}
@@ -0,0 +1,172 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+func hours(h int) time.Duration { return time.Duration(h) * time.Hour }
+
+func at(h int) time.Time { return start.Add(hours(h)) }
+
+// Test function for Compute on well-formed inputs
+func TestCompute(t *testing.T) {
+	deadline := at(5)
+
+	tests := []struct {
+		name         string
+		items        []Item
+		finish       time.Time
+		criticalPath []uint
+		// expected earliest start, slack and critical flag per item
+		earliestStart map[uint]time.Time
+		slack         map[uint]time.Duration
+		critical      map[uint]bool
+		late          map[uint]bool
+	}{
+		{
+			name:          "empty project finishes at start",
+			items:         nil,
+			finish:        start,
+			criticalPath:  nil,
+			earliestStart: map[uint]time.Time{},
+		},
+		{
+			name: "single chain is entirely critical",
+			items: []Item{
+				{ID: 1, Duration: hours(2)},
+				{ID: 2, Duration: hours(3), DependsOn: []uint{1}},
+				{ID: 3, Duration: hours(1), DependsOn: []uint{2}},
+			},
+			finish:        at(6),
+			criticalPath:  []uint{1, 2, 3},
+			earliestStart: map[uint]time.Time{1: at(0), 2: at(2), 3: at(5)},
+			slack:         map[uint]time.Duration{1: 0, 2: 0, 3: 0},
+			critical:      map[uint]bool{1: true, 2: true, 3: true},
+		},
+		{
+			name: "shorter branch of a diamond has slack",
+			items: []Item{
+				{ID: 1, Duration: hours(1)},
+				{ID: 2, Duration: hours(4), DependsOn: []uint{1}},
+				{ID: 3, Duration: hours(2), DependsOn: []uint{1}},
+				{ID: 4, Duration: hours(1), DependsOn: []uint{2, 3}},
+			},
+			finish:        at(6),
+			criticalPath:  []uint{1, 2, 4},
+			earliestStart: map[uint]time.Time{1: at(0), 2: at(1), 3: at(1), 4: at(5)},
+			slack:         map[uint]time.Duration{1: 0, 2: 0, 3: hours(2), 4: 0},
+			critical:      map[uint]bool{1: true, 2: true, 3: false, 4: true},
+		},
+		{
+			name: "independent items run in parallel",
+			items: []Item{
+				{ID: 1, Duration: hours(3)},
+				{ID: 2, Duration: hours(1)},
+			},
+			finish:        at(3),
+			criticalPath:  []uint{1},
+			earliestStart: map[uint]time.Time{1: at(0), 2: at(0)},
+			slack:         map[uint]time.Duration{1: 0, 2: hours(2)},
+			critical:      map[uint]bool{1: true, 2: false},
+		},
+		{
+			name: "tight deadline produces negative slack",
+			items: []Item{
+				{ID: 1, Duration: hours(4)},
+				{ID: 2, Duration: hours(2), DependsOn: []uint{1}, Deadline: &deadline},
+				{ID: 3, Duration: hours(7)},
+			},
+			finish:        at(7),
+			criticalPath:  []uint{1, 2},
+			earliestStart: map[uint]time.Time{1: at(0), 2: at(4), 3: at(0)},
+			slack:         map[uint]time.Duration{1: -hours(1), 2: -hours(1), 3: 0},
+			critical:      map[uint]bool{1: true, 2: true, 3: false},
+			late:          map[uint]bool{1: false, 2: true, 3: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Compute(start, tt.items)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.finish, result.Finish)
+			assert.Equal(t, tt.criticalPath, result.CriticalPath)
+			require.Len(t, result.Entries, len(tt.items))
+
+			for _, entry := range result.Entries {
+				assert.Equal(t, tt.earliestStart[entry.ID], entry.EarliestStart, "earliest start of %d", entry.ID)
+				assert.Equal(t, entry.EarliestStart.Add(entry.Duration), entry.EarliestFinish, "earliest finish of %d", entry.ID)
+				assert.Equal(t, entry.LatestStart.Add(entry.Duration), entry.LatestFinish, "latest finish of %d", entry.ID)
+				assert.Equal(t, tt.slack[entry.ID], entry.Slack, "slack of %d", entry.ID)
+				assert.Equal(t, tt.critical[entry.ID], entry.Critical, "critical flag of %d", entry.ID)
+				assert.Equal(t, tt.late[entry.ID], entry.Late, "late flag of %d", entry.ID)
+			}
+		})
+	}
+}
+
+// Entries must come after everything they depend on
+func TestCompute_TopologicalOrder(t *testing.T) {
+	items := []Item{
+		{ID: 5, Duration: hours(1), DependsOn: []uint{7}},
+		{ID: 7, Duration: hours(1)},
+		{ID: 6, Duration: hours(1), DependsOn: []uint{5}},
+	}
+
+	result, err := Compute(start, items)
+	require.NoError(t, err)
+
+	var order []uint
+	for _, entry := range result.Entries {
+		order = append(order, entry.ID)
+	}
+	assert.Equal(t, []uint{7, 5, 6}, order)
+}
+
+// Test function for Compute on malformed inputs
+func TestCompute_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []Item
+		err   error
+	}{
+		{
+			name: "cycle",
+			items: []Item{
+				{ID: 1, DependsOn: []uint{3}},
+				{ID: 2, DependsOn: []uint{1}},
+				{ID: 3, DependsOn: []uint{2}},
+			},
+			err: ErrCycle,
+		},
+		{
+			name:  "self dependency",
+			items: []Item{{ID: 1, DependsOn: []uint{1}}},
+			err:   ErrCycle,
+		},
+		{
+			name:  "unknown dependency",
+			items: []Item{{ID: 1, DependsOn: []uint{42}}},
+			err:   ErrUnknownDependency,
+		},
+		{
+			name:  "duplicate item",
+			items: []Item{{ID: 1}, {ID: 1}},
+			err:   ErrDuplicateItem,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compute(start, tt.items)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
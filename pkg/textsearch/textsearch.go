@@ -0,0 +1,192 @@
+// Package textsearch finds the words of a search in text and marks where
+// they appear. Matching is case-insensitive and positions are rune offsets,
+// so clients can highlight them in any language.
+package textsearch
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// MaxWords caps the words taken from a search
+const MaxWords = 10
+
+// Ellipsis marks text cut from either side of a snippet
+const Ellipsis = "…"
+
+// Marker runes wrap highlighted words in text produced elsewhere, e.g. by
+// Postgres' ts_headline. They come from the private use area, so they
+// don't occur in ordinary text.
+const (
+	MarkStart = "\uE000"
+	MarkStop  = "\uE001"
+)
+
+// Span is a highlighted range of a text: runes Start up to End
+type Span struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Text is a text with its highlighted spans, in order and apart. Texts
+// made by this package have an empty, not nil, Highlights without any.
+type Text struct {
+	Text       string `json:"text"`
+	Highlights []Span `json:"highlights"`
+}
+
+// isWordRune reports whether r is part of a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Words splits a search into its distinct lowercase words, at most MaxWords
+func Words(search string) []string {
+	var words []string
+	seen := map[string]bool{}
+	for _, word := range strings.FieldsFunc(search, func(r rune) bool { return !isWordRune(r) }) {
+		word = lower(word)
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		words = append(words, word)
+		if len(words) == MaxWords {
+			break
+		}
+	}
+	return words
+}
+
+// lower lowercases rune by rune, keeping every rune at its offset
+func lower(s string) string {
+	return strings.Map(unicode.ToLower, s)
+}
+
+// find returns the spans of text where one of the words occurs, in order
+// and merged where they touch
+func find(text []rune, words []string) []Span {
+	folded := []rune(lower(string(text)))
+	spans := []Span{}
+	for _, word := range words {
+		needle := []rune(word)
+		for i := 0; i+len(needle) <= len(folded); i++ {
+			if string(folded[i:i+len(needle)]) == word {
+				spans = append(spans, Span{Start: i, End: i + len(needle)})
+			}
+		}
+	}
+	if len(spans) == 0 {
+		return spans
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].Start < spans[j].Start })
+	merged := spans[:1]
+	for _, span := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if span.Start <= last.End {
+			if span.End > last.End {
+				last.End = span.End
+			}
+			continue
+		}
+		merged = append(merged, span)
+	}
+	return merged
+}
+
+// Count returns how often the words occur in text, overlaps included
+func Count(text string, words []string) int {
+	folded := lower(text)
+	count := 0
+	for _, word := range words {
+		count += strings.Count(folded, word)
+	}
+	return count
+}
+
+// Highlight marks every occurrence of the words in text
+func Highlight(text string, words []string) Text {
+	return Text{Text: text, Highlights: find([]rune(text), words)}
+}
+
+// Snippet cuts text down to about size runes around the first occurrence
+// of the words, marking the occurrences it keeps. Text without one is cut
+// from its start.
+func Snippet(text string, words []string, size int) Text {
+	runes := []rune(text)
+	if len(runes) <= size {
+		return Highlight(text, words)
+	}
+
+	spans := find(runes, words)
+	start := 0
+	if len(spans) > 0 {
+		// Some context before the first occurrence
+		start = spans[0].Start - size/4
+		if start > len(runes)-size {
+			start = len(runes) - size
+		}
+		if start < 0 {
+			start = 0
+		}
+	}
+	end := start + size
+
+	// Widen the cut rather than split a word, then drop the spaces at its ends
+	for start > 0 && isWordRune(runes[start-1]) && isWordRune(runes[start]) {
+		start--
+	}
+	for end < len(runes) && isWordRune(runes[end-1]) && isWordRune(runes[end]) {
+		end++
+	}
+	for start < end && unicode.IsSpace(runes[start]) {
+		start++
+	}
+	for end > start && unicode.IsSpace(runes[end-1]) {
+		end--
+	}
+
+	snippet := Text{Highlights: []Span{}}
+	offset := -start
+	if start > 0 {
+		snippet.Text = Ellipsis
+		offset += len([]rune(Ellipsis))
+	}
+	snippet.Text += string(runes[start:end])
+	if end < len(runes) {
+		snippet.Text += Ellipsis
+	}
+	for _, span := range spans {
+		if span.Start >= start && span.End <= end {
+			snippet.Highlights = append(snippet.Highlights, Span{Start: span.Start + offset, End: span.End + offset})
+		}
+	}
+	return snippet
+}
+
+// Unmark turns text with highlights wrapped in MarkStart and MarkStop into
+// a Text. Stray markers are dropped.
+func Unmark(marked string) Text {
+	var text strings.Builder
+	spans := []Span{}
+	pos, open := 0, -1
+	for _, r := range marked {
+		switch string(r) {
+		case MarkStart:
+			if open < 0 {
+				open = pos
+			}
+		case MarkStop:
+			if open >= 0 && pos > open {
+				spans = append(spans, Span{Start: open, End: pos})
+			}
+			open = -1
+		default:
+			text.WriteRune(r)
+			pos++
+		}
+	}
+	return Text{Text: text.String(), Highlights: spans}
+}
@@ -0,0 +1,116 @@
+package textsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test function for Words on searches with punctuation, case and repeats
+func TestWords(t *testing.T) {
+	tests := []struct {
+		name   string
+		search string
+		want   []string
+	}{
+		{name: "plain", search: "login page", want: []string{"login", "page"}},
+		{name: "case and repeats", search: "Login LOGIN login", want: []string{"login"}},
+		{name: "punctuation", search: `"crash-on" save!`, want: []string{"crash", "on", "save"}},
+		{name: "unicode", search: "Größe ÄNDERN", want: []string{"größe", "ändern"}},
+		{name: "no words", search: " -- ", want: nil},
+		{name: "capped", search: "a b c d e f g h i j k l", want: []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Words(tt.search))
+		})
+	}
+}
+
+// Test function for Count and Highlight
+func TestHighlight(t *testing.T) {
+	words := []string{"log", "login"}
+	text := "Login fails; see the LOG. Größe"
+
+	assert.Equal(t, 3, Count(text, words))
+	// Overlapping matches merge
+	assert.Equal(t, Text{Text: text, Highlights: []Span{{Start: 0, End: 5}, {Start: 21, End: 24}}}, Highlight(text, words))
+	// Offsets count runes, not bytes
+	assert.Equal(t, []Span{{Start: 26, End: 31}}, Highlight(text, []string{"größe"}).Highlights)
+	assert.Equal(t, []Span{}, Highlight(text, []string{"absent"}).Highlights)
+}
+
+// Test function for Snippet around the first match
+func TestSnippet(t *testing.T) {
+	text := "The first part of this description is long. The crash happens when saving a draft twice in a row."
+
+	tests := []struct {
+		name  string
+		words []string
+		size  int
+		want  Text
+	}{
+		{
+			name:  "short text is kept",
+			words: []string{"crash"},
+			size:  200,
+			want:  Text{Text: text, Highlights: []Span{{Start: 48, End: 53}}},
+		},
+		{
+			name:  "cut around the match",
+			words: []string{"crash"},
+			size:  30,
+			want:  Text{Text: "…long. The crash happens when saving…", Highlights: []Span{{Start: 11, End: 16}}},
+		},
+		{
+			name:  "match at the end",
+			words: []string{"row"},
+			size:  20,
+			want:  Text{Text: "…draft twice in a row.", Highlights: []Span{{Start: 18, End: 21}}},
+		},
+		{
+			name:  "no match cuts from the start",
+			words: []string{"absent"},
+			size:  12,
+			want:  Text{Text: "The first part…", Highlights: []Span{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Snippet(text, tt.words, tt.size))
+		})
+	}
+}
+
+// Test function for Unmark on marked text
+func TestUnmark(t *testing.T) {
+	tests := []struct {
+		name   string
+		marked string
+		want   Text
+	}{
+		{
+			name:   "two highlights",
+			marked: "a " + MarkStart + "crash" + MarkStop + " on " + MarkStart + "säve" + MarkStop,
+			want:   Text{Text: "a crash on säve", Highlights: []Span{{Start: 2, End: 7}, {Start: 11, End: 15}}},
+		},
+		{
+			name:   "no highlights",
+			marked: "plain",
+			want:   Text{Text: "plain", Highlights: []Span{}},
+		},
+		{
+			name:   "stray markers",
+			marked: MarkStop + "a" + MarkStart + MarkStop + "b" + MarkStart,
+			want:   Text{Text: "ab", Highlights: []Span{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Unmark(tt.marked))
+		})
+	}
+}
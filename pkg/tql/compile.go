@@ -0,0 +1,105 @@
+package tql
+
+import (
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FieldFunc compiles a term to a condition. Values must reach the SQL as
+// bind variables (clause.Expr Vars), never as part of the SQL text. An
+// error is reported at the term's value.
+type FieldFunc func(term Term) (clause.Expression, error)
+
+// Schema gives the fields of a query their meaning. Text compiles free
+// text terms and may be nil to reject them. Sorts maps each sort key to the
+// ORDER BY expression of ascending order; descending order is added by
+// Compile.
+type Schema struct {
+	Fields map[string]FieldFunc
+	Text   FieldFunc
+	Sorts  map[string]string
+}
+
+// Compiled is a query compiled against a schema
+type Compiled struct {
+	Conditions []clause.Expression
+	Order      []string
+}
+
+// Compile turns the terms of a query into conditions and its sort keys into
+// ORDER BY expressions. Unknown fields and sort keys, and values a field
+// rejects, are reported as an *Error at their position.
+func (s Schema) Compile(q *Query) (*Compiled, error) {
+	compiled := &Compiled{}
+	for _, term := range q.Terms {
+		compile := s.Text
+		if term.Field != "" {
+			compile = s.Fields[term.Field]
+			if compile == nil {
+				return nil, errorf(term.Pos, "unknown field %q; use one of %s", term.Field, strings.Join(s.fieldNames(), ", "))
+			}
+		} else if compile == nil {
+			return nil, errorf(term.Pos, "free text isn't supported; use field:value")
+		}
+
+		cond, err := compile(term)
+		if err != nil {
+			if _, ok := err.(*Error); ok {
+				return nil, err
+			}
+			return nil, errorf(term.ValuePos, "%s", err.Error())
+		}
+		if term.Negated {
+			cond = clause.Expr{SQL: "NOT (?)", Vars: []interface{}{cond}}
+		}
+		compiled.Conditions = append(compiled.Conditions, cond)
+	}
+
+	for _, key := range q.Sort {
+		order, ok := s.Sorts[key.Key]
+		if !ok {
+			return nil, errorf(key.Pos, "can't sort by %q; use one of %s", key.Key, strings.Join(s.sortNames(), ", "))
+		}
+		if key.Desc {
+			order += " DESC NULLS LAST"
+		} else {
+			order += " ASC NULLS LAST"
+		}
+		compiled.Order = append(compiled.Order, order)
+	}
+	return compiled, nil
+}
+
+// fieldNames lists the schema's fields in alphabetical order
+func (s Schema) fieldNames() []string {
+	names := make([]string, 0, len(s.Fields))
+	for name := range s.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortNames lists the schema's sort keys in alphabetical order
+func (s Schema) sortNames() []string {
+	names := make([]string, 0, len(s.Sorts))
+	for name := range s.Sorts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Apply adds the conditions and order to db
+func (c *Compiled) Apply(db *gorm.DB) *gorm.DB {
+	for _, cond := range c.Conditions {
+		db = db.Where(cond)
+	}
+	for _, order := range c.Order {
+		db = db.Order(order)
+	}
+	return db
+}
@@ -0,0 +1,347 @@
+// Package tql parses a small query language for searching tasks, e.g.
+//
+//	status:open assignee:me due<7d label:bug -label:wontfix "login page" sort:priority
+//
+// A query is a list of terms that must all match. A term is a field
+// comparison (field:value, field<value, field>value, field<=value or
+// field>=value) or free text, bare or in double quotes; a leading "-"
+// negates it. The sort term takes a comma-separated list of sort keys, each
+// prefixed with "-" for descending order. Values containing spaces or
+// quotes are written in double quotes, with \" and \\ as escapes.
+//
+// Parse only checks the syntax; a Schema gives fields their meaning and
+// compiles a query to parameterised GORM conditions.
+package tql
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Limits of a query
+const (
+	MaxLength = 1000
+	MaxTerms  = 50
+)
+
+// SortField is the field name of the sort term
+const SortField = "sort"
+
+// Op is the comparison of a field term
+type Op string
+
+// Comparisons
+const (
+	OpEq  Op = ":"
+	OpLt  Op = "<"
+	OpGt  Op = ">"
+	OpLte Op = "<="
+	OpGte Op = ">="
+)
+
+// Term is one condition of a query. Field is empty for free text. Pos and
+// ValuePos are the 1-based character positions of the term and its value.
+type Term struct {
+	Pos      int
+	Negated  bool
+	Field    string
+	Op       Op
+	Value    string
+	ValuePos int
+}
+
+// SortKey orders the results by a key of the schema
+type SortKey struct {
+	Pos  int
+	Key  string
+	Desc bool
+}
+
+// Query is a parsed query
+type Query struct {
+	Terms []Term
+	Sort  []SortKey
+}
+
+// Error reports a problem with a query at a 1-based character position
+type Error struct {
+	Pos     int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Pos)
+}
+
+// errorf creates an *Error
+func errorf(pos int, format string, args ...interface{}) *Error {
+	return &Error{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// parser reads a query one character at a time; pos indexes input, which
+// is also the 0-based character position
+type parser struct {
+	input []rune
+	pos   int
+}
+
+// Parse reads a query. Field names are case-insensitive and returned in
+// lower case.
+func Parse(s string) (*Query, error) {
+	p := &parser{input: []rune(s)}
+	if len(p.input) > MaxLength {
+		return nil, errorf(MaxLength+1, "query is longer than %d characters", MaxLength)
+	}
+
+	q := &Query{}
+	sorted := false
+	for {
+		p.skipSpace()
+		if p.done() {
+			return q, nil
+		}
+		if len(q.Terms)+len(q.Sort) >= MaxTerms {
+			return nil, errorf(p.pos+1, "query has more than %d terms", MaxTerms)
+		}
+
+		term, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		if term.Field != SortField {
+			q.Terms = append(q.Terms, term)
+			continue
+		}
+
+		switch {
+		case sorted:
+			return nil, errorf(term.Pos, "sort is given twice")
+		case term.Negated:
+			return nil, errorf(term.Pos, "sort can't be negated")
+		case term.Op != OpEq:
+			return nil, errorf(term.ValuePos-len(term.Op), "sort takes a list like sort:priority,-due")
+		}
+		sorted = true
+		if q.Sort, err = parseSortKeys(term); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) skipSpace() {
+	for !p.done() && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+// isFieldRune reports whether r may appear in a field name
+func isFieldRune(r rune) bool {
+	return r == '_' || r == '.' || (r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)))
+}
+
+// isOpRune reports whether r starts a comparison
+func isOpRune(r rune) bool {
+	return r == ':' || r == '<' || r == '>'
+}
+
+// term reads one term
+func (p *parser) term() (Term, error) {
+	term := Term{Pos: p.pos + 1}
+	if p.input[p.pos] == '-' {
+		term.Negated = true
+		p.pos++
+		if p.done() || unicode.IsSpace(p.input[p.pos]) {
+			return term, errorf(term.Pos, "expected a term after -")
+		}
+	}
+
+	if p.input[p.pos] == '"' {
+		term.ValuePos = p.pos + 1
+		value, err := p.quoted()
+		if err != nil {
+			return term, err
+		}
+		term.Value = value
+		return term, p.endOfTerm()
+	}
+
+	// A field name if a comparison follows it, free text otherwise
+	start := p.pos
+	for !p.done() && isFieldRune(p.input[p.pos]) {
+		p.pos++
+	}
+	if p.done() || !isOpRune(p.input[p.pos]) {
+		p.pos = start
+		term.ValuePos = p.pos + 1
+		value, err := p.bare()
+		if err != nil {
+			return term, err
+		}
+		term.Value = value
+		return term, nil
+	}
+	if p.pos == start {
+		return term, errorf(p.pos+1, "expected a field name before %q", p.input[p.pos])
+	}
+	term.Field = strings.ToLower(string(p.input[start:p.pos]))
+
+	term.Op = Op(p.input[p.pos])
+	p.pos++
+	if term.Op != OpEq && !p.done() && p.input[p.pos] == '=' {
+		term.Op += "="
+		p.pos++
+	}
+
+	term.ValuePos = p.pos + 1
+	switch {
+	case p.done() || unicode.IsSpace(p.input[p.pos]):
+		return term, errorf(p.pos+1, "expected a value after %s%s", term.Field, term.Op)
+	case p.input[p.pos] == '"':
+		value, err := p.quoted()
+		if err != nil {
+			return term, err
+		}
+		term.Value = value
+		return term, p.endOfTerm()
+	default:
+		value, err := p.bare()
+		if err != nil {
+			return term, err
+		}
+		term.Value = value
+		return term, nil
+	}
+}
+
+// bare reads a value up to the next space
+func (p *parser) bare() (string, error) {
+	start := p.pos
+	for !p.done() && !unicode.IsSpace(p.input[p.pos]) {
+		if p.input[p.pos] == '"' {
+			return "", errorf(p.pos+1, "unexpected quote; quote the whole value instead")
+		}
+		p.pos++
+	}
+	return string(p.input[start:p.pos]), nil
+}
+
+// quoted reads a value in double quotes
+func (p *parser) quoted() (string, error) {
+	open := p.pos
+	p.pos++
+	var b strings.Builder
+	for !p.done() {
+		r := p.input[p.pos]
+		switch r {
+		case '"':
+			p.pos++
+			return b.String(), nil
+		case '\\':
+			if p.pos+1 < len(p.input) && (p.input[p.pos+1] == '"' || p.input[p.pos+1] == '\\') {
+				p.pos++
+				r = p.input[p.pos]
+			}
+		}
+		b.WriteRune(r)
+		p.pos++
+	}
+	return "", errorf(open+1, "unterminated quoted string")
+}
+
+// endOfTerm checks that a space or the end of the query follows a quoted value
+func (p *parser) endOfTerm() error {
+	if !p.done() && !unicode.IsSpace(p.input[p.pos]) {
+		return errorf(p.pos+1, "expected a space after the closing quote")
+	}
+	return nil
+}
+
+// ParseSort reads a list of sort keys as given to the sort term, e.g.
+// "priority,-due"; an empty list has no keys
+func ParseSort(s string) ([]SortKey, error) {
+	if len([]rune(s)) > MaxLength {
+		return nil, errorf(MaxLength+1, "sort is longer than %d characters", MaxLength)
+	}
+	if s == "" {
+		return nil, nil
+	}
+	return parseSortKeys(Term{Value: s, ValuePos: 1})
+}
+
+// parseSortKeys reads the keys of a sort term
+func parseSortKeys(term Term) ([]SortKey, error) {
+	var keys []SortKey
+	pos := term.ValuePos
+	seen := map[string]bool{}
+	for _, part := range strings.Split(term.Value, ",") {
+		key := SortKey{Pos: pos, Key: strings.ToLower(strings.TrimPrefix(part, "-")), Desc: strings.HasPrefix(part, "-")}
+		pos += len([]rune(part)) + 1
+
+		if key.Key == "" {
+			return nil, errorf(key.Pos, "expected a sort key")
+		}
+		for _, r := range key.Key {
+			if !isFieldRune(r) {
+				return nil, errorf(key.Pos, "invalid sort key %q", part)
+			}
+		}
+		if seen[key.Key] {
+			return nil, errorf(key.Pos, "sort key %q is given twice", key.Key)
+		}
+		seen[key.Key] = true
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// quote writes a value the way Parse reads it back. Free text is quoted
+// when it could be taken for a negation or a field term; values of fields
+// when a comparison could swallow their leading "=".
+func quote(value string, free bool) string {
+	needsQuotes := value == "" || strings.ContainsRune(value, '"') || strings.IndexFunc(value, unicode.IsSpace) >= 0
+	if free {
+		needsQuotes = needsQuotes || strings.HasPrefix(value, "-") || strings.IndexFunc(value, isOpRune) >= 0
+	} else {
+		needsQuotes = needsQuotes || strings.HasPrefix(value, "=")
+	}
+	if !needsQuotes {
+		return value
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// String formats the query in its canonical form, which parses back to the
+// same terms
+func (q *Query) String() string {
+	parts := make([]string, 0, len(q.Terms)+1)
+	for _, term := range q.Terms {
+		var b strings.Builder
+		if term.Negated {
+			b.WriteString("-")
+		}
+		if term.Field != "" {
+			b.WriteString(term.Field)
+			b.WriteString(string(term.Op))
+		}
+		b.WriteString(quote(term.Value, term.Field == ""))
+		parts = append(parts, b.String())
+	}
+
+	if len(q.Sort) > 0 {
+		keys := make([]string, 0, len(q.Sort))
+		for _, key := range q.Sort {
+			if key.Desc {
+				keys = append(keys, "-"+key.Key)
+			} else {
+				keys = append(keys, key.Key)
+			}
+		}
+		parts = append(parts, SortField+":"+strings.Join(keys, ","))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,330 @@
+package tql
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Test function for Parse on valid queries
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantTerms []Term
+		wantSort  []SortKey
+	}{
+		{name: "empty", input: "  "},
+		{
+			name:  "example",
+			input: "status:open assignee:me due<7d label:bug -label:wontfix sort:priority",
+			wantTerms: []Term{
+				{Pos: 1, Field: "status", Op: OpEq, Value: "open", ValuePos: 8},
+				{Pos: 13, Field: "assignee", Op: OpEq, Value: "me", ValuePos: 22},
+				{Pos: 25, Field: "due", Op: OpLt, Value: "7d", ValuePos: 29},
+				{Pos: 32, Field: "label", Op: OpEq, Value: "bug", ValuePos: 38},
+				{Pos: 42, Negated: true, Field: "label", Op: OpEq, Value: "wontfix", ValuePos: 49},
+			},
+			wantSort: []SortKey{{Pos: 62, Key: "priority"}},
+		},
+		{
+			name:  "comparisons",
+			input: "due<=2024-01-31 created>=3d due>-1w",
+			wantTerms: []Term{
+				{Pos: 1, Field: "due", Op: OpLte, Value: "2024-01-31", ValuePos: 6},
+				{Pos: 17, Field: "created", Op: OpGte, Value: "3d", ValuePos: 26},
+				{Pos: 29, Field: "due", Op: OpGt, Value: "-1w", ValuePos: 33},
+			},
+		},
+		{
+			name:  "free text",
+			input: `login -"page not found" crash-report`,
+			wantTerms: []Term{
+				{Pos: 1, Value: "login", ValuePos: 1},
+				{Pos: 7, Negated: true, Value: "page not found", ValuePos: 8},
+				{Pos: 25, Value: "crash-report", ValuePos: 25},
+			},
+		},
+		{
+			name:  "quoted values and escapes",
+			input: `label:"needs review" title:"say \"hi\" \\o/"`,
+			wantTerms: []Term{
+				{Pos: 1, Field: "label", Op: OpEq, Value: "needs review", ValuePos: 7},
+				{Pos: 22, Field: "title", Op: OpEq, Value: `say "hi" \o/`, ValuePos: 28},
+			},
+		},
+		{
+			name:      "field names are case-insensitive",
+			input:     "Label:Bug",
+			wantTerms: []Term{{Pos: 1, Field: "label", Op: OpEq, Value: "Bug", ValuePos: 7}},
+		},
+		{
+			name:      "multi-byte characters count once",
+			input:     "épée label:x",
+			wantTerms: []Term{{Pos: 1, Value: "épée", ValuePos: 1}, {Pos: 6, Field: "label", Op: OpEq, Value: "x", ValuePos: 12}},
+		},
+		{
+			name:     "sort keys",
+			input:    "sort:-due,Priority",
+			wantSort: []SortKey{{Pos: 6, Key: "due", Desc: true}, {Pos: 11, Key: "priority"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTerms, q.Terms)
+			assert.Equal(t, tt.wantSort, q.Sort)
+		})
+	}
+}
+
+// Test function for the positions of Parse errors
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantPos int
+	}{
+		{name: "lone negation", input: "bug - x", wantPos: 5},
+		{name: "missing value", input: "label: bug", wantPos: 7},
+		{name: "missing value at end", input: "due<", wantPos: 5},
+		{name: "missing field", input: "bug :x", wantPos: 5},
+		{name: "unterminated quote", input: `label:"needs review`, wantPos: 7},
+		{name: "quote inside value", input: `label:a"b`, wantPos: 8},
+		{name: "text after quote", input: `"a"b`, wantPos: 4},
+		{name: "sort twice", input: "sort:due x sort:title", wantPos: 12},
+		{name: "negated sort", input: "-sort:due", wantPos: 1},
+		{name: "sort comparison", input: "sort<due", wantPos: 5},
+		{name: "empty sort key", input: "sort:due,,title", wantPos: 10},
+		{name: "invalid sort key", input: "sort:due,ti*tle", wantPos: 10},
+		{name: "repeated sort key", input: "sort:due,-due", wantPos: 10},
+		{name: "too long", input: strings.Repeat("a", MaxLength+1), wantPos: MaxLength + 1},
+		{name: "too many terms", input: strings.Repeat("a ", MaxTerms+1), wantPos: 2*MaxTerms + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			var parseErr *Error
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, tt.wantPos, parseErr.Pos, parseErr.Message)
+		})
+	}
+}
+
+// Test function for String producing queries that parse back to the same terms
+func TestString(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "status:open  -label:wontfix sort:priority,-due", want: "status:open -label:wontfix sort:priority,-due"},
+		{input: `"a:b" "-x" "two words" ""`, want: `"a:b" "-x" "two words" ""`},
+		{input: `label:"a b" title:"say \"hi\"" due<"=x"`, want: `label:"a b" title:"say \"hi\"" due<"=x"`},
+		{input: `path:a\b`, want: `path:a\b`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			q, err := Parse(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, q.String())
+		})
+	}
+}
+
+// Test function for ParseSort
+func TestParseSort(t *testing.T) {
+	keys, err := ParseSort("priority,-Due")
+	require.NoError(t, err)
+	assert.Equal(t, []SortKey{{Pos: 1, Key: "priority"}, {Pos: 10, Key: "due", Desc: true}}, keys)
+
+	keys, err = ParseSort("")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	_, err = ParseSort("due,due")
+	var parseErr *Error
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, 5, parseErr.Pos)
+}
+
+// testSchema compiles label and free text terms to comparisons on one column
+var testSchema = Schema{
+	Fields: map[string]FieldFunc{
+		"label": func(term Term) (clause.Expression, error) {
+			if term.Op != OpEq {
+				return nil, errors.New("label only supports label:name")
+			}
+			return clause.Expr{SQL: "label = ?", Vars: []interface{}{term.Value}}, nil
+		},
+	},
+	Text: func(term Term) (clause.Expression, error) {
+		return clause.Expr{SQL: "title LIKE ?", Vars: []interface{}{"%" + term.Value + "%"}}, nil
+	},
+	Sorts: map[string]string{"title": "title"},
+}
+
+// dryRunDB returns a database that builds SQL without connecting
+func dryRunDB(t testing.TB) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+	return db
+}
+
+// Test function for Compile and the SQL it builds
+func TestCompile(t *testing.T) {
+	db := dryRunDB(t)
+
+	tests := []struct {
+		name     string
+		input    string
+		wantSQL  string
+		wantVars []interface{}
+		wantPos  int
+	}{
+		{
+			name:     "conditions and order",
+			input:    "label:bug -label:wontfix crash sort:-title",
+			wantSQL:  `SELECT * FROM "tasks" WHERE label = $1 AND NOT (label = $2) AND title LIKE $3 ORDER BY title DESC NULLS LAST`,
+			wantVars: []interface{}{"bug", "wontfix", "%crash%"},
+		},
+		{
+			name:     "values never reach the SQL text",
+			input:    `label:"x' OR 1=1 --"`,
+			wantSQL:  `SELECT * FROM "tasks" WHERE label = $1`,
+			wantVars: []interface{}{"x' OR 1=1 --"},
+		},
+		{name: "unknown field", input: "bug owner:me", wantPos: 5},
+		{name: "rejected value", input: "label<bug", wantPos: 7},
+		{name: "unknown sort key", input: "sort:title,due", wantPos: 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.input)
+			require.NoError(t, err)
+
+			compiled, err := testSchema.Compile(q)
+			if tt.wantPos != 0 {
+				var compileErr *Error
+				require.ErrorAs(t, err, &compileErr)
+				assert.Equal(t, tt.wantPos, compileErr.Pos)
+				return
+			}
+			require.NoError(t, err)
+
+			stmt := compiled.Apply(db.Table("tasks")).Find(&[]map[string]interface{}{}).Statement
+			assert.Equal(t, tt.wantSQL, stmt.SQL.String())
+			assert.Equal(t, tt.wantVars, stmt.Vars)
+		})
+	}
+}
+
+// Test function for Compile rejecting free text without a Text function
+func TestCompileNoText(t *testing.T) {
+	q, err := Parse("label:bug crash")
+	require.NoError(t, err)
+
+	_, err = Schema{Fields: testSchema.Fields}.Compile(q)
+	var compileErr *Error
+	require.ErrorAs(t, err, &compileErr)
+	assert.Equal(t, 11, compileErr.Pos)
+}
+
+// Fuzz function for Parse: errors point into the query and parsed queries
+// survive a round trip through String
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"status:open assignee:me due<7d label:bug -label:wontfix sort:priority",
+		`label:"needs review" -"page not found" title:"say \"hi\""`,
+		"due<=2024-01-31 created>=-1w sort:-due,title",
+		`"a:b" "-x" "" path:a\b due<"=x"`,
+		"- :x sort:a,,b \"open",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		q, err := Parse(input)
+		if err != nil {
+			var parseErr *Error
+			require.ErrorAs(t, err, &parseErr)
+			assert.GreaterOrEqual(t, parseErr.Pos, 1)
+			assert.LessOrEqual(t, parseErr.Pos, utf8.RuneCountInString(input)+1)
+			return
+		}
+
+		canonical := q.String()
+		again, err := Parse(canonical)
+		if err != nil {
+			// Quoting may push a query that was at the limit over it
+			var parseErr *Error
+			require.ErrorAs(t, err, &parseErr)
+			require.Greater(t, utf8.RuneCountInString(canonical), MaxLength, "%q: %v", canonical, err)
+			return
+		}
+		require.Equal(t, len(q.Terms), len(again.Terms), canonical)
+		for i := range q.Terms {
+			want, got := q.Terms[i], again.Terms[i]
+			assert.Equal(t, want.Negated, got.Negated, canonical)
+			assert.Equal(t, want.Field, got.Field, canonical)
+			assert.Equal(t, want.Op, got.Op, canonical)
+			assert.Equal(t, want.Value, got.Value, canonical)
+		}
+		require.Equal(t, len(q.Sort), len(again.Sort), canonical)
+		for i := range q.Sort {
+			assert.Equal(t, q.Sort[i].Key, again.Sort[i].Key, canonical)
+			assert.Equal(t, q.Sort[i].Desc, again.Sort[i].Desc, canonical)
+		}
+	})
+}
+
+// Fuzz function for Compile: every value is bound as a variable and the SQL
+// text only depends on the shape of the query
+func FuzzCompile(f *testing.F) {
+	for _, seed := range []string{
+		"label:bug -label:wontfix crash sort:-title",
+		`label:"'; DROP TABLE tasks; --" "$1 ? ?"`,
+		`-"%_\\" label:""`,
+	} {
+		f.Add(seed)
+	}
+	db := dryRunDB(f)
+
+	f.Fuzz(func(t *testing.T, input string) {
+		q, err := Parse(input)
+		if err != nil {
+			return
+		}
+		compiled, err := testSchema.Compile(q)
+		if err != nil {
+			var compileErr *Error
+			require.ErrorAs(t, err, &compileErr)
+			return
+		}
+
+		stmt := compiled.Apply(db.Table("tasks")).Find(&[]map[string]interface{}{}).Statement
+		require.Len(t, stmt.Vars, len(q.Terms))
+
+		// The same query with placeholder values builds the same SQL
+		shape := &Query{Sort: q.Sort}
+		for _, term := range q.Terms {
+			term.Value = "v"
+			shape.Terms = append(shape.Terms, term)
+		}
+		shaped, err := testSchema.Compile(shape)
+		require.NoError(t, err)
+		want := shaped.Apply(db.Table("tasks")).Find(&[]map[string]interface{}{}).Statement
+		assert.Equal(t, want.SQL.String(), stmt.SQL.String())
+	})
+}
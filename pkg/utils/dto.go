@@ -1,15 +1,20 @@
 // internal/dto/user.go
 package utils
 
-import "time"
+import (
+	"TaskManager/pkg/textsearch"
+	"time"
+)
 
 // UserResponse defines the response structure for user data
 type UserResponse struct {
-	ID        uint      `json:"id"`
-	Email     string    `json:"email"`
-	Username  string    `json:"username"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID            uint      `json:"id"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	PendingEmail  string    `json:"pending_email,omitempty"`
+	Username      string    `json:"username"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 // UserCreateRequest defines the request structure for user creation
@@ -24,3 +29,671 @@ type UserUpdateRequest struct {
 	Email    string `json:"email"`
 	Username string `json:"username"`
 }
+
+// TaskCreateRequest defines the request structure for task creation
+type TaskCreateRequest struct {
+	Title           string     `json:"title" binding:"required"`
+	Description     string     `json:"description"`
+	Status          string     `json:"status"`
+	Priority        string     `json:"priority"`
+	DueDate         *time.Time `json:"due_date"`
+	ProjectID       *uint      `json:"project_id"`
+	ParentID        *uint      `json:"parent_id"`
+	EstimateMinutes int        `json:"estimate_minutes"`
+	// Recurrence is an RRULE such as "FREQ=WEEKLY;BYDAY=MO"; the series starts at the due date
+	Recurrence         string `json:"recurrence"`
+	RecurrenceTimezone string `json:"recurrence_timezone"`
+	// Checklist lists the texts of the task's initial checklist items
+	Checklist          []string `json:"checklist"`
+	ChecklistAdvanceTo string   `json:"checklist_advance_to"`
+	// CustomFields maps custom field keys of the task's project to values
+	CustomFields map[string]interface{} `json:"custom_fields"`
+}
+
+// TaskUpdateRequest defines the request structure for updating task data
+type TaskUpdateRequest struct {
+	Title           *string    `json:"title"`
+	Description     *string    `json:"description"`
+	Status          *string    `json:"status"`
+	Resolution      *string    `json:"resolution"`
+	Priority        *string    `json:"priority"`
+	DueDate         *time.Time `json:"due_date"`
+	ProjectID       *uint      `json:"project_id"`
+	EstimateMinutes *int       `json:"estimate_minutes"`
+	// An empty recurrence stops the task from repeating
+	Recurrence         *string `json:"recurrence"`
+	RecurrenceTimezone *string `json:"recurrence_timezone"`
+	// The status the task moves to once its checklist is complete; empty turns it off
+	ChecklistAdvanceTo *string `json:"checklist_advance_to"`
+	// Only the custom fields listed change; a null value clears a field
+	CustomFields map[string]interface{} `json:"custom_fields"`
+}
+
+// TaskResponse defines the response structure for task data
+type TaskResponse struct {
+	ID                 uint                `json:"id"`
+	Title              string              `json:"title"`
+	Description        string              `json:"description"`
+	Status             string              `json:"status"`
+	Closed             bool                `json:"closed"`
+	Resolution         string              `json:"resolution,omitempty"`
+	Priority           string              `json:"priority"`
+	DueDate            *time.Time          `json:"due_date"`
+	EstimateMinutes    int                 `json:"estimate_minutes"`
+	UserID             uint                `json:"user_id"`
+	ProjectID          *uint               `json:"project_id"`
+	ParentID           *uint               `json:"parent_id"`
+	Progress           int                 `json:"progress"`
+	Recurrence         string              `json:"recurrence,omitempty"`
+	RecurrenceTimezone string              `json:"recurrence_timezone,omitempty"`
+	RecurrenceStart    *time.Time          `json:"recurrence_start,omitempty"`
+	Assignees          []TaskUserResponse  `json:"assignees,omitempty"`
+	Watchers           []TaskUserResponse  `json:"watchers,omitempty"`
+	Labels             []TaskLabelResponse `json:"labels,omitempty"`
+	Checklist          *ChecklistSummary   `json:"checklist,omitempty"`
+	ChecklistAdvanceTo string              `json:"checklist_advance_to,omitempty"`
+	// CustomFields maps field keys to values: dates as YYYY-MM-DD, multi-select
+	// fields as lists and user fields as user IDs
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+}
+
+// ChecklistSummary defines the checklist progress shown with a task, e.g. "3/5 done"
+type ChecklistSummary struct {
+	Done    int    `json:"done"`
+	Total   int    `json:"total"`
+	Summary string `json:"summary"`
+}
+
+// TaskUserResponse defines the response structure for an assignee or watcher of a task
+type TaskUserResponse struct {
+	ID       uint   `json:"id"`
+	Username string `json:"username"`
+}
+
+// TaskLabelResponse defines the response structure for a label on a task
+type TaskLabelResponse struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// TaskLabelRequest defines the request structure for tagging a task with a label
+type TaskLabelRequest struct {
+	LabelID uint `json:"label_id" binding:"required"`
+}
+
+// TaskAssigneeRequest defines the request structure for assigning a user to a task
+type TaskAssigneeRequest struct {
+	UserID uint `json:"user_id" binding:"required"`
+}
+
+// TaskTreeResponse defines the response structure for a task and its nested subtasks
+type TaskTreeResponse struct {
+	TaskResponse
+	Subtasks []TaskTreeResponse `json:"subtasks"`
+}
+
+// TaskMoveRequest defines the request structure for re-parenting a task;
+// a null parent_id turns the task into a root task
+type TaskMoveRequest struct {
+	ParentID *uint `json:"parent_id"`
+}
+
+// ProjectCreateRequest defines the request structure for project creation
+type ProjectCreateRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// ProjectUpdateRequest defines the request structure for updating project data
+type ProjectUpdateRequest struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+// ProjectResponse defines the response structure for project data
+type ProjectResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	OwnerID     uint      `json:"owner_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ProjectMemberRequest defines the request structure for adding a project member
+type ProjectMemberRequest struct {
+	UserID uint   `json:"user_id" binding:"required"`
+	Role   string `json:"role" binding:"required"`
+}
+
+// ProjectMemberRoleRequest defines the request structure for changing a member's role
+type ProjectMemberRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// ProjectMemberResponse defines the response structure for project membership data
+type ProjectMemberResponse struct {
+	UserID   uint      `json:"user_id"`
+	Username string    `json:"username,omitempty"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// TaskDependencyRequest defines the request structure for blocking a task by another task
+type TaskDependencyRequest struct {
+	BlockedByID uint `json:"blocked_by_id" binding:"required"`
+}
+
+// RecurrencePreviewRequest defines the request structure for previewing a
+// rule before saving it on a task
+type RecurrencePreviewRequest struct {
+	Recurrence string    `json:"recurrence" binding:"required"`
+	Timezone   string    `json:"timezone"`
+	Start      time.Time `json:"start" binding:"required"`
+	Count      int       `json:"count"`
+}
+
+// RecurrencePreviewResponse defines the response structure for upcoming occurrences
+type RecurrencePreviewResponse struct {
+	Recurrence  string      `json:"recurrence"`
+	Timezone    string      `json:"timezone"`
+	Occurrences []time.Time `json:"occurrences"`
+}
+
+// NextTaskResponse defines the response structure for an entry of the work order list
+type NextTaskResponse struct {
+	TaskResponse
+	Ready     bool   `json:"ready"`
+	BlockedBy []uint `json:"blocked_by"`
+}
+
+// ScheduleTaskResponse defines one bar of a project's Gantt chart
+type ScheduleTaskResponse struct {
+	ID             uint       `json:"id"`
+	Title          string     `json:"title"`
+	Status         string     `json:"status"`
+	DueDate        *time.Time `json:"due_date"`
+	DependsOn      []uint     `json:"depends_on"`
+	Duration       int        `json:"duration_minutes"`
+	EarliestStart  time.Time  `json:"earliest_start"`
+	EarliestFinish time.Time  `json:"earliest_finish"`
+	LatestStart    time.Time  `json:"latest_start"`
+	LatestFinish   time.Time  `json:"latest_finish"`
+	Slack          int        `json:"slack_minutes"`
+	Critical       bool       `json:"critical"`
+	Late           bool       `json:"late"`
+}
+
+// ScheduleResponse defines the response structure for a project's critical-path schedule
+type ScheduleResponse struct {
+	ProjectID    uint                   `json:"project_id"`
+	Start        time.Time              `json:"start"`
+	Finish       time.Time              `json:"finish"`
+	CriticalPath []uint                 `json:"critical_path"`
+	Tasks        []ScheduleTaskResponse `json:"tasks"`
+}
+
+// BoardColumnRequest defines one column of a board; columns are shown in the order given
+type BoardColumnRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Status   string `json:"status" binding:"required"`
+	WipLimit int    `json:"wip_limit"`
+}
+
+// BoardCreateRequest defines the request structure for board creation
+type BoardCreateRequest struct {
+	Name    string               `json:"name" binding:"required"`
+	Columns []BoardColumnRequest `json:"columns" binding:"required,dive"`
+}
+
+// BoardUpdateRequest defines the request structure for updating a board;
+// a non-null columns list replaces the board's columns
+type BoardUpdateRequest struct {
+	Name    *string              `json:"name"`
+	Columns []BoardColumnRequest `json:"columns" binding:"omitempty,dive"`
+}
+
+// BoardColumnResponse defines the response structure for a board column
+type BoardColumnResponse struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Position int    `json:"position"`
+	WipLimit int    `json:"wip_limit"`
+}
+
+// BoardResponse defines the response structure for board data
+type BoardResponse struct {
+	ID        uint                  `json:"id"`
+	ProjectID uint                  `json:"project_id"`
+	Name      string                `json:"name"`
+	Columns   []BoardColumnResponse `json:"columns"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+// BoardColumnCardsResponse defines a board column together with its cards in order
+type BoardColumnCardsResponse struct {
+	BoardColumnResponse
+	Cards []TaskResponse `json:"cards"`
+}
+
+// BoardViewResponse defines the response structure for a board and its cards
+type BoardViewResponse struct {
+	ID        uint                       `json:"id"`
+	ProjectID uint                       `json:"project_id"`
+	Name      string                     `json:"name"`
+	Columns   []BoardColumnCardsResponse `json:"columns"`
+}
+
+// CardMoveRequest defines the request structure for moving a card; position
+// is the card's zero-based index in the target column
+type CardMoveRequest struct {
+	ColumnID uint `json:"column_id" binding:"required"`
+	Position int  `json:"position"`
+}
+
+// WorkflowStateRequest defines one state of a workflow; tasks in a final state count as done
+type WorkflowStateRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Final bool   `json:"final"`
+}
+
+// WorkflowTransitionRequest defines an allowed move between two states;
+// a from of "*" allows the move from every state
+type WorkflowTransitionRequest struct {
+	Name   string   `json:"name"`
+	From   string   `json:"from" binding:"required"`
+	To     string   `json:"to" binding:"required"`
+	Guards []string `json:"guards"`
+}
+
+// WorkflowRequest defines the request structure for replacing a project's workflow;
+// the initial state defaults to the first state
+type WorkflowRequest struct {
+	InitialState string                      `json:"initial_state"`
+	States       []WorkflowStateRequest      `json:"states" binding:"required,dive"`
+	Transitions  []WorkflowTransitionRequest `json:"transitions" binding:"dive"`
+}
+
+// WorkflowStateResponse defines the response structure for a workflow state
+type WorkflowStateResponse struct {
+	Name  string `json:"name"`
+	Final bool   `json:"final"`
+}
+
+// WorkflowTransitionResponse defines the response structure for a workflow transition
+type WorkflowTransitionResponse struct {
+	Name   string   `json:"name,omitempty"`
+	From   string   `json:"from"`
+	To     string   `json:"to"`
+	Guards []string `json:"guards"`
+}
+
+// WorkflowResponse defines the response structure for a project's workflow;
+// custom is false while the project uses the default workflow
+type WorkflowResponse struct {
+	ProjectID    uint                         `json:"project_id"`
+	Custom       bool                         `json:"custom"`
+	InitialState string                       `json:"initial_state"`
+	States       []WorkflowStateResponse      `json:"states"`
+	Transitions  []WorkflowTransitionResponse `json:"transitions"`
+}
+
+// LabelCreateRequest defines the request structure for label creation;
+// labels without a project_id are personal
+type LabelCreateRequest struct {
+	Name      string `json:"name" binding:"required"`
+	Color     string `json:"color"`
+	ProjectID *uint  `json:"project_id"`
+}
+
+// LabelUpdateRequest defines the request structure for renaming or recolouring a label
+type LabelUpdateRequest struct {
+	Name  *string `json:"name"`
+	Color *string `json:"color"`
+}
+
+// LabelMergeRequest defines the request structure for merging a label into another
+type LabelMergeRequest struct {
+	IntoID uint `json:"into_id" binding:"required"`
+}
+
+// LabelResponse defines the response structure for label data
+type LabelResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	UserID    *uint     `json:"user_id,omitempty"`
+	ProjectID *uint     `json:"project_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CommentCreateRequest defines the request structure for posting a comment;
+// parent_id makes it a reply to a top-level comment
+type CommentCreateRequest struct {
+	Body     string `json:"body" binding:"required"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+// CommentUpdateRequest defines the request structure for editing a comment
+type CommentUpdateRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// CommentResponse defines the response structure for a comment. body is the
+// Markdown source and body_html its sanitised rendering; both are empty for
+// deleted comments kept for their replies.
+type CommentResponse struct {
+	ID        uint               `json:"id"`
+	TaskID    uint               `json:"task_id"`
+	ParentID  *uint              `json:"parent_id,omitempty"`
+	Author    TaskUserResponse   `json:"author"`
+	Body      string             `json:"body"`
+	BodyHTML  string             `json:"body_html"`
+	Mentions  []TaskUserResponse `json:"mentions,omitempty"`
+	Deleted   bool               `json:"deleted,omitempty"`
+	EditedAt  *time.Time         `json:"edited_at,omitempty"`
+	CreatedAt time.Time          `json:"created_at"`
+	Replies   []CommentResponse  `json:"replies,omitempty"`
+}
+
+// CommentRevisionResponse defines the response structure for a previous version of a comment
+type CommentRevisionResponse struct {
+	ID        uint      `json:"id"`
+	Body      string    `json:"body"`
+	BodyHTML  string    `json:"body_html"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AttachmentResponse defines the response structure for attachment metadata;
+// checksum is the hex SHA-256 of the content
+type AttachmentResponse struct {
+	ID          uint      `json:"id"`
+	TaskID      uint      `json:"task_id"`
+	UserID      uint      `json:"user_id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Checksum    string    `json:"checksum"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// AttachmentUsageResponse defines the response structure for a user's attachment quota, in bytes
+type AttachmentUsageResponse struct {
+	Used        int64 `json:"used"`
+	Quota       int64 `json:"quota"`
+	MaxFileSize int64 `json:"max_file_size"`
+}
+
+// TimerStartRequest defines the request structure for starting a timer on a task
+type TimerStartRequest struct {
+	Note string `json:"note"`
+}
+
+// WorklogCreateRequest defines the request structure for logging time
+// manually; without started_at the work is assumed to have just ended
+type WorklogCreateRequest struct {
+	Minutes   int        `json:"minutes" binding:"required"`
+	StartedAt *time.Time `json:"started_at"`
+	Note      string     `json:"note"`
+}
+
+// WorklogUpdateRequest defines the request structure for correcting a worklog
+type WorklogUpdateRequest struct {
+	Minutes   int       `json:"minutes" binding:"required"`
+	StartedAt time.Time `json:"started_at" binding:"required"`
+	Note      string    `json:"note"`
+}
+
+// WorklogResponse defines the response structure for a worklog; ended_at is
+// omitted while the timer is running
+type WorklogResponse struct {
+	ID        uint              `json:"id"`
+	TaskID    uint              `json:"task_id"`
+	User      *TaskUserResponse `json:"user,omitempty"`
+	StartedAt time.Time         `json:"started_at"`
+	EndedAt   *time.Time        `json:"ended_at,omitempty"`
+	Seconds   int64             `json:"seconds"`
+	Running   bool              `json:"running"`
+	Note      string            `json:"note"`
+}
+
+// TaskWorklogsResponse defines the response structure for the time logged on
+// a task; variance_minutes is logged minus estimated time and is omitted
+// for tasks without an estimate
+type TaskWorklogsResponse struct {
+	TaskID          uint              `json:"task_id"`
+	EstimateMinutes int               `json:"estimate_minutes"`
+	LoggedMinutes   int64             `json:"logged_minutes"`
+	VarianceMinutes *int64            `json:"variance_minutes,omitempty"`
+	Worklogs        []WorklogResponse `json:"worklogs"`
+}
+
+// TimesheetRowResponse defines the response structure for one day, week,
+// task or project of a timesheet; task rows compare all time logged on the
+// task with its estimate
+type TimesheetRowResponse struct {
+	Key                string  `json:"key"`
+	Label              string  `json:"label"`
+	Seconds            int64   `json:"seconds"`
+	Hours              float64 `json:"hours"`
+	TaskID             *uint   `json:"task_id,omitempty"`
+	ProjectID          *uint   `json:"project_id,omitempty"`
+	EstimateMinutes    *int    `json:"estimate_minutes,omitempty"`
+	TotalLoggedMinutes *int64  `json:"total_logged_minutes,omitempty"`
+	VarianceMinutes    *int64  `json:"variance_minutes,omitempty"`
+}
+
+// TimesheetResponse defines the response structure for a user's timesheet
+type TimesheetResponse struct {
+	From         string                 `json:"from"`
+	To           string                 `json:"to"`
+	Group        string                 `json:"group"`
+	Timezone     string                 `json:"timezone"`
+	TotalSeconds int64                  `json:"total_seconds"`
+	TotalHours   float64                `json:"total_hours"`
+	Rows         []TimesheetRowResponse `json:"rows"`
+}
+
+// ChecklistItemCreateRequest defines the request structure for adding a checklist item
+type ChecklistItemCreateRequest struct {
+	Text string `json:"text" binding:"required"`
+}
+
+// ChecklistItemUpdateRequest defines the request structure for renaming a checklist item
+type ChecklistItemUpdateRequest struct {
+	Text string `json:"text" binding:"required"`
+}
+
+// ChecklistToggleRequest defines the request structure for checking or
+// unchecking an item; without done the item is flipped
+type ChecklistToggleRequest struct {
+	Done *bool `json:"done"`
+}
+
+// ChecklistMoveRequest defines the request structure for reordering a
+// checklist item; position is zero-based among the other items
+type ChecklistMoveRequest struct {
+	Position *int `json:"position" binding:"required"`
+}
+
+// ChecklistItemResponse defines the response structure for a checklist item
+type ChecklistItemResponse struct {
+	ID        uint       `json:"id"`
+	Text      string     `json:"text"`
+	Done      bool       `json:"done"`
+	DoneBy    *uint      `json:"done_by,omitempty"`
+	DoneAt    *time.Time `json:"done_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ChecklistToggleResponse defines the response structure for a toggled item.
+// advanced reports that completing the checklist moved the task on;
+// advance_error explains why a configured advance didn't happen.
+type ChecklistToggleResponse struct {
+	Item         ChecklistItemResponse `json:"item"`
+	Task         TaskResponse          `json:"task"`
+	Advanced     bool                  `json:"advanced"`
+	AdvanceError string                `json:"advance_error,omitempty"`
+}
+
+// CustomFieldCreateRequest defines the request structure for adding a custom
+// field to a project; the key defaults to one derived from the name
+type CustomFieldCreateRequest struct {
+	Name     string   `json:"name" binding:"required"`
+	Key      string   `json:"key"`
+	Type     string   `json:"type" binding:"required"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options"`
+}
+
+// CustomFieldUpdateRequest defines the request structure for changing a
+// custom field; its key and type can't be changed
+type CustomFieldUpdateRequest struct {
+	Name     *string  `json:"name"`
+	Required *bool    `json:"required"`
+	Options  []string `json:"options"`
+}
+
+// CustomFieldResponse defines the response structure for a custom field definition
+type CustomFieldResponse struct {
+	ID        uint      `json:"id"`
+	ProjectID uint      `json:"project_id"`
+	Key       string    `json:"key"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Required  bool      `json:"required"`
+	Options   []string  `json:"options,omitempty"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TemplateTask defines a task of a template with its subtasks. due_offset
+// is relative to the anchor date of an instantiation, e.g. "+3d" or "-1w";
+// labels are label names.
+type TemplateTask struct {
+	Title           string                 `json:"title" binding:"required"`
+	Description     string                 `json:"description,omitempty"`
+	Priority        string                 `json:"priority,omitempty"`
+	EstimateMinutes int                    `json:"estimate_minutes,omitempty"`
+	DueOffset       string                 `json:"due_offset,omitempty"`
+	Checklist       []string               `json:"checklist,omitempty"`
+	Labels          []string               `json:"labels,omitempty"`
+	CustomFields    map[string]interface{} `json:"custom_fields,omitempty"`
+	Subtasks        []TemplateTask         `json:"subtasks,omitempty" binding:"dive"`
+}
+
+// TemplateCreateRequest defines the request structure for creating a task
+// template; templates without a project_id are personal
+type TemplateCreateRequest struct {
+	Name        string         `json:"name" binding:"required"`
+	Description string         `json:"description"`
+	ProjectID   *uint          `json:"project_id"`
+	Tasks       []TemplateTask `json:"tasks" binding:"required,dive"`
+}
+
+// TemplateUpdateRequest defines the request structure for changing a task
+// template; tasks, when given, replace the whole tree
+type TemplateUpdateRequest struct {
+	Name        *string        `json:"name"`
+	Description *string        `json:"description"`
+	Tasks       []TemplateTask `json:"tasks" binding:"omitempty,dive"`
+}
+
+// TemplateResponse defines the response structure for a task template
+type TemplateResponse struct {
+	ID          uint           `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	UserID      uint           `json:"user_id"`
+	ProjectID   *uint          `json:"project_id"`
+	Tasks       []TemplateTask `json:"tasks"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// TemplateInstantiateRequest defines the request structure for creating
+// tasks from a template. anchor (YYYY-MM-DD or RFC 3339) is the date due
+// offsets count from and defaults to today; without a project_id the
+// tasks are personal.
+type TemplateInstantiateRequest struct {
+	ProjectID *uint  `json:"project_id"`
+	Anchor    string `json:"anchor"`
+}
+
+// PageResponse defines the envelope of paginated lists. next_cursor is null
+// on the last page; otherwise it is passed as the cursor parameter to fetch
+// the next one.
+type PageResponse struct {
+	Data       interface{} `json:"data"`
+	NextCursor *string     `json:"next_cursor"`
+}
+
+// ViewCreateRequest defines the request structure for saving a view. query
+// uses the task query language and sort lists sort keys ("priority,-due");
+// with a project_id the view is shared with the project's members.
+type ViewCreateRequest struct {
+	Name      string   `json:"name" binding:"required"`
+	Query     string   `json:"query"`
+	Sort      string   `json:"sort"`
+	Columns   []string `json:"columns"`
+	ProjectID *uint    `json:"project_id"`
+	IsDefault bool     `json:"is_default"`
+}
+
+// ViewUpdateRequest defines the request structure for changing a view;
+// omitted fields are left alone and a project_id of 0 makes a shared view
+// personal again
+type ViewUpdateRequest struct {
+	Name      *string  `json:"name"`
+	Query     *string  `json:"query"`
+	Sort      *string  `json:"sort"`
+	Columns   []string `json:"columns"`
+	ProjectID *uint    `json:"project_id"`
+	IsDefault *bool    `json:"is_default"`
+}
+
+// ViewResponse defines the response structure for a saved view
+type ViewResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	UserID    uint      `json:"user_id"`
+	ProjectID *uint     `json:"project_id"`
+	Query     string    `json:"query"`
+	Sort      string    `json:"sort"`
+	Columns   []string  `json:"columns"`
+	IsDefault bool      `json:"is_default"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SearchHitResponse defines the response structure for a task or comment
+// found by a full-text search. title and snippet carry the rune offsets of
+// the words found, for highlighting.
+type SearchHitResponse struct {
+	Kind      string          `json:"kind"`
+	TaskID    uint            `json:"task_id"`
+	CommentID *uint           `json:"comment_id,omitempty"`
+	ProjectID *uint           `json:"project_id"`
+	Title     textsearch.Text `json:"title"`
+	Snippet   textsearch.Text `json:"snippet"`
+	Rank      float64         `json:"rank"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// SessionResponse defines the response structure for a session of the
+// authenticated user; current marks the session of the request
+type SessionResponse struct {
+	ID         uint      `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Current    bool      `json:"current"`
+}
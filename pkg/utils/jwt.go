@@ -2,21 +2,51 @@ package utils
 
 import (
 	"TaskManager/internal/config"
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
 )
 
-// GenerateJWT generates a JWT token for a given user ID with configurable expiration
-func GenerateJWT(userID uint, expiration time.Duration) (string, error) {
+// AccessClaims identify who an access token is issued to: the user, the
+// login (refresh token family) it belongs to, and the user's token version
+// and whether their email was verified at the time
+type AccessClaims struct {
+	UserID        uint
+	SessionID     string
+	Version       int
+	EmailVerified bool
+}
+
+// TokenClaims are the claims of a valid access token. ID is the token's
+// unique jti, used to revoke it.
+type TokenClaims struct {
+	AccessClaims
+	ID        string
+	ExpiresAt time.Time
+}
+
+// GenerateJWT generates a JWT token for the given claims with configurable
+// expiration, under a new unique ID
+func GenerateJWT(claims AccessClaims, expiration time.Duration) (string, error) {
 	jwtSecret := []byte(config.Config.JWTSecret)
 
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate token: %v", err)
+	}
+
 	token := jwt.New(jwt.SigningMethodHS256)
 
-	claims := token.Claims.(jwt.MapClaims)
-	claims["user_id"] = userID
-	claims["exp"] = time.Now().Add(expiration).Unix()
+	mapClaims := token.Claims.(jwt.MapClaims)
+	mapClaims["jti"] = base64.RawURLEncoding.EncodeToString(id)
+	mapClaims["user_id"] = claims.UserID
+	mapClaims["sid"] = claims.SessionID
+	mapClaims["ver"] = claims.Version
+	mapClaims["ev"] = claims.EmailVerified
+	mapClaims["exp"] = time.Now().Add(expiration).Unix()
 
 	tokenString, err := token.SignedString(jwtSecret)
 	if err != nil {
@@ -26,8 +56,8 @@ func GenerateJWT(userID uint, expiration time.Duration) (string, error) {
 	return tokenString, nil
 }
 
-// ValidateToken validates the JWT token and returns the user ID
-func ValidateToken(tokenString string) (uint, error) {
+// ParseToken validates the JWT token and returns its claims
+func ParseToken(tokenString string) (*TokenClaims, error) {
 	jwtSecret := []byte(config.Config.JWTSecret)
 
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
@@ -38,18 +68,38 @@ func ValidateToken(tokenString string) (uint, error) {
 	})
 
 	if err != nil {
-		return 0, fmt.Errorf("invalid token: %v", err)
+		return nil, fmt.Errorf("invalid token: %v", err)
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
+	mapClaims, ok := token.Claims.(jwt.MapClaims)
 	if !ok || !token.Valid {
-		return 0, fmt.Errorf("invalid token")
+		return nil, fmt.Errorf("invalid token")
 	}
 
-	userID, ok := claims["user_id"].(float64)
+	userID, ok := mapClaims["user_id"].(float64)
 	if !ok {
-		return 0, fmt.Errorf("user_id not found in token")
+		return nil, fmt.Errorf("user_id not found in token")
 	}
 
-	return uint(userID), nil
+	// Tokens minted before jti, sid, ver and ev existed have none of them
+	claims := &TokenClaims{AccessClaims: AccessClaims{UserID: uint(userID)}}
+	claims.ID, _ = mapClaims["jti"].(string)
+	claims.SessionID, _ = mapClaims["sid"].(string)
+	if version, ok := mapClaims["ver"].(float64); ok {
+		claims.Version = int(version)
+	}
+	claims.EmailVerified, _ = mapClaims["ev"].(bool)
+	if exp, ok := mapClaims["exp"].(float64); ok {
+		claims.ExpiresAt = time.Unix(int64(exp), 0)
+	}
+	return claims, nil
+}
+
+// ValidateToken validates the JWT token and returns the user ID
+func ValidateToken(tokenString string) (uint, error) {
+	claims, err := ParseToken(tokenString)
+	if err != nil {
+		return 0, err
+	}
+	return claims.UserID, nil
 }
@@ -24,7 +24,7 @@ func TestGenerateJWT(t *testing.T) {
 	expiration := time.Hour * 24
 
 	// Generate JWT token
-	token, err := GenerateJWT(userID, expiration)
+	token, err := GenerateJWT(AccessClaims{UserID: userID}, expiration)
 	// Assert no error
 	require.NoError(t, err, "JWT should be generated without error")
 
@@ -55,7 +55,7 @@ func TestValidateToken_ExpiredToken(t *testing.T) {
 	// Generate a token with a very short expiration
 	userID := uint(123)
 	expiration := time.Second * 1
-	token, err := GenerateJWT(userID, expiration)
+	token, err := GenerateJWT(AccessClaims{UserID: userID}, expiration)
 	require.NoError(t, err)
 
 	// Wait for the token to expire
@@ -67,3 +67,23 @@ func TestValidateToken_ExpiredToken(t *testing.T) {
 	// Assert that an error is returned for expired token
 	assert.Error(t, err, "Validation of an expired token should return an error")
 }
+
+// Test function for ParseToken on the claims of a token
+func TestParseToken(t *testing.T) {
+	claims := AccessClaims{UserID: 123, SessionID: "family", Version: 4, EmailVerified: true}
+	first, err := GenerateJWT(claims, time.Hour)
+	require.NoError(t, err)
+	second, err := GenerateJWT(claims, time.Hour)
+	require.NoError(t, err)
+
+	parsed, err := ParseToken(first)
+	require.NoError(t, err)
+	assert.Equal(t, claims, parsed.AccessClaims)
+	assert.NotEmpty(t, parsed.ID)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), parsed.ExpiresAt, time.Minute)
+
+	// Every token gets its own ID
+	other, err := ParseToken(second)
+	require.NoError(t, err)
+	assert.NotEqual(t, parsed.ID, other.ID)
+}
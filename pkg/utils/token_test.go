@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test function for GenerateOpaqueToken and HashToken
+func TestOpaqueToken(t *testing.T) {
+	first, err := GenerateOpaqueToken()
+	require.NoError(t, err)
+	second, err := GenerateOpaqueToken()
+	require.NoError(t, err)
+
+	// 32 random bytes, URL-safe without padding
+	assert.Len(t, first, 43)
+	assert.NotEqual(t, first, second)
+	assert.NotContains(t, first, "=")
+
+	// Hashes are stable hex digests that differ per token
+	assert.Equal(t, HashToken(first), HashToken(first))
+	assert.Len(t, HashToken(first), 64)
+	assert.NotEqual(t, HashToken(first), HashToken(second))
+}